@@ -665,6 +665,42 @@ func TestAddEvolveTasks(t *testing.T) {
 	require.True(t, status == bindinfo.Enabled || status == bindinfo.Rejected)
 }
 
+func TestEvolvePlanBaselines(t *testing.T) {
+	restore := config.RestoreFunc()
+	defer restore()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.Experimental.EnablePlanBaselineEvolution = true
+	})
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, c int, index idx_a(a), index idx_b(b), index idx_c(c))")
+	tk.MustExec("insert into t values (1,1,1), (2,2,2), (3,3,3), (4,4,4), (5,5,5)")
+	tk.MustExec("analyze table t")
+	tk.MustExec("create global binding for select * from t where a >= 1 and b >= 1 and c = 0 using select * from t use index(idx_a) where a >= 1 and b >= 1 and c = 0")
+	tk.MustExec("set @@tidb_evolve_plan_baselines=1")
+	tk.MustQuery("select * from t where a >= 4 and b >= 1 and c = 0")
+	tk.MustExec("admin flush bindings")
+	tk.MustQuery("select count(*) from mysql.bind_info where status = 'pending verify'").Check(testkit.Rows("1"))
+
+	// all the pending verify bindings are evolved and recorded in the history
+	require.NoError(t, dom.BindHandle().EvolvePlanBaselines(context.Background(), testkit.NewTestKit(t, store).Session()))
+	tk.MustQuery("select count(*) from mysql.bind_info where status = 'pending verify'").Check(testkit.Rows("0"))
+	rows := tk.MustQuery("select original_sql, default_db, sql_digest is not null, baseline_time is not null, result from mysql.bind_evolution_history").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "select * from `test` . `t` where `a` >= ? and `b` >= ? and `c` = ?", rows[0][0])
+	require.Equal(t, "test", rows[0][1])
+	require.Equal(t, "1", rows[0][2])
+	require.Equal(t, "1", rows[0][3])
+	result := rows[0][4].(string)
+	require.True(t, result == "accepted" || result == "rejected")
+
+	// nothing is left to evolve
+	require.NoError(t, dom.BindHandle().EvolvePlanBaselines(context.Background(), testkit.NewTestKit(t, store).Session()))
+	tk.MustQuery("select count(*) from mysql.bind_evolution_history").Check(testkit.Rows("1"))
+}
+
 func TestRuntimeHintsInEvolveTasks(t *testing.T) {
 	originalVal := config.CheckTableBeforeDrop
 	config.CheckTableBeforeDrop = true
@@ -1133,6 +1169,29 @@ func TestForbidEvolvePlanBaseLinesBeforeGA(t *testing.T) {
 	require.EqualError(t, err, "Cannot enable baseline evolution feature, it is not generally available now")
 }
 
+func TestEnableExperimentalEvolvePlanBaseLines(t *testing.T) {
+	originalVal := config.CheckTableBeforeDrop
+	config.CheckTableBeforeDrop = false
+	restore := config.RestoreFunc()
+	defer func() {
+		config.CheckTableBeforeDrop = originalVal
+		restore()
+	}()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.Experimental.EnablePlanBaselineEvolution = true
+	})
+
+	store := testkit.CreateMockStore(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set @@tidb_evolve_plan_baselines=0")
+	tk.MustExec("set @@TiDB_Evolve_pLan_baselines=1")
+	tk.MustQuery("select @@tidb_evolve_plan_baselines").Check(testkit.Rows("1"))
+	tk.MustExec("set @@global.TiDB_Evolve_pLan_baselines=oN")
+	tk.MustQuery("select @@global.tidb_evolve_plan_baselines").Check(testkit.Rows("1"))
+	tk.MustExec("admin evolve bindings")
+}
+
 func TestExplainTableStmts(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 
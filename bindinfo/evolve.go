@@ -0,0 +1,146 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/timer/api"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+const (
+	// EvolveTimerKey is the key of the timer which schedules the plan baseline evolution.
+	EvolveTimerKey = "/tidb/bindinfo/evolve"
+	// EvolveTimerHookClass is the hook class of the timer which schedules the plan baseline evolution.
+	EvolveTimerHookClass = "tidb.bindinfo.evolve"
+	// EvolveTimerInterval is the interval to schedule the plan baseline evolution.
+	EvolveTimerInterval = "1m"
+
+	// evolveTimerDelayInterval is the interval to check again when the instance is not the owner of bindinfo.
+	evolveTimerDelayInterval = time.Minute
+	// evolveHistoryRetention is how long the evolution history is kept.
+	evolveHistoryRetention = 30 * 24 * time.Hour
+
+	evolveResultAccepted = "accepted"
+	evolveResultRejected = "rejected"
+	evolveResultFailed   = "failed"
+)
+
+// EvolvePlanBaselines evolves the pending verified plans one by one until there is no more task, the evolution is
+// out of the time window `tidb_evolve_plan_task_start_time` to `tidb_evolve_plan_task_end_time` or the context is done.
+func (h *BindHandle) EvolvePlanBaselines(ctx context.Context, sctx sessionctx.Context) error {
+	gcEvolveHistory(sctx)
+	for ctx.Err() == nil {
+		evolved, err := h.handleEvolvePlanTask(sctx, false)
+		if err != nil || !evolved {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordEvolveHistory saves the result of verifying a plan into mysql.bind_evolution_history.
+// A negative duration means the plan is not finished in the time limit.
+func recordEvolveHistory(sctx sessionctx.Context, originalSQL, db string, binding Binding,
+	currentPlanTime, verifyPlanTime time.Duration, result, message string) {
+	seconds := func(d time.Duration) interface{} {
+		if d < 0 {
+			return nil
+		}
+		return d.Seconds()
+	}
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
+	_, _, err := sctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(ctx, nil,
+		`INSERT INTO mysql.bind_evolution_history (original_sql, default_db, bind_sql, sql_digest, baseline_time, candidate_time, result, message)
+		VALUES (%?, %?, %?, %?, %?, %?, %?, %?)`,
+		originalSQL, db, binding.BindSQL, binding.SQLDigest, seconds(currentPlanTime), seconds(verifyPlanTime), result, message)
+	if err != nil {
+		logutil.BgLogger().Warn("[sql-bind] save evolution history failed", zap.Error(err))
+	}
+}
+
+func gcEvolveHistory(sctx sessionctx.Context) {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
+	_, _, err := sctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(ctx, nil,
+		"DELETE FROM mysql.bind_evolution_history WHERE evolve_time < %?", time.Now().Add(-evolveHistoryRetention))
+	if err != nil {
+		logutil.BgLogger().Warn("[sql-bind] gc evolution history failed", zap.Error(err))
+	}
+}
+
+// NewEvolveTimerSpec returns the spec of the timer which schedules the plan baseline evolution.
+func NewEvolveTimerSpec() api.TimerSpec {
+	return api.TimerSpec{
+		Key:             EvolveTimerKey,
+		SchedPolicyType: api.SchedEventInterval,
+		SchedPolicyExpr: EvolveTimerInterval,
+		HookClass:       EvolveTimerHookClass,
+		Enable:          true,
+	}
+}
+
+// evolveTimerHook implements api.Hook, it evolves the plan baselines when the timer event is triggered.
+type evolveTimerHook struct {
+	h       *BindHandle
+	sctx    sessionctx.Context
+	isOwner func() bool
+	cli     api.TimerClient
+}
+
+// NewEvolveTimerHook creates a hook for the timer which schedules the plan baseline evolution. The evolution only
+// runs on the owner of bindinfo.
+func NewEvolveTimerHook(h *BindHandle, sctx sessionctx.Context, isOwner func() bool, cli api.TimerClient) api.Hook {
+	return &evolveTimerHook{
+		h:       h,
+		sctx:    sctx,
+		isOwner: isOwner,
+		cli:     cli,
+	}
+}
+
+// Start implements the api.Hook interface.
+func (*evolveTimerHook) Start() {}
+
+// Stop implements the api.Hook interface.
+func (*evolveTimerHook) Stop() {}
+
+// OnPreSchedEvent implements the api.Hook interface.
+func (t *evolveTimerHook) OnPreSchedEvent(context.Context, api.TimerShedEvent) (api.PreSchedEventResult, error) {
+	if !t.isOwner() {
+		return api.PreSchedEventResult{Delay: evolveTimerDelayInterval}, nil
+	}
+	return api.PreSchedEventResult{}, nil
+}
+
+// OnSchedEvent implements the api.Hook interface.
+func (t *evolveTimerHook) OnSchedEvent(ctx context.Context, event api.TimerShedEvent) error {
+	// the event may be triggered by another instance which was the owner, it'll be retried until the owner closes it
+	if !t.isOwner() {
+		return errors.New("the plan baselines can only be evolved by the owner of bindinfo")
+	}
+	timer := event.Timer()
+	if err := t.h.EvolvePlanBaselines(ctx, t.sctx); err != nil {
+		// the left tasks will be evolved in the next event
+		logutil.BgLogger().Info("[sql-bind] evolve plan baselines failed", zap.Error(err))
+	}
+	return t.cli.CloseTimerEvent(ctx, timer.ID, event.EventID(), api.WithSetWatermark(timer.EventStart))
+}
@@ -1212,16 +1212,22 @@ func runSQL(ctx context.Context, sctx sessionctx.Context, sql string, resultChan
 // HandleEvolvePlanTask tries to evolve one plan task.
 // It only processes one task at a time because we want each task to use the latest parameters.
 func (h *BindHandle) HandleEvolvePlanTask(sctx sessionctx.Context, adminEvolve bool) error {
+	_, err := h.handleEvolvePlanTask(sctx, adminEvolve)
+	return err
+}
+
+// handleEvolvePlanTask returns whether a plan task is evolved.
+func (h *BindHandle) handleEvolvePlanTask(sctx sessionctx.Context, adminEvolve bool) (bool, error) {
 	originalSQL, db, binding := h.getOnePendingVerifyJob()
 	if originalSQL == "" {
-		return nil
+		return false, nil
 	}
 	maxTime, startTime, endTime, err := getEvolveParameters(sctx)
 	if err != nil {
-		return err
+		return false, err
 	}
 	if maxTime == 0 || (!timeutil.WithinDayTimePeriod(startTime, endTime, time.Now()) && !adminEvolve) {
-		return nil
+		return false, nil
 	}
 	sctx.GetSessionVars().UsePlanBaselines = true
 	currentPlanTime, err := h.getRunningDuration(sctx, db, binding.BindSQL, maxTime)
@@ -1229,8 +1235,9 @@ func (h *BindHandle) HandleEvolvePlanTask(sctx sessionctx.Context, adminEvolve b
 	// since it is still in the bind record. Now we just drop it and if it is actually retryable,
 	// we will hope for that we can capture this evolve task again.
 	if err != nil {
+		recordEvolveHistory(sctx, originalSQL, db, binding, -1, -1, evolveResultFailed, err.Error())
 		_, err = h.DropBindRecord(originalSQL, db, &binding)
-		return err
+		return true, err
 	}
 	// If the accepted plan timeouts, it is hard to decide the timeout for verify plan.
 	// Currently we simply mark the verify plan as `using` if it could run successfully within maxTime.
@@ -1240,11 +1247,14 @@ func (h *BindHandle) HandleEvolvePlanTask(sctx sessionctx.Context, adminEvolve b
 	sctx.GetSessionVars().UsePlanBaselines = false
 	verifyPlanTime, err := h.getRunningDuration(sctx, db, binding.BindSQL, maxTime)
 	if err != nil {
+		recordEvolveHistory(sctx, originalSQL, db, binding, currentPlanTime, -1, evolveResultFailed, err.Error())
 		_, err = h.DropBindRecord(originalSQL, db, &binding)
-		return err
+		return true, err
 	}
+	result := evolveResultAccepted
 	if verifyPlanTime == -1 || (float64(verifyPlanTime)*acceptFactor > float64(currentPlanTime)) {
 		binding.Status = Rejected
+		result = evolveResultRejected
 		digestText, _ := parser.NormalizeDigest(binding.BindSQL) // for log desensitization
 		logutil.BgLogger().Debug("[sql-bind] new plan rejected",
 			zap.Duration("currentPlanTime", currentPlanTime),
@@ -1254,8 +1264,9 @@ func (h *BindHandle) HandleEvolvePlanTask(sctx sessionctx.Context, adminEvolve b
 	} else {
 		binding.Status = Enabled
 	}
+	recordEvolveHistory(sctx, originalSQL, db, binding, currentPlanTime, verifyPlanTime, result, "")
 	// We don't need to pass the `sctx` because the BindSQL has been validated already.
-	return h.AddBindRecord(nil, &BindRecord{OriginalSQL: originalSQL, Db: db, Bindings: []Binding{binding}})
+	return true, h.AddBindRecord(nil, &BindRecord{OriginalSQL: originalSQL, Db: db, Bindings: []Binding{binding}})
 }
 
 // Clear resets the bind handle. It is only used for test.
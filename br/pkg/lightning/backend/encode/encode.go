@@ -88,3 +88,22 @@ type Row interface {
 	// Size represents the total kv size of this Row.
 	Size() uint64
 }
+
+// missingValue is the value of the Datum which marks a column missing in a row of the data file.
+type missingValue struct{}
+
+// SetMissing marks the column of the Datum as missing in the row. It's used when a column is absent from some rows
+// only, like a key missing in an object of the NDJSON files. The encoders use the default value of the column for it,
+// the same as a column absent from the whole data file.
+func SetMissing(d *types.Datum) {
+	d.SetInterface(missingValue{})
+}
+
+// IsMissing checks whether the column of the Datum is missing in the row, see SetMissing.
+func IsMissing(d *types.Datum) bool {
+	if d.Kind() != types.KindInterface {
+		return false
+	}
+	_, ok := d.GetInterface().(missingValue)
+	return ok
+}
@@ -77,16 +77,19 @@ var kindStr = [...]string{
 
 // MarshalLogArray implements the zapcore.ArrayMarshaler interface
 func (row RowArrayMarshaller) MarshalLogArray(encoder zapcore.ArrayEncoder) error {
-	for _, datum := range row {
+	for i := range row {
+		datum := &row[i]
 		kind := datum.Kind()
 		var str string
 		var err error
-		switch kind {
-		case types.KindNull:
+		switch {
+		case encode.IsMissing(datum):
+			str = "DEFAULT"
+		case kind == types.KindNull:
 			str = "NULL"
-		case types.KindMinNotNull:
+		case kind == types.KindMinNotNull:
 			str = "-inf"
-		case types.KindMaxValue:
+		case kind == types.KindMaxValue:
 			str = "+inf"
 		default:
 			str, err = datum.ToString()
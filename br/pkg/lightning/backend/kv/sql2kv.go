@@ -200,7 +200,7 @@ func (kvcodec *tableKVEncoder) Encode(row []types.Datum,
 	for i, col := range kvcodec.Columns {
 		var theDatum *types.Datum
 		j := columnPermutation[i]
-		if j >= 0 && j < len(row) {
+		if j >= 0 && j < len(row) && !encode.IsMissing(&row[j]) {
 			theDatum = &row[j]
 		}
 		value, err = kvcodec.ProcessColDatum(col, rowID, theDatum)
@@ -15,16 +15,21 @@
 package kv_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/pingcap/tidb/br/pkg/lightning/backend/encode"
 	lkv "github.com/pingcap/tidb/br/pkg/lightning/backend/kv"
 	"github.com/pingcap/tidb/br/pkg/lightning/common"
+	"github.com/pingcap/tidb/br/pkg/lightning/config"
 	"github.com/pingcap/tidb/br/pkg/lightning/log"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
 	"github.com/pingcap/tidb/br/pkg/lightning/verification"
+	"github.com/pingcap/tidb/br/pkg/lightning/worker"
 	"github.com/pingcap/tidb/ddl"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
@@ -340,6 +345,57 @@ func TestEncodeTimestamp(t *testing.T) {
 	}))
 }
 
+func TestEncodeGeometry(t *testing.T) {
+	tblInfo := mockTableInfo(t, "create table t (id int primary key, g geometry, p point srid 4326)")
+	tbl, err := tables.TableFromMeta(lkv.NewPanickingAllocators(0), tblInfo)
+	require.NoError(t, err)
+	sessionOpts := encode.SessionOptions{SQLMode: mysql.ModeStrictAllTables}
+	encoder, err := lkv.NewTableKVEncoder(&encode.EncodingConfig{
+		Table:          tbl,
+		SessionOptions: sessionOpts,
+		Logger:         log.L(),
+	}, nil)
+	require.NoError(t, err)
+	decoder, err := lkv.NewTableKVDecoder(tbl, "`test`.`t`", &sessionOpts, log.L())
+	require.NoError(t, err)
+
+	// POINT(1 1) with SRID 4326 in the internal format of geometries.
+	point := "\xe6\x10\x00\x00\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf0\x3f\x00\x00\x00\x00\x00\x00\xf0\x3f"
+	ioWorkers := worker.NewPool(context.Background(), 1, "test")
+	// The geometries are dumped by Dumpling as hex literals in SQL files, and as escaped binary strings in CSV files.
+	sqlParser := mydump.NewChunkParser(context.Background(), mysql.ModeNone, mydump.NewStringReader(
+		"INSERT INTO `t` VALUES\n(1,x'e61000000101000000000000000000f03f000000000000f03f',x'e61000000101000000000000000000f03f000000000000f03f');\n"),
+		int64(config.ReadBlockSize), ioWorkers)
+	csvPoint := `"` + strings.ReplaceAll(point, "\x00", `\0`) + `"`
+	csvParser, err := mydump.NewCSVParser(context.Background(), &config.CSVConfig{
+		Separator: ",",
+		Delimiter: `"`,
+		Null:      []string{`\N`},
+		EscapedBy: `\`,
+	}, mydump.NewStringReader("1,"+csvPoint+","+csvPoint+"\r\n"), int64(config.ReadBlockSize), ioWorkers, false, nil)
+	require.NoError(t, err)
+
+	for _, parser := range []mydump.Parser{sqlParser, csvParser} {
+		require.NoError(t, parser.ReadRow())
+		row, err := encoder.Encode(parser.LastRow().Row, 1, []int{0, 1, 2, -1}, 1234)
+		require.NoError(t, err)
+		pairs := lkv.Row2KvPairs(row)
+		require.Len(t, pairs, 1)
+		h, err := decoder.DecodeHandleFromRowKey(pairs[0].Key)
+		require.NoError(t, err)
+		decoded, _, err := decoder.DecodeRawRowData(h, pairs[0].Val)
+		require.NoError(t, err)
+		require.Equal(t, point, decoded[1].GetString())
+		require.Equal(t, point, decoded[2].GetString())
+	}
+
+	_, err = encoder.Encode([]types.Datum{types.NewIntDatum(2), types.NewStringDatum("abc"), {}}, 2, []int{0, 1, 2, -1}, 1234)
+	require.ErrorContains(t, err, "Cannot get geometry object from data you send to the GEOMETRY field")
+	cartesianPoint := "\x00\x00\x00\x00" + point[4:]
+	_, err = encoder.Encode([]types.Datum{types.NewIntDatum(3), {}, types.NewStringDatum(cartesianPoint)}, 3, []int{0, 1, 2, -1}, 1234)
+	require.ErrorContains(t, err, "The SRID of the geometry does not match the SRID of the column 'p'")
+}
+
 func TestEncodeDoubleAutoIncrement(t *testing.T) {
 	tblInfo := mockTableInfo(t, "create table t (id double not null auto_increment, unique key `u_id` (`id`));")
 	tbl, err := tables.TableFromMeta(lkv.NewPanickingAllocators(0), tblInfo)
@@ -456,6 +512,39 @@ func TestEncodeMissingAutoValue(t *testing.T) {
 	}
 }
 
+func TestEncodeNDJSONMissingKey(t *testing.T) {
+	tblInfo := mockTableInfo(t, "create table t (a int, b int not null default 5);")
+	tbl, err := tables.TableFromMeta(lkv.NewPanickingAllocators(0), tblInfo)
+	require.NoError(t, err)
+	encoder, err := lkv.NewTableKVEncoder(&encode.EncodingConfig{
+		Table: tbl,
+		SessionOptions: encode.SessionOptions{
+			SQLMode: mysql.ModeStrictAllTables,
+		},
+		Logger: log.L(),
+	}, nil)
+	require.NoError(t, err)
+	pairsExpect, err := encoder.Encode([]types.Datum{types.NewIntDatum(1), types.NewIntDatum(5)}, 1, []int{0, 1, -1}, 1234)
+	require.NoError(t, err)
+
+	newParser := func(cfg *config.JSONConfig) *mydump.NDJSONParser {
+		parser := mydump.NewNDJSONParser(context.Background(), cfg, mydump.NewStringReader(`{"a":1}`),
+			int64(config.ReadBlockSize), nil, []string{"a", "b"}, nil)
+		require.NoError(t, parser.ReadRow())
+		return parser
+	}
+
+	// the missing key of the NOT NULL column uses the default value by default.
+	parser := newParser(nil)
+	pairs, err := encoder.Encode(parser.LastRow().Row, 1, []int{0, 1, -1}, 1234)
+	require.NoError(t, err)
+	require.Equal(t, pairsExpect, pairs)
+
+	parser = newParser(&config.JSONConfig{MissingKey: config.JSONMissingKeyNull, ExtraKey: config.JSONExtraKeyIgnore})
+	_, err = encoder.Encode(parser.LastRow().Row, 1, []int{0, 1, -1}, 1234)
+	require.ErrorContains(t, err, "Column 'b' cannot be null")
+}
+
 func TestEncodeExpressionColumn(t *testing.T) {
 	tblInfo := mockTableInfo(t, "create table t (id varchar(40) not null DEFAULT uuid(), unique key `u_id` (`id`));")
 	tbl, err := tables.TableFromMeta(lkv.NewPanickingAllocators(0), tblInfo)
@@ -360,6 +360,10 @@ func (enc *tidbEncoder) appendSQLBytes(sb *strings.Builder, value []byte) {
 // appendSQL appends the SQL representation of the Datum into the string builder.
 // Note that we cannot use Datum.ToString since it doesn't perform SQL escaping.
 func (enc *tidbEncoder) appendSQL(sb *strings.Builder, datum *types.Datum, _ *table.Column) error {
+	if encode.IsMissing(datum) {
+		sb.WriteString("DEFAULT")
+		return nil
+	}
 	switch datum.Kind() {
 	case types.KindNull:
 		sb.WriteString("NULL")
@@ -71,6 +71,18 @@ const (
 	// ErrorOnDup indicates using INSERT INTO to insert data, which would violate PK or UNIQUE constraint
 	ErrorOnDup = "error"
 
+	// JSONMissingKeyDefault indicates using the default values for the columns missing in a JSON object, the same
+	// as the columns absent from the CSV and SQL files.
+	JSONMissingKeyDefault = "default"
+	// JSONMissingKeyNull indicates setting the columns missing in a JSON object to NULL.
+	JSONMissingKeyNull = "null"
+	// JSONMissingKeyError indicates reporting an error when a column is missing in a JSON object.
+	JSONMissingKeyError = "error"
+	// JSONExtraKeyIgnore indicates ignoring the keys of a JSON object which don't match any column.
+	JSONExtraKeyIgnore = "ignore"
+	// JSONExtraKeyError indicates reporting an error when a key of a JSON object doesn't match any column.
+	JSONExtraKeyError = "error"
+
 	// KVWriteBatchSize batch size when write to TiKV.
 	// this is the default value of linux send buffer size(net.ipv4.tcp_wmem) too.
 	KVWriteBatchSize        = 16 * units.KiB
@@ -657,6 +669,17 @@ type CSVConfig struct {
 	UnescapedQuote bool `toml:"-" json:"-"`
 }
 
+// JSONConfig is the config for NDJSON files, each line of which is a JSON object whose top-level keys are mapped to
+// the columns by name.
+type JSONConfig struct {
+	// MissingKey specifies how to handle the columns missing in an object, see JSONMissingKeyDefault,
+	// JSONMissingKeyNull and JSONMissingKeyError.
+	MissingKey string `toml:"missing-key" json:"missing-key"`
+	// ExtraKey specifies how to handle the keys which don't match any column, see JSONExtraKeyIgnore and
+	// JSONExtraKeyError.
+	ExtraKey string `toml:"extra-key" json:"extra-key"`
+}
+
 // MydumperRuntime is the runtime config for mydumper.
 type MydumperRuntime struct {
 	ReadBlockSize    ByteSize         `toml:"read-block-size" json:"read-block-size"`
@@ -666,6 +689,7 @@ type MydumperRuntime struct {
 	SourceDir        string           `toml:"data-source-dir" json:"data-source-dir"`
 	CharacterSet     string           `toml:"character-set" json:"character-set"`
 	CSV              CSVConfig        `toml:"csv" json:"csv"`
+	JSON             JSONConfig       `toml:"json" json:"json"`
 	MaxRegionSize    ByteSize         `toml:"max-region-size" json:"max-region-size"`
 	Filter           []string         `toml:"filter" json:"filter"`
 	FileRouters      []*FileRouteRule `toml:"files" json:"files"`
@@ -952,6 +976,10 @@ func NewConfig() *Config {
 				EscapedBy:         `\`,
 				TrimLastSep:       false,
 			},
+			JSON: JSONConfig{
+				MissingKey: JSONMissingKeyDefault,
+				ExtraKey:   JSONExtraKeyIgnore,
+			},
 			StrictFormat:           false,
 			MaxRegionSize:          MaxRegionSize,
 			Filter:                 GetDefaultFilter(),
@@ -1113,6 +1141,25 @@ func (cfg *Config) Adjust(ctx context.Context) error {
 		}
 	}
 
+	// Reject problematic NDJSON configurations.
+	jsonCfg := &cfg.Mydumper.JSON
+	jsonCfg.MissingKey = strings.ToLower(jsonCfg.MissingKey)
+	switch jsonCfg.MissingKey {
+	case "":
+		jsonCfg.MissingKey = JSONMissingKeyDefault
+	case JSONMissingKeyDefault, JSONMissingKeyNull, JSONMissingKeyError:
+	default:
+		return common.ErrInvalidConfig.GenWithStack("unsupported `mydumper.json.missing-key` (%s)", jsonCfg.MissingKey)
+	}
+	jsonCfg.ExtraKey = strings.ToLower(jsonCfg.ExtraKey)
+	switch jsonCfg.ExtraKey {
+	case "":
+		jsonCfg.ExtraKey = JSONExtraKeyIgnore
+	case JSONExtraKeyIgnore, JSONExtraKeyError:
+	default:
+		return common.ErrInvalidConfig.GenWithStack("unsupported `mydumper.json.extra-key` (%s)", jsonCfg.ExtraKey)
+	}
+
 	// adjust file routing
 	for _, rule := range cfg.Mydumper.FileRouters {
 		if filepath.IsAbs(rule.Path) {
@@ -1020,6 +1020,35 @@ func TestAdjustOnDuplicate(t *testing.T) {
 	require.NoError(t, cfg.Adjust(ctx))
 }
 
+func TestAdjustJSONConfig(t *testing.T) {
+	cfg := config.NewConfig()
+	assignMinimalLegalValue(cfg)
+	ctx := context.Background()
+
+	cfg.Mydumper.JSON.MissingKey = ""
+	cfg.Mydumper.JSON.ExtraKey = ""
+	require.NoError(t, cfg.Adjust(ctx))
+	require.Equal(t, config.JSONMissingKeyDefault, cfg.Mydumper.JSON.MissingKey)
+	require.Equal(t, config.JSONExtraKeyIgnore, cfg.Mydumper.JSON.ExtraKey)
+
+	cfg.Mydumper.JSON.MissingKey = "NULL"
+	require.NoError(t, cfg.Adjust(ctx))
+	require.Equal(t, config.JSONMissingKeyNull, cfg.Mydumper.JSON.MissingKey)
+
+	cfg.Mydumper.JSON.MissingKey = "ERROR"
+	cfg.Mydumper.JSON.ExtraKey = "Error"
+	require.NoError(t, cfg.Adjust(ctx))
+	require.Equal(t, config.JSONMissingKeyError, cfg.Mydumper.JSON.MissingKey)
+	require.Equal(t, config.JSONExtraKeyError, cfg.Mydumper.JSON.ExtraKey)
+
+	cfg.Mydumper.JSON.MissingKey = "ignore"
+	require.EqualError(t, cfg.Adjust(ctx), "[Lightning:Config:ErrInvalidConfig]unsupported `mydumper.json.missing-key` (ignore)")
+
+	cfg.Mydumper.JSON.MissingKey = config.JSONMissingKeyNull
+	cfg.Mydumper.JSON.ExtraKey = "null"
+	require.EqualError(t, cfg.Adjust(ctx), "[Lightning:Config:ErrInvalidConfig]unsupported `mydumper.json.extra-key` (null)")
+}
+
 func TestAdjustMaxErrorRecords(t *testing.T) {
 	cfg := config.NewConfig()
 	assignMinimalLegalValue(cfg)
@@ -109,6 +109,9 @@ func openParser(
 		if err != nil {
 			return nil, err
 		}
+	case mydump.SourceTypeNDJSON:
+		columns, jsonColumns := mydump.NDJSONColumns(tblInfo)
+		parser = mydump.NewNDJSONParser(ctx, &cfg.Mydumper.JSON, reader, blockBufSize, ioWorkers, columns, jsonColumns)
 	default:
 		return nil, errors.Errorf("file '%s' with unknown source type '%s'", chunk.Key.Path, chunk.FileMeta.Type.String())
 	}
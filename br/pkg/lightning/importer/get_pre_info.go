@@ -474,6 +474,9 @@ func (p *PreImportInfoGetterImpl) ReadFirstNRowsByFileMeta(ctx context.Context,
 		if err != nil {
 			return nil, nil, errors.Trace(err)
 		}
+	case mydump.SourceTypeNDJSON:
+		// the keys of the first object are used as the columns
+		parser = mydump.NewNDJSONParser(ctx, &p.cfg.Mydumper.JSON, reader, blockBufSize, p.ioWorkers, nil, nil)
 	default:
 		panic(fmt.Sprintf("unknown file type '%s'", dataFileMeta.Type))
 	}
@@ -642,6 +645,9 @@ func (p *PreImportInfoGetterImpl) sampleDataFromTable(
 		if err != nil {
 			return 0.0, false, errors.Trace(err)
 		}
+	case mydump.SourceTypeNDJSON:
+		columns, jsonColumns := mydump.NDJSONColumns(tableInfo)
+		parser = mydump.NewNDJSONParser(ctx, &p.cfg.Mydumper.JSON, reader, blockBufSize, p.ioWorkers, columns, jsonColumns)
 	default:
 		panic(fmt.Sprintf("file '%s' with unknown source type '%s'", sampleFile.Path, sampleFile.Type.String()))
 	}
@@ -126,6 +126,8 @@ func NewImportSource(dbSrcDataMap map[string]*DBSourceData) (*ImportSource, erro
 					fileInfo.FileMeta.Type = mydump.SourceTypeSQL
 				case strings.HasSuffix(fileName, ".parquet"):
 					fileInfo.FileMeta.Type = mydump.SourceTypeParquet
+				case strings.HasSuffix(fileName, ".ndjson"), strings.HasSuffix(fileName, ".jsonl"):
+					fileInfo.FileMeta.Type = mydump.SourceTypeNDJSON
 				default:
 					return nil, errors.Errorf("unsupported file type: %s", tblDataFile.FileName)
 				}
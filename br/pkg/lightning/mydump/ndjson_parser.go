@@ -0,0 +1,296 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/backend/encode"
+	"github.com/pingcap/tidb/br/pkg/lightning/config"
+	"github.com/pingcap/tidb/br/pkg/lightning/log"
+	"github.com/pingcap/tidb/br/pkg/lightning/metric"
+	"github.com/pingcap/tidb/br/pkg/lightning/worker"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+)
+
+// ndjsonMinRowSize is the size of the smallest row "{}\n" in NDJSON files.
+const ndjsonMinRowSize = 3
+
+var errNDJSONNotObject = errors.NewNoStackError("syntax error: each line of NDJSON file must be a JSON object")
+
+// NDJSONParser is a parser for the newline-delimited JSON files, each line of which is a JSON object. The top-level
+// keys of the objects are mapped to the columns by name case-insensitively.
+type NDJSONParser struct {
+	blockParser
+	cfg *config.JSONConfig
+
+	// jsonColumns are the lower-case names of the JSON columns, whose values are kept as JSON text.
+	jsonColumns map[string]struct{}
+	// colIndexes maps the lower-case column names to their offsets in the row.
+	colIndexes map[string]int
+
+	// the keys and values of the last object
+	keys   []string
+	values []json.RawMessage
+	filled []bool
+}
+
+// NDJSONColumns returns the columns which can be imported from the NDJSON files of the table, and the JSON columns
+// among them.
+func NDJSONColumns(tblInfo *model.TableInfo) (columns, jsonColumns []string) {
+	columns = make([]string, 0, len(tblInfo.Columns))
+	for _, col := range tblInfo.Columns {
+		if col.IsGenerated() || col.Hidden {
+			continue
+		}
+		columns = append(columns, col.Name.L)
+		if col.GetType() == mysql.TypeJSON {
+			jsonColumns = append(jsonColumns, col.Name.L)
+		}
+	}
+	return columns, jsonColumns
+}
+
+// NewNDJSONParser creates a NDJSON parser. If columns is empty, the keys of the first object are used as the columns.
+// The values of jsonColumns are kept as JSON text, so the nested objects and arrays can be loaded into the JSON
+// columns. A nil cfg means using the default values for the missing columns and ignoring the extra keys.
+func NewNDJSONParser(
+	ctx context.Context,
+	cfg *config.JSONConfig,
+	reader ReadSeekCloser,
+	blockBufSize int64,
+	ioWorkers *worker.Pool,
+	columns []string,
+	jsonColumns []string,
+) *NDJSONParser {
+	if cfg == nil {
+		cfg = &config.JSONConfig{
+			MissingKey: config.JSONMissingKeyDefault,
+			ExtraKey:   config.JSONExtraKeyIgnore,
+		}
+	}
+	metrics, _ := metric.FromContext(ctx)
+	parser := &NDJSONParser{
+		blockParser: makeBlockParser(reader, blockBufSize, ioWorkers, metrics, log.FromContext(ctx)),
+		cfg:         cfg,
+		jsonColumns: make(map[string]struct{}, len(jsonColumns)),
+	}
+	for _, col := range jsonColumns {
+		parser.jsonColumns[strings.ToLower(col)] = struct{}{}
+	}
+	parser.SetColumns(columns)
+	return parser
+}
+
+// SetColumns sets the columns which the keys are mapped to.
+func (parser *NDJSONParser) SetColumns(columns []string) {
+	parser.columns = make([]string, 0, len(columns))
+	parser.colIndexes = make(map[string]int, len(columns))
+	for _, col := range columns {
+		col = strings.ToLower(col)
+		if _, ok := parser.colIndexes[col]; ok {
+			continue
+		}
+		parser.colIndexes[col] = len(parser.columns)
+		parser.columns = append(parser.columns, col)
+	}
+}
+
+// readLine reads a line without the terminator '\n'. The last line may not have the terminator.
+func (parser *NDJSONParser) readLine() ([]byte, error) {
+	index := bytes.IndexByte(parser.buf, '\n')
+	if index >= 0 {
+		line := parser.buf[:index]
+		parser.buf = parser.buf[index+1:]
+		parser.pos += int64(index) + 1
+		return line, nil
+	}
+
+	// not found in parser.buf, need allocate and loop.
+	var buf []byte
+	for {
+		buf = append(buf, parser.buf...)
+		if len(buf) > LargestEntryLimit {
+			return buf, errors.New("size of row cannot exceed the max value of txn-entry-size-limit")
+		}
+		parser.buf = nil
+		if err := parser.readBlock(); err != nil || len(parser.buf) == 0 {
+			parser.pos += int64(len(buf))
+			if err == nil {
+				if len(buf) > 0 {
+					return buf, nil
+				}
+				err = io.EOF
+			}
+			return buf, errors.Trace(err)
+		}
+		index := bytes.IndexByte(parser.buf, '\n')
+		if index >= 0 {
+			buf = append(buf, parser.buf[:index]...)
+			parser.buf = parser.buf[index+1:]
+			parser.pos += int64(len(buf)) + 1
+			return buf, nil
+		}
+	}
+}
+
+// ReadUntilTerminator seeks the file until the next line, and returns the file offset beyond the terminator.
+func (parser *NDJSONParser) ReadUntilTerminator() (int64, error) {
+	_, err := parser.readLine()
+	return parser.pos, err
+}
+
+// ndjsonSyntaxError wraps the error of decoding JSON, the cause of which is not io.EOF even if the object is truncated.
+func ndjsonSyntaxError(err error) error {
+	return errors.Errorf("syntax error: %s", err.Error())
+}
+
+// readObject reads the keys and the raw values of the JSON object in the line.
+func (parser *NDJSONParser) readObject(line []byte) error {
+	parser.keys = parser.keys[:0]
+	parser.values = parser.values[:0]
+
+	dec := json.NewDecoder(bytes.NewReader(line))
+	tok, err := dec.Token()
+	if err != nil {
+		return ndjsonSyntaxError(err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return errNDJSONNotObject
+	}
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return ndjsonSyntaxError(err)
+		}
+		//nolint: forcetypeassert
+		key := tok.(string)
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return ndjsonSyntaxError(err)
+		}
+		parser.keys = append(parser.keys, key)
+		parser.values = append(parser.values, value)
+	}
+	// the closing '}'
+	if _, err = dec.Token(); err != nil {
+		return ndjsonSyntaxError(err)
+	}
+	if _, err = dec.Token(); err != io.EOF {
+		return errNDJSONNotObject
+	}
+	return nil
+}
+
+// setDatumByJSONValue converts a JSON value to Datum. If keepJSON is true, the value is kept as JSON text. Otherwise,
+// the strings are unquoted, the booleans are converted to 1 and 0, and the numbers, objects and arrays are kept as
+// text. The JSON null is always converted to NULL.
+func setDatumByJSONValue(d *types.Datum, value json.RawMessage, keepJSON bool) error {
+	switch {
+	case value[0] == 'n':
+		d.SetNull()
+	case keepJSON:
+		d.SetString(string(value), "utf8mb4_bin")
+	case value[0] == '"':
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return ndjsonSyntaxError(err)
+		}
+		d.SetString(s, "utf8mb4_bin")
+	case value[0] == 't':
+		d.SetInt64(1)
+	case value[0] == 'f':
+		d.SetInt64(0)
+	default:
+		d.SetString(string(value), "utf8mb4_bin")
+	}
+	return nil
+}
+
+// ReadRow reads a row from the datafile.
+func (parser *NDJSONParser) ReadRow() error {
+	row := &parser.lastRow
+	row.Length = 0
+	row.RowID++
+
+	var line []byte
+	for len(line) == 0 {
+		content, err := parser.readLine()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// skip the empty lines
+		line = bytes.TrimSpace(content)
+	}
+	if err := parser.readObject(line); err != nil {
+		return errors.Trace(err)
+	}
+	if len(parser.columns) == 0 {
+		parser.SetColumns(parser.keys)
+	}
+
+	row.Row = parser.acquireDatumSlice()
+	if cap(row.Row) >= len(parser.columns) {
+		row.Row = row.Row[:len(parser.columns)]
+	} else {
+		row.Row = make([]types.Datum, len(parser.columns))
+	}
+	if cap(parser.filled) >= len(parser.columns) {
+		parser.filled = parser.filled[:len(parser.columns)]
+	} else {
+		parser.filled = make([]bool, len(parser.columns))
+	}
+	for i := range parser.filled {
+		parser.filled[i] = false
+	}
+	row.Length = len(line)
+
+	for i, key := range parser.keys {
+		key = strings.ToLower(key)
+		idx, ok := parser.colIndexes[key]
+		if !ok {
+			if parser.cfg.ExtraKey == config.JSONExtraKeyError {
+				return errors.Errorf("key '%s' doesn't match any column", parser.keys[i])
+			}
+			continue
+		}
+		_, keepJSON := parser.jsonColumns[key]
+		if err := setDatumByJSONValue(&row.Row[idx], parser.values[i], keepJSON); err != nil {
+			return errors.Trace(err)
+		}
+		parser.filled[idx] = true
+	}
+	for i, filled := range parser.filled {
+		if filled {
+			continue
+		}
+		switch parser.cfg.MissingKey {
+		case config.JSONMissingKeyError:
+			return errors.Errorf("column '%s' is missing in the JSON object", parser.columns[i])
+		case config.JSONMissingKeyNull:
+			row.Row[i].SetNull()
+		default:
+			encode.SetMissing(&row.Row[i])
+		}
+	}
+	return nil
+}
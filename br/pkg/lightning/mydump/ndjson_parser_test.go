@@ -0,0 +1,123 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/backend/encode"
+	"github.com/pingcap/tidb/br/pkg/lightning/config"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/types"
+	"github.com/stretchr/testify/require"
+)
+
+var missingDatum = func() (d types.Datum) {
+	encode.SetMissing(&d)
+	return d
+}()
+
+func TestNDJSONParser(t *testing.T) {
+	line1 := `{"a":1.50,"B":"x\"y","c":{"k":[1, 2]}}`
+	line2 := `  {"c":null,"a":true,"d":1}  `
+	line3 := `{"b":"z","c":"s"}`
+	input := line1 + "\n\n" + line2 + "\r\n" + line3
+
+	for _, blockBufSize := range []int64{4, int64(config.ReadBlockSize)} {
+		parser := mydump.NewNDJSONParser(context.Background(), nil, mydump.NewStringReader(input), blockBufSize,
+			ioWorkersForCSV, []string{"A", "b", "c"}, []string{"c"})
+		require.Equal(t, []string{"a", "b", "c"}, parser.Columns())
+
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, mydump.Row{
+			RowID: 1,
+			Row: []types.Datum{
+				types.NewStringDatum("1.50"),
+				types.NewStringDatum(`x"y`),
+				types.NewStringDatum(`{"k":[1, 2]}`),
+			},
+			Length: len(line1),
+		}, parser.LastRow())
+		assertPosEqual(t, parser, int64(len(line1))+1, 1)
+
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, mydump.Row{
+			RowID:  2,
+			Row:    []types.Datum{types.NewIntDatum(1), missingDatum, nullDatum},
+			Length: len(`{"c":null,"a":true,"d":1}`),
+		}, parser.LastRow())
+		assertPosEqual(t, parser, int64(len(line1)+len(line2))+4, 2)
+
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, mydump.Row{
+			RowID:  3,
+			Row:    []types.Datum{missingDatum, types.NewStringDatum("z"), types.NewStringDatum(`"s"`)},
+			Length: len(line3),
+		}, parser.LastRow())
+		assertPosEqual(t, parser, int64(len(input)), 3)
+
+		require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+		require.NoError(t, parser.Close())
+	}
+}
+
+func TestNDJSONParserColumnsFromFirstObject(t *testing.T) {
+	input := "{\"Id\":1,\"v\":false}\n{\"v\":\"a\",\"ID\":2,\"x\":0}\n"
+	parser := mydump.NewNDJSONParser(context.Background(), nil, mydump.NewStringReader(input),
+		int64(config.ReadBlockSize), ioWorkersForCSV, nil, nil)
+	require.Empty(t, parser.Columns())
+
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []string{"id", "v"}, parser.Columns())
+	require.Equal(t, []types.Datum{types.NewStringDatum("1"), types.NewIntDatum(0)}, parser.LastRow().Row)
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{types.NewStringDatum("2"), types.NewStringDatum("a")}, parser.LastRow().Row)
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+}
+
+func TestNDJSONParserMissingAndExtraKeys(t *testing.T) {
+	cfg := &config.JSONConfig{MissingKey: config.JSONMissingKeyError, ExtraKey: config.JSONExtraKeyIgnore}
+	parser := mydump.NewNDJSONParser(context.Background(), cfg, mydump.NewStringReader("{\"a\":1,\"c\":2}\n{\"b\":1}"),
+		int64(config.ReadBlockSize), ioWorkersForCSV, []string{"a"}, nil)
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{types.NewStringDatum("1")}, parser.LastRow().Row)
+	require.ErrorContains(t, parser.ReadRow(), "column 'a' is missing in the JSON object")
+
+	cfg = &config.JSONConfig{MissingKey: config.JSONMissingKeyNull, ExtraKey: config.JSONExtraKeyError}
+	parser = mydump.NewNDJSONParser(context.Background(), cfg, mydump.NewStringReader("{}\n{\"a\":1,\"C\":2}"),
+		int64(config.ReadBlockSize), ioWorkersForCSV, []string{"a", "b"}, nil)
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{nullDatum, nullDatum}, parser.LastRow().Row)
+	require.ErrorContains(t, parser.ReadRow(), "key 'C' doesn't match any column")
+}
+
+func TestNDJSONParserSyntaxError(t *testing.T) {
+	for _, input := range []string{
+		`[1, 2]`,
+		`"a"`,
+		`{"a":1`,
+		`{"a":1} {"a":2}`,
+		`{"a":}`,
+	} {
+		parser := mydump.NewNDJSONParser(context.Background(), nil, mydump.NewStringReader(input),
+			int64(config.ReadBlockSize), ioWorkersForCSV, []string{"a"}, nil)
+		err := parser.ReadRow()
+		require.ErrorContains(t, err, "syntax error", input)
+		require.NotErrorIs(t, errors.Cause(err), io.EOF, input)
+	}
+}
@@ -554,6 +554,13 @@ func setDatumByInt(d *types.Datum, v int64, meta *parquet.SchemaElement) error {
 		timeStr := formatTime(v, logicalType.TIME.Unit, "15:04:05.999999", "15:04:05.999999Z",
 			logicalType.TIME.IsAdjustedToUTC)
 		d.SetString(timeStr, "utf8mb4_bin")
+	case logicalType.INTEGER != nil && !logicalType.INTEGER.IsSigned:
+		// the unsigned integers are stored in the signed physical types
+		u := uint64(v)
+		if bitWidth := logicalType.INTEGER.BitWidth; bitWidth < 64 {
+			u &= 1<<uint(bitWidth) - 1
+		}
+		d.SetUint64(u)
 	default:
 		d.SetInt64(v)
 	}
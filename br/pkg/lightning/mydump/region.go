@@ -251,6 +251,10 @@ func MakeTableRegions(
 				// avoid split a lot of small chunks.
 				// If a csv file is compressed, we can't split it now because we can't get the exact size of a row.
 				regions, sizes, err = SplitLargeCSV(egCtx, cfg, info)
+			} else if info.FileMeta.Type == SourceTypeNDJSON && info.FileMeta.Compression == CompressionNone &&
+				dataFileSize > cfg.MaxChunkSize+cfg.MaxChunkSize/largeCSVLowerThresholdRation {
+				// a row of NDJSON file never contains the line terminator, so it can always be split.
+				regions, sizes, err = SplitLargeNDJSON(egCtx, cfg, info)
 			} else {
 				regions, sizes, err = MakeSourceFileRegion(egCtx, cfg, info)
 			}
@@ -319,8 +323,12 @@ func MakeSourceFileRegion(
 	fi FileInfo,
 ) ([]*TableRegion, []float64, error) {
 	divisor := int64(cfg.ColumnCnt)
-	isCsvFile := fi.FileMeta.Type == SourceTypeCSV
-	if !isCsvFile {
+	switch fi.FileMeta.Type {
+	case SourceTypeCSV:
+	case SourceTypeNDJSON:
+		// the keys may be missing in the objects, so we can only use the smallest row to estimate the rows.
+		divisor = ndjsonMinRowSize
+	default:
 		divisor += 2
 	}
 
@@ -494,3 +502,67 @@ func SplitLargeCSV(
 	}
 	return regions, dataFileSizes, nil
 }
+
+// SplitLargeNDJSON splits a large NDJSON file into multiple regions, the size of
+// each regions is specified by `config.MaxRegionSize`. Each region ends at a line
+// terminator.
+func SplitLargeNDJSON(
+	ctx context.Context,
+	cfg *DataDivideConfig,
+	dataFile FileInfo,
+) (regions []*TableRegion, dataFileSizes []float64, err error) {
+	maxRegionSize := cfg.MaxChunkSize
+	blockBufSize := cfg.ReadBlockSize
+	if blockBufSize <= 0 {
+		blockBufSize = int64(config.ReadBlockSize)
+	}
+	fileSize := dataFile.FileMeta.FileSize
+	dataFileSizes = make([]float64, 0, fileSize/maxRegionSize+1)
+	startOffset, endOffset := int64(0), mathutil.Min(maxRegionSize, fileSize)
+	var prevRowIdxMax int64
+	for {
+		if endOffset != fileSize {
+			r, err := cfg.Store.Open(ctx, dataFile.FileMeta.Path)
+			if err != nil {
+				return nil, nil, err
+			}
+			parser := NewNDJSONParser(ctx, nil, r, blockBufSize, cfg.IOWorkers, nil, nil)
+			if err = parser.SetPos(endOffset, 0); err != nil {
+				return nil, nil, err
+			}
+			pos, err := parser.ReadUntilTerminator()
+			if err != nil {
+				if !errors.ErrorEqual(err, io.EOF) {
+					return nil, nil, err
+				}
+				pos = fileSize
+			}
+			endOffset = pos
+			parser.Close()
+		}
+		// the keys may be missing in the objects, so we can only use the smallest row to estimate the rows.
+		rowIDMax := prevRowIdxMax + (endOffset-startOffset)/ndjsonMinRowSize
+		regions = append(regions,
+			&TableRegion{
+				DB:       cfg.TableMeta.DB,
+				Table:    cfg.TableMeta.Name,
+				FileMeta: dataFile.FileMeta,
+				Chunk: Chunk{
+					Offset:       startOffset,
+					EndOffset:    endOffset,
+					PrevRowIDMax: prevRowIdxMax,
+					RowIDMax:     rowIDMax,
+				},
+			})
+		dataFileSizes = append(dataFileSizes, float64(endOffset-startOffset))
+		prevRowIdxMax = rowIDMax
+		if endOffset == fileSize {
+			break
+		}
+		startOffset = endOffset
+		if endOffset += maxRegionSize; endOffset > fileSize {
+			endOffset = fileSize
+		}
+	}
+	return regions, dataFileSizes, nil
+}
@@ -487,3 +487,41 @@ func TestSplitLargeFileOnlyOneChunk(t *testing.T) {
 		require.Equal(t, columns, regions[i].Chunk.Columns)
 	}
 }
+
+func TestSplitLargeNDJSON(t *testing.T) {
+	meta := &MDTableMeta{
+		DB:   "json",
+		Name: "large_ndjson_file",
+	}
+	cfg := &config.Config{
+		Mydumper: config.MydumperRuntime{
+			ReadBlockSize: config.ReadBlockSize,
+			Filter:        []string{"*.*"},
+			MaxRegionSize: 10,
+		},
+	}
+
+	dir := t.TempDir()
+	fileName := "test.ndjson"
+	content := []byte("{\"a\":1,\"b\":2}\n{\"a\":3}\n{}\n{\"a\":4,\"b\":5}")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, fileName), content, 0o644))
+	fileInfo := FileInfo{FileMeta: SourceFileMeta{Path: fileName, Type: SourceTypeNDJSON, FileSize: int64(len(content))}}
+	ioWorker := worker.NewPool(context.Background(), 4, "io")
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	divideConfig := NewDataDivideConfig(cfg, 2, ioWorker, store, meta)
+
+	regions, _, err := SplitLargeNDJSON(context.Background(), divideConfig, fileInfo)
+	require.NoError(t, err)
+	offsets := [][]int64{{0, 14}, {14, 25}, {25, 38}}
+	require.Len(t, regions, len(offsets))
+	var prevRowIDMax int64
+	for i := range offsets {
+		require.Equal(t, offsets[i][0], regions[i].Chunk.Offset)
+		require.Equal(t, offsets[i][1], regions[i].Chunk.EndOffset)
+		require.Equal(t, prevRowIDMax, regions[i].Chunk.PrevRowIDMax)
+		// the row-id range must be large enough for the rows in the region
+		require.GreaterOrEqual(t, regions[i].Chunk.RowIDMax-prevRowIDMax, int64(2))
+		prevRowIDMax = regions[i].Chunk.RowIDMax
+	}
+}
@@ -34,6 +34,8 @@ const (
 	SourceTypeParquet
 	// SourceTypeViewSchema means this source file is a schema file for the view.
 	SourceTypeViewSchema
+	// SourceTypeNDJSON means this source file is a newline-delimited JSON data file.
+	SourceTypeNDJSON
 )
 
 const (
@@ -49,6 +51,10 @@ const (
 	TypeCSV = "csv"
 	// TypeParquet is the source type value for parquet data file.
 	TypeParquet = "parquet"
+	// TypeNDJSON is the source type value for newline-delimited JSON data file.
+	TypeNDJSON = "ndjson"
+	// TypeJSONLines is an alias of TypeNDJSON, which is the extension of JSON Lines files.
+	TypeJSONLines = "jsonl"
 	// TypeIgnore is the source type value for a ignored data file.
 	TypeIgnore = "ignore"
 )
@@ -102,6 +108,8 @@ func parseSourceType(t string) (SourceType, error) {
 		return SourceTypeCSV, nil
 	case TypeParquet:
 		return SourceTypeParquet, nil
+	case TypeNDJSON, TypeJSONLines:
+		return SourceTypeNDJSON, nil
 	case TypeIgnore:
 		return SourceTypeIgnore, nil
 	case ViewSchema:
@@ -123,6 +131,8 @@ func (s SourceType) String() string {
 		return TypeSQL
 	case SourceTypeParquet:
 		return TypeParquet
+	case SourceTypeNDJSON:
+		return TypeNDJSON
 	case SourceTypeViewSchema:
 		return ViewSchema
 	default:
@@ -171,7 +181,7 @@ var defaultFileRouteRules = []*config.FileRouteRule{
 	// ignore *-schema-trigger.sql, *-schema-post.sql files
 	{Pattern: `(?i).*(-schema-trigger|-schema-post)\.sql(?:\.(\w*?))?$`, Type: "ignore"},
 	// ignore backup files
-	{Pattern: `(?i).*\.(sql|csv|parquet)(\.(\w+))?\.(bak|BAK)$`, Type: "ignore"},
+	{Pattern: `(?i).*\.(sql|csv|parquet|ndjson|jsonl)(\.(\w+))?\.(bak|BAK)$`, Type: "ignore"},
 	// db schema create file pattern, matches files like '{schema}-schema-create.sql[.{compress}]'
 	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)-schema-create\.sql(?:\.(\w*?))?$`,
 		Schema: "$1", Table: "", Type: SchemaSchema, Compression: "$2", Unescape: true},
@@ -181,8 +191,8 @@ var defaultFileRouteRules = []*config.FileRouteRule{
 	// view schema create file pattern, matches files like '{schema}.{table}-schema-view.sql[.{compress}]'
 	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)-schema-view\.sql(?:\.(\w*?))?$`,
 		Schema: "$1", Table: "$2", Type: ViewSchema, Compression: "$3", Unescape: true},
-	// source file pattern, matches files like '{schema}.{table}.0001.{sql|csv}[.{compress}]'
-	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet)(?:\.(\w+))?$`,
+	// source file pattern, matches files like '{schema}.{table}.0001.{sql|csv|parquet|ndjson|jsonl}[.{compress}]'
+	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet|ndjson|jsonl)(?:\.(\w+))?$`,
 		Schema: "$1", Table: "$2", Type: "$4", Key: "$3", Compression: "$5", Unescape: true},
 }
 
@@ -55,6 +55,9 @@ func TestDefaultRouter(t *testing.T) {
 		"/test/123/my_schema.my_table.sql.gz":    {"my_schema", "my_table", "", "gz", "sql"},
 		"my_dir/my_schema.my_table.csv.lzo":      {"my_schema", "my_table", "", "lzo", "csv"},
 		"my_schema.my_table.0001.sql.snappy":     {"my_schema", "my_table", "0001", "snappy", "sql"},
+		"my_schema.my_table.0001.ndjson":         {"my_schema", "my_table", "0001", "", "ndjson"},
+		"my_schema.my_table.jsonl.gz":            {"my_schema", "my_table", "", "gz", "jsonl"},
+		"my_schema.my_table.jsonl.bak":           nil,
 	}
 	for path, fields := range inputOutputMap {
 		res, err := r.Route(path)
@@ -877,6 +877,8 @@ type Experimental struct {
 	AllowsExpressionIndex bool `toml:"allow-expression-index" json:"allow-expression-index"`
 	// Whether enable charset feature.
 	EnableNewCharset bool `toml:"enable-new-charset" json:"-"`
+	// Whether enable the evolution of plan baselines.
+	EnablePlanBaselineEvolution bool `toml:"enable-plan-baseline-evolution" json:"enable-plan-baseline-evolution"`
 }
 
 var defTiKVCfg = tikvcfg.DefaultConfig()
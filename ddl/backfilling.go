@@ -57,6 +57,7 @@ const (
 	typeCleanUpIndexWorker     backfillerType = 2
 	typeAddIndexMergeTmpWorker backfillerType = 3
 	typeReorgPartitionWorker   backfillerType = 4
+	typeCheckConstraintWorker  backfillerType = 5
 )
 
 func (bT backfillerType) String() string {
@@ -71,6 +72,8 @@ func (bT backfillerType) String() string {
 		return "merge temporary index"
 	case typeReorgPartitionWorker:
 		return "reorganize partition"
+	case typeCheckConstraintWorker:
+		return "check constraint"
 	default:
 		return "unknown"
 	}
@@ -81,6 +84,7 @@ func (bT backfillerType) String() string {
 // 2: modify-column-type
 // 3: clean-up global index
 // 4: reorganize partition
+// 5: add check constraint (only verifies the rows existed, nothing is written back)
 //
 // They all have a write reorganization state to back fill data into the rows existed.
 // Backfilling is time consuming, to accelerate this process, TiDB has built some sub
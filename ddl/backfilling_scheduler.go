@@ -221,6 +221,13 @@ func (b *txnBackfillScheduler) adjustWorkerSize() error {
 			}
 			runner = newBackfillWorker(jc.ddlJobCtx, partWorker)
 			worker = partWorker
+		case typeCheckConstraintWorker:
+			constrWorker, err := newCheckConstraintWorker(sessCtx, i, b.tbl, b.decodeColMap, reorgInfo, jc)
+			if err != nil {
+				return err
+			}
+			runner = newBackfillWorker(jc.ddlJobCtx, constrWorker)
+			worker = constrWorker
 		default:
 			return errors.New("unknown backfill type")
 		}
@@ -392,6 +392,8 @@ func needChangeColumnData(oldCol, newCol *model.ColumnInfo) bool {
 			if types.IsBinaryStr(&oldCol.FieldType) {
 				return newCol.GetFlen() != oldCol.GetFlen()
 			}
+		case mysql.TypeGeometry:
+			return types.GeometryChangeNeedsReorg(&oldCol.FieldType, &newCol.FieldType)
 		}
 
 		return needTruncationOrToggleSign()
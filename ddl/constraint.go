@@ -18,20 +18,27 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	sess "github.com/pingcap/tidb/ddl/internal/session"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	tidbutil "github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/dbterror"
-	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/pingcap/tidb/util/logutil"
+	decoder "github.com/pingcap/tidb/util/rowDecoder"
+	"go.uber.org/zap"
 )
 
 func (w *worker) onAddCheckConstraint(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
@@ -95,9 +102,20 @@ func (w *worker) onAddCheckConstraint(d *ddlCtx, t *meta.Meta, job *model.Job) (
 		constraintInfoInMeta.State = model.StateWriteReorganization
 		ver, err = updateVersionAndTableInfoWithCheck(d, t, job, tblInfo, originalState != constraintInfoInMeta.State)
 	case model.StateWriteReorganization:
-		err = w.verifyRemainRecordsForCheckConstraint(dbInfo, tblInfo, constraintInfoInMeta, job)
-		if err != nil {
-			return ver, errors.Trace(err)
+		// The existing rows are validated when the constraint is enforced, or altered to enforced later.
+		if constraintInfoInMeta.Enforced {
+			var done bool
+			done, err = w.verifyRemainRecordsForCheckConstraint(d, dbInfo, tblInfo, constraintInfoInMeta, job)
+			if err != nil {
+				if !errorIsRetryable(err, job) {
+					logutil.BgLogger().Warn("[ddl] run add check constraint job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
+					return convertAddCheckConstraintJob2RollbackJob(d, t, job, tblInfo, constraintInfoInMeta, err)
+				}
+				return ver, errors.Trace(err)
+			}
+			if !done {
+				return ver, nil
+			}
 		}
 		constraintInfoInMeta.State = model.StatePublic
 		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != constraintInfoInMeta.State)
@@ -226,12 +244,26 @@ func (w *worker) onAlterCheckConstraint(d *ddlCtx, t *meta.Meta, job *model.Job)
 			constraintInfo.State = model.StateWriteOnly
 			ver, err = updateVersionAndTableInfoWithCheck(d, t, job, tblInfo, originalState != constraintInfo.State)
 		case model.StateWriteOnly:
-			err = w.verifyRemainRecordsForCheckConstraint(dbInfo, tblInfo, constraintInfo, job)
+			var done bool
+			done, err = w.verifyRemainRecordsForCheckConstraint(d, dbInfo, tblInfo, constraintInfo, job)
 			if err != nil {
-				if !table.ErrCheckConstraintViolated.Equal(err) {
+				if errorIsRetryable(err, job) {
 					return ver, errors.Trace(err)
 				}
+				// The existing rows are not validated, so the constraint can't be enforced.
+				logutil.BgLogger().Warn("[ddl] run alter check constraint job failed, the constraint is still not enforced", zap.String("job", job.String()), zap.Error(err))
 				constraintInfo.Enforced = !enforced
+				constraintInfo.State = model.StatePublic
+				var err1 error
+				ver, err1 = updateVersionAndTableInfoWithCheck(d, t, job, tblInfo, true)
+				if err1 != nil {
+					return ver, errors.Trace(err1)
+				}
+				job.FinishTableJob(model.JobStateRollbackDone, model.StatePublic, ver, tblInfo)
+				return ver, errors.Trace(err)
+			}
+			if !done {
+				return ver, nil
 			}
 			constraintInfo.State = model.StatePublic
 			ver, err = updateVersionAndTableInfoWithCheck(d, t, job, tblInfo, originalState != constraintInfo.State)
@@ -336,36 +368,206 @@ func findDependentColsInExpr(expr ast.ExprNode) map[string]struct{} {
 	return colsMap
 }
 
-func (w *worker) verifyRemainRecordsForCheckConstraint(dbInfo *model.DBInfo, tableInfo *model.TableInfo, constr *model.ConstraintInfo, job *model.Job) error {
+// verifyRemainRecordsForCheckConstraint validates the existing rows against the check constraint by the backfill
+// workers, so the validation can be paused, resumed and its progress is reported as the row count of the job. It
+// returns false if the validation isn't finished in this round.
+// The validation always runs on the txn backfill workers of the DDL owner, even if tidb_enable_dist_task is on,
+// since the distributed backfill only supports adding indexes by ingest now.
+func (w *worker) verifyRemainRecordsForCheckConstraint(d *ddlCtx, dbInfo *model.DBInfo, tblInfo *model.TableInfo, constr *model.ConstraintInfo, job *model.Job) (done bool, err error) {
 	// Inject a fail-point to skip the remaining records check.
 	failpoint.Inject("mockVerifyRemainDataSuccess", func(val failpoint.Value) {
 		if val.(bool) {
-			failpoint.Return(nil)
+			failpoint.Return(true, nil)
 		}
 	})
-	// Get sessionctx from ddl context resource pool in ddl worker.
-	var sctx sessionctx.Context
+	tbl, err := getTable(d.store, dbInfo.ID, tblInfo)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
 	sctx, err := w.sessPool.Get()
 	if err != nil {
-		return errors.Trace(err)
+		return false, errors.Trace(err)
 	}
 	defer w.sessPool.Put(sctx)
+	rh := newReorgHandler(sess.NewSession(sctx))
+	elements := []*meta.Element{{ID: constr.ID, TypeKey: meta.ConstraintElementKey}}
+	reorgInfo, err := getReorgInfo(d.jobContext(job.ID), d, rh, job, dbInfo, tbl, elements, false)
+	if err != nil || reorgInfo == nil || reorgInfo.first {
+		// If we run reorg firstly, we should update the job snapshot version
+		// and then run the reorg next time.
+		return false, errors.Trace(err)
+	}
+	err = w.runReorgJob(reorgInfo, tblInfo, d.lease, func() (checkErr error) {
+		defer tidbutil.Recover(metrics.LabelDDL, "onCheckConstraint",
+			func() {
+				checkErr = dbterror.ErrCancelledDDLJob.GenWithStack("check table `%v` constraint `%v` panic", tblInfo.Name, constr.Name)
+			}, false)
+		return w.checkTableConstraint(tbl, reorgInfo)
+	})
+	if err != nil {
+		if dbterror.ErrPausedDDLJob.Equal(err) {
+			return false, nil
+		}
+		if dbterror.ErrWaitReorgTimeout.Equal(err) {
+			// if timeout, we should return, check for the owner and re-wait job done.
+			return false, nil
+		}
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// checkTableConstraint validates the existing rows of a table, the partitions are validated one by one.
+func (w *worker) checkTableConstraint(t table.Table, reorgInfo *reorgInfo) error {
+	tbl, ok := t.(table.PartitionedTable)
+	if !ok {
+		//nolint:forcetypeassert
+		return w.checkPhysicalTableConstraint(t.(table.PhysicalTable), reorgInfo)
+	}
+	for {
+		p := tbl.GetPartition(reorgInfo.PhysicalTableID)
+		if p == nil {
+			return dbterror.ErrCancelledDDLJob.GenWithStack("Can not find partition id %d for table %d", reorgInfo.PhysicalTableID, t.Meta().ID)
+		}
+		err := w.checkPhysicalTableConstraint(p, reorgInfo)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		finish, err := updateReorgInfo(w.sessPool, tbl, reorgInfo)
+		if err != nil || finish {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// checkPhysicalTableConstraint validates the existing rows of a non-partitioned table or a partition.
+func (w *worker) checkPhysicalTableConstraint(t table.PhysicalTable, reorgInfo *reorgInfo) error {
+	logutil.BgLogger().Info("[ddl] start to check constraint", zap.String("job", reorgInfo.Job.String()), zap.String("reorgInfo", reorgInfo.String()))
+	return w.writePhysicalTableRecord(w.sessPool, t, typeCheckConstraintWorker, reorgInfo)
+}
+
+// checkConstraintWorker validates the existing rows against a check constraint. It only reads the rows, so the
+// validation doesn't rewrite any data.
+type checkConstraintWorker struct {
+	*backfillCtx
+	constraint *table.Constraint
+
+	// The following attributes are used to reduce memory allocation.
+	rowDecoder *decoder.RowDecoder
+	rowMap     map[int64]types.Datum
+}
+
+func newCheckConstraintWorker(sessCtx sessionctx.Context, id int, t table.PhysicalTable, decodeColMap map[int64]decoder.Column, reorgInfo *reorgInfo, jc *JobContext) (*checkConstraintWorker, error) {
+	var constrInfo *model.ConstraintInfo
+	for _, constr := range t.Meta().Constraints {
+		if constr.ID == reorgInfo.currElement.ID {
+			constrInfo = constr
+			break
+		}
+	}
+	if constrInfo == nil {
+		return nil, errors.Errorf("constraint %d not found in table %s", reorgInfo.currElement.ID, t.Meta().Name)
+	}
+	constraint, err := table.ToConstraint(constrInfo, t.Meta())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &checkConstraintWorker{
+		backfillCtx: newBackfillCtx(reorgInfo.d, id, sessCtx, reorgInfo.SchemaName, t, jc, "check_constraint_rate", false),
+		constraint:  constraint,
+		rowDecoder:  decoder.NewRowDecoder(t, t.WritableCols(), decodeColMap),
+		rowMap:      make(map[int64]types.Datum, len(decodeColMap)),
+	}, nil
+}
+
+func (w *checkConstraintWorker) AddMetricInfo(cnt float64) {
+	w.metricCounter.Add(cnt)
+}
+
+func (*checkConstraintWorker) String() string {
+	return typeCheckConstraintWorker.String()
+}
+
+func (w *checkConstraintWorker) GetCtx() *backfillCtx {
+	return w.backfillCtx
+}
+
+// BackfillData checks the rows in the handleRange, the scanned rows are counted as the added rows to report the
+// progress.
+func (w *checkConstraintWorker) BackfillData(handleRange reorgBackfillTask) (taskCtx backfillTaskContext, errInTxn error) {
+	oprStartTime := time.Now()
+	ctx := kv.WithInternalSourceType(context.Background(), w.jobContext.ddlJobSourceType())
+	errInTxn = kv.RunInNewTxn(ctx, w.sessCtx.GetStore(), true, func(ctx context.Context, txn kv.Transaction) error {
+		txn.SetOption(kv.Priority, handleRange.priority)
+		if tagger := w.GetCtx().getResourceGroupTaggerForTopSQL(handleRange.getJobID()); tagger != nil {
+			txn.SetOption(kv.ResourceGroupTagger, tagger)
+		}
+
+		scanCount, nextKey, taskDone, err := w.checkRows(txn, handleRange)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		taskCtx.nextKey = nextKey
+		taskCtx.done = taskDone
+		taskCtx.scanCount = scanCount
+		taskCtx.addedCount = scanCount
+		return nil
+	})
+	logSlowOperations(time.Since(oprStartTime), "checkConstraintBackfillDataInTxn", 3000)
+	return
+}
+
+func (w *checkConstraintWorker) checkRows(txn kv.Transaction, taskRange reorgBackfillTask) (int, kv.Key, bool, error) {
+	// taskDone means that the checked handle is out of taskRange.endHandle.
+	taskDone := false
+	scanCount := 0
+	var lastAccessedHandle kv.Key
+	sysTZ := w.sessCtx.GetSessionVars().StmtCtx.TimeZone
+	err := iterateSnapshotKeys(w.jobContext, w.sessCtx.GetStore(), taskRange.priority, taskRange.physicalTable.RecordPrefix(),
+		txn.StartTS(), taskRange.startKey, taskRange.endKey, func(handle kv.Handle, recordKey kv.Key, rawRow []byte) (bool, error) {
+			if taskRange.endInclude {
+				taskDone = recordKey.Cmp(taskRange.endKey) > 0
+			} else {
+				taskDone = recordKey.Cmp(taskRange.endKey) >= 0
+			}
 
-	// If there is any row can't pass the check expression, the add constraint action will error.
-	// It's no need to construct expression node out and pull the chunk rows through it. Here we
-	// can let the check expression restored string as the filter in where clause directly.
-	// Prepare internal SQL to fetch data from physical table under this filter.
-	sql := fmt.Sprintf("select count(1) from `%s`.`%s` where not %s limit 1", dbInfo.Name.L, tableInfo.Name.L, constr.ExprString)
-	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
-	rows, _, err := sctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(ctx, nil, sql)
+			if taskDone || scanCount >= w.batchCnt {
+				return false, nil
+			}
+
+			if err := w.checkRow(handle, rawRow, sysTZ); err != nil {
+				return false, errors.Trace(err)
+			}
+			scanCount++
+			lastAccessedHandle = recordKey
+			if recordKey.Cmp(taskRange.endKey) == 0 {
+				taskDone = true
+				return false, nil
+			}
+			return true, nil
+		})
+
+	if scanCount == 0 {
+		taskDone = true
+	}
+	return scanCount, getNextHandleKey(taskRange, taskDone, lastAccessedHandle), taskDone, errors.Trace(err)
+}
+
+func (w *checkConstraintWorker) checkRow(handle kv.Handle, rawRow []byte, sysTZ *time.Location) error {
+	_, err := w.rowDecoder.DecodeAndEvalRowWithMap(w.sessCtx, handle, rawRow, sysTZ, w.rowMap)
+	if err != nil {
+		return errors.Trace(dbterror.ErrCantDecodeRecord.GenWithStackByArgs("constraint", err))
+	}
+	// Clean up the map to reuse it for the next row.
+	for id := range w.rowMap {
+		delete(w.rowMap, id)
+	}
+	ok, isNull, err := w.constraint.ConstraintExpr.EvalInt(w.sessCtx, w.rowDecoder.CurrentRowWithDefaultVal())
 	if err != nil {
 		return errors.Trace(err)
 	}
-	rowCount := rows[0].GetInt64(0)
-	if rowCount != 0 {
-		// If check constraint fail, the job state should be changed to canceled, otherwise it will tracked in.
-		job.State = model.JobStateCancelled
-		return dbterror.ErrCheckConstraintIsViolated.GenWithStackByArgs(constr.Name.L)
+	if ok == 0 && !isNull {
+		return dbterror.ErrCheckConstraintIsViolated.GenWithStackByArgs(w.constraint.Name.L)
 	}
 	return nil
 }
@@ -184,7 +184,7 @@ func TestAlterTableAddCheckConstraints(t *testing.T) {
 	tk.MustExec("create table t(a int)")
 	tk.MustExec("insert into t values(1), (2), (3)")
 	tk.MustGetErrMsg("alter table t add constraint check(a < 2)", "[ddl:3819]Check constraint 't_chk_1' is violated.")
-	tk.MustGetErrMsg("alter table t add constraint check(a < 2) not enforced", "[ddl:3819]Check constraint 't_chk_2' is violated.")
+	tk.MustExec("alter table t add constraint check(a < 2) not enforced")
 }
 
 func TestAlterTableDropCheckConstraints(t *testing.T) {
@@ -1138,3 +1138,70 @@ func TestIssue44689(t *testing.T) {
 		tk.MustExec(fmt.Sprintf("ALTER TABLE t2 ADD CONSTRAINT CHECK(%s)", expr))
 	}
 }
+
+func TestJSONSchemaValidCheckConstraint(t *testing.T) {
+	store, _ := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec(`create table t(id int, geo json, constraint geo_check check(json_schema_valid('{"type": "object", "properties": {"latitude": {"type": "number", "minimum": -90, "maximum": 90}}, "required": ["latitude"]}', geo)))`)
+	tk.MustExec(`insert into t values (1, '{"latitude": 59, "longitude": 18}')`)
+	tk.MustGetErrMsg(`insert into t values (2, '{"latitude": 91}')`, "[table:3819]Check constraint 'geo_check' is violated.")
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 3934 The JSON document location '#/latitude' failed requirement 'maximum' at JSON Schema location '#/properties/latitude'.",
+		"Error 3819 Check constraint 'geo_check' is violated."))
+	tk.MustGetErrMsg(`update t set geo = '{"longitude": 18}'`, "[table:3819]Check constraint 'geo_check' is violated.")
+	tk.MustExec(`update t set geo = '{"latitude": 60}'`)
+	tk.MustQuery("select id, geo from t").Check(testkit.Rows(`1 {"latitude": 60}`))
+
+	tk.MustExec("drop table t")
+	tk.MustExec("create table t(id int, geo json)")
+	tk.MustExec(`insert into t values (1, '{"latitude": 91}')`)
+	tk.MustGetErrMsg(`alter table t add constraint geo_check check(json_schema_valid('{"properties": {"latitude": {"maximum": 90}}}', geo))`,
+		"[ddl:3819]Check constraint 'geo_check' is violated.")
+}
+
+func TestCheckConstraintValidateExistingRows(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@global.tidb_ddl_reorg_batch_size = 32")
+	defer tk.MustExec("set @@global.tidb_ddl_reorg_batch_size = default")
+
+	tk.MustExec("create table t(a int, b int as (a * 2))")
+	tk.MustExec("create table pt(a int) partition by hash(a) partitions 4")
+	for i := 0; i < 100; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t(a) values (%d)", i))
+		tk.MustExec(fmt.Sprintf("insert into pt values (%d)", i))
+	}
+
+	// The existing rows are validated by the backfill workers, and the scanned rows are reported as the row count.
+	tk.MustExec("alter table t add constraint c1 check(b >= 0)")
+	require.Equal(t, "100", tk.MustQuery("admin show ddl jobs 1").Rows()[0][7])
+	tk.MustExec("alter table pt add constraint c1 check(a >= 0)")
+	require.Equal(t, "100", tk.MustQuery("admin show ddl jobs 1").Rows()[0][7])
+
+	// The job is rolled back if any row violates the constraint, and the constraint is removed.
+	tk.MustGetErrMsg("alter table t add constraint c2 check(b < 100)", "[ddl:3819]Check constraint 'c2' is violated.")
+	tk.MustGetErrMsg("alter table pt add constraint c2 check(a < 99)", "[ddl:3819]Check constraint 'c2' is violated.")
+	require.Len(t, external.GetTableByName(t, tk, "test", "t").Meta().Constraints, 1)
+	require.Len(t, external.GetTableByName(t, tk, "test", "pt").Meta().Constraints, 1)
+	tk.MustExec("insert into t(a) values (100)")
+	tk.MustExec("insert into pt values (100)")
+
+	// Enforcing a constraint validates the existing rows without rewriting them.
+	tk.MustExec("alter table t add constraint c3 check(a < 100) not enforced")
+	tk.MustGetErrMsg("alter table t alter constraint c3 enforced", "[ddl:3819]Check constraint 'c3' is violated.")
+	constrs := external.GetTableByName(t, tk, "test", "t").Meta().Constraints
+	require.Len(t, constrs, 2)
+	require.False(t, constrs[1].Enforced)
+	require.Equal(t, model.StatePublic, constrs[1].State)
+	tk.MustExec("insert into t(a) values (101)")
+
+	tk.MustExec("delete from t where a >= 100")
+	tk.MustExec("alter table t alter constraint c3 enforced")
+	require.Equal(t, "100", tk.MustQuery("admin show ddl jobs 1").Rows()[0][7])
+	require.True(t, external.GetTableByName(t, tk, "test", "t").Meta().Constraints[1].Enforced)
+	tk.MustGetErrMsg("insert into t(a) values (100)", "[table:3819]Check constraint 'c3' is violated.")
+	tk.MustQuery("select count(*), sum(a) from t").Check(testkit.Rows("100 4950"))
+}
@@ -401,7 +401,7 @@ func TestCreateTableWithHashPartition(t *testing.T) {
                                        PARTITION p0 VALUES LESS THAN (100),
                                        PARTITION p1 VALUES LESS THAN (200),
                                        PARTITION p2 VALUES LESS THAN MAXVALUE)`)
-	tk.MustQuery(`show warnings`).Check(testkit.Rows("Warning 8200 Unsupported subpartitioning, only using RANGE partitioning"))
+	tk.MustQuery(`show warnings`).Check(testkit.Rows())
 	tk.MustQuery("select * from t_sub partition (p0)").Check(testkit.Rows())
 	tk.MustQuery("show create table t_sub").Check(testkit.Rows("" +
 		"t_sub CREATE TABLE `t_sub` (\n" +
@@ -409,6 +409,7 @@ func TestCreateTableWithHashPartition(t *testing.T) {
 		"  `b` varchar(128) DEFAULT NULL\n" +
 		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
 		"PARTITION BY RANGE (`a`)\n" +
+		"SUBPARTITION BY HASH (`a`) SUBPARTITIONS 2\n" +
 		"(PARTITION `p0` VALUES LESS THAN (100),\n" +
 		" PARTITION `p1` VALUES LESS THAN (200),\n" +
 		" PARTITION `p2` VALUES LESS THAN (MAXVALUE))"))
@@ -424,24 +425,82 @@ func TestSubPartitioning(t *testing.T) {
 
 	tk := testkit.NewTestKit(t, store)
 	tk.MustExec("use test")
-	tk.MustExec(`create table t (a int) partition by range (a) subpartition by hash (a) subpartitions 2 (partition pMax values less than (maxvalue))`)
-	tk.MustQuery(`show warnings`).Check(testkit.Rows("Warning 8200 Unsupported subpartitioning, only using RANGE partitioning"))
+	tk.MustExec(`create table t (a int, b int) partition by range (a) subpartition by hash (b) subpartitions 2 (partition p0 values less than (10), partition p1 values less than (20))`)
+	tk.MustQuery(`show warnings`).Check(testkit.Rows())
 	tk.MustQuery(`show create table t`).Check(testkit.Rows("" +
 		"t CREATE TABLE `t` (\n" +
-		"  `a` int(11) DEFAULT NULL\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL\n" +
 		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
 		"PARTITION BY RANGE (`a`)\n" +
-		"(PARTITION `pMax` VALUES LESS THAN (MAXVALUE))"))
-	tk.MustExec(`drop table t`)
+		"SUBPARTITION BY HASH (`b`) SUBPARTITIONS 2\n" +
+		"(PARTITION `p0` VALUES LESS THAN (10),\n" +
+		" PARTITION `p1` VALUES LESS THAN (20))"))
+	tk.MustExec(`insert into t values (1, 1), (2, 2), (11, 3), (12, 4)`)
+	tk.MustGetErrCode(`insert into t values (21, 1)`, errno.ErrNoPartitionForGivenValue)
+	tk.MustQuery(`select * from t partition (p0) order by a`).Check(testkit.Rows("1 1", "2 2"))
+	tk.MustQuery(`select * from t partition (p0sp1)`).Check(testkit.Rows("1 1"))
+	tk.MustQuery(`select * from t partition (p1sp0)`).Check(testkit.Rows("12 4"))
+	tk.MustPartition(`select * from t where a = 11 and b = 3`, "p1sp1").Check(testkit.Rows("11 3"))
+	tk.MustPartition(`select * from t where a = 11`, "p1sp0,p1sp1").Check(testkit.Rows("11 3"))
+	tk.MustPartition(`select * from t where b = 3`, "p0sp1,p1sp1").Check(testkit.Rows("11 3"))
+	tk.MustQuery(`select partition_name, subpartition_name, partition_ordinal_position, subpartition_ordinal_position, subpartition_method, subpartition_expression from information_schema.partitions where table_schema = 'test' and table_name = 't'`).Check(testkit.Rows(
+		"p0 p0sp0 1 1 HASH `b`",
+		"p0 p0sp1 1 2 HASH `b`",
+		"p1 p1sp0 2 1 HASH `b`",
+		"p1 p1sp1 2 2 HASH `b`"))
+
+	tk.MustExec(`alter table t add partition (partition p2 values less than (30) (subpartition s0, subpartition s1 comment 'x'))`)
+	tk.MustExec(`insert into t values (25, 5)`)
+	tk.MustQuery(`select * from t partition (s1)`).Check(testkit.Rows("25 5"))
+	tk.MustQuery(`show create table t`).Check(testkit.Rows("" +
+		"t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY RANGE (`a`)\n" +
+		"SUBPARTITION BY HASH (`b`) SUBPARTITIONS 2\n" +
+		"(PARTITION `p0` VALUES LESS THAN (10),\n" +
+		" PARTITION `p1` VALUES LESS THAN (20),\n" +
+		" PARTITION `p2` VALUES LESS THAN (30)\n" +
+		" (SUBPARTITION `s0`,\n" +
+		"  SUBPARTITION `s1` COMMENT 'x'))"))
+	tk.MustGetErrCode(`alter table t add partition (partition p3 values less than (40) (subpartition s2))`, errno.ErrPartitionWrongNoSubpart)
+	tk.MustGetErrCode(`alter table t add partition (partition p3 values less than (40) (subpartition p0, subpartition s3))`, errno.ErrSameNamePartition)
+
+	tk.MustExec(`create table t2 (a int, b int)`)
+	tk.MustExec(`insert into t2 values (13, 5)`)
+	tk.MustGetErrCode(`alter table t exchange partition p1 with table t2`, errno.ErrPartitionInsteadOfSubpartition)
+	tk.MustExec(`alter table t exchange partition p1sp1 with table t2`)
+	tk.MustQuery(`select * from t partition (p1sp1)`).Check(testkit.Rows("13 5"))
+	tk.MustQuery(`select * from t2`).Check(testkit.Rows("11 3"))
+	tk.MustGetErrCode(`alter table t exchange partition p1sp0 with table t2`, errno.ErrRowDoesNotMatchPartition)
 
-	tk.MustExec(`create table t (a int) partition by list (a) subpartition by key (a) subpartitions 2 (partition pMax values in (1,3,4))`)
-	tk.MustQuery(`show warnings`).Check(testkit.Rows("Warning 8200 Unsupported subpartitioning, only using LIST partitioning"))
+	tk.MustExec(`alter table t truncate partition p0`)
+	tk.MustQuery(`select * from t partition (p0)`).Check(testkit.Rows())
+	tk.MustExec(`alter table t truncate partition p1sp0`)
+	tk.MustQuery(`select * from t order by a`).Check(testkit.Rows("13 5", "25 5"))
+	tk.MustGetErrCode(`alter table t drop partition p1sp1`, errno.ErrDropPartitionNonExistent)
+	tk.MustExec(`alter table t drop partition p0`)
+	tk.MustQuery(`select distinct partition_name from information_schema.partitions where table_schema = 'test' and table_name = 't'`).Sort().Check(testkit.Rows("p1", "p2"))
+	tk.MustExec(`drop table t, t2`)
+
+	tk.MustExec(`create table t (a int, b int) partition by list (a) subpartition by key (b) subpartitions 2 (partition p0 values in (1,3,4), partition p1 values in (2,5))`)
+	tk.MustQuery(`show warnings`).Check(testkit.Rows())
 	tk.MustQuery(`show create table t`).Check(testkit.Rows("" +
 		"t CREATE TABLE `t` (\n" +
-		"  `a` int(11) DEFAULT NULL\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL\n" +
 		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
 		"PARTITION BY LIST (`a`)\n" +
-		"(PARTITION `pMax` VALUES IN (1,3,4))"))
+		"SUBPARTITION BY KEY (`b`) SUBPARTITIONS 2\n" +
+		"(PARTITION `p0` VALUES IN (1,3,4),\n" +
+		" PARTITION `p1` VALUES IN (2,5))"))
+	tk.MustExec(`insert into t values (1, 1), (2, 2), (3, 3), (5, 4)`)
+	tk.MustQuery(`select * from t partition (p1) order by a`).Check(testkit.Rows("2 2", "5 4"))
+	tk.MustQuery(`select count(*) from t partition (p0sp0, p0sp1)`).Check(testkit.Rows("2"))
+	tk.MustPartition(`select * from t where a = 3`, "p0sp0,p0sp1").Check(testkit.Rows("3 3"))
+	tk.MustGetErrCode(`create table t3 (a int, b int, primary key (a)) partition by range (a) subpartition by hash (b) subpartitions 2 (partition p0 values less than (10))`, errno.ErrUniqueKeyNeedAllFieldsInPf)
 	tk.MustExec(`drop table t`)
 
 	tk.MustGetErrMsg(`create table t (a int) partition by hash (a) partitions 2 subpartition by key (a) subpartitions 2`, "[ddl:1500]It is only possible to mix RANGE/LIST partitioning with HASH/KEY partitioning for subpartitioning")
@@ -3851,9 +3910,9 @@ func TestPartitionErrorCode(t *testing.T) {
 		);`)
 	tk.MustGetDBError("alter table t_part coalesce partition 4;", dbterror.ErrCoalesceOnlyOnHashPartition)
 
-	tk.MustGetErrCode("alter table t_part check partition p0, p1;", errno.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t_part optimize partition p0,p1;", errno.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t_part rebuild partition p0,p1;", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("alter table t_part check partition p0, p1;")
+	tk.MustExec("alter table t_part optimize partition p0,p1;")
+	tk.MustExec("alter table t_part rebuild partition p0,p1;")
 	tk.MustGetErrCode("alter table t_part remove partitioning;", errno.ErrUnsupportedDDLOperation)
 	tk.MustGetErrCode("alter table t_part repair partition p1;", errno.ErrUnsupportedDDLOperation)
 
@@ -928,10 +928,10 @@ func checkColumnDefaultValue(ctx sessionctx.Context, col *table.Column, value in
 	hasDefaultValue := true
 	if value != nil && (col.GetType() == mysql.TypeJSON ||
 		col.GetType() == mysql.TypeTinyBlob || col.GetType() == mysql.TypeMediumBlob ||
-		col.GetType() == mysql.TypeLongBlob || col.GetType() == mysql.TypeBlob) {
+		col.GetType() == mysql.TypeLongBlob || col.GetType() == mysql.TypeBlob || col.GetType() == mysql.TypeGeometry) {
 		// In non-strict SQL mode.
 		if !ctx.GetSessionVars().SQLMode.HasStrictMode() && value == "" {
-			if col.GetType() == mysql.TypeBlob || col.GetType() == mysql.TypeLongBlob {
+			if col.GetType() == mysql.TypeBlob || col.GetType() == mysql.TypeLongBlob || col.GetType() == mysql.TypeGeometry {
 				// The TEXT/BLOB default value can be ignored.
 				hasDefaultValue = false
 			}
@@ -1153,6 +1153,10 @@ func columnDefToCol(ctx sessionctx.Context, offset int, colDef *ast.ColumnDef, o
 				if field_types.HasCharset(colDef.Tp) {
 					col.FieldType.SetCollate(v.StrValue)
 				}
+			case ast.ColumnOptionSRID:
+				if err = setColumnSRID(col, v); err != nil {
+					return nil, nil, errors.Trace(err)
+				}
 			case ast.ColumnOptionFulltext:
 				ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.GenWithStackByArgs())
 			case ast.ColumnOptionCheck:
@@ -1264,8 +1268,8 @@ func getDefaultValue(ctx sessionctx.Context, col *table.Column, option *ast.Colu
 	}
 
 	if v.Kind() == types.KindBinaryLiteral || v.Kind() == types.KindMysqlBit {
-		if types.IsTypeBlob(tp) || tp == mysql.TypeJSON {
-			// BLOB/TEXT/JSON column cannot have a default value.
+		if types.IsTypeBlob(tp) || tp == mysql.TypeJSON || tp == mysql.TypeGeometry {
+			// BLOB/TEXT/JSON/GEOMETRY column cannot have a default value.
 			// Skip the unnecessary decode procedure.
 			return v.GetString(), false, err
 		}
@@ -2212,6 +2216,11 @@ func checkTableInfoValidWithStmt(ctx sessionctx.Context, tbInfo *model.TableInfo
 			if err := checkPartitionFuncType(ctx, s.Partition.Expr, tbInfo); err != nil {
 				return errors.Trace(err)
 			}
+			if s.Partition.Sub != nil && tbInfo.Partition.Sub != nil {
+				if err := checkPartitionFuncType(ctx, s.Partition.Sub.Expr, tbInfo); err != nil {
+					return errors.Trace(err)
+				}
+			}
 			if err := checkPartitioningKeysConstraints(ctx, s, tbInfo); err != nil {
 				return errors.Trace(err)
 			}
@@ -2241,6 +2250,12 @@ func checkPartitionDefinitionConstraints(ctx sessionctx.Context, tbInfo *model.T
 		return err
 	}
 
+	if tbInfo.Partition.Sub != nil {
+		// The partition values are checked on the partitions, not the subpartitions.
+		partTbInfo := *tbInfo
+		partTbInfo.Partition = tbInfo.Partition.PartitionLevel()
+		tbInfo = &partTbInfo
+	}
 	switch tbInfo.Partition.Type {
 	case model.PartitionTypeRange:
 		err = checkPartitionByRange(ctx, tbInfo)
@@ -3265,12 +3280,12 @@ func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) err
 			tbInfo.PlacementPolicyRef = &model.PolicyRefInfo{
 				Name: model.NewCIStr(op.StrValue),
 			}
-		case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval:
+		case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval, ast.TableOptionTTLTimestampUnit, ast.TableOptionTTLArchiveTable:
 			if ttlOptionsHandled {
 				continue
 			}
 
-			ttlInfo, ttlEnable, ttlJobInterval, err := getTTLInfoInOptions(options)
+			ttlInfo, ttlEnable, ttlJobInterval, ttlTimestampUnit, ttlArchiveTable, err := getTTLInfoInOptions(options)
 			if err != nil {
 				return err
 			}
@@ -3283,6 +3298,12 @@ func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) err
 				if ttlJobInterval != nil {
 					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_JOB_INTERVAL"))
 				}
+				if ttlTimestampUnit != nil {
+					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_TIMESTAMP_UNIT"))
+				}
+				if ttlArchiveTable != nil {
+					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ARCHIVE_TABLE"))
+				}
 			}
 
 			tbInfo.TTLInfo = ttlInfo
@@ -3514,11 +3535,11 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 		case ast.AlterTableReorganizeLastPartition:
 			err = dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("SPLIT LAST PARTITION")
 		case ast.AlterTableCheckPartitions:
-			err = errors.Trace(dbterror.ErrUnsupportedCheckPartition)
-		case ast.AlterTableRebuildPartition:
-			err = errors.Trace(dbterror.ErrUnsupportedRebuildPartition)
-		case ast.AlterTableOptimizePartition:
-			err = errors.Trace(dbterror.ErrUnsupportedOptimizePartition)
+			// A single CHECK PARTITION is built as a CheckTable plan, so here it is combined with other specs.
+			err = errors.Trace(dbterror.ErrRunMultiSchemaChanges.FastGenByArgs("check partition"))
+		case ast.AlterTableRebuildPartition, ast.AlterTableOptimizePartition:
+			// Like InnoDB, OPTIMIZE PARTITION is done by rebuilding the partitions.
+			err = d.RebuildTablePartitions(sctx, ident, spec)
 		case ast.AlterTableRemovePartitioning:
 			err = errors.Trace(dbterror.ErrUnsupportedRemovePartition)
 		case ast.AlterTableRepairPartition:
@@ -3636,19 +3657,21 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 						Name: model.NewCIStr(opt.StrValue),
 					}
 				case ast.TableOptionEngine:
-				case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval:
+				case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval, ast.TableOptionTTLTimestampUnit, ast.TableOptionTTLArchiveTable:
 					var ttlInfo *model.TTLInfo
 					var ttlEnable *bool
 					var ttlJobInterval *string
+					var ttlTimestampUnit *string
+					var ttlArchiveTable *model.TTLArchiveTable
 
 					if ttlOptionsHandled {
 						continue
 					}
-					ttlInfo, ttlEnable, ttlJobInterval, err = getTTLInfoInOptions(spec.Options)
+					ttlInfo, ttlEnable, ttlJobInterval, ttlTimestampUnit, ttlArchiveTable, err = getTTLInfoInOptions(spec.Options)
 					if err != nil {
 						return err
 					}
-					err = d.AlterTableTTLInfoOrEnable(sctx, ident, ttlInfo, ttlEnable, ttlJobInterval)
+					err = d.AlterTableTTLInfoOrEnable(sctx, ident, ttlInfo, ttlEnable, ttlJobInterval, ttlTimestampUnit, ttlArchiveTable)
 
 					ttlOptionsHandled = true
 				default:
@@ -4127,7 +4150,7 @@ func getReorganizedDefinitions(pi *model.PartitionInfo, firstPartIdx, lastPartId
 	return tmpDefs
 }
 
-func getReplacedPartitionIDs(names []model.CIStr, pi *model.PartitionInfo) (firstPartIdx int, lastPartIdx int, idMap map[int]struct{}, err error) {
+func getReplacedPartitionIDs(names []model.CIStr, pi *model.PartitionInfo, rebuild bool) (firstPartIdx int, lastPartIdx int, idMap map[int]struct{}, err error) {
 	idMap = make(map[int]struct{})
 	firstPartIdx, lastPartIdx = -1, -1
 	for _, name := range names {
@@ -4157,7 +4180,8 @@ func getReplacedPartitionIDs(names []model.CIStr, pi *model.PartitionInfo) (firs
 				"REORGANIZE PARTITION of RANGE; not adjacent partitions"))
 		}
 	case model.PartitionTypeHash, model.PartitionTypeKey:
-		if len(idMap) != len(pi.Definitions) {
+		// A rebuilt partition keeps its position, so the rows stay in the same partition.
+		if len(idMap) != len(pi.Definitions) && !rebuild {
 			return 0, 0, nil, errors.Trace(dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(
 				"REORGANIZE PARTITION of HASH/RANGE; must reorganize all partitions"))
 		}
@@ -4188,7 +4212,10 @@ func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec
 	default:
 		return errors.Trace(dbterror.ErrUnsupportedReorganizePartition)
 	}
-	firstPartIdx, lastPartIdx, idMap, err := getReplacedPartitionIDs(spec.PartitionNames, pi)
+	if pi.Sub != nil {
+		return errors.Trace(dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("REORGANIZE PARTITION of a subpartitioned table"))
+	}
+	firstPartIdx, lastPartIdx, idMap, err := getReplacedPartitionIDs(spec.PartitionNames, pi, false)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -4232,6 +4259,84 @@ func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec
 	return errors.Trace(err)
 }
 
+// RebuildTablePartitions rebuilds the data and indexes of the partitions, by reorganizing
+// each partition into a copy of itself.
+func (d *ddl) RebuildTablePartitions(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.FastGenByArgs(ident.Schema, ident.Name))
+	}
+
+	meta := t.Meta()
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return dbterror.ErrPartitionMgmtOnNonpartitioned
+	}
+	if pi.Sub != nil {
+		return errors.Trace(dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("REBUILD PARTITION of a subpartitioned table"))
+	}
+	var defs []model.PartitionDefinition
+	if spec.OnAllPartitions {
+		defs = pi.Definitions
+	} else {
+		posMap := make(map[int]struct{}, len(spec.PartitionNames))
+		for _, name := range spec.PartitionNames {
+			pos := pi.FindPartitionDefinitionByName(name.L)
+			if pos < 0 {
+				return errors.Trace(table.ErrUnknownPartition.GenWithStackByArgs(name.O, ident.Name.O))
+			}
+			if _, ok := posMap[pos]; !ok {
+				defs = append(defs, pi.Definitions[pos])
+				posMap[pos] = struct{}{}
+			}
+		}
+	}
+
+	tzName, tzOffset := ddlutil.GetTimeZone(ctx)
+	// Rebuild the partitions one by one, so a rebuilt partition keeps its position
+	// and its rows, whatever the partitioning type is.
+	for i := range defs {
+		def := defs[i].Clone()
+		def.ID = 0
+		partInfo := &model.PartitionInfo{
+			Type:        pi.Type,
+			Expr:        pi.Expr,
+			Columns:     pi.Columns,
+			Enable:      pi.Enable,
+			Num:         1,
+			Definitions: []model.PartitionDefinition{def},
+		}
+		if err = d.assignPartitionIDs(partInfo.Definitions); err != nil {
+			return errors.Trace(err)
+		}
+		if err = handlePartitionPlacement(ctx, partInfo); err != nil {
+			return errors.Trace(err)
+		}
+		job := &model.Job{
+			SchemaID:   schema.ID,
+			TableID:    meta.ID,
+			SchemaName: schema.Name.L,
+			TableName:  meta.Name.L,
+			Type:       model.ActionReorganizePartition,
+			BinlogInfo: &model.HistoryInfo{},
+			Args:       []interface{}{[]model.CIStr{defs[i].Name}, partInfo},
+			ReorgMeta: &model.DDLReorgMeta{
+				SQLMode:       ctx.GetSessionVars().SQLMode,
+				Warnings:      make(map[errors.ErrorID]*terror.Error),
+				WarningsCount: make(map[errors.ErrorID]int64),
+				Location:      &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
+			},
+		}
+		err = d.DoDDLJob(ctx, job)
+		err = d.callHookOnChanged(job, err)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	ctx.GetSessionVars().StmtCtx.AppendWarning(errors.New("The statistics of related partitions will be outdated after rebuilding partitions. Please use 'ANALYZE TABLE' statement if you want to update it now"))
+	return nil
+}
+
 func checkReorgPartitionDefs(ctx sessionctx.Context, tblInfo *model.TableInfo, partInfo *model.PartitionInfo, firstPartIdx, lastPartIdx int, idMap map[int]struct{}) error {
 	// partInfo contains only the new added partition, we have to combine it with the
 	// old partitions to check all partitions is strictly increasing.
@@ -4380,13 +4485,23 @@ func (d *ddl) TruncateTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 		// so we filter them out through a hash
 		posMap := make(map[int]bool)
 		for _, name := range spec.PartitionNames {
-			pos := pi.FindPartitionDefinitionByName(name.L)
-			if pos < 0 {
+			var positions []int
+			if pos := pi.FindPartitionDefinitionByName(name.L); pos >= 0 {
+				positions = append(positions, pos)
+			} else if subDefs := pi.GetSubPartitionDefinitions(name.L); len(subDefs) > 0 {
+				// Truncating a partition truncates all of its subpartitions.
+				pos = pi.FindPartitionDefinitionByName(subDefs[0].Name.L)
+				for i := range subDefs {
+					positions = append(positions, pos+i)
+				}
+			} else {
 				return nil, errors.Trace(table.ErrUnknownPartition.GenWithStackByArgs(name.L, ident.Name.O))
 			}
-			if _, ok := posMap[pos]; !ok {
-				defs = append(defs, pi.Definitions[pos])
-				posMap[pos] = true
+			for _, pos := range positions {
+				if _, ok := posMap[pos]; !ok {
+					defs = append(defs, pi.Definitions[pos])
+					posMap[pos] = true
+				}
 			}
 		}
 		pi = pi.Clone()
@@ -4465,8 +4580,9 @@ func (d *ddl) DropTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *
 		if intervalOptions.NullPart {
 			pNullOffset = 1
 		}
+		partDefs := meta.Partition.PartitionLevel().Definitions
 		if len(spec.Partition.Definitions) == 0 ||
-			len(spec.Partition.Definitions) >= len(meta.Partition.Definitions)-pNullOffset {
+			len(spec.Partition.Definitions) >= len(partDefs)-pNullOffset {
 			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(
 				"FIRST PARTITION, number of partitions does not match")
 		}
@@ -4475,7 +4591,7 @@ func (d *ddl) DropTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *
 				"FIRST PARTITION, given value does not generate a list of partition names to be dropped")
 		}
 		for i := range spec.Partition.Definitions {
-			spec.PartitionNames = append(spec.PartitionNames, meta.Partition.Definitions[i+pNullOffset].Name)
+			spec.PartitionNames = append(spec.PartitionNames, partDefs[i+pNullOffset].Name)
 		}
 		// Use the last generated partition as First, i.e. do not drop the last name in the slice
 		spec.PartitionNames = spec.PartitionNames[:len(spec.PartitionNames)-1]
@@ -4701,7 +4817,10 @@ func (d *ddl) ExchangeTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 
 	partName := spec.PartitionNames[0].L
 
-	// NOTE: if pt is subPartitioned, it should be checked
+	// Only the subpartitions of a subpartitioned table can be exchanged.
+	if ptMeta.Partition.GetSubPartitionDefinitions(partName) != nil {
+		return errors.Trace(dbterror.ErrPartitionInsteadOfSubpartition)
+	}
 
 	defID, err := tables.FindPartitionByName(ptMeta, partName)
 	if err != nil {
@@ -4905,6 +5024,19 @@ func checkModifyTypes(origin *types.FieldType, to *types.FieldType, needRewriteC
 	return errors.Trace(err)
 }
 
+// setColumnSRID restricts the spatial column to the SRID of the option.
+func setColumnSRID(col *table.Column, option *ast.ColumnOption) error {
+	if col.GetType() != mysql.TypeGeometry {
+		return dbterror.ErrWrongUsage.GenWithStackByArgs("SRID", "non-geometry column")
+	}
+	srid, _ := option.Expr.(ast.ValueExpr).GetValue().(uint64)
+	if srid > math.MaxUint32 || !types.IsKnownSRID(uint32(srid)) {
+		return types.ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	col.FieldType.SetSRID(uint32(srid))
+	return nil
+}
+
 // SetDefaultValue sets the default value of the column.
 func SetDefaultValue(ctx sessionctx.Context, col *table.Column, option *ast.ColumnOption) (bool, error) {
 	hasDefaultValue := false
@@ -5019,6 +5151,10 @@ func processColumnOptions(ctx sessionctx.Context, col *table.Column, options []*
 			}
 		case ast.ColumnOptionCollate:
 			col.SetCollate(opt.StrValue)
+		case ast.ColumnOptionSRID:
+			if err = setColumnSRID(col, opt); err != nil {
+				return errors.Trace(err)
+			}
 		case ast.ColumnOptionReference:
 			return errors.Trace(dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't modify with references"))
 		case ast.ColumnOptionFulltext:
@@ -5344,9 +5480,11 @@ func GetModifiableColumnJob(
 	}
 
 	if t.Meta().TTLInfo != nil {
-		// the column referenced by TTL should be a time type
-		if t.Meta().TTLInfo.ColumnName.L == originalColName.L && !types.IsTypeTime(newCol.ColumnInfo.FieldType.GetType()) {
-			return nil, errors.Trace(dbterror.ErrUnsupportedColumnInTTLConfig.GenWithStackByArgs(newCol.ColumnInfo.Name.O))
+		// the column referenced by TTL should be a time type, or an integer type if the timestamp unit is set
+		if t.Meta().TTLInfo.ColumnName.L == originalColName.L {
+			if err = checkTTLColumnType(newCol.ColumnInfo, t.Meta().TTLInfo); err != nil {
+				return nil, errors.Trace(err)
+			}
 		}
 	}
 
@@ -5919,8 +6057,15 @@ func (d *ddl) AlterTableSetTiFlashReplica(ctx sessionctx.Context, ident ast.Iden
 // `.Enable`. If the `.TTLInfo` in the table info is empty, this function will return an error.
 // When `ttlInfo` is nil, and `ttlCronJobSchedule` is not, it will use the original `.TTLInfo` in the table info and modify the
 // `.JobInterval`. If the `.TTLInfo` in the table info is empty, this function will return an error.
-// When `ttlInfo` is not nil, it simply submits the job with the `ttlInfo` and ignore the `ttlEnable`.
-func (d *ddl) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident, ttlInfo *model.TTLInfo, ttlEnable *bool, ttlCronJobSchedule *string) error {
+// When `ttlInfo` is nil, and `ttlTimestampUnit` is not, it will use the original `.TTLInfo` in the table info and modify the
+// `.TimestampUnit`. If the `.TTLInfo` in the table info is empty, this function will return an error.
+// When `ttlInfo` is nil, and `ttlArchiveTable` is not, it will use the original `.TTLInfo` in the table info and modify the
+// `.ArchiveTable`, an empty `ttlArchiveTable` removes the archive table. If the `.TTLInfo` in the table info is empty,
+// this function will return an error.
+// When `ttlInfo` is not nil, it simply submits the job with the `ttlInfo` and ignore the `ttlEnable`. If `ttlTimestampUnit`
+// is nil and the TTL column is still an integer column, the original `.TimestampUnit` is kept. If `ttlArchiveTable` is nil,
+// the original `.ArchiveTable` is kept.
+func (d *ddl) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident, ttlInfo *model.TTLInfo, ttlEnable *bool, ttlCronJobSchedule *string, ttlTimestampUnit *string, ttlArchiveTable *model.TTLArchiveTable) error {
 	is := d.infoCache.GetLatest()
 	schema, ok := is.SchemaByName(ident.Schema)
 	if !ok {
@@ -5937,7 +6082,28 @@ func (d *ddl) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident,
 	tableName := tblInfo.Name.L
 
 	var job *model.Job
+	if ttlInfo == nil && (ttlTimestampUnit != nil || ttlArchiveTable != nil) && tblInfo.TTLInfo != nil {
+		ttlInfo = tblInfo.TTLInfo.Clone()
+		if ttlTimestampUnit != nil {
+			ttlInfo.TimestampUnit = *ttlTimestampUnit
+		}
+		if ttlArchiveTable != nil {
+			ttlInfo.ArchiveTable = nil
+			if ttlArchiveTable.Table.L != "" {
+				ttlInfo.ArchiveTable = ttlArchiveTable
+			}
+		}
+	}
 	if ttlInfo != nil {
+		if ttlTimestampUnit == nil && tblInfo.TTLInfo != nil {
+			if col := findColumnByName(ttlInfo.ColumnName.L, tblInfo); col != nil && mysql.IsIntegerType(col.GetType()) {
+				ttlInfo.TimestampUnit = tblInfo.TTLInfo.TimestampUnit
+			}
+		}
+		if ttlArchiveTable == nil && tblInfo.TTLInfo != nil && tblInfo.TTLInfo.ArchiveTable != nil {
+			archiveTable := *tblInfo.TTLInfo.ArchiveTable
+			ttlInfo.ArchiveTable = &archiveTable
+		}
 		tblInfo.TTLInfo = ttlInfo
 		err = checkTTLInfoValid(ctx, ident.Schema, tblInfo)
 		if err != nil {
@@ -5951,6 +6117,12 @@ func (d *ddl) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident,
 			if ttlCronJobSchedule != nil {
 				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_JOB_INTERVAL"))
 			}
+			if ttlTimestampUnit != nil {
+				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_TIMESTAMP_UNIT"))
+			}
+			if ttlArchiveTable != nil {
+				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ARCHIVE_TABLE"))
+			}
 		}
 	}
 
@@ -7446,6 +7618,13 @@ func BuildAddedPartitionInfo(ctx sessionctx.Context, meta *model.TableInfo, spec
 	}
 
 	part.Definitions = defs
+	if meta.Partition.Sub != nil {
+		part.Sub = meta.Partition.Sub.Clone()
+		part.Definitions, err = buildSubPartitionDefinitions(ctx, spec.PartDefinitions, part)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return part, nil
 }
 
@@ -8586,14 +8765,21 @@ func (d *ddl) CreateCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constr
 	if err := table.IfCheckConstraintExprBoolType(constraintInfo, tblInfo); err != nil {
 		return err
 	}
+	tzName, tzOffset := ddlutil.GetTimeZone(ctx)
 	job := &model.Job{
 		SchemaID:   schema.ID,
 		TableID:    t.Meta().ID,
 		SchemaName: schema.Name.L,
 		Type:       model.ActionAddCheckConstraint,
 		BinlogInfo: &model.HistoryInfo{},
-		Args:       []interface{}{constraintInfo},
-		Priority:   ctx.GetSessionVars().DDLReorgPriority,
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+			Location:      &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
+		},
+		Args:     []interface{}{constraintInfo},
+		Priority: ctx.GetSessionVars().DDLReorgPriority,
 	}
 
 	err = d.DoDDLJob(ctx, job)
@@ -8647,13 +8833,23 @@ func (d *ddl) AlterCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constrN
 		return dbterror.ErrConstraintNotFound.GenWithStackByArgs(constrName)
 	}
 
+	tzName, tzOffset := ddlutil.GetTimeZone(ctx)
 	job := &model.Job{
 		SchemaID:   schema.ID,
 		TableID:    t.Meta().ID,
 		SchemaName: schema.Name.L,
 		Type:       model.ActionAlterCheckConstraint,
 		BinlogInfo: &model.HistoryInfo{},
-		Args:       []interface{}{constrName, enforced},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+			Location:      &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
+		},
+		// Enforcing the constraint needs to validate the existing data.
+		CtxVars:  []interface{}{enforced},
+		Args:     []interface{}{constrName, enforced},
+		Priority: ctx.GetSessionVars().DDLReorgPriority,
 	}
 
 	err = d.DoDDLJob(ctx, job)
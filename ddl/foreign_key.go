@@ -263,9 +263,6 @@ func checkTableForeignKey(referTblInfo, tblInfo *model.TableInfo, fkInfo *model.
 	if referTblInfo.TempTableType != model.TempTableNone || tblInfo.TempTableType != model.TempTableNone {
 		return infoschema.ErrCannotAddForeign
 	}
-	if referTblInfo.TTLInfo != nil {
-		return dbterror.ErrUnsupportedTTLReferencedByFK
-	}
 	if referTblInfo.GetPartitionInfo() != nil || tblInfo.GetPartitionInfo() != nil {
 		return infoschema.ErrForeignKeyOnPartitioned
 	}
@@ -177,8 +177,8 @@ func checkIndexColumn(ctx sessionctx.Context, col *model.ColumnInfo, indexColumn
 		return errors.Trace(dbterror.ErrJSONUsedAsKey.GenWithStackByArgs(col.Name.O))
 	}
 
-	// Length must be specified and non-zero for BLOB and TEXT column indexes.
-	if types.IsTypeBlob(col.FieldType.GetType()) {
+	// Length must be specified and non-zero for BLOB, TEXT and GEOMETRY column indexes.
+	if types.IsTypeBlob(col.FieldType.GetType()) || col.FieldType.GetType() == mysql.TypeGeometry {
 		if indexColumnLen == types.UnspecifiedLength {
 			if col.Hidden {
 				return dbterror.ErrFunctionalIndexOnBlob
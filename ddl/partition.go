@@ -339,7 +339,7 @@ func rollbackAddingPartitionInfo(tblInfo *model.TableInfo) ([]int64, []string, [
 // checkAddPartitionValue values less than value must be strictly increasing for each partition.
 func checkAddPartitionValue(meta *model.TableInfo, part *model.PartitionInfo) error {
 	if meta.Partition.Type == model.PartitionTypeRange && len(meta.Partition.Columns) == 0 {
-		newDefs, oldDefs := part.Definitions, meta.Partition.Definitions
+		newDefs, oldDefs := part.PartitionLevel().Definitions, meta.Partition.PartitionLevel().Definitions
 		rangeValue := oldDefs[len(oldDefs)-1].LessThan[0]
 		if strings.EqualFold(rangeValue, "MAXVALUE") {
 			return errors.Trace(dbterror.ErrPartitionMaxvalue)
@@ -487,9 +487,6 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 		ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedCreatePartition.GenWithStack(fmt.Sprintf("Unsupported partition type %v, treat as normal table", s.Tp)))
 		return nil
 	}
-	if s.Sub != nil {
-		ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedCreatePartition.GenWithStack(fmt.Sprintf("Unsupported subpartitioning, only using %v partitioning", s.Tp)))
-	}
 
 	pi := &model.PartitionInfo{
 		Type:   s.Tp,
@@ -545,6 +542,20 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 		}
 	}
 
+	if s.Sub != nil {
+		sub, err := buildSubPartitionInfo(ctx, s.Sub, tbInfo)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if sub != nil {
+			pi.Sub = sub
+			pi.Definitions, err = buildSubPartitionDefinitions(ctx, s.Definitions, pi)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+
 	partCols, err := getPartitionColSlices(ctx, tbInfo, s)
 	if err != nil {
 		return errors.Trace(err)
@@ -558,7 +569,107 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 	return nil
 }
 
+// buildSubPartitionInfo builds the subpartitioning info, it returns nil if the subpartitioning isn't supported.
+func buildSubPartitionInfo(ctx sessionctx.Context, s *ast.PartitionMethod, tbInfo *model.TableInfo) (*model.SubPartitionInfo, error) {
+	if s.Tp == model.PartitionTypeKey && len(s.ColumnNames) == 0 {
+		ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedCreatePartition.GenWithStack(fmt.Sprintf("Unsupported subpartitioning, only using %v partitioning", tbInfo.Partition.Type)))
+		return nil, nil
+	}
+	// Note that linear hash is simply ignored, and creates non-linear hash/key.
+	if s.Linear {
+		ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedCreatePartition.GenWithStack(fmt.Sprintf("LINEAR %s is not supported, using non-linear %s instead", s.Tp.String(), s.Tp.String())))
+	}
+	sub := &model.SubPartitionInfo{
+		Type: s.Tp,
+		Num:  s.Num,
+	}
+	if sub.Num == 0 {
+		sub.Num = 1
+	}
+	if s.Expr != nil {
+		if err := checkPartitionFuncValid(ctx, tbInfo, s.Expr); err != nil {
+			return nil, errors.Trace(err)
+		}
+		buf := new(bytes.Buffer)
+		restoreCtx := format.NewRestoreCtx(format.DefaultRestoreFlags|format.RestoreBracketAroundBinaryOperation, buf)
+		if err := s.Expr.Restore(restoreCtx); err != nil {
+			return nil, err
+		}
+		sub.Expr = buf.String()
+	} else {
+		sub.Columns = make([]model.CIStr, 0, len(s.ColumnNames))
+		for _, cn := range s.ColumnNames {
+			colInfo := tbInfo.FindPublicColumnByName(cn.Name.L)
+			if colInfo == nil {
+				return nil, errors.Trace(dbterror.ErrFieldNotFoundPart)
+			}
+			if !isColTypeAllowedAsPartitioningCol(s.Tp, colInfo.FieldType) {
+				return nil, dbterror.ErrNotAllowedTypeInPartition.GenWithStackByArgs(cn.Name.O)
+			}
+			sub.Columns = append(sub.Columns, cn.Name)
+		}
+	}
+	return sub, nil
+}
+
+// buildSubPartitionDefinitions splits every partition of pi into pi.Sub.Num subpartitions,
+// the subpartitions without explicit definitions are named after their partitions.
+func buildSubPartitionDefinitions(ctx sessionctx.Context, defs []*ast.PartitionDefinition, pi *model.PartitionInfo) ([]model.PartitionDefinition, error) {
+	subDefs := make([]model.PartitionDefinition, 0, len(pi.Definitions)*int(pi.Sub.Num))
+	for i, partDef := range pi.Definitions {
+		var astSubDefs []*ast.SubPartitionDefinition
+		if i < len(defs) {
+			astSubDefs = defs[i].Sub
+		}
+		if len(astSubDefs) > 0 && len(astSubDefs) != int(pi.Sub.Num) {
+			return nil, errors.Trace(ast.ErrPartitionWrongNoSubpart)
+		}
+		for j := 0; j < int(pi.Sub.Num); j++ {
+			subDef := partDef
+			subDef.ParentName = partDef.Name
+			subDef.Name = model.NewCIStr(fmt.Sprintf("%ssp%d", partDef.Name.O, j))
+			if j < len(astSubDefs) {
+				subDef.Name = astSubDefs[j].Name
+				for _, opt := range astSubDefs[j].Options {
+					if opt.Tp == ast.TableOptionComment {
+						comment := opt.StrValue
+						comment, err := validateCommentLength(ctx.GetSessionVars(), subDef.Name.L, &comment, dbterror.ErrTooLongTablePartitionComment)
+						if err != nil {
+							return nil, err
+						}
+						subDef.Comment = comment
+					}
+				}
+				if err := setPartitionPlacementFromOptions(&subDef, astSubDefs[j].Options); err != nil {
+					return nil, err
+				}
+			}
+			if err := checkTooLongTable(subDef.Name); err != nil {
+				return nil, err
+			}
+			subDefs = append(subDefs, subDef)
+		}
+	}
+	return subDefs, nil
+}
+
 func getPartitionColSlices(sctx sessionctx.Context, tblInfo *model.TableInfo, s *ast.PartitionOptions) (partCols stringSlice, err error) {
+	partCols, err = getPartitionMethodColSlices(sctx, tblInfo, &s.PartitionMethod)
+	if err != nil {
+		return nil, err
+	}
+	// The subpartitioning is ignored if it's not supported.
+	if s.Sub != nil && tblInfo.Partition != nil && tblInfo.Partition.Sub != nil {
+		subCols, err := getPartitionMethodColSlices(sctx, tblInfo, s.Sub)
+		if err != nil {
+			return nil, err
+		}
+		partCols = joinedStringSlice{partCols, subCols}
+	}
+	return partCols, nil
+}
+
+func getPartitionMethodColSlices(sctx sessionctx.Context, tblInfo *model.TableInfo, s *ast.PartitionMethod) (partCols stringSlice, err error) {
 	if s.Expr != nil {
 		extractCols := newPartitionExprChecker(sctx, tblInfo)
 		s.Expr.Accept(extractCols)
@@ -582,6 +693,12 @@ func getPartitionIntervalFromTable(ctx sessionctx.Context, tbInfo *model.TableIn
 		tbInfo.Partition.Type != model.PartitionTypeRange {
 		return nil
 	}
+	if tbInfo.Partition.Sub != nil {
+		// The INTERVAL is calculated from the partitions, not the subpartitions.
+		partTbInfo := *tbInfo
+		partTbInfo.Partition = tbInfo.Partition.PartitionLevel()
+		tbInfo = &partTbInfo
+	}
 	if len(tbInfo.Partition.Columns) > 1 {
 		// Multi-column RANGE COLUMNS is not supported with INTERVAL
 		return nil
@@ -1366,13 +1483,13 @@ func checkPartitionValuesIsInt(ctx sessionctx.Context, defName interface{}, expr
 }
 
 func checkPartitionNameUnique(pi *model.PartitionInfo) error {
-	newPars := pi.Definitions
+	newPars := getPartitionDefinitionNames(pi, pi.Definitions)
 	partNames := make(map[string]struct{}, len(newPars))
 	for _, newPar := range newPars {
-		if _, ok := partNames[newPar.Name.L]; ok {
-			return dbterror.ErrSameNamePartition.GenWithStackByArgs(newPar.Name)
+		if _, ok := partNames[newPar.L]; ok {
+			return dbterror.ErrSameNamePartition.GenWithStackByArgs(newPar)
 		}
-		partNames[newPar.Name.L] = struct{}{}
+		partNames[newPar.L] = struct{}{}
 	}
 	return nil
 }
@@ -1380,21 +1497,36 @@ func checkPartitionNameUnique(pi *model.PartitionInfo) error {
 func checkAddPartitionNameUnique(tbInfo *model.TableInfo, pi *model.PartitionInfo) error {
 	partNames := make(map[string]struct{})
 	if tbInfo.Partition != nil {
-		oldPars := tbInfo.Partition.Definitions
+		oldPars := getPartitionDefinitionNames(tbInfo.Partition, tbInfo.Partition.Definitions)
 		for _, oldPar := range oldPars {
-			partNames[oldPar.Name.L] = struct{}{}
+			partNames[oldPar.L] = struct{}{}
 		}
 	}
-	newPars := pi.Definitions
+	newPars := getPartitionDefinitionNames(pi, pi.Definitions)
 	for _, newPar := range newPars {
-		if _, ok := partNames[newPar.Name.L]; ok {
-			return dbterror.ErrSameNamePartition.GenWithStackByArgs(newPar.Name)
+		if _, ok := partNames[newPar.L]; ok {
+			return dbterror.ErrSameNamePartition.GenWithStackByArgs(newPar)
 		}
-		partNames[newPar.Name.L] = struct{}{}
+		partNames[newPar.L] = struct{}{}
 	}
 	return nil
 }
 
+// getPartitionDefinitionNames returns the names of defs, for a subpartitioned table,
+// the names of the partitions which the subpartitions belong to are returned too.
+func getPartitionDefinitionNames(pi *model.PartitionInfo, defs []model.PartitionDefinition) []model.CIStr {
+	names := make([]model.CIStr, 0, len(defs))
+	for i := range defs {
+		names = append(names, defs[i].Name)
+	}
+	if pi.Sub != nil {
+		for _, def := range pi.GetPartitionDefinitions(defs) {
+			names = append(names, def.Name)
+		}
+	}
+	return names
+}
+
 func checkReorgPartitionNames(p *model.PartitionInfo, droppedNames []model.CIStr, pi *model.PartitionInfo) error {
 	partNames := make(map[string]struct{})
 	oldDefs := p.Definitions
@@ -1663,7 +1795,8 @@ func getRangeValue(ctx sessionctx.Context, str string, unsigned bool) (interface
 
 // CheckDropTablePartition checks if the partition exists and does not allow deleting the last existing partition in the table.
 func CheckDropTablePartition(meta *model.TableInfo, partLowerNames []string) error {
-	pi := meta.Partition
+	// Only the partitions can be dropped from a subpartitioned table, not the subpartitions.
+	pi := meta.Partition.PartitionLevel()
 	if pi.Type != model.PartitionTypeRange && pi.Type != model.PartitionTypeList {
 		return dbterror.ErrOnlyOnRangeListPartition.GenWithStackByArgs("DROP")
 	}
@@ -1706,7 +1839,8 @@ func updateDroppingPartitionInfo(tblInfo *model.TableInfo, partLowerNames []stri
 	for i := range oldDefs {
 		found := false
 		for _, partName := range partLowerNames {
-			if oldDefs[i].Name.L == partName {
+			// All the subpartitions of a dropped partition are dropped.
+			if oldDefs[i].Name.L == partName || oldDefs[i].ParentName.L == partName {
 				found = true
 				break
 			}
@@ -1857,7 +1991,15 @@ func (w *worker) onDropTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (
 			return ver, errors.Trace(err)
 		}
 		physicalTableIDs = updateDroppingPartitionInfo(tblInfo, partNames)
-		err = dropLabelRules(d, job.SchemaName, tblInfo.Name.L, partNames)
+		labelPartNames := partNames
+		if tblInfo.Partition.Sub != nil {
+			// The label rules are bound to the subpartitions.
+			labelPartNames = make([]string, 0, len(tblInfo.Partition.DroppingDefinitions))
+			for _, def := range tblInfo.Partition.DroppingDefinitions {
+				labelPartNames = append(labelPartNames, def.Name.L)
+			}
+		}
+		err = dropLabelRules(d, job.SchemaName, tblInfo.Name.L, labelPartNames)
 		if err != nil {
 			job.State = model.JobStateCancelled
 			return ver, errors.Wrapf(err, "failed to notify PD the label rules")
@@ -2529,6 +2671,20 @@ func checkReorgPartition(t *meta.Meta, job *model.Job) (*model.TableInfo, []mode
 	return tblInfo, partNames, partInfo, droppingDefs, addingDefs, nil
 }
 
+// isReorgPartitionRebuild checks if the partitions are reorganized into partitions with the
+// same names, which is how ALTER TABLE ... REBUILD PARTITION is done.
+func isReorgPartitionRebuild(partNames []model.CIStr, partInfo *model.PartitionInfo) bool {
+	if len(partNames) != len(partInfo.Definitions) {
+		return false
+	}
+	for i := range partNames {
+		if partNames[i].L != partInfo.Definitions[i].Name.L {
+			return false
+		}
+	}
+	return true
+}
+
 func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	// Handle the rolling back job
 	if job.IsRollingback() {
@@ -2575,7 +2731,7 @@ func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job)
 		}
 
 		// Re-check that the dropped/added partitions are compatible with current definition
-		firstPartIdx, lastPartIdx, idMap, err := getReplacedPartitionIDs(partNamesCIStr, tblInfo.Partition)
+		firstPartIdx, lastPartIdx, idMap, err := getReplacedPartitionIDs(partNamesCIStr, tblInfo.Partition, isReorgPartitionRebuild(partNamesCIStr, partInfo))
 		if err != nil {
 			job.State = model.JobStateCancelled
 			return ver, err
@@ -2741,7 +2897,7 @@ func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job)
 			return ver, err
 		}
 
-		firstPartIdx, lastPartIdx, idMap, err2 := getReplacedPartitionIDs(partNamesCIStr, tblInfo.Partition)
+		firstPartIdx, lastPartIdx, idMap, err2 := getReplacedPartitionIDs(partNamesCIStr, tblInfo.Partition, isReorgPartitionRebuild(partNamesCIStr, partInfo))
 		failpoint.Inject("reorgPartWriteReorgReplacedPartIDsFail", func(val failpoint.Value) {
 			if val.(bool) {
 				err2 = errors.New("Injected error by reorgPartWriteReorgReplacedPartIDsFail")
@@ -3190,6 +3346,16 @@ func checkExchangePartitionRecordValidation(w *worker, pt *model.TableInfo, inde
 	var paramList []interface{}
 
 	pi := pt.Partition
+	if pi.Sub != nil {
+		// The records must match both the partition and the subpartition.
+		partTbl, subTbl := *pt, *pt
+		partTbl.Partition = pi.PartitionLevel()
+		subTbl.Partition = pi.SubPartitionLevel()
+		if err := checkExchangePartitionRecordValidation(w, &partTbl, index/int(pi.Sub.Num), schemaName, tableName); err != nil {
+			return err
+		}
+		return checkExchangePartitionRecordValidation(w, &subTbl, index%int(pi.Sub.Num), schemaName, tableName)
+	}
 
 	switch pi.Type {
 	case model.PartitionTypeHash:
@@ -3444,27 +3610,16 @@ func checkPartitioningKeysConstraints(sctx sessionctx.Context, s *ast.CreateTabl
 }
 
 func checkPartitionKeysConstraint(pi *model.PartitionInfo, indexColumns []*model.IndexColumn, tblInfo *model.TableInfo) (bool, error) {
-	var (
-		partCols []*model.ColumnInfo
-		err      error
-	)
-	// The expr will be an empty string if the partition is defined by:
-	// CREATE TABLE t (...) PARTITION BY RANGE COLUMNS(...)
-	if partExpr := pi.Expr; partExpr != "" {
-		// Parse partitioning key, extract the column names in the partitioning key to slice.
-		partCols, err = extractPartitionColumns(partExpr, tblInfo)
+	partCols, err := getPartitionColumns(pi.Expr, pi.Columns, tblInfo)
+	if err != nil {
+		return false, err
+	}
+	if pi.Sub != nil {
+		subCols, err := getPartitionColumns(pi.Sub.Expr, pi.Sub.Columns, tblInfo)
 		if err != nil {
 			return false, err
 		}
-	} else {
-		partCols = make([]*model.ColumnInfo, 0, len(pi.Columns))
-		for _, col := range pi.Columns {
-			colInfo := tblInfo.FindPublicColumnByName(col.L)
-			if colInfo == nil {
-				return false, infoschema.ErrColumnNotExists.GenWithStackByArgs(col, tblInfo.Name)
-			}
-			partCols = append(partCols, colInfo)
-		}
+		partCols = append(partCols, subCols...)
 	}
 
 	// In MySQL, every unique key on the table must use every column in the table's partitioning expression.(This
@@ -3474,6 +3629,24 @@ func checkPartitionKeysConstraint(pi *model.PartitionInfo, indexColumns []*model
 	return checkUniqueKeyIncludePartKey(columnInfoSlice(partCols), indexColumns), nil
 }
 
+func getPartitionColumns(partExpr string, columns []model.CIStr, tblInfo *model.TableInfo) ([]*model.ColumnInfo, error) {
+	// The expr will be an empty string if the partition is defined by:
+	// CREATE TABLE t (...) PARTITION BY RANGE COLUMNS(...)
+	if partExpr != "" {
+		// Parse partitioning key, extract the column names in the partitioning key to slice.
+		return extractPartitionColumns(partExpr, tblInfo)
+	}
+	partCols := make([]*model.ColumnInfo, 0, len(columns))
+	for _, col := range columns {
+		colInfo := tblInfo.FindPublicColumnByName(col.L)
+		if colInfo == nil {
+			return nil, infoschema.ErrColumnNotExists.GenWithStackByArgs(col, tblInfo.Name)
+		}
+		partCols = append(partCols, colInfo)
+	}
+	return partCols, nil
+}
+
 type columnNameExtractor struct {
 	extractedColumns []*model.ColumnInfo
 	tblInfo          *model.TableInfo
@@ -3550,6 +3723,28 @@ func checkUniqueKeyIncludePartKey(partCols stringSlice, idxCols []*model.IndexCo
 	return true
 }
 
+// joinedStringSlice implements the stringSlice interface, it joins the partitioning
+// columns and the subpartitioning columns.
+type joinedStringSlice []stringSlice
+
+func (jss joinedStringSlice) Len() int {
+	l := 0
+	for _, ss := range jss {
+		l += ss.Len()
+	}
+	return l
+}
+
+func (jss joinedStringSlice) At(i int) string {
+	for _, ss := range jss {
+		if i < ss.Len() {
+			return ss.At(i)
+		}
+		i -= ss.Len()
+	}
+	return ""
+}
+
 // columnInfoSlice implements the stringSlice interface.
 type columnInfoSlice []*model.ColumnInfo
 
@@ -3881,10 +4076,26 @@ func AppendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 			fmt.Fprintf(buf, "\nPARTITION BY %s COLUMNS(", partitionInfo.Type.String())
 		}
 		writeColumnListToBuffer(partitionInfo, sqlMode, buf)
-		buf.WriteString(")\n(")
+		buf.WriteString(")")
 	} else {
-		fmt.Fprintf(buf, "\nPARTITION BY %s (%s)\n(", partitionInfo.Type.String(), partitionInfo.Expr)
+		fmt.Fprintf(buf, "\nPARTITION BY %s (%s)", partitionInfo.Type.String(), partitionInfo.Expr)
+	}
+	if sub := partitionInfo.Sub; sub != nil {
+		if sub.Type == model.PartitionTypeHash {
+			fmt.Fprintf(buf, "\nSUBPARTITION BY HASH (%s)", sub.Expr)
+		} else {
+			buf.WriteString("\nSUBPARTITION BY KEY (")
+			for i, col := range sub.Columns {
+				if i > 0 {
+					buf.WriteString(",")
+				}
+				buf.WriteString(stringutil.Escape(col.O, sqlMode))
+			}
+			buf.WriteString(")")
+		}
+		fmt.Fprintf(buf, " SUBPARTITIONS %d", sub.Num)
 	}
+	buf.WriteString("\n(")
 
 	AppendPartitionDefs(partitionInfo, buf, sqlMode)
 	buf.WriteString(")")
@@ -3894,7 +4105,11 @@ func AppendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 // as well as needed for generating the ADD PARTITION query for INTERVAL partitioning of ALTER TABLE t LAST PARTITION
 // and generating the CREATE TABLE query from CREATE TABLE ... INTERVAL
 func AppendPartitionDefs(partitionInfo *model.PartitionInfo, buf *bytes.Buffer, sqlMode mysql.SQLMode) {
-	for i, def := range partitionInfo.Definitions {
+	defs := partitionInfo.Definitions
+	if partitionInfo.Sub != nil {
+		defs = partitionInfo.GetPartitionDefinitions(defs)
+	}
+	for i, def := range defs {
 		if i > 0 {
 			fmt.Fprintf(buf, ",\n ")
 		}
@@ -3926,12 +4141,46 @@ func AppendPartitionDefs(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 			}
 			fmt.Fprintf(buf, " VALUES IN (%s)", values.String())
 		}
-		if len(def.Comment) > 0 {
-			fmt.Fprintf(buf, " COMMENT '%s'", format.OutputFormat(def.Comment))
+		if partitionInfo.Sub != nil {
+			// The options are kept in the subpartitions.
+			num := int(partitionInfo.Sub.Num)
+			appendSubPartitionDefs(def.Name, partitionInfo.Definitions[i*num:(i+1)*num], buf, sqlMode)
+			continue
 		}
-		if def.PlacementPolicyRef != nil {
-			// add placement ref info here
-			fmt.Fprintf(buf, " /*T![placement] PLACEMENT POLICY=%s */", stringutil.Escape(def.PlacementPolicyRef.Name.O, sqlMode))
+		appendPartitionDefOptions(&defs[i], buf, sqlMode)
+	}
+}
+
+func appendPartitionDefOptions(def *model.PartitionDefinition, buf *bytes.Buffer, sqlMode mysql.SQLMode) {
+	if len(def.Comment) > 0 {
+		fmt.Fprintf(buf, " COMMENT '%s'", format.OutputFormat(def.Comment))
+	}
+	if def.PlacementPolicyRef != nil {
+		// add placement ref info here
+		fmt.Fprintf(buf, " /*T![placement] PLACEMENT POLICY=%s */", stringutil.Escape(def.PlacementPolicyRef.Name.O, sqlMode))
+	}
+}
+
+// appendSubPartitionDefs generates the subpartition definitions of a partition,
+// nothing is generated if they are all default.
+func appendSubPartitionDefs(partName model.CIStr, subDefs []model.PartitionDefinition, buf *bytes.Buffer, sqlMode mysql.SQLMode) {
+	defaultSubDefs := true
+	for j, def := range subDefs {
+		if def.Name.O != fmt.Sprintf("%ssp%d", partName.O, j) || len(def.Comment) > 0 || def.PlacementPolicyRef != nil {
+			defaultSubDefs = false
+			break
 		}
 	}
+	if defaultSubDefs {
+		return
+	}
+	buf.WriteString("\n (")
+	for j := range subDefs {
+		if j > 0 {
+			buf.WriteString(",\n  ")
+		}
+		fmt.Fprintf(buf, "SUBPARTITION %s", stringutil.Escape(subDefs[j].Name.O, sqlMode))
+		appendPartitionDefOptions(&subDefs[j], buf, sqlMode)
+	}
+	buf.WriteString(")")
 }
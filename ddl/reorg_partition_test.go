@@ -1374,3 +1374,81 @@ func TestReorgPartitionData(t *testing.T) {
 	tk.MustQuery(`select * from t`).Sort().Check(testkit.Rows("0 Zero value! 0 2022-02-30 00:00:00"))
 	tk.MustExec(`admin check table t`)
 }
+
+func TestRebuildPartition(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	schemaName := "RebuildPart"
+	tk.MustExec("create database " + schemaName)
+	tk.MustExec("use " + schemaName)
+	partitionIDs := func() map[string]string {
+		ids := make(map[string]string)
+		for _, row := range tk.MustQuery(`select partition_name, tidb_partition_id from information_schema.partitions where table_schema = '` + schemaName + `' and table_name = 't'`).Rows() {
+			ids[row[0].(string)] = row[1].(string)
+		}
+		return ids
+	}
+
+	tk.MustExec(`create table t (a int primary key, b varchar(255), c int, key (b), key (c,b)) partition by range (a) (partition p0 values less than (10), partition p1 values less than (20), partition pMax values less than (maxvalue))`)
+	tk.MustExec(`insert into t values (1,"1",1), (5,"5",5), (11,"11",11), (15,"15",15), (21,"21",21)`)
+	orgIDs := partitionIDs()
+	tk.MustExec(`alter table t rebuild partition p1`)
+	tk.MustQuery(`show warnings`).Check(testkit.Rows("Warning 1105 The statistics of related partitions will be outdated after rebuilding partitions. Please use 'ANALYZE TABLE' statement if you want to update it now"))
+	newIDs := partitionIDs()
+	require.Equal(t, orgIDs["p0"], newIDs["p0"])
+	require.NotEqual(t, orgIDs["p1"], newIDs["p1"])
+	require.Equal(t, orgIDs["pMax"], newIDs["pMax"])
+	tk.MustQuery(`select * from t partition (p1)`).Sort().Check(testkit.Rows("11 11 11", "15 15 15"))
+	tk.MustQuery(`select a from t use index (b) where b = "15"`).Check(testkit.Rows("15"))
+	tk.MustExec(`admin check table t`)
+	tk.MustExec(`alter table t optimize partition p0, pMax, p0`)
+	newIDs = partitionIDs()
+	require.NotEqual(t, orgIDs["p0"], newIDs["p0"])
+	require.NotEqual(t, orgIDs["pMax"], newIDs["pMax"])
+	tk.MustQuery(`select * from t`).Sort().Check(testkit.Rows("1 1 1", "11 11 11", "15 15 15", "21 21 21", "5 5 5"))
+	tk.MustExec(`admin check table t`)
+	tk.MustGetErrCode(`alter table t rebuild partition p2`, errno.ErrUnknownPartition)
+
+	tk.MustExec(`drop table t`)
+	tk.MustExec(`create table t (a int, b varchar(255), key (b)) partition by list (a) (partition p0 values in (1,3,5), partition p1 values in (2,4), partition p2 values in (6))`)
+	tk.MustExec(`insert into t values (1,"1"), (2,"2"), (3,"3"), (4,"4"), (6,"6")`)
+	tk.MustExec(`alter table t rebuild partition p0, p2`)
+	tk.MustQuery(`show create table t`).Check(testkit.Rows("" +
+		"t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` varchar(255) DEFAULT NULL,\n" +
+		"  KEY `b` (`b`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY LIST (`a`)\n" +
+		"(PARTITION `p0` VALUES IN (1,3,5),\n" +
+		" PARTITION `p1` VALUES IN (2,4),\n" +
+		" PARTITION `p2` VALUES IN (6))"))
+	tk.MustQuery(`select * from t partition (p0)`).Sort().Check(testkit.Rows("1 1", "3 3"))
+	tk.MustExec(`admin check table t`)
+
+	// The rows of a rebuilt HASH/KEY partition stay in the partition.
+	tk.MustExec(`drop table t`)
+	tk.MustExec(`create table t (a int, b varchar(255), key (b)) partition by hash (a) partitions 3`)
+	tk.MustExec(`insert into t values (1,"1"), (2,"2"), (3,"3"), (4,"4"), (5,"5")`)
+	tk.MustExec(`alter table t rebuild partition p1`)
+	tk.MustQuery(`select * from t partition (p1)`).Sort().Check(testkit.Rows("1 1", "4 4"))
+	tk.MustExec(`insert into t values (7,"7")`)
+	tk.MustQuery(`select * from t partition (p1)`).Sort().Check(testkit.Rows("1 1", "4 4", "7 7"))
+	tk.MustExec(`alter table t rebuild partition all`)
+	tk.MustQuery(`select * from t partition (p2)`).Sort().Check(testkit.Rows("2 2", "5 5"))
+	tk.MustQuery(`select * from t where a = 3`).Check(testkit.Rows("3 3"))
+	tk.MustExec(`admin check table t`)
+	tk.MustExec(`drop table t`)
+	tk.MustExec(`create table t (a int, b varchar(255)) partition by key (b) partitions 2`)
+	tk.MustExec(`insert into t values (1,"1"), (2,"2"), (3,"3"), (4,"4")`)
+	rows := tk.MustQuery(`select * from t partition (p0) order by a`).Rows()
+	tk.MustExec(`alter table t rebuild partition p0`)
+	tk.MustQuery(`select * from t partition (p0) order by a`).Check(rows)
+
+	tk.MustExec(`drop table t`)
+	tk.MustExec(`create table t (a int, b int) partition by range (a) subpartition by hash (b) subpartitions 2 (partition p0 values less than (10))`)
+	tk.MustGetErrCode(`alter table t rebuild partition p0`, errno.ErrUnsupportedDDLOperation)
+	tk.MustExec(`drop table t`)
+	tk.MustExec(`create table t (a int)`)
+	tk.MustGetErrCode(`alter table t rebuild partition p0`, errno.ErrPartitionMgmtOnNonpartitioned)
+}
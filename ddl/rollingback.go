@@ -430,7 +430,7 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 	case model.ActionMultiSchemaChange:
 		err = rollingBackMultiSchemaChange(job)
 	case model.ActionAddCheckConstraint:
-		ver, err = rollingBackAddConstraint(d, t, job)
+		ver, err = rollingBackAddConstraint(w, d, t, job)
 	case model.ActionDropCheckConstraint:
 		ver, err = rollingBackDropConstraint(t, job)
 	default:
@@ -478,7 +478,13 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 	return
 }
 
-func rollingBackAddConstraint(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+func rollingBackAddConstraint(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	if needNotifyAndStopReorgWorker(job) {
+		// The workers validating the existing rows are started, need to ask them to exit.
+		logutil.Logger(w.logCtx).Info("[ddl] run the cancelling DDL job", zap.String("job", job.String()))
+		d.notifyReorgWorkerJobStateChange(job)
+		return w.onAddCheckConstraint(d, t, job)
+	}
 	job.State = model.JobStateRollingback
 	_, tblInfo, constrInfoInMeta, _, err := checkAddCheckConstraint(t, job)
 	if err != nil {
@@ -492,16 +498,23 @@ func rollingBackAddConstraint(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int6
 	}
 	// Add constraint has stored constraint info into meta, that means the job has at least
 	// arrived write only state.
-	originalState := constrInfoInMeta.State
-	constrInfoInMeta.State = model.StateWriteOnly
+	return convertAddCheckConstraintJob2RollbackJob(d, t, job, tblInfo, constrInfoInMeta, dbterror.ErrCancelledDDLJob)
+}
+
+// convertAddCheckConstraintJob2RollbackJob converts the add check constraint job to a rollback job, and the
+// constraint will be removed by onDropCheckConstraint.
+func convertAddCheckConstraintJob2RollbackJob(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo, constrInfo *model.ConstraintInfo, err error) (int64, error) {
+	originalState := constrInfo.State
+	constrInfo.State = model.StateWriteOnly
 	job.SchemaState = model.StateWriteOnly
 
-	job.Args = []interface{}{constrInfoInMeta.Name}
-	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != constrInfoInMeta.State)
-	if err != nil {
-		return ver, errors.Trace(err)
+	job.Args = []interface{}{constrInfo.Name}
+	ver, err1 := updateVersionAndTableInfo(d, t, job, tblInfo, originalState != constrInfo.State)
+	if err1 != nil {
+		return ver, errors.Trace(err1)
 	}
-	return ver, dbterror.ErrCancelledDDLJob
+	job.State = model.JobStateRollingback
+	return ver, errors.Trace(err)
 }
 
 func rollingBackDropConstraint(t *meta.Meta, job *model.Job) (ver int64, err error) {
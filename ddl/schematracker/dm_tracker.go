@@ -822,7 +822,7 @@ func (d SchemaTracker) dropTablePartitions(_ sessionctx.Context, ident ast.Ident
 	for _, def := range tblInfo.Partition.Definitions {
 		found := false
 		for _, partName := range partNames {
-			if def.Name.L == partName {
+			if def.Name.L == partName || def.ParentName.L == partName {
 				found = true
 				break
 			}
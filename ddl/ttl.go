@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/meta"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
@@ -101,11 +102,15 @@ func checkTTLInfoValid(ctx sessionctx.Context, schema model.CIStr, tblInfo *mode
 		return err
 	}
 
-	if err := checkTTLTableSuitable(ctx, schema, tblInfo); err != nil {
+	if err := checkTTLTableSuitable(tblInfo); err != nil {
 		return err
 	}
 
-	return checkTTLInfoColumnType(tblInfo)
+	if err := checkTTLInfoColumnType(tblInfo); err != nil {
+		return err
+	}
+
+	return checkTTLArchiveTable(ctx, schema, tblInfo)
 }
 
 func checkTTLIntervalExpr(ctx sessionctx.Context, ttlInfo *model.TTLInfo) error {
@@ -129,30 +134,80 @@ func checkTTLInfoColumnType(tblInfo *model.TableInfo) error {
 	if colInfo == nil {
 		return dbterror.ErrBadField.GenWithStackByArgs(tblInfo.TTLInfo.ColumnName.O, "TTL config")
 	}
-	if !types.IsTypeTime(colInfo.FieldType.GetType()) {
-		return dbterror.ErrUnsupportedColumnInTTLConfig.GenWithStackByArgs(tblInfo.TTLInfo.ColumnName.O)
-	}
+	return checkTTLColumnType(colInfo, tblInfo.TTLInfo)
+}
 
-	return nil
+// checkTTLColumnType checks whether the column could be used as the TTL column. It should be a time column, or
+// an integer column storing the Unix timestamp in the unit of `TTL_TIMESTAMP_UNIT`.
+func checkTTLColumnType(colInfo *model.ColumnInfo, ttlInfo *model.TTLInfo) error {
+	tp := colInfo.FieldType.GetType()
+	if types.IsTypeTime(tp) {
+		if ttlInfo.TimestampUnit != "" {
+			return dbterror.ErrUnsupportedTTLTimestampUnit.GenWithStackByArgs(colInfo.Name.O)
+		}
+		return nil
+	}
+	if mysql.IsIntegerType(tp) && ttlInfo.TimestampUnit != "" {
+		return nil
+	}
+	return dbterror.ErrUnsupportedColumnInTTLConfig.GenWithStackByArgs(colInfo.Name.O)
 }
 
 // checkTTLTableSuitable returns whether this table is suitable to be a TTL table
-// A temporary table or a parent table referenced by a foreign key cannot be TTL table
-func checkTTLTableSuitable(ctx sessionctx.Context, schema model.CIStr, tblInfo *model.TableInfo) error {
+// A temporary table cannot be TTL table. A parent table referenced by a foreign key could be TTL table, the TTL
+// deletes do the foreign key actions like other deletes when `foreign_key_checks` is on.
+func checkTTLTableSuitable(tblInfo *model.TableInfo) error {
 	if tblInfo.TempTableType != model.TempTableNone {
 		return dbterror.ErrTempTableNotAllowedWithTTL
 	}
 
-	if err := checkPrimaryKeyForTTLTable(tblInfo); err != nil {
-		return err
+	return checkPrimaryKeyForTTLTable(tblInfo)
+}
+
+// checkTTLArchiveTable checks whether the archive table could store the expired rows of the TTL table. The expired rows
+// are moved by `INSERT INTO archive (cols) SELECT cols FROM t`, so every column of the TTL table should exist in the
+// archive table and should not be a generated column there. If the schema of the archive table is not specified, it's
+// filled with the schema of the TTL table.
+func checkTTLArchiveTable(ctx sessionctx.Context, schema model.CIStr, tblInfo *model.TableInfo) error {
+	archive := tblInfo.TTLInfo.ArchiveTable
+	if archive == nil {
+		return nil
+	}
+	if archive.Schema.L == "" {
+		archive.Schema = schema
+	}
+
+	archiveName := fmt.Sprintf("%s.%s", archive.Schema.O, archive.Table.O)
+	if archive.Schema.L == schema.L && archive.Table.L == tblInfo.Name.L {
+		return dbterror.ErrUnsupportedTTLArchiveTable.GenWithStackByArgs(archiveName, "it's the TTL table itself")
 	}
 
-	// checks even when the foreign key check is not enabled, to keep safe
 	is := sessiontxn.GetTxnManager(ctx).GetTxnInfoSchema()
-	if referredFK := checkTableHasForeignKeyReferred(is, schema.L, tblInfo.Name.L, nil, true); referredFK != nil {
-		return dbterror.ErrUnsupportedTTLReferencedByFK
+	archiveTbl, err := is.TableByName(archive.Schema, archive.Table)
+	if err != nil {
+		return infoschema.ErrTableNotExists.GenWithStackByArgs(archive.Schema.O, archive.Table.O)
+	}
+	archiveTblInfo := archiveTbl.Meta()
+	if archiveTblInfo.IsView() || archiveTblInfo.IsSequence() {
+		return dbterror.ErrUnsupportedTTLArchiveTable.GenWithStackByArgs(archiveName, "it's not a base table")
+	}
+	if archiveTblInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrUnsupportedTTLArchiveTable.GenWithStackByArgs(archiveName, "it's a temporary table")
 	}
 
+	archiveCols := archiveTblInfo.Cols()
+	for _, col := range tblInfo.Cols() {
+		if col.Hidden {
+			continue
+		}
+		archiveCol := model.FindColumnInfo(archiveCols, col.Name.L)
+		if archiveCol == nil || archiveCol.Hidden {
+			return dbterror.ErrUnsupportedTTLArchiveTable.GenWithStackByArgs(archiveName, fmt.Sprintf("column '%s' doesn't exist in it", col.Name.O))
+		}
+		if archiveCol.IsGenerated() {
+			return dbterror.ErrUnsupportedTTLArchiveTable.GenWithStackByArgs(archiveName, fmt.Sprintf("column '%s' is a generated column in it", col.Name.O))
+		}
+	}
 	return nil
 }
 
@@ -192,10 +247,13 @@ func checkPrimaryKeyForTTLTable(tblInfo *model.TableInfo) error {
 }
 
 // getTTLInfoInOptions returns the aggregated ttlInfo, the ttlEnable, or an error.
-// if TTL, TTL_ENABLE or TTL_JOB_INTERVAL is not set in the config, the corresponding return value will be nil.
+// if TTL, TTL_ENABLE, TTL_JOB_INTERVAL, TTL_TIMESTAMP_UNIT or TTL_ARCHIVE_TABLE is not set in the config, the corresponding return value will be nil.
 // if both of TTL and TTL_ENABLE are set, the `ttlInfo.Enable` will be equal with `ttlEnable`.
 // if both of TTL and TTL_JOB_INTERVAL are set, the `ttlInfo.JobInterval` will be equal with `ttlCronJobSchedule`.
-func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, ttlEnable *bool, ttlCronJobSchedule *string, err error) {
+// if both of TTL and TTL_TIMESTAMP_UNIT are set, the `ttlInfo.TimestampUnit` will be equal with `ttlTimestampUnit`.
+// if TTL_ARCHIVE_TABLE is set to NULL, the `ttlArchiveTable` will be an empty `model.TTLArchiveTable`, and if both of TTL
+// and TTL_ARCHIVE_TABLE are set, the `ttlInfo.ArchiveTable` will be equal with `ttlArchiveTable` unless it's empty.
+func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, ttlEnable *bool, ttlCronJobSchedule *string, ttlTimestampUnit *string, ttlArchiveTable *model.TTLArchiveTable, err error) {
 	for _, op := range options {
 		switch op.Tp {
 		case ast.TableOptionTTL:
@@ -204,7 +262,7 @@ func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, tt
 			restoreCtx := format.NewRestoreCtx(restoreFlags, &sb)
 			err := op.Value.Restore(restoreCtx)
 			if err != nil {
-				return nil, nil, nil, err
+				return nil, nil, nil, nil, nil, err
 			}
 
 			intervalExpr := sb.String()
@@ -219,6 +277,14 @@ func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, tt
 			ttlEnable = &op.BoolValue
 		case ast.TableOptionTTLJobInterval:
 			ttlCronJobSchedule = &op.StrValue
+		case ast.TableOptionTTLTimestampUnit:
+			ttlTimestampUnit = &op.StrValue
+		case ast.TableOptionTTLArchiveTable:
+			ttlArchiveTable = &model.TTLArchiveTable{}
+			if len(op.TableNames) > 0 {
+				ttlArchiveTable.Schema = op.TableNames[0].Schema
+				ttlArchiveTable.Table = op.TableNames[0].Name
+			}
 		}
 	}
 
@@ -229,6 +295,13 @@ func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, tt
 		if ttlCronJobSchedule != nil {
 			ttlInfo.JobInterval = *ttlCronJobSchedule
 		}
+		if ttlTimestampUnit != nil {
+			ttlInfo.TimestampUnit = *ttlTimestampUnit
+		}
+		if ttlArchiveTable != nil && ttlArchiveTable.Table.L != "" {
+			archiveTable := *ttlArchiveTable
+			ttlInfo.ArchiveTable = &archiveTable
+		}
 	}
-	return ttlInfo, ttlEnable, ttlCronJobSchedule, nil
+	return ttlInfo, ttlEnable, ttlCronJobSchedule, ttlTimestampUnit, ttlArchiveTable, nil
 }
@@ -26,12 +26,15 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 	falseValue := false
 	trueValue := true
 	twentyFourHours := "24h"
+	millisecond := model.TTLTimestampUnitMillisecond
 
 	cases := []struct {
 		options            []*ast.TableOption
 		ttlInfo            *model.TTLInfo
 		ttlEnable          *bool
 		ttlCronJobSchedule *string
+		ttlTimestampUnit   *string
+		ttlArchiveTable    *model.TTLArchiveTable
 		err                error
 	}{
 		{
@@ -40,6 +43,8 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
+			nil,
 		},
 		{
 			[]*ast.TableOption{
@@ -60,6 +65,8 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
+			nil,
 		},
 		{
 			[]*ast.TableOption{
@@ -84,6 +91,8 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			&falseValue,
 			nil,
 			nil,
+			nil,
+			nil,
 		},
 		{
 			[]*ast.TableOption{
@@ -112,6 +121,8 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			&trueValue,
 			nil,
 			nil,
+			nil,
+			nil,
 		},
 		{
 			[]*ast.TableOption{
@@ -136,15 +147,86 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			nil,
 			&twentyFourHours,
 			nil,
+			nil,
+			nil,
+		},
+		{
+			[]*ast.TableOption{
+				{
+					Tp:       ast.TableOptionTTLTimestampUnit,
+					StrValue: model.TTLTimestampUnitMillisecond,
+				},
+				{
+					Tp:            ast.TableOptionTTL,
+					ColumnName:    &ast.ColumnName{Name: model.NewCIStr("test_column")},
+					Value:         ast.NewValueExpr(5, "", ""),
+					TimeUnitValue: &ast.TimeUnitExpr{Unit: ast.TimeUnitYear},
+				},
+			},
+			&model.TTLInfo{
+				ColumnName:       model.NewCIStr("test_column"),
+				IntervalExprStr:  "5",
+				IntervalTimeUnit: int(ast.TimeUnitYear),
+				Enable:           true,
+				JobInterval:      "1h",
+				TimestampUnit:    model.TTLTimestampUnitMillisecond,
+			},
+			nil,
+			nil,
+			&millisecond,
+			nil,
+			nil,
+		},
+		{
+			[]*ast.TableOption{
+				{
+					Tp:            ast.TableOptionTTL,
+					ColumnName:    &ast.ColumnName{Name: model.NewCIStr("test_column")},
+					Value:         ast.NewValueExpr(5, "", ""),
+					TimeUnitValue: &ast.TimeUnitExpr{Unit: ast.TimeUnitYear},
+				},
+				{
+					Tp:         ast.TableOptionTTLArchiveTable,
+					TableNames: []*ast.TableName{{Schema: model.NewCIStr("test"), Name: model.NewCIStr("t_history")}},
+				},
+			},
+			&model.TTLInfo{
+				ColumnName:       model.NewCIStr("test_column"),
+				IntervalExprStr:  "5",
+				IntervalTimeUnit: int(ast.TimeUnitYear),
+				Enable:           true,
+				JobInterval:      "1h",
+				ArchiveTable:     &model.TTLArchiveTable{Schema: model.NewCIStr("test"), Table: model.NewCIStr("t_history")},
+			},
+			nil,
+			nil,
+			nil,
+			&model.TTLArchiveTable{Schema: model.NewCIStr("test"), Table: model.NewCIStr("t_history")},
+			nil,
+		},
+		{
+			[]*ast.TableOption{
+				{
+					Tp: ast.TableOptionTTLArchiveTable,
+				},
+			},
+			nil,
+			nil,
+			nil,
+			nil,
+			&model.TTLArchiveTable{},
+			nil,
 		},
 	}
 
 	for _, c := range cases {
-		ttlInfo, ttlEnable, ttlCronJobSchedule, err := getTTLInfoInOptions(c.options)
+		ttlInfo, ttlEnable, ttlCronJobSchedule, ttlTimestampUnit, ttlArchiveTable, err := getTTLInfoInOptions(c.options)
 
 		assert.Equal(t, c.ttlInfo, ttlInfo)
 		assert.Equal(t, c.ttlEnable, ttlEnable)
 		assert.Equal(t, c.ttlCronJobSchedule, ttlCronJobSchedule)
+		assert.Equal(t, c.ttlTimestampUnit, ttlTimestampUnit)
+		assert.Equal(t, c.ttlArchiveTable, ttlArchiveTable)
 		assert.Equal(t, c.err, err)
 	}
 }
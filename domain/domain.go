@@ -66,6 +66,9 @@ import (
 	"github.com/pingcap/tidb/statistics/handle"
 	"github.com/pingcap/tidb/store/helper"
 	"github.com/pingcap/tidb/telemetry"
+	timerapi "github.com/pingcap/tidb/timer/api"
+	timerrt "github.com/pingcap/tidb/timer/runtime"
+	"github.com/pingcap/tidb/timer/tablestore"
 	"github.com/pingcap/tidb/ttl/cache"
 	"github.com/pingcap/tidb/ttl/sqlbuilder"
 	"github.com/pingcap/tidb/ttl/ttlworker"
@@ -2074,13 +2077,32 @@ func (do *Domain) globalBindHandleWorkerLoop(owner owner.Manager) {
 	}, "globalBindHandleWorkerLoop")
 }
 
+// handleEvolvePlanTasksLoop runs a timer runtime which schedules the plan baseline evolution. The evolution timer is
+// created by the owner of bindinfo if it doesn't exist.
 func (do *Domain) handleEvolvePlanTasksLoop(ctx sessionctx.Context, owner owner.Manager) {
+	var clusterID uint64
+	if pdCli := do.GetPDClient(); pdCli != nil {
+		clusterID = pdCli.GetClusterID(context.Background())
+	}
+	store := tablestore.NewTableTimerStore(clusterID, do.sysSessionPool, mysql.SystemDB, "tidb_timers", do.etcdClient)
+	cli := timerapi.NewDefaultTimerClient(store)
+	bindHandle := do.bindHandle.Load()
+	rt := timerrt.NewTimerRuntimeBuilder("bindinfo-evolve", store).
+		SetCond(&timerapi.TimerCond{Key: timerapi.NewOptionalVal(bindinfo.EvolveTimerKey)}).
+		RegisterHookFactory(bindinfo.EvolveTimerHookClass, func(_ string, cli timerapi.TimerClient) timerapi.Hook {
+			return bindinfo.NewEvolveTimerHook(bindHandle, ctx, owner.IsOwner, cli)
+		}).
+		Build()
+
 	do.wg.Run(func() {
 		defer func() {
 			logutil.BgLogger().Info("handleEvolvePlanTasksLoop exited.")
 		}()
 		defer util.Recover(metrics.LabelDomain, "handleEvolvePlanTasksLoop", nil, false)
 
+		rt.Start()
+		defer rt.Stop()
+		timerCreated := false
 		for {
 			select {
 			case <-do.exit:
@@ -2088,16 +2110,29 @@ func (do *Domain) handleEvolvePlanTasksLoop(ctx sessionctx.Context, owner owner.
 				return
 			case <-time.After(bindinfo.Lease):
 			}
-			if owner.IsOwner() {
-				err := do.bindHandle.Load().HandleEvolvePlanTask(ctx, false)
-				if err != nil {
-					logutil.BgLogger().Info("evolve plan failed", zap.Error(err))
-				}
+			if !timerCreated && owner.IsOwner() {
+				timerCreated = createEvolveTimerIfNotExist(cli)
 			}
 		}
 	}, "handleEvolvePlanTasksLoop")
 }
 
+func createEvolveTimerIfNotExist(cli timerapi.TimerClient) bool {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
+	_, err := cli.GetTimerByKey(ctx, bindinfo.EvolveTimerKey)
+	if err == nil {
+		return true
+	}
+	if errors.ErrorEqual(err, timerapi.ErrTimerNotExist) {
+		_, err = cli.CreateTimer(ctx, bindinfo.NewEvolveTimerSpec())
+		if err == nil {
+			return true
+		}
+	}
+	logutil.BgLogger().Warn("create the timer of plan baseline evolution failed", zap.Error(err))
+	return false
+}
+
 // TelemetryReportLoop create a goroutine that reports usage data in a loop, it should be called only once
 // in BootstrapSession.
 func (do *Domain) TelemetryReportLoop(ctx sessionctx.Context) {
@@ -2688,6 +2723,8 @@ func (do *Domain) autoAnalyzeWorker(owner owner.Manager) {
 	analyzeTicker := time.NewTicker(do.statsLease)
 	defer func() {
 		analyzeTicker.Stop()
+		statsHandle.ClearAutoAnalyzeJobs()
+		statsHandle.WaitAutoAnalyzeJobs()
 		logutil.BgLogger().Info("autoAnalyzeWorker exited.")
 	}()
 	for {
@@ -2695,6 +2732,8 @@ func (do *Domain) autoAnalyzeWorker(owner owner.Manager) {
 		case <-analyzeTicker.C:
 			if variable.RunAutoAnalyze.Load() && !do.stopAutoAnalyze.Load() && owner.IsOwner() {
 				statsHandle.HandleAutoAnalyze(do.InfoSchema())
+			} else {
+				statsHandle.ClearAutoAnalyzeJobs()
 			}
 		case <-do.exit:
 			return
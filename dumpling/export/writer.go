@@ -58,6 +58,8 @@ func NewWriter(
 		sw.fileFmt = FileFormatSQLText
 	case FileFormatCSVString:
 		sw.fileFmt = FileFormatCSV
+	case FileFormatParquetString:
+		sw.fileFmt = FileFormatParquet
 	}
 	return sw
 }
@@ -237,9 +239,14 @@ func (w *Writer) tryToWriteTableData(tctx *tcontext.Context, meta TableMeta, ir
 		return err
 	}
 
+	compressType := conf.CompressType
+	if format == FileFormatParquet {
+		// parquet compresses the pages inside the file by itself
+		compressType = storage.NoCompression
+	}
 	somethingIsWritten := false
 	for {
-		fileWriter, tearDown := buildInterceptFileWriter(tctx, w.extStorage, fileName, conf.CompressType)
+		fileWriter, tearDown := buildInterceptFileWriter(tctx, w.extStorage, fileName, compressType)
 		n, err := format.WriteInsert(tctx, conf, meta, ir, fileWriter, w.metrics)
 		tearDown(tctx)
 		if err != nil {
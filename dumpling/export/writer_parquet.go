@@ -0,0 +1,257 @@
+// Copyright 2023 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/summary"
+	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"github.com/pingcap/tidb/dumpling/log"
+	"github.com/xitongsys/parquet-go/marshal"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+	"go.uber.org/zap"
+)
+
+const (
+	// parquetMaxRowGroupSize is the default row group size of parquet-go.
+	parquetMaxRowGroupSize = 128 * 1024 * 1024
+	// parquetPageSize is the default page size of parquet-go.
+	parquetPageSize = 8 * 1024
+)
+
+// parquetColumn converts the raw bytes of a column to the value accepted by parquet-go.
+type parquetColumn func(raw []byte) (interface{}, error)
+
+func parquetInt64Column(raw []byte) (interface{}, error) {
+	v, err := strconv.ParseInt(string(raw), 10, 64)
+	return v, errors.Trace(err)
+}
+
+func parquetUint64Column(raw []byte) (interface{}, error) {
+	// parquet stores UINT_64 in the physical type INT64
+	v, err := strconv.ParseUint(string(raw), 10, 64)
+	return int64(v), errors.Trace(err)
+}
+
+func parquetDoubleColumn(raw []byte) (interface{}, error) {
+	v, err := strconv.ParseFloat(string(raw), 64)
+	return v, errors.Trace(err)
+}
+
+func parquetByteArrayColumn(raw []byte) (interface{}, error) {
+	return string(raw), nil
+}
+
+// buildParquetSchema maps the SQL types of the columns to parquet types:
+//
+//	integer          -> INT64 (UINT_64 for unsigned integer)
+//	float/double     -> DOUBLE
+//	binary/blob/bit  -> BYTE_ARRAY
+//	others           -> BYTE_ARRAY (UTF8)
+//
+// DECIMAL and the temporal types are dumped as UTF8 strings in the MySQL text format, because their precision and
+// scale are not known here, and the zero dates can't be represented by the parquet logical types.
+func buildParquetSchema(colNames, colTypes []string) ([]*parquet.SchemaElement, []parquetColumn) {
+	schemas := make([]*parquet.SchemaElement, 0, len(colTypes)+1)
+	columns := make([]parquetColumn, 0, len(colTypes))
+
+	root := parquet.NewSchemaElement()
+	root.Name = "schema"
+	root.NumChildren = func(n int32) *int32 { return &n }(int32(len(colTypes)))
+	root.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REQUIRED)
+	schemas = append(schemas, root)
+
+	for i, colType := range colTypes {
+		se := parquet.NewSchemaElement()
+		se.Name = colNames[i]
+		se.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_OPTIONAL)
+		_, isInt := dataTypeInt[colType]
+		_, isBin := dataTypeBin[colType]
+		switch {
+		case isInt && strings.HasPrefix(colType, "UNSIGNED"):
+			se.Type = parquet.TypePtr(parquet.Type_INT64)
+			se.ConvertedType = parquet.ConvertedTypePtr(parquet.ConvertedType_UINT_64)
+			columns = append(columns, parquetUint64Column)
+		case isInt:
+			se.Type = parquet.TypePtr(parquet.Type_INT64)
+			columns = append(columns, parquetInt64Column)
+		case colType == "FLOAT" || colType == "REAL" || colType == "DOUBLE" || colType == "DOUBLE PRECISION":
+			se.Type = parquet.TypePtr(parquet.Type_DOUBLE)
+			columns = append(columns, parquetDoubleColumn)
+		case isBin:
+			se.Type = parquet.TypePtr(parquet.Type_BYTE_ARRAY)
+			columns = append(columns, parquetByteArrayColumn)
+		default:
+			se.Type = parquet.TypePtr(parquet.Type_BYTE_ARRAY)
+			se.ConvertedType = parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8)
+			columns = append(columns, parquetByteArrayColumn)
+		}
+		schemas = append(schemas, se)
+	}
+	return schemas, columns
+}
+
+func parquetCompressionCodec(compressType storage.CompressType) (parquet.CompressionCodec, error) {
+	switch compressType {
+	case storage.NoCompression:
+		return parquet.CompressionCodec_UNCOMPRESSED, nil
+	case storage.Gzip:
+		return parquet.CompressionCodec_GZIP, nil
+	case storage.Snappy:
+		return parquet.CompressionCodec_SNAPPY, nil
+	case storage.Zstd:
+		return parquet.CompressionCodec_ZSTD, nil
+	default:
+		return 0, errors.Errorf("unsupported compress type %v for parquet", compressType)
+	}
+}
+
+// rawBytesOfReceiver returns the raw bytes received by the receiver made by MakeRowReceiver, nil means NULL.
+func rawBytesOfReceiver(receiver RowReceiverStringer) []byte {
+	switch r := receiver.(type) {
+	case *SQLTypeNumber:
+		return r.RawBytes
+	case *SQLTypeBytes:
+		return r.RawBytes
+	case *SQLTypeString:
+		return r.RawBytes
+	default:
+		return nil
+	}
+}
+
+// parquetFileWriter adapts storage.ExternalFileWriter to io.Writer for parquet-go.
+type parquetFileWriter struct {
+	ctx     context.Context
+	w       storage.ExternalFileWriter
+	metrics *metrics
+
+	finishedFileSize uint64
+}
+
+// Write implements io.Writer.
+func (w *parquetFileWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(w.ctx, p)
+	w.finishedFileSize += uint64(n)
+	AddGauge(w.metrics.finishedSizeGauge, float64(n))
+	return n, err
+}
+
+// WriteInsertInParquet writes TableDataIR to a storage.ExternalFileWriter in parquet type.
+// The compression of cfg.CompressType is applied to the pages inside the parquet file, so w must not compress again.
+// The rows are written to a row group until its size reaches cfg.FileSize (at most 128MB), and the function returns
+// to switch to a new file once the size of the dumped values reaches cfg.FileSize.
+func WriteInsertInParquet(
+	pCtx *tcontext.Context,
+	cfg *Config,
+	meta TableMeta,
+	tblIR TableDataIR,
+	w storage.ExternalFileWriter,
+	metrics *metrics,
+) (n uint64, err error) {
+	fileRowIter := tblIR.Rows()
+	if !fileRowIter.HasNext() {
+		return 0, fileRowIter.Error()
+	}
+	if meta.SelectedField() == "" || len(meta.ColumnTypes()) == 0 {
+		return 0, errors.Errorf("can't dump table %s.%s without any column in parquet type",
+			meta.DatabaseName(), meta.TableName())
+	}
+	codec, err := parquetCompressionCodec(cfg.CompressType)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		row             = MakeRowReceiver(meta.ColumnTypes())
+		counter         uint64
+		lastCounter     uint64
+		currentFileSize uint64
+		fw              = &parquetFileWriter{ctx: pCtx, w: w, metrics: metrics}
+	)
+
+	defer func() {
+		if err != nil {
+			pCtx.L().Warn("fail to dumping table(chunk), will revert some metrics and start a retry if possible",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", lastCounter),
+				zap.Uint64("finished size", fw.finishedFileSize),
+				log.ShortError(err))
+			SubGauge(metrics.finishedRowsGauge, float64(lastCounter))
+			SubGauge(metrics.finishedSizeGauge, float64(fw.finishedFileSize))
+		} else {
+			pCtx.L().Debug("finish dumping table(chunk)",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", counter),
+				zap.Uint64("finished size", fw.finishedFileSize))
+			summary.CollectSuccessUnit(summary.TotalBytes, 1, fw.finishedFileSize)
+			summary.CollectSuccessUnit("total rows", 1, counter)
+		}
+	}()
+
+	schemas, columns := buildParquetSchema(meta.ColumnNames(), meta.ColumnTypes())
+	pw, err := writer.NewParquetWriterFromWriter(fw, schemas, 1)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	pw.MarshalFunc = marshal.MarshalCSV
+	pw.PageSize = parquetPageSize
+	pw.CompressionType = codec
+	pw.RowGroupSize = parquetMaxRowGroupSize
+	if cfg.FileSize != UnspecifiedSize && cfg.FileSize < parquetMaxRowGroupSize {
+		pw.RowGroupSize = int64(cfg.FileSize)
+	}
+
+	for fileRowIter.HasNext() {
+		if err = fileRowIter.Decode(row); err != nil {
+			return counter, errors.Trace(err)
+		}
+		values := make([]interface{}, len(columns))
+		for i, receiver := range row.receivers {
+			raw := rawBytesOfReceiver(receiver)
+			if raw == nil {
+				continue
+			}
+			if values[i], err = columns[i](raw); err != nil {
+				return counter, errors.Annotatef(err, "can't convert column %s to parquet", meta.ColumnNames()[i])
+			}
+			currentFileSize += uint64(len(raw))
+		}
+		lastFinishedFileSize := fw.finishedFileSize
+		if err = pw.Write(values); err != nil {
+			return counter, errors.Trace(err)
+		}
+		counter++
+		if fw.finishedFileSize != lastFinishedFileSize {
+			// a row group is flushed to the file
+			AddGauge(metrics.finishedRowsGauge, float64(counter-lastCounter))
+			lastCounter = counter
+		}
+
+		select {
+		case <-pCtx.Done():
+			return counter, pCtx.Err()
+		default:
+		}
+
+		fileRowIter.Next()
+		if cfg.FileSize != UnspecifiedSize && currentFileSize >= cfg.FileSize {
+			break
+		}
+	}
+
+	if err = pw.WriteStop(); err != nil {
+		return counter, errors.Trace(err)
+	}
+	AddGauge(metrics.finishedRowsGauge, float64(counter-lastCounter))
+	lastCounter = counter
+	return counter, errors.Trace(fileRowIter.Error())
+}
@@ -257,6 +257,35 @@ func TestSQLDataTypes(t *testing.T) {
 	}
 }
 
+func TestGeometryDataTypes(t *testing.T) {
+	cfg := createMockConfig()
+	cfg.EscapeBackslash = true
+	// POINT(1 1) with SRID 4326 in the internal format of geometries.
+	point := []byte("\xe6\x10\x00\x00\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf0\x3f\x00\x00\x00\x00\x00\x00\xf0\x3f")
+
+	for _, colType := range []string{"GEOMETRY", "POINT"} {
+		tableIR := newMockTableIR("test", "t", [][]driver.Value{{point}}, nil, []string{colType})
+		bf := storage.NewBufferWriter()
+		conf := configForWriteSQL(cfg, UnspecifiedSize, UnspecifiedSize)
+		m := newMetrics(conf.PromFactory, conf.Labels)
+		n, err := WriteInsert(tcontext.Background(), conf, tableIR, tableIR, bf, m)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), n)
+		lines := strings.Split(bf.String(), "\n")
+		require.Len(t, lines, 3)
+		require.Equal(t, "(x'e61000000101000000000000000000f03f000000000000f03f');", lines[1])
+
+		tableIR = newMockTableIR("test", "t", [][]driver.Value{{point}}, nil, []string{colType})
+		bf = storage.NewBufferWriter()
+		conf = configForWriteCSV(cfg, true, &csvOption{separator: []byte(","), delimiter: []byte{'"'}, nullValue: "\\N"})
+		m = newMetrics(conf.PromFactory, conf.Labels)
+		n, err = WriteInsertInCsv(tcontext.Background(), conf, tableIR, tableIR, bf, m)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), n)
+		require.Equal(t, "\"\xe6\x10\\0\\0\x01\x01\\0\\0\\0\\0\\0\\0\\0\\0\\0\xf0?\\0\\0\\0\\0\\0\\0\xf0?\"\r\n", bf.String())
+	}
+}
+
 func TestWrite(t *testing.T) {
 	mocksw := &mockPoisonWriter{}
 	src := []string{"test", "loooooooooooooooooooong", "poison"}
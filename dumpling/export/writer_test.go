@@ -5,14 +5,18 @@ package export
 import (
 	"context"
 	"database/sql/driver"
+	"io"
 	"os"
 	"path"
 	"sync"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/br/pkg/version"
 	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/promutil"
 	"github.com/stretchr/testify/require"
 )
@@ -328,6 +332,71 @@ func TestWriteTableDataWithStatementSize(t *testing.T) {
 	}
 }
 
+func TestWriteTableDataInParquet(t *testing.T) {
+	dir := t.TempDir()
+	config := defaultConfigForTest(t)
+	config.OutputDirPath = dir
+	config.FileType = FileFormatParquetString
+	config.CompressType = storage.Gzip
+	// the size of the values in the first two rows
+	config.FileSize = 50
+
+	writer := createTestWriter(config, t)
+
+	data := [][]driver.Value{
+		{"1", "18446744073709551615", "1.5", "12.30", "bob", "\x00\x01"},
+		{"2", nil, "-2.25", "-0.01", "sarah", nil},
+		{"3", "0", nil, nil, nil, "x"},
+		{"4", "42", "0", "0.00", "", ""},
+	}
+	colTypes := []string{"INT", "UNSIGNED BIGINT", "DOUBLE", "DECIMAL", "VARCHAR", "BLOB"}
+	tableIR := newMockTableIR("test", "employee", data, nil, colTypes)
+	tableIR.colNames = []string{"id", "u", "d", "dec", "na,me", "B"}
+	require.NoError(t, writer.WriteTableData(tableIR, tableIR, 0))
+
+	str := func(s string) types.Datum {
+		return types.NewCollationStringDatum(s, "utf8mb4_bin")
+	}
+	null := types.Datum{}
+	cases := map[string][][]types.Datum{
+		"test.employee.000000000.parquet": {
+			{types.NewIntDatum(1), types.NewUintDatum(18446744073709551615), types.NewFloat64Datum(1.5), str("12.30"), str("bob"), str("\x00\x01")},
+			{types.NewIntDatum(2), null, types.NewFloat64Datum(-2.25), str("-0.01"), str("sarah"), null},
+		},
+		"test.employee.000000001.parquet": {
+			{types.NewIntDatum(3), types.NewUintDatum(0), null, null, null, str("x")},
+			{types.NewIntDatum(4), types.NewUintDatum(42), types.NewFloat64Datum(0), str("0.00"), str(""), str("")},
+		},
+	}
+
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+	for name, expected := range cases {
+		r, err := store.Open(ctx, name)
+		require.NoError(t, err)
+		parser, err := mydump.NewParquetParser(ctx, store, r, name)
+		require.NoError(t, err)
+		require.Equal(t, []string{"id", "u", "d", "dec", "na,me", "b"}, parser.Columns())
+		for _, row := range expected {
+			require.NoError(t, parser.ReadRow())
+			lastRow := parser.LastRow().Row
+			require.Len(t, lastRow, len(row))
+			for i, d := range row {
+				if d.IsNull() {
+					require.True(t, lastRow[i].IsNull())
+				} else {
+					require.Equal(t, d, lastRow[i])
+				}
+			}
+		}
+		require.ErrorIs(t, parser.ReadRow(), io.EOF)
+		require.NoError(t, parser.Close())
+	}
+	_, err = os.Stat(path.Join(dir, "test.employee.000000002.parquet"))
+	require.True(t, os.IsNotExist(err))
+}
+
 var mu sync.Mutex
 
 func createTestWriter(conf *Config, t *testing.T) *Writer {
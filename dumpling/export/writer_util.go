@@ -600,7 +600,7 @@ func compressFileSuffix(compressType storage.CompressType) string {
 	}
 }
 
-// FileFormat is the format that output to file. Currently we support SQL text and CSV file format.
+// FileFormat is the format that output to file. Currently we support SQL text, CSV and parquet file format.
 type FileFormat int32
 
 const (
@@ -610,6 +610,8 @@ const (
 	FileFormatSQLText
 	// FileFormatCSV indicates the given file type is csv type
 	FileFormatCSV
+	// FileFormatParquet indicates the given file type is parquet type
+	FileFormatParquet
 )
 
 const (
@@ -617,6 +619,8 @@ const (
 	FileFormatSQLTextString = "sql"
 	// FileFormatCSVString indicates the string/suffix of csv type file
 	FileFormatCSVString = "csv"
+	// FileFormatParquetString indicates the string/suffix of parquet type file
+	FileFormatParquetString = "parquet"
 )
 
 // String implement Stringer.String method.
@@ -626,6 +630,8 @@ func (f FileFormat) String() string {
 		return strings.ToUpper(FileFormatSQLTextString)
 	case FileFormatCSV:
 		return strings.ToUpper(FileFormatCSVString)
+	case FileFormatParquet:
+		return strings.ToUpper(FileFormatParquetString)
 	default:
 		return "unknown"
 	}
@@ -633,20 +639,23 @@ func (f FileFormat) String() string {
 
 // Extension returns the extension for specific format.
 //
-//	text -> "sql"
-//	csv  -> "csv"
+//	text    -> "sql"
+//	csv     -> "csv"
+//	parquet -> "parquet"
 func (f FileFormat) Extension() string {
 	switch f {
 	case FileFormatSQLText:
 		return FileFormatSQLTextString
 	case FileFormatCSV:
 		return FileFormatCSVString
+	case FileFormatParquet:
+		return FileFormatParquetString
 	default:
 		return "unknown_format"
 	}
 }
 
-// WriteInsert writes TableDataIR to a storage.ExternalFileWriter in sql/csv type
+// WriteInsert writes TableDataIR to a storage.ExternalFileWriter in sql/csv/parquet type
 func (f FileFormat) WriteInsert(
 	pCtx *tcontext.Context,
 	cfg *Config,
@@ -660,6 +669,8 @@ func (f FileFormat) WriteInsert(
 		return WriteInsert(pCtx, cfg, meta, tblIR, w, metrics)
 	case FileFormatCSV:
 		return WriteInsertInCsv(pCtx, cfg, meta, tblIR, w, metrics)
+	case FileFormatParquet:
+		return WriteInsertInParquet(pCtx, cfg, meta, tblIR, w, metrics)
 	default:
 		return 0, errors.Errorf("unknown file format")
 	}
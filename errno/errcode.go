@@ -828,6 +828,8 @@ const (
 	ErrInvalidArgumentForLogarithm                           = 3020
 	ErrMaxExecTimeExceeded                                   = 3024
 	ErrAggregateOrderNonAggQuery                             = 3029
+	ErrGISDifferentSRIDs                                     = 3033
+	ErrGISInvalidData                                        = 3037
 	ErrUserLockWrongName                                     = 3057
 	ErrUserLockDeadlock                                      = 3058
 	ErrIncorrectType                                         = 3064
@@ -860,9 +862,11 @@ const (
 	ErrInvalidJSONPathArrayCell                              = 3165
 	ErrInvalidEncryptionOption                               = 3184
 	ErrTooLongValueForType                                   = 3505
+	ErrGISUnsupportedArgument                                = 3516
 	ErrPKIndexCantBeInvisible                                = 3522
 	ErrGrantRole                                             = 3523
 	ErrRoleNotGranted                                        = 3530
+	ErrSRSNotFound                                           = 3548
 	ErrLockAcquireFailAndNoWaitSet                           = 3572
 	ErrCTERecursiveRequiresUnion                             = 3573
 	ErrCTERecursiveRequiresNonRecursiveFirst                 = 3574
@@ -892,10 +896,17 @@ const (
 	ErrWindowFunctionIgnoresFrame                            = 3599
 	ErrInvalidNumberOfArgs                                   = 3601
 	ErrFieldInGroupingNotGroupBy                             = 3602
+	ErrLongitudeOutOfRange                                   = 3616
+	ErrLatitudeOutOfRange                                    = 3617
 	ErrIllegalPrivilegeLevel                                 = 3619
 	ErrCTEMaxRecursionDepth                                  = 3636
 	ErrNotHintUpdatable                                      = 3637
 	ErrExistsInHistoryPassword                               = 3638
+	ErrWrongSRIDForColumn                                    = 3643
+	ErrMissingJSONTableValue                                 = 3665
+	ErrWrongJSONTableValue                                   = 3666
+	ErrTFForbiddenJoinType                                   = 3668
+	ErrNonPositiveRadius                                     = 3706
 	ErrForeignKeyCannotDropParent                            = 3730
 	ErrForeignKeyCannotUseVirtualColumn                      = 3733
 	ErrForeignKeyNoColumnInParent                            = 3734
@@ -926,6 +937,7 @@ const (
 	ErrCheckConstraintDupName                                = 3822
 	ErrCheckConstraintClauseUsingFKReferActionColumn         = 3823
 	ErrDependentByFunctionalIndex                            = 3837
+	ErrInvalidJSONType                                       = 3853
 	ErrCannotConvertString                                   = 3854
 	ErrDependentByPartitionFunctional                        = 3855
 	ErrInvalidJSONValueForFuncIndex                          = 3903
@@ -933,9 +945,11 @@ const (
 	ErrFunctionalIndexDataIsTooLong                          = 3907
 	ErrFunctionalIndexNotApplicable                          = 3909
 	ErrDynamicPrivilegeNotRegistered                         = 3929
+	ErrJSONSchemaValidationErrorWithDetailedReport           = 3934
 	ErrConstraintNotFound                                    = 3940
 	ErUserAccessDeniedForUserAccountBlockedByPasswordLock    = 3955
 	ErrDependentByCheckConstraint                            = 3959
+	ErrMissingJSONValue                                      = 3966
 	ErrJSONInBooleanContext                                  = 3986
 	ErrTableWithoutPrimaryKey                                = 3750
 	// MariaDB errors.
@@ -1078,6 +1092,8 @@ const (
 	ErrLoadDataLocalUnsupportedOption      = 8172
 	ErrLoadDataPreCheckFailed              = 8173
 	ErrBRJobNotFound                       = 8174
+	ErrUnsupportedTTLTimestampUnit         = 8175
+	ErrUnsupportedTTLArchiveTable          = 8176
 
 	// Error codes used by TiDB ddl package
 	ErrUnsupportedDDLOperation            = 8200
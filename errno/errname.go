@@ -892,6 +892,17 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrLockAcquireFailAndNoWaitSet:                           mysql.Message("Statement aborted because lock(s) could not be acquired immediately and NOWAIT is set.", nil),
 	ErrNotHintUpdatable:                                      mysql.Message("Variable '%s' cannot be set using SET_VAR hint.", nil),
 	ErrExistsInHistoryPassword:                               mysql.Message("Cannot use these credentials for '%s@%s' because they contradict the password history policy.", nil),
+	ErrMissingJSONTableValue:                                 mysql.Message("Missing value for JSON_TABLE column '%-.192s'", nil),
+	ErrWrongJSONTableValue:                                   mysql.Message("Can't store an array or an object in the scalar JSON_TABLE column '%-.192s'", nil),
+	ErrTFForbiddenJoinType:                                   mysql.Message("INNER or LEFT JOIN must be used for LATERAL references made by '%-.192s'", nil),
+	ErrGISDifferentSRIDs:                                     mysql.Message("Binary geometry function %s given two geometries of different srids: %d and %d, which should have been identical.", nil),
+	ErrGISInvalidData:                                        mysql.Message("Invalid GIS data provided to function %s.", nil),
+	ErrGISUnsupportedArgument:                                mysql.Message("Calling geometry function %s with unsupported types of arguments.", nil),
+	ErrSRSNotFound:                                           mysql.Message("There's no spatial reference system with SRID %d.", nil),
+	ErrLongitudeOutOfRange:                                   mysql.Message("Longitude %f is out of range in function %s. It must be within (%f, %f].", nil),
+	ErrLatitudeOutOfRange:                                    mysql.Message("Latitude %f is out of range in function %s. It must be within [%f, %f].", nil),
+	ErrWrongSRIDForColumn:                                    mysql.Message("The SRID of the geometry does not match the SRID of the column '%-.64s'. The SRID of the geometry is %d, but the SRID of the column is %d. Consider changing the SRID of the geometry or the SRID property of the column.", nil),
+	ErrNonPositiveRadius:                                     mysql.Message("Invalid radius provided to function %s: Radius must be greater than zero.", nil),
 	ErrForeignKeyCannotDropParent:                            mysql.Message("Cannot drop table '%s' referenced by a foreign key constraint '%s' on table '%s'.", nil),
 	ErrForeignKeyCannotUseVirtualColumn:                      mysql.Message("Foreign key '%s' uses virtual column '%s' which is not supported.", nil),
 	ErrForeignKeyNoColumnInParent:                            mysql.Message("Failed to add the foreign key constraint. Missing column '%s' for constraint '%s' in the referenced table '%s'", nil),
@@ -921,6 +932,7 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrCheckConstraintClauseUsingFKReferActionColumn:         mysql.Message("Column '%s' cannot be used in a check constraint '%s': needed in a foreign key constraint referential action.", nil),
 	ErrDependentByFunctionalIndex:                            mysql.Message("Column '%s' has an expression index dependency and cannot be dropped or renamed", nil),
 	ErrDependentByPartitionFunctional:                        mysql.Message("Column '%s' has a partitioning function dependency and cannot be dropped or renamed", nil),
+	ErrInvalidJSONType:                                       mysql.Message("Invalid JSON type in argument %d to function %s; an %s is required.", nil),
 	ErrCannotConvertString:                                   mysql.Message("Cannot convert string '%.64s' from %s to %s", nil),
 	ErrInvalidJSONValueForFuncIndex:                          mysql.Message("Invalid JSON value for CAST for expression index '%s'", nil),
 	ErrJSONValueOutOfRangeForFuncIndex:                       mysql.Message("Out of range JSON value for CAST for expression index '%s'", nil),
@@ -928,6 +940,7 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrFunctionalIndexNotApplicable:                          mysql.Message("Cannot use expression index '%s' due to type or collation conversion", nil),
 	ErrUnsupportedConstraintCheck:                            mysql.Message("%s is not supported", nil),
 	ErrDynamicPrivilegeNotRegistered:                         mysql.Message("Dynamic privilege '%s' is not registered with the server.", nil),
+	ErrJSONSchemaValidationErrorWithDetailedReport:           mysql.Message("%s.", nil),
 	ErrIllegalPrivilegeLevel:                                 mysql.Message("Illegal privilege level specified for %s", nil),
 	ErrCTERecursiveRequiresUnion:                             mysql.Message("Recursive Common Table Expression '%s' should contain a UNION", nil),
 	ErrCTERecursiveRequiresNonRecursiveFirst:                 mysql.Message("Recursive Common Table Expression '%s' should have one or more non-recursive query blocks followed by one or more recursive ones", nil),
@@ -938,6 +951,7 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrTableWithoutPrimaryKey:                                mysql.Message("Unable to create or change a table without a primary key, when the system variable 'sql_require_primary_key' is set. Add a primary key to the table or unset this variable to avoid this message. Note that tables without a primary key can cause performance problems in row-based replication, so please consult your DBA before changing this setting.", nil),
 	ErrConstraintNotFound:                                    mysql.Message("Constraint '%s' does not exist.", nil),
 	ErrDependentByCheckConstraint:                            mysql.Message("Check constraint '%s' uses column '%s', hence column cannot be dropped or renamed.", nil),
+	ErrMissingJSONValue:                                      mysql.Message("No value was found by '%.192s' on the specified path.", nil),
 	ErrJSONInBooleanContext:                                  mysql.Message("Evaluating a JSON value in SQL boolean context does an implicit comparison against JSON integer 0; if this is not what you want, consider converting JSON to a SQL numeric type with JSON_VALUE RETURNING", nil),
 	// MariaDB errors.
 	ErrOnlyOneDefaultPartionAllowed:         mysql.Message("Only one DEFAULT partition allowed", nil),
@@ -1048,12 +1062,14 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrGettingNoopVariable:              mysql.Message("variable %s has no effect in TiDB", nil),
 	ErrCannotMigrateSession:             mysql.Message("cannot migrate the current session: %s", nil),
 	ErrLazyUniquenessCheckFailure:       mysql.Message("transaction aborted because lazy uniqueness check is enabled and an error occurred: %s", nil),
-	ErrUnsupportedColumnInTTLConfig:     mysql.Message("Field '%-.192s' is of a not supported type for TTL config, expect DATETIME, DATE or TIMESTAMP", nil),
+	ErrUnsupportedColumnInTTLConfig:     mysql.Message("Field '%-.192s' is of a not supported type for TTL config, expect DATETIME, DATE, TIMESTAMP or an integer with TTL_TIMESTAMP_UNIT", nil),
 	ErrTTLColumnCannotDrop:              mysql.Message("Cannot drop column '%-.192s': needed in TTL config", nil),
 	ErrSetTTLOptionForNonTTLTable:       mysql.Message("Cannot set %s on a table without TTL config", nil),
 	ErrTempTableNotAllowedWithTTL:       mysql.Message("Set TTL for temporary table is not allowed", nil),
 	ErrUnsupportedTTLReferencedByFK:     mysql.Message("Set TTL for a table referenced by foreign key is not allowed", nil),
 	ErrUnsupportedPrimaryKeyTypeWithTTL: mysql.Message("Unsupported clustered primary key type FLOAT/DOUBLE for TTL", nil),
+	ErrUnsupportedTTLTimestampUnit:      mysql.Message("Cannot set TTL_TIMESTAMP_UNIT for the TTL column '%-.192s', it's only allowed for an integer column", nil),
+	ErrUnsupportedTTLArchiveTable:       mysql.Message("Cannot use '%-.192s' as the TTL archive table, %s", nil),
 	ErrLoadDataFromServerDisk:           mysql.Message("Don't support load data from tidb-server's disk. Or if you want to load local data via client, the path of INFILE '%s' needs to specify the clause of LOCAL first", nil),
 	ErrLoadParquetFromLocal:             mysql.Message("Do not support loading parquet files from local. Please try to load the parquet files from the cloud storage", nil),
 	ErrLoadDataEmptyPath:                mysql.Message("The value of INFILE must not be empty when LOAD DATA from LOCAL", nil),
@@ -94,6 +94,7 @@ var (
 	// All the AggFunc implementations for "GROUP_CONCAT" are listed here.
 	_ AggFunc = (*groupConcatDistinct)(nil)
 	_ AggFunc = (*groupConcat)(nil)
+	_ AggFunc = (*groupConcatSliding)(nil)
 
 	// All the AggFunc implementations for "BIT_OR" are listed here.
 	_ AggFunc = (*bitOrUint64)(nil)
@@ -109,6 +110,9 @@ var (
 
 	// All the AggFunc implementations for "JSON_OBJECTAGG" are listed here
 	_ AggFunc = (*jsonObjectAgg)(nil)
+
+	// All the AggFunc implementations for the extension aggregate functions are listed here
+	_ AggFunc = (*extensionAggFunc)(nil)
 )
 
 const (
@@ -73,6 +73,9 @@ func Build(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal
 	case ast.AggFuncStddevSamp:
 		return buildStddevSamp(aggFuncDesc, ordinal)
 	}
+	if def, ok := expression.GetExtensionAggFunc(aggFuncDesc.Name); ok {
+		return buildExtensionAggFunc(ctx, aggFuncDesc, ordinal, def)
+	}
 	return nil
 }
 
@@ -106,6 +109,8 @@ func BuildWindowFunctions(ctx sessionctx.Context, windowFuncDesc *aggregation.Ag
 		return buildMaxMinInWindowFunction(windowFuncDesc, ordinal, true)
 	case ast.AggFuncMin:
 		return buildMaxMinInWindowFunction(windowFuncDesc, ordinal, false)
+	case ast.AggFuncGroupConcat:
+		return buildGroupConcatInWindowFunction(ctx, windowFuncDesc, ordinal)
 	default:
 		return Build(ctx, windowFuncDesc, ordinal)
 	}
@@ -496,6 +501,17 @@ func buildGroupConcat(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDe
 	}
 }
 
+// buildGroupConcatInWindowFunction builds the AggFunc implementation for function "GROUP_CONCAT" used by window function.
+func buildGroupConcatInWindowFunction(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	base := buildGroupConcat(ctx, aggFuncDesc, ordinal)
+	// The values of group_concat with DISTINCT or ORDER BY are not concatenated in the order of rows,
+	// so only the plain one is able to use the sliding window.
+	if baseAggFunc, ok := base.(*groupConcat); ok {
+		return &groupConcatSliding{baseAggFunc.baseGroupConcat4String}
+	}
+	return base
+}
+
 // buildBitOr builds the AggFunc implementation for function "BIT_OR".
 func buildBitOr(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 	base := baseAggFunc{
@@ -649,7 +665,7 @@ func buildFirstValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
-	return &firstValue{baseAggFunc: base, tp: aggFuncDesc.RetTp}
+	return &firstValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, ignoreNull: aggFuncDesc.IgnoreNull}
 }
 
 func buildLastValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
@@ -657,7 +673,7 @@ func buildLastValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
-	return &lastValue{baseAggFunc: base, tp: aggFuncDesc.RetTp}
+	return &lastValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, ignoreNull: aggFuncDesc.IgnoreNull}
 }
 
 func buildCumeDist(ordinal int, orderByCols []*expression.Column) AggFunc {
@@ -675,7 +691,10 @@ func buildNthValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 	}
 	// Already checked when building the function description.
 	nth, _, _ := expression.GetUint64FromConstant(aggFuncDesc.Args[1])
-	return &nthValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, nth: nth}
+	if aggFuncDesc.FromLast {
+		return &nthValueFromLast{baseAggFunc: base, tp: aggFuncDesc.RetTp, nth: nth, ignoreNull: aggFuncDesc.IgnoreNull}
+	}
+	return &nthValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, nth: nth, ignoreNull: aggFuncDesc.IgnoreNull}
 }
 
 func buildNtile(aggFuncDes *aggregation.AggFuncDesc, ordinal int) AggFunc {
@@ -716,7 +735,7 @@ func buildLeadLag(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc,
 		ordinal: ordinal,
 	}
 	ve, _ := buildValueEvaluator(aggFuncDesc.RetTp)
-	return baseLeadLag{baseAggFunc: base, offset: offset, defaultExpr: defaultExpr, valueEvaluator: ve}
+	return baseLeadLag{baseAggFunc: base, offset: offset, defaultExpr: defaultExpr, valueEvaluator: ve, ignoreNull: aggFuncDesc.IgnoreNull}
 }
 
 func buildLead(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
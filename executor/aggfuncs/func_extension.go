@@ -0,0 +1,129 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression/aggregation"
+	"github.com/pingcap/tidb/extension"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+const (
+	// DefPartialResult4ExtensionSize is the size of partialResult4Extension
+	DefPartialResult4ExtensionSize = int64(unsafe.Sizeof(partialResult4Extension{}))
+)
+
+var _ extension.FunctionContext = &extensionAggFunc{}
+
+// extensionAggFunc evaluates an extension aggregate function by its `extension.AggFunction`.
+type extensionAggFunc struct {
+	baseAggFunc
+	context.Context
+
+	sctx sessionctx.Context
+	def  *extension.AggFunctionDef
+}
+
+type partialResult4Extension struct {
+	pr extension.AggPartialResult
+}
+
+func buildExtensionAggFunc(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int, def *extension.AggFunctionDef) AggFunc {
+	return &extensionAggFunc{
+		baseAggFunc: baseAggFunc{
+			args:    aggFuncDesc.Args,
+			ordinal: ordinal,
+			retTp:   aggFuncDesc.RetTp,
+		},
+		Context: context.TODO(),
+		sctx:    ctx,
+		def:     def,
+	}
+}
+
+func (e *extensionAggFunc) AllocPartialResult() (pr PartialResult, memDelta int64) {
+	p := &partialResult4Extension{}
+	p.pr, memDelta = e.def.Impl.AllocPartialResult()
+	return PartialResult(p), DefPartialResult4ExtensionSize + memDelta
+}
+
+func (e *extensionAggFunc) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4Extension)(pr)
+	e.def.Impl.ResetPartialResult(p.pr)
+}
+
+func (e *extensionAggFunc) UpdatePartialResult(_ sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	p := (*partialResult4Extension)(pr)
+	return e.def.Impl.UpdatePartialResult(e, rowsInGroup, p.pr)
+}
+
+func (e *extensionAggFunc) MergePartialResult(_ sessionctx.Context, src, dst PartialResult) (memDelta int64, err error) {
+	p1, p2 := (*partialResult4Extension)(src), (*partialResult4Extension)(dst)
+	return e.def.Impl.MergePartialResult(e, p1.pr, p2.pr)
+}
+
+func (e *extensionAggFunc) AppendFinalResult2Chunk(_ sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4Extension)(pr)
+	col := chk.Column(e.ordinal)
+	expected := col.Rows() + 1
+	if err := e.def.Impl.AppendFinalResult(e, p.pr, col); err != nil {
+		return err
+	}
+	if col.Rows() != expected {
+		return errors.Errorf("extension aggregate function '%s' should append exactly one value as the final result", e.def.Name)
+	}
+	return nil
+}
+
+// EvalArgs implements the extension.FunctionContext interface.
+func (e *extensionAggFunc) EvalArgs(row chunk.Row) ([]types.Datum, error) {
+	result := make([]types.Datum, 0, len(e.args))
+	for _, arg := range e.args {
+		val, err := arg.Eval(row)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, val)
+	}
+	return result, nil
+}
+
+// ConnectionInfo implements the extension.FunctionContext interface.
+func (e *extensionAggFunc) ConnectionInfo() *variable.ConnectionInfo {
+	return e.sctx.GetSessionVars().ConnectionInfo
+}
+
+// User implements the extension.FunctionContext interface.
+func (e *extensionAggFunc) User() *auth.UserIdentity {
+	return e.sctx.GetSessionVars().User
+}
+
+// ActiveRoles implements the extension.FunctionContext interface.
+func (e *extensionAggFunc) ActiveRoles() []*auth.RoleIdentity {
+	return e.sctx.GetSessionVars().ActiveRoles
+}
+
+// CurrentDB implements the extension.FunctionContext interface.
+func (e *extensionAggFunc) CurrentDB() string {
+	return e.sctx.GetSessionVars().CurrentDB
+}
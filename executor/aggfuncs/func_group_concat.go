@@ -41,6 +41,8 @@ const (
 	DefPartialResult4GroupConcatOrderSize = int64(unsafe.Sizeof(partialResult4GroupConcatOrder{}))
 	// DefPartialResult4GroupConcatOrderDistinctSize is the size of partialResult4GroupConcatOrderDistinct
 	DefPartialResult4GroupConcatOrderDistinctSize = int64(unsafe.Sizeof(partialResult4GroupConcatOrderDistinct{}))
+	// DefPartialResult4GroupConcatSlidingSize is the size of partialResult4GroupConcatSliding
+	DefPartialResult4GroupConcatSlidingSize = int64(unsafe.Sizeof(partialResult4GroupConcatSliding{}))
 
 	// DefBytesBufferSize is the size of bytes.Buffer.
 	DefBytesBufferSize = int64(unsafe.Sizeof(bytes.Buffer{}))
@@ -374,6 +376,7 @@ func (h *topNRows) reset() {
 	h.rows = h.rows[:0]
 	h.err = nil
 	h.currSize = 0
+	h.isSepTruncated = false
 }
 
 func (h *topNRows) concat(sep string, truncated bool) string {
@@ -611,6 +614,119 @@ func (e *groupConcatDistinctOrder) MergePartialResult(sctx sessionctx.Context, s
 	return 0, plannercore.ErrInternal.GenWithStack("groupConcatDistinctOrder.MergePartialResult should not be called")
 }
 
+type partialResult4GroupConcatSliding struct {
+	basePartialResult4GroupConcat
+	// valLens keeps the lengths of the values concatenated in buffer, the first one is the oldest value.
+	valLens []int
+}
+
+// groupConcatSliding is used for GROUP_CONCAT without DISTINCT and ORDER BY in window functions.
+// The values are concatenated in the order of rows, so when the frame slides, only the new values are
+// appended to the buffer and the values moved out of the frame are dropped from the head of the buffer.
+type groupConcatSliding struct {
+	baseGroupConcat4String
+}
+
+var _ SlidingWindowAggFunc = &groupConcatSliding{}
+
+func (e *groupConcatSliding) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4GroupConcatSliding)(pr)
+	if len(p.valLens) == 0 {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	// The buffer keeps all the values in the frame for sliding, so it is truncated when the result is generated.
+	res := p.buffer.Bytes()
+	if e.maxLen > 0 && uint64(len(res)) > e.maxLen {
+		res = res[:e.maxLen]
+		if err := e.handleTruncateError(sctx); err != nil {
+			return err
+		}
+	}
+	chk.AppendBytes(e.ordinal, res)
+	return nil
+}
+
+func (e *groupConcatSliding) AllocPartialResult() (pr PartialResult, memDelta int64) {
+	p := new(partialResult4GroupConcatSliding)
+	p.valsBuf = &bytes.Buffer{}
+	p.buffer = &bytes.Buffer{}
+	return PartialResult(p), DefPartialResult4GroupConcatSlidingSize + DefBytesBufferSize*2
+}
+
+func (e *groupConcatSliding) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4GroupConcatSliding)(pr)
+	p.buffer.Reset()
+	p.valLens = p.valLens[:0]
+}
+
+// evalValue concatenates the arguments of the row into valsBuf, isNull is true if any of the arguments is null.
+func (e *groupConcatSliding) evalValue(sctx sessionctx.Context, row chunk.Row, p *partialResult4GroupConcatSliding) (isNull bool, err error) {
+	var v string
+	p.valsBuf.Reset()
+	for _, arg := range e.args {
+		v, isNull, err = arg.EvalString(sctx, row)
+		if err != nil || isNull {
+			return isNull, err
+		}
+		p.valsBuf.WriteString(v)
+	}
+	return false, nil
+}
+
+func (e *groupConcatSliding) appendValue(sctx sessionctx.Context, row chunk.Row, p *partialResult4GroupConcatSliding) error {
+	isNull, err := e.evalValue(sctx, row, p)
+	if err != nil || isNull {
+		return err
+	}
+	if len(p.valLens) > 0 {
+		p.buffer.WriteString(e.sep)
+	}
+	p.buffer.Write(p.valsBuf.Bytes())
+	p.valLens = append(p.valLens, p.valsBuf.Len())
+	return nil
+}
+
+func (e *groupConcatSliding) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	p := (*partialResult4GroupConcatSliding)(pr)
+	memDelta -= int64(p.valsBuf.Cap()+p.buffer.Cap()) + int64(cap(p.valLens))*DefInt64Size
+	defer func() {
+		memDelta += int64(p.valsBuf.Cap()+p.buffer.Cap()) + int64(cap(p.valLens))*DefInt64Size
+	}()
+	for _, row := range rowsInGroup {
+		if err = e.appendValue(sctx, row, p); err != nil {
+			return memDelta, err
+		}
+	}
+	return memDelta, nil
+}
+
+func (e *groupConcatSliding) Slide(sctx sessionctx.Context, getRow func(uint64) chunk.Row, lastStart, lastEnd uint64, shiftStart, shiftEnd uint64, pr PartialResult) error {
+	p := (*partialResult4GroupConcatSliding)(pr)
+	for i := uint64(0); i < shiftEnd; i++ {
+		if err := e.appendValue(sctx, getRow(lastEnd+i), p); err != nil {
+			return err
+		}
+	}
+	for i := uint64(0); i < shiftStart; i++ {
+		isNull, err := e.evalValue(sctx, getRow(lastStart+i), p)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		// Drop the oldest value together with the separator after it.
+		n := p.valLens[0]
+		p.valLens = p.valLens[1:]
+		if len(p.valLens) > 0 {
+			n += len(e.sep)
+		}
+		p.buffer.Next(n)
+	}
+	return nil
+}
+
 // GetDatumMemSize calculates the memory size of each types.Datum in sortRow.byItems.
 // types.Datum memory size = variable type's memory size + variable value's memory size.
 func GetDatumMemSize(d *types.Datum) int64 {
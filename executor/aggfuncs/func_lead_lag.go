@@ -15,6 +15,7 @@
 package aggfuncs
 
 import (
+	"sort"
 	"unsafe"
 
 	"github.com/pingcap/tidb/expression"
@@ -33,11 +34,17 @@ type baseLeadLag struct {
 
 	defaultExpr expression.Expression
 	offset      uint64
+	ignoreNull  bool
 }
 
 type partialResult4LeadLag struct {
 	rows   []chunk.Row
 	curIdx uint64
+	// nonNullIdx keeps the ascending indexes of the rows whose value is not
+	// NULL, and checkedRows is the number of rows already checked. They are
+	// only used for IGNORE NULLS.
+	nonNullIdx  []uint64
+	checkedRows uint64
 }
 
 func (v *baseLeadLag) AllocPartialResult() (pr PartialResult, memDelta int64) {
@@ -48,6 +55,8 @@ func (v *baseLeadLag) ResetPartialResult(pr PartialResult) {
 	p := (*partialResult4LeadLag)(pr)
 	p.rows = p.rows[:0]
 	p.curIdx = 0
+	p.nonNullIdx = p.nonNullIdx[:0]
+	p.checkedRows = 0
 }
 
 func (v *baseLeadLag) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
@@ -57,6 +66,46 @@ func (v *baseLeadLag) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup [
 	return memDelta, nil
 }
 
+// collectNonNullRows finds the rows whose value is not NULL among the rows
+// appended since the last call.
+func (v *baseLeadLag) collectNonNullRows(sctx sessionctx.Context, p *partialResult4LeadLag) error {
+	for ; p.checkedRows < uint64(len(p.rows)); p.checkedRows++ {
+		_, err := v.evaluateRow(sctx, v.args[0], p.rows[p.checkedRows])
+		if err != nil {
+			return err
+		}
+		if !v.valueIsNull() {
+			p.nonNullIdx = append(p.nonNullIdx, p.checkedRows)
+		}
+	}
+	return nil
+}
+
+// evaluateIgnoreNull evaluates the value of the offset-th non-null row after
+// (for LEAD) or before (for LAG) the current row, it returns false if there
+// is no such row.
+func (v *baseLeadLag) evaluateIgnoreNull(sctx sessionctx.Context, p *partialResult4LeadLag, isLead bool) (found bool, err error) {
+	if err = v.collectNonNullRows(sctx, p); err != nil {
+		return false, err
+	}
+	if v.offset > uint64(len(p.nonNullIdx)) {
+		return false, nil
+	}
+	var pos int
+	if isLead {
+		pos = sort.Search(len(p.nonNullIdx), func(i int) bool { return p.nonNullIdx[i] > p.curIdx })
+		pos += int(v.offset) - 1
+	} else {
+		pos = sort.Search(len(p.nonNullIdx), func(i int) bool { return p.nonNullIdx[i] >= p.curIdx })
+		pos -= int(v.offset)
+	}
+	if pos < 0 || pos >= len(p.nonNullIdx) {
+		return false, nil
+	}
+	_, err = v.evaluateRow(sctx, v.args[0], p.rows[p.nonNullIdx[pos]])
+	return true, err
+}
+
 type lead struct {
 	baseLeadLag
 }
@@ -64,7 +113,13 @@ type lead struct {
 func (v *lead) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
 	p := (*partialResult4LeadLag)(pr)
 	var err error
-	if p.curIdx+v.offset < uint64(len(p.rows)) {
+	if v.ignoreNull && v.offset > 0 {
+		var found bool
+		found, err = v.evaluateIgnoreNull(sctx, p, true)
+		if err == nil && !found {
+			_, err = v.evaluateRow(sctx, v.defaultExpr, p.rows[p.curIdx])
+		}
+	} else if p.curIdx+v.offset < uint64(len(p.rows)) {
 		_, err = v.evaluateRow(sctx, v.args[0], p.rows[p.curIdx+v.offset])
 	} else {
 		_, err = v.evaluateRow(sctx, v.defaultExpr, p.rows[p.curIdx])
@@ -84,7 +139,13 @@ type lag struct {
 func (v *lag) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
 	p := (*partialResult4LeadLag)(pr)
 	var err error
-	if p.curIdx >= v.offset {
+	if v.ignoreNull && v.offset > 0 {
+		var found bool
+		found, err = v.evaluateIgnoreNull(sctx, p, false)
+		if err == nil && !found {
+			_, err = v.evaluateRow(sctx, v.defaultExpr, p.rows[p.curIdx])
+		}
+	} else if p.curIdx >= v.offset {
 		_, err = v.evaluateRow(sctx, v.args[0], p.rows[p.curIdx-v.offset])
 	} else {
 		_, err = v.evaluateRow(sctx, v.defaultExpr, p.rows[p.curIdx])
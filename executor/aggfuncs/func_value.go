@@ -31,6 +31,8 @@ const (
 	DefPartialResult4LastValueSize = int64(unsafe.Sizeof(partialResult4LastValue{}))
 	// DefPartialResult4NthValueSize is the size of partialResult4NthValue
 	DefPartialResult4NthValueSize = int64(unsafe.Sizeof(partialResult4NthValue{}))
+	// DefPartialResult4NthValueFromLastSize is the size of partialResult4NthValueFromLast
+	DefPartialResult4NthValueFromLastSize = int64(unsafe.Sizeof(partialResult4NthValueFromLast{}))
 
 	// DefValue4IntSize is the size of value4Int
 	DefValue4IntSize = int64(unsafe.Sizeof(value4Int{}))
@@ -57,6 +59,8 @@ type valueEvaluator interface {
 	evaluateRow(ctx sessionctx.Context, expr expression.Expression, row chunk.Row) (memDelta int64, err error)
 	// appendResult appends the result to chunk.
 	appendResult(chk *chunk.Chunk, colIdx int)
+	// valueIsNull returns whether the last evaluated value is NULL.
+	valueIsNull() bool
 }
 
 type value4Int struct {
@@ -77,6 +81,10 @@ func (v *value4Int) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4Int) valueIsNull() bool {
+	return v.isNull
+}
+
 type value4Float32 struct {
 	val    float32
 	isNull bool
@@ -97,6 +105,10 @@ func (v *value4Float32) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4Float32) valueIsNull() bool {
+	return v.isNull
+}
+
 type value4Decimal struct {
 	val    *types.MyDecimal
 	isNull bool
@@ -115,6 +127,10 @@ func (v *value4Decimal) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4Decimal) valueIsNull() bool {
+	return v.isNull
+}
+
 type value4Float64 struct {
 	val    float64
 	isNull bool
@@ -133,6 +149,10 @@ func (v *value4Float64) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4Float64) valueIsNull() bool {
+	return v.isNull
+}
+
 type value4String struct {
 	val    string
 	isNull bool
@@ -152,6 +172,10 @@ func (v *value4String) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4String) valueIsNull() bool {
+	return v.isNull
+}
+
 type value4Time struct {
 	val    types.Time
 	isNull bool
@@ -170,6 +194,10 @@ func (v *value4Time) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4Time) valueIsNull() bool {
+	return v.isNull
+}
+
 type value4Duration struct {
 	val    types.Duration
 	isNull bool
@@ -188,6 +216,10 @@ func (v *value4Duration) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4Duration) valueIsNull() bool {
+	return v.isNull
+}
+
 type value4JSON struct {
 	val    types.BinaryJSON
 	isNull bool
@@ -208,6 +240,10 @@ func (v *value4JSON) appendResult(chk *chunk.Chunk, colIdx int) {
 	}
 }
 
+func (v *value4JSON) valueIsNull() bool {
+	return v.isNull
+}
+
 func buildValueEvaluator(tp *types.FieldType) (ve valueEvaluator, memDelta int64) {
 	evalType := tp.EvalType()
 	if tp.GetType() == mysql.TypeBit {
@@ -240,7 +276,8 @@ func buildValueEvaluator(tp *types.FieldType) (ve valueEvaluator, memDelta int64
 type firstValue struct {
 	baseAggFunc
 
-	tp *types.FieldType
+	tp         *types.FieldType
+	ignoreNull bool
 }
 
 type partialResult4FirstValue struct {
@@ -264,12 +301,17 @@ func (v *firstValue) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []
 	if p.gotFirstValue {
 		return 0, nil
 	}
-	if len(rowsInGroup) > 0 {
-		p.gotFirstValue = true
-		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[0])
+	for _, row := range rowsInGroup {
+		delta, err := p.evaluator.evaluateRow(sctx, v.args[0], row)
 		if err != nil {
 			return 0, err
 		}
+		memDelta += delta
+		// With IGNORE NULLS, the first non-null value is returned.
+		if !v.ignoreNull || !p.evaluator.valueIsNull() {
+			p.gotFirstValue = true
+			break
+		}
 	}
 	return memDelta, nil
 }
@@ -287,18 +329,27 @@ func (v *firstValue) AppendFinalResult2Chunk(sctx sessionctx.Context, pr Partial
 type lastValue struct {
 	baseAggFunc
 
-	tp *types.FieldType
+	tp         *types.FieldType
+	ignoreNull bool
 }
 
 type partialResult4LastValue struct {
 	gotLastValue bool
 	evaluator    valueEvaluator
+	// scratch is used to evaluate a row without overwriting the current
+	// last value, it is only allocated for IGNORE NULLS.
+	scratch valueEvaluator
 }
 
 func (v *lastValue) AllocPartialResult() (pr PartialResult, memDelta int64) {
 	ve, veMemDelta := buildValueEvaluator(v.tp)
-	p := &partialResult4FirstValue{evaluator: ve}
-	return PartialResult(p), DefPartialResult4LastValueSize + veMemDelta
+	p := &partialResult4LastValue{evaluator: ve}
+	memDelta = DefPartialResult4LastValueSize + veMemDelta
+	if v.ignoreNull {
+		p.scratch, veMemDelta = buildValueEvaluator(v.tp)
+		memDelta += veMemDelta
+	}
+	return PartialResult(p), memDelta
 }
 
 func (v *lastValue) ResetPartialResult(pr PartialResult) {
@@ -308,12 +359,30 @@ func (v *lastValue) ResetPartialResult(pr PartialResult) {
 
 func (v *lastValue) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
 	p := (*partialResult4LastValue)(pr)
-	if len(rowsInGroup) > 0 {
-		p.gotLastValue = true
-		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[len(rowsInGroup)-1])
+	if !v.ignoreNull {
+		if len(rowsInGroup) > 0 {
+			p.gotLastValue = true
+			memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[len(rowsInGroup)-1])
+			if err != nil {
+				return 0, err
+			}
+		}
+		return memDelta, nil
+	}
+	// With IGNORE NULLS, the last non-null value is returned. Rows are evaluated
+	// into the scratch evaluator so that a NULL won't overwrite the value found
+	// in the previous rows.
+	for i := len(rowsInGroup) - 1; i >= 0; i-- {
+		delta, err := p.scratch.evaluateRow(sctx, v.args[0], rowsInGroup[i])
 		if err != nil {
 			return 0, err
 		}
+		memDelta += delta
+		if !p.scratch.valueIsNull() {
+			p.gotLastValue = true
+			p.evaluator, p.scratch = p.scratch, p.evaluator
+			break
+		}
 	}
 	return memDelta, nil
 }
@@ -331,19 +400,28 @@ func (v *lastValue) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialR
 type nthValue struct {
 	baseAggFunc
 
-	tp  *types.FieldType
-	nth uint64
+	tp         *types.FieldType
+	nth        uint64
+	ignoreNull bool
 }
 
 type partialResult4NthValue struct {
 	seenRows  uint64
 	evaluator valueEvaluator
+	// scratch is used to evaluate a row before knowing whether it is NULL,
+	// it is only allocated for IGNORE NULLS.
+	scratch valueEvaluator
 }
 
 func (v *nthValue) AllocPartialResult() (pr PartialResult, memDelta int64) {
 	ve, veMemDelta := buildValueEvaluator(v.tp)
-	p := &partialResult4FirstValue{evaluator: ve}
-	return PartialResult(p), DefPartialResult4NthValueSize + veMemDelta
+	p := &partialResult4NthValue{evaluator: ve}
+	memDelta = DefPartialResult4NthValueSize + veMemDelta
+	if v.ignoreNull {
+		p.scratch, veMemDelta = buildValueEvaluator(v.tp)
+		memDelta += veMemDelta
+	}
+	return PartialResult(p), memDelta
 }
 
 func (v *nthValue) ResetPartialResult(pr PartialResult) {
@@ -356,6 +434,27 @@ func (v *nthValue) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []ch
 		return 0, nil
 	}
 	p := (*partialResult4NthValue)(pr)
+	if v.ignoreNull {
+		// seenRows only counts the non-null rows for IGNORE NULLS.
+		for _, row := range rowsInGroup {
+			if p.seenRows >= v.nth {
+				break
+			}
+			delta, err := p.scratch.evaluateRow(sctx, v.args[0], row)
+			if err != nil {
+				return 0, err
+			}
+			memDelta += delta
+			if p.scratch.valueIsNull() {
+				continue
+			}
+			p.seenRows++
+			if p.seenRows == v.nth {
+				p.evaluator, p.scratch = p.scratch, p.evaluator
+			}
+		}
+		return memDelta, nil
+	}
 	numRows := uint64(len(rowsInGroup))
 	if v.nth > p.seenRows && v.nth-p.seenRows <= numRows {
 		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[v.nth-p.seenRows-1])
@@ -376,3 +475,77 @@ func (v *nthValue) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialRe
 	}
 	return nil
 }
+
+// nthValueFromLast is the implementation of `NTH_VALUE(expr, N) FROM LAST`,
+// which counts the rows backwards from the last row of the frame.
+type nthValueFromLast struct {
+	baseAggFunc
+
+	tp         *types.FieldType
+	nth        uint64
+	ignoreNull bool
+}
+
+type partialResult4NthValueFromLast struct {
+	// lastN is a ring buffer keeping the values of the last N counted rows,
+	// lastN[head] is the earliest one, which is the result once the buffer is full.
+	lastN   []valueEvaluator
+	head    int
+	scratch valueEvaluator
+}
+
+func (v *nthValueFromLast) AllocPartialResult() (pr PartialResult, memDelta int64) {
+	ve, veMemDelta := buildValueEvaluator(v.tp)
+	p := &partialResult4NthValueFromLast{scratch: ve}
+	return PartialResult(p), DefPartialResult4NthValueFromLastSize + veMemDelta
+}
+
+func (v *nthValueFromLast) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4NthValueFromLast)(pr)
+	p.lastN = p.lastN[:0]
+	p.head = 0
+}
+
+func (v *nthValueFromLast) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	if v.nth == 0 {
+		return 0, nil
+	}
+	p := (*partialResult4NthValueFromLast)(pr)
+	// Only the last N rows are able to affect the result if NULLs are not ignored.
+	if !v.ignoreNull && uint64(len(rowsInGroup)) > v.nth {
+		rowsInGroup = rowsInGroup[uint64(len(rowsInGroup))-v.nth:]
+	}
+	for _, row := range rowsInGroup {
+		delta, err := p.scratch.evaluateRow(sctx, v.args[0], row)
+		if err != nil {
+			return 0, err
+		}
+		memDelta += delta
+		if v.ignoreNull && p.scratch.valueIsNull() {
+			continue
+		}
+		if uint64(len(p.lastN)) < v.nth {
+			if len(p.lastN) == cap(p.lastN) {
+				memDelta += DefInterfaceSize * int64(len(p.lastN)+1)
+			}
+			p.lastN = append(p.lastN, p.scratch)
+			var veMemDelta int64
+			p.scratch, veMemDelta = buildValueEvaluator(v.tp)
+			memDelta += veMemDelta
+			continue
+		}
+		p.lastN[p.head], p.scratch = p.scratch, p.lastN[p.head]
+		p.head = (p.head + 1) % len(p.lastN)
+	}
+	return memDelta, nil
+}
+
+func (v *nthValueFromLast) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4NthValueFromLast)(pr)
+	if v.nth == 0 || uint64(len(p.lastN)) < v.nth {
+		chk.AppendNull(v.ordinal)
+	} else {
+		p.lastN[p.head].appendResult(chk, v.ordinal)
+	}
+	return nil
+}
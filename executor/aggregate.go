@@ -90,6 +90,11 @@ type HashAggPartialWorker struct {
 	// chk stores the input data from child,
 	// and is reused by childExec and partial worker.
 	chk *chunk.Chunk
+
+	// spillHelper is not nil if the data is allowed to be spilled to the disk.
+	spillHelper *parallelHashAggSpillHelper
+	// tmpChksForSpill buffers the rows to be spilled, one chunk for each final worker.
+	tmpChksForSpill []*chunk.Chunk
 }
 
 // HashAggFinalWorker indicates the final workers of parallel hash agg execution,
@@ -105,6 +110,19 @@ type HashAggFinalWorker struct {
 	outputCh            chan *AfFinalResult
 	finalResultHolderCh chan *chunk.Chunk
 	groupKeys           [][]byte
+
+	// spillHelper is not nil if the data is allowed to be spilled to the disk.
+	spillHelper *parallelHashAggSpillHelper
+	// finalWorkerIdx is the index of this final worker, which is also the partition of its spilled data.
+	finalWorkerIdx int
+	// spilledData stores the rows spilled by the partial workers for this final worker.
+	spilledData *chunk.ListInDisk
+	// partialAggFuncs and groupByItems are used to aggregate the spilled rows.
+	partialAggFuncs []aggfuncs.AggFunc
+	groupByItems    []expression.Expression
+	spilledGroupKey [][]byte
+	// tmpChkForSpill buffers the rows spilled again in a round of restoring.
+	tmpChkForSpill *chunk.Chunk
 }
 
 // AfFinalResult indicates aggregation functions final result.
@@ -210,6 +228,8 @@ type HashAggExec struct {
 	tmpChkForSpill *chunk.Chunk
 	// spillAction save the Action for spilling.
 	spillAction *AggSpillDiskAction
+	// spillHelper manages the spilled data of the parallel execution.
+	spillHelper *parallelHashAggSpillHelper
 	// isChildDrained indicates whether the all data from child has been taken out.
 	isChildDrained bool
 }
@@ -288,6 +308,10 @@ func (e *HashAggExec) Close() error {
 		if e.memTracker != nil {
 			e.memTracker.ReplaceBytesUsed(0)
 		}
+		if e.spillHelper != nil {
+			e.spillHelper.close()
+			e.spillHelper, e.spillAction = nil, nil
+		}
 	}
 	return e.baseExecutor.Close()
 }
@@ -377,10 +401,29 @@ func (e *HashAggExec) initForParallelExec(_ sessionctx.Context) {
 	e.finalWorkers = make([]HashAggFinalWorker, finalConcurrency)
 	e.initRuntimeStats()
 
+	e.spillHelper = nil
+	atomic.StoreUint32(&e.inSpillMode, 0)
+	if sessionVars.TrackAggregateMemoryUsage && variable.EnableTmpStorageOnOOM.Load() {
+		e.diskTracker = disk.NewTracker(e.id, -1)
+		e.diskTracker.AttachTo(sessionVars.StmtCtx.DiskTracker)
+		e.spillHelper = newParallelHashAggSpillHelper(&e.inSpillMode, retTypes(e.children[0]), e.diskTracker, e.partialWorkers, finalConcurrency)
+		sessionVars.MemTracker.FallbackOldAndSetNewActionForSoftLimit(e.ActionSpill())
+	}
+	// newWorkerMemTracker gives every worker its own tracker when spilling is enabled,
+	// so that the memory can be released once the partial results are no longer needed.
+	newWorkerMemTracker := func() *memory.Tracker {
+		if e.spillHelper == nil {
+			return e.memTracker
+		}
+		memTracker := memory.NewTracker(e.id, -1)
+		memTracker.AttachTo(e.memTracker)
+		return memTracker
+	}
+
 	// Init partial workers.
 	for i := 0; i < partialConcurrency; i++ {
 		w := HashAggPartialWorker{
-			baseHashAggWorker: newBaseHashAggWorker(e.ctx, e.finishCh, e.PartialAggFuncs, e.maxChunkSize, e.memTracker),
+			baseHashAggWorker: newBaseHashAggWorker(e.ctx, e.finishCh, e.PartialAggFuncs, e.maxChunkSize, newWorkerMemTracker()),
 			inputCh:           e.partialInputChs[i],
 			outputChs:         e.partialOutputChs,
 			giveBackCh:        e.inputCh,
@@ -389,15 +432,16 @@ func (e *HashAggExec) initForParallelExec(_ sessionctx.Context) {
 			groupByItems:      e.GroupByItems,
 			chk:               tryNewCacheChunk(e.children[0]),
 			groupKey:          make([][]byte, 0, 8),
+			spillHelper:       e.spillHelper,
 		}
 		// There is a bucket in the empty partialResultsMap.
 		failpoint.Inject("ConsumeRandomPanic", nil)
-		e.memTracker.Consume(hack.DefBucketMemoryUsageForMapStrToSlice * (1 << w.BInMap))
+		w.memTracker.Consume(hack.DefBucketMemoryUsageForMapStrToSlice * (1 << w.BInMap))
 		if e.stats != nil {
 			w.stats = &AggWorkerStat{}
 			e.stats.PartialStats = append(e.stats.PartialStats, w.stats)
 		}
-		e.memTracker.Consume(w.chk.MemoryUsage())
+		w.memTracker.Consume(w.chk.MemoryUsage())
 		e.partialWorkers[i] = w
 		input := &HashAggInput{
 			chk:        newFirstChunk(e.children[0]),
@@ -411,7 +455,7 @@ func (e *HashAggExec) initForParallelExec(_ sessionctx.Context) {
 	for i := 0; i < finalConcurrency; i++ {
 		groupSet, setSize := set.NewStringSetWithMemoryUsage()
 		w := HashAggFinalWorker{
-			baseHashAggWorker:   newBaseHashAggWorker(e.ctx, e.finishCh, e.FinalAggFuncs, e.maxChunkSize, e.memTracker),
+			baseHashAggWorker:   newBaseHashAggWorker(e.ctx, e.finishCh, e.FinalAggFuncs, e.maxChunkSize, newWorkerMemTracker()),
 			partialResultMap:    make(aggPartialResultMapper),
 			groupSet:            groupSet,
 			inputCh:             e.partialOutputChs[i],
@@ -421,9 +465,16 @@ func (e *HashAggExec) initForParallelExec(_ sessionctx.Context) {
 			mutableRow:          chunk.MutRowFromTypes(retTypes(e)),
 			groupKeys:           make([][]byte, 0, 8),
 		}
+		if e.spillHelper != nil {
+			w.spillHelper = e.spillHelper
+			w.finalWorkerIdx = i
+			w.spilledData = e.spillHelper.partitions[i].list
+			w.partialAggFuncs = e.PartialAggFuncs
+			w.groupByItems = e.GroupByItems
+		}
 		// There is a bucket in the empty partialResultsMap.
-		e.memTracker.Consume(hack.DefBucketMemoryUsageForMapStrToSlice*(1<<w.BInMap) + setSize)
-		groupSet.SetTracker(e.memTracker)
+		w.memTracker.Consume(hack.DefBucketMemoryUsageForMapStrToSlice*(1<<w.BInMap) + setSize)
+		groupSet.SetTracker(w.memTracker)
 		if e.stats != nil {
 			w.stats = &AggWorkerStat{}
 			e.stats.FinalStats = append(e.stats.FinalStats, w.stats)
@@ -465,6 +516,9 @@ func (w *HashAggPartialWorker) run(ctx sessionctx.Context, waitGroup *sync.WaitG
 		if r := recover(); r != nil {
 			recoveryHashAgg(w.globalOutputCh, r)
 		}
+		if err := w.flushSpilledRows(); err != nil {
+			w.globalOutputCh <- &AfFinalResult{err: err}
+		}
 		if needShuffle {
 			w.shuffleIntermData(sc, finalConcurrency)
 		}
@@ -515,6 +569,14 @@ func (w *HashAggPartialWorker) updatePartialResult(ctx sessionctx.Context, sc *s
 	if err != nil {
 		return err
 	}
+	failpoint.Inject("spillParallelHashAggAfterFirstChunk", func(val failpoint.Value) {
+		if val.(bool) && w.spillHelper != nil && len(w.partialResultsMap) > 0 && !w.spillHelper.isInSpillMode() {
+			w.spillHelper.setSpillMode()
+		}
+	})
+	if w.spillHelper != nil && w.spillHelper.isInSpillMode() && len(w.partialResultsMap) > 0 {
+		return w.updatePartialResultInSpillMode(ctx, chk)
+	}
 
 	partialResults := w.getPartialResult(sc, w.groupKey, w.partialResultsMap)
 	numRows := chk.NumRows()
@@ -534,6 +596,67 @@ func (w *HashAggPartialWorker) updatePartialResult(ctx sessionctx.Context, sc *s
 	return nil
 }
 
+// updatePartialResultInSpillMode only updates the groups already in memory, the rows of the
+// other groups are spilled to the disk, partitioned by the final worker they belong to.
+func (w *HashAggPartialWorker) updatePartialResultInSpillMode(ctx sessionctx.Context, chk *chunk.Chunk) error {
+	rows := make([]chunk.Row, 1)
+	allMemDelta := int64(0)
+	for i := 0; i < chk.NumRows(); i++ {
+		partialResult, ok := w.partialResultsMap[string(w.groupKey[i])]
+		if !ok {
+			if err := w.spillRow(w.groupKey[i], chk.GetRow(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		rows[0] = chk.GetRow(i)
+		for j, af := range w.aggFuncs {
+			memDelta, err := af.UpdatePartialResult(ctx, rows, partialResult[j])
+			if err != nil {
+				return err
+			}
+			allMemDelta += memDelta
+		}
+	}
+	w.memTracker.Consume(allMemDelta)
+	return nil
+}
+
+func (w *HashAggPartialWorker) spillRow(groupKey []byte, row chunk.Row) error {
+	if w.tmpChksForSpill == nil {
+		w.tmpChksForSpill = make([]*chunk.Chunk, len(w.outputChs))
+	}
+	// Use the same partition as shuffleIntermData, so that all the data of a group is merged by one final worker.
+	finalWorkerIdx := int(murmur3.Sum32(groupKey)) % len(w.outputChs)
+	chk := w.tmpChksForSpill[finalWorkerIdx]
+	if chk == nil {
+		chk = chunk.NewChunkWithCapacity(w.spillHelper.fieldTypes, w.maxChunkSize)
+		w.tmpChksForSpill[finalWorkerIdx] = chk
+	}
+	chk.AppendRow(row)
+	if !chk.IsFull() {
+		return nil
+	}
+	err := w.spillHelper.addSpilledChunk(finalWorkerIdx, chk)
+	chk.Reset()
+	return err
+}
+
+// flushSpilledRows spills the rows left in tmpChksForSpill.
+func (w *HashAggPartialWorker) flushSpilledRows() error {
+	for i, chk := range w.tmpChksForSpill {
+		if chk == nil || chk.NumRows() == 0 {
+			continue
+		}
+		err := w.spillHelper.addSpilledChunk(i, chk)
+		chk.Reset()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // shuffleIntermData shuffles the intermediate data of partial workers to corresponded final workers.
 // We only support parallel execution for single-machine, so process of encode and decode can be skipped.
 func (w *HashAggPartialWorker) shuffleIntermData(_ *stmtctx.StatementContext, finalConcurrency int) {
@@ -664,8 +787,6 @@ func (w *HashAggFinalWorker) consumeIntermData(sctx sessionctx.Context) (err err
 		input            *HashAggIntermData
 		ok               bool
 		intermDataBuffer [][]aggfuncs.PartialResult
-		groupKeys        []string
-		sc               = sctx.GetSessionVars().StmtCtx
 	)
 	for {
 		waitStart := time.Now()
@@ -677,36 +798,8 @@ func (w *HashAggFinalWorker) consumeIntermData(sctx sessionctx.Context) (err err
 			return nil
 		}
 		execStart := time.Now()
-		if intermDataBuffer == nil {
-			intermDataBuffer = make([][]aggfuncs.PartialResult, 0, w.maxChunkSize)
-		}
-		// Consume input in batches, size of every batch is less than w.maxChunkSize.
-		for reachEnd := false; !reachEnd; {
-			intermDataBuffer, groupKeys, reachEnd = input.getPartialResultBatch(sc, intermDataBuffer[:0], w.aggFuncs, w.maxChunkSize)
-			groupKeysLen := len(groupKeys)
-			memSize := getGroupKeyMemUsage(w.groupKeys)
-			w.groupKeys = w.groupKeys[:0]
-			for i := 0; i < groupKeysLen; i++ {
-				w.groupKeys = append(w.groupKeys, []byte(groupKeys[i]))
-			}
-			failpoint.Inject("ConsumeRandomPanic", nil)
-			w.memTracker.Consume(getGroupKeyMemUsage(w.groupKeys) - memSize)
-			finalPartialResults := w.getPartialResult(sc, w.groupKeys, w.partialResultMap)
-			allMemDelta := int64(0)
-			for i, groupKey := range groupKeys {
-				if !w.groupSet.Exist(groupKey) {
-					allMemDelta += w.groupSet.Insert(groupKey)
-				}
-				prs := intermDataBuffer[i]
-				for j, af := range w.aggFuncs {
-					memDelta, err := af.MergePartialResult(sctx, prs[j], finalPartialResults[i][j])
-					if err != nil {
-						return err
-					}
-					allMemDelta += memDelta
-				}
-			}
-			w.memTracker.Consume(allMemDelta)
+		if intermDataBuffer, err = w.mergeIntermData(sctx, input, intermDataBuffer); err != nil {
+			return err
 		}
 		if w.stats != nil {
 			w.stats.ExecTime += int64(time.Since(execStart))
@@ -715,6 +808,46 @@ func (w *HashAggFinalWorker) consumeIntermData(sctx sessionctx.Context) (err err
 	}
 }
 
+// mergeIntermData merges the partial results of input into the final results.
+func (w *HashAggFinalWorker) mergeIntermData(sctx sessionctx.Context, input *HashAggIntermData, intermDataBuffer [][]aggfuncs.PartialResult) ([][]aggfuncs.PartialResult, error) {
+	var (
+		groupKeys []string
+		sc        = sctx.GetSessionVars().StmtCtx
+	)
+	if intermDataBuffer == nil {
+		intermDataBuffer = make([][]aggfuncs.PartialResult, 0, w.maxChunkSize)
+	}
+	// Consume input in batches, size of every batch is less than w.maxChunkSize.
+	for reachEnd := false; !reachEnd; {
+		intermDataBuffer, groupKeys, reachEnd = input.getPartialResultBatch(sc, intermDataBuffer[:0], w.aggFuncs, w.maxChunkSize)
+		groupKeysLen := len(groupKeys)
+		memSize := getGroupKeyMemUsage(w.groupKeys)
+		w.groupKeys = w.groupKeys[:0]
+		for i := 0; i < groupKeysLen; i++ {
+			w.groupKeys = append(w.groupKeys, []byte(groupKeys[i]))
+		}
+		failpoint.Inject("ConsumeRandomPanic", nil)
+		w.memTracker.Consume(getGroupKeyMemUsage(w.groupKeys) - memSize)
+		finalPartialResults := w.getPartialResult(sc, w.groupKeys, w.partialResultMap)
+		allMemDelta := int64(0)
+		for i, groupKey := range groupKeys {
+			if !w.groupSet.Exist(groupKey) {
+				allMemDelta += w.groupSet.Insert(groupKey)
+			}
+			prs := intermDataBuffer[i]
+			for j, af := range w.aggFuncs {
+				memDelta, err := af.MergePartialResult(sctx, prs[j], finalPartialResults[i][j])
+				if err != nil {
+					return intermDataBuffer, err
+				}
+				allMemDelta += memDelta
+			}
+		}
+		w.memTracker.Consume(allMemDelta)
+	}
+	return intermDataBuffer, nil
+}
+
 func (w *HashAggFinalWorker) loadFinalResult(sctx sessionctx.Context) {
 	waitStart := time.Now()
 	result, finished := w.receiveFinalResultHolder()
@@ -779,7 +912,136 @@ func (w *HashAggFinalWorker) run(ctx sessionctx.Context, waitGroup *sync.WaitGro
 	if err := w.consumeIntermData(ctx); err != nil {
 		w.outputCh <- &AfFinalResult{err: err}
 	}
-	w.loadFinalResult(ctx)
+	if w.spillHelper == nil {
+		w.loadFinalResult(ctx)
+		return
+	}
+	w.spillHelper.intermDataConsumed()
+	if err := w.restoreSpilledData(ctx); err != nil && !w.isFinished() {
+		w.outputCh <- &AfFinalResult{err: err}
+	}
+}
+
+func (w *HashAggFinalWorker) isFinished() bool {
+	select {
+	case <-w.finishCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// restoreSpilledData aggregates the rows spilled by the partial workers in rounds. If the memory
+// quota is exceeded again in a round, the rows of the new groups are spilled to another ListInDisk
+// and processed in the next round, after the results of the groups in memory are returned.
+// A group may be kept in memory by some partial workers and spilled by the others, so the groups
+// merged from the partial results are kept in the first round until all the spilled rows of them
+// are aggregated, every group is returned exactly once.
+func (w *HashAggFinalWorker) restoreSpilledData(sctx sessionctx.Context) error {
+	list := w.spilledData
+	for {
+		var next *chunk.ListInDisk
+		if list != nil && list.NumChunks() > 0 {
+			var err error
+			w.spillHelper.resetFinalWorkerSpillMode(w.finalWorkerIdx)
+			if next, err = w.consumeSpilledData(sctx, list); err != nil || w.isFinished() {
+				return err
+			}
+		}
+		w.loadFinalResult(sctx)
+		if next == nil {
+			return nil
+		}
+		w.resetForNextRound()
+		list = next
+	}
+}
+
+// resetForNextRound releases the final results which have been returned.
+func (w *HashAggFinalWorker) resetForNextRound() {
+	var setSize int64
+	w.memTracker.ReplaceBytesUsed(0)
+	w.groupSet, setSize = set.NewStringSetWithMemoryUsage()
+	w.groupSet.SetTracker(w.memTracker)
+	w.partialResultMap = make(aggPartialResultMapper)
+	w.BInMap = 0
+	w.groupKeys = make([][]byte, 0, 8)
+	w.memTracker.Consume(hack.DefBucketMemoryUsageForMapStrToSlice*(1<<w.BInMap) + setSize)
+}
+
+// consumeSpilledData aggregates the rows in list and returns the rows spilled again.
+func (w *HashAggFinalWorker) consumeSpilledData(sctx sessionctx.Context, list *chunk.ListInDisk) (next *chunk.ListInDisk, err error) {
+	defer w.spillHelper.closeListInDisk(list)
+	var (
+		chk              *chunk.Chunk
+		intermDataBuffer [][]aggfuncs.PartialResult
+		rows             = make([]chunk.Row, 1)
+	)
+	for i := 0; i < list.NumChunks(); i++ {
+		if w.isFinished() {
+			return nil, nil
+		}
+		if chk, err = list.GetChunk(i); err != nil {
+			return nil, err
+		}
+		if w.spilledGroupKey, err = getGroupKey(w.ctx, chk, w.spilledGroupKey, w.groupByItems); err != nil {
+			return nil, err
+		}
+		// Aggregate the rows of the chunk by the partial aggregate functions first, then merge
+		// the partial results into the final results like the data from the partial workers.
+		inSpillMode := w.spillHelper.isFinalWorkerInSpillMode(w.finalWorkerIdx) && w.groupSet.Count() > 0
+		input := &HashAggIntermData{partialResultMap: make(aggPartialResultMapper)}
+		for j := 0; j < chk.NumRows(); j++ {
+			groupKey := string(w.spilledGroupKey[j])
+			if inSpillMode && !w.groupSet.Exist(groupKey) {
+				if next == nil {
+					if next, err = w.spillHelper.newListInDisk(); err != nil {
+						return nil, err
+					}
+				}
+				if err = w.spillRow(next, chk.GetRow(j)); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			partialResults, ok := input.partialResultMap[groupKey]
+			if !ok {
+				partialResults = make([]aggfuncs.PartialResult, len(w.partialAggFuncs))
+				for k, af := range w.partialAggFuncs {
+					partialResults[k], _ = af.AllocPartialResult()
+				}
+				input.partialResultMap[groupKey] = partialResults
+				input.groupKeys = append(input.groupKeys, groupKey)
+			}
+			rows[0] = chk.GetRow(j)
+			for k, af := range w.partialAggFuncs {
+				if _, err = af.UpdatePartialResult(sctx, rows, partialResults[k]); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if intermDataBuffer, err = w.mergeIntermData(sctx, input, intermDataBuffer); err != nil {
+			return nil, err
+		}
+	}
+	if next != nil && w.tmpChkForSpill.NumRows() > 0 {
+		err = next.Add(w.tmpChkForSpill)
+		w.tmpChkForSpill.Reset()
+	}
+	return next, err
+}
+
+func (w *HashAggFinalWorker) spillRow(list *chunk.ListInDisk, row chunk.Row) error {
+	if w.tmpChkForSpill == nil {
+		w.tmpChkForSpill = chunk.NewChunkWithCapacity(w.spillHelper.fieldTypes, w.maxChunkSize)
+	}
+	w.tmpChkForSpill.AppendRow(row)
+	if !w.tmpChkForSpill.IsFull() {
+		return nil
+	}
+	err := list.Add(w.tmpChkForSpill)
+	w.tmpChkForSpill.Reset()
+	return err
 }
 
 // Next implements the Executor Next interface.
@@ -1930,7 +2192,7 @@ func (e *HashAggExec) ActionSpill() *AggSpillDiskAction {
 // maxSpillTimes indicates how many times the data can spill at most.
 const maxSpillTimes = 10
 
-// AggSpillDiskAction implements memory.ActionOnExceed for unparalleled HashAgg.
+// AggSpillDiskAction implements memory.ActionOnExceed for HashAgg.
 // If the memory quota of a query is exceeded, AggSpillDiskAction.Action is
 // triggered.
 type AggSpillDiskAction struct {
@@ -1942,13 +2204,21 @@ type AggSpillDiskAction struct {
 // Action set HashAggExec spill mode.
 func (a *AggSpillDiskAction) Action(t *memory.Tracker) {
 	// Guarantee that processed data is at least 20% of the threshold, to avoid spilling too frequently.
-	if atomic.LoadUint32(&a.e.inSpillMode) == 0 && a.spillTimes < maxSpillTimes && a.e.memTracker.BytesConsumed() >= t.GetBytesLimit()/5 {
+	inSpillMode := atomic.LoadUint32(&a.e.inSpillMode) == 1
+	if a.e.spillHelper != nil {
+		inSpillMode = a.e.spillHelper.isAllInSpillMode()
+	}
+	if !inSpillMode && a.spillTimes < maxSpillTimes && a.e.memTracker.BytesConsumed() >= t.GetBytesLimit()/5 {
 		a.spillTimes++
 		logutil.BgLogger().Info("memory exceeds quota, set aggregate mode to spill-mode",
 			zap.Uint32("spillTimes", a.spillTimes),
 			zap.Int64("consumed", t.BytesConsumed()),
 			zap.Int64("quota", t.GetBytesLimit()))
-		atomic.StoreUint32(&a.e.inSpillMode, 1)
+		if a.e.spillHelper != nil {
+			a.e.spillHelper.setSpillMode()
+		} else {
+			atomic.StoreUint32(&a.e.inSpillMode, 1)
+		}
 		memory.QueryForceDisk.Add(1)
 		return
 	}
@@ -1961,3 +2231,142 @@ func (a *AggSpillDiskAction) Action(t *memory.Tracker) {
 func (*AggSpillDiskAction) GetPriority() int64 {
 	return memory.DefSpillPriority
 }
+
+// parallelHashAggSpillHelper manages the data spilled by the parallel HashAgg.
+// In `spill mode`, the partial workers stop creating new groups, the rows of the new groups are
+// spilled to the disk, partitioned by the final worker the group belongs to. Every final worker
+// aggregates its spilled partition together with the partial results merged in memory, and the
+// rows spilled again are aggregated in the following rounds.
+type parallelHashAggSpillHelper struct {
+	// inSpillMode points to HashAggExec.inSpillMode, which is set by AggSpillDiskAction.
+	inSpillMode *uint32
+	// finalSpillModes[i] indicates whether the i-th final worker is in `spill mode` when it's restoring
+	// the spilled data. The final workers restore the data in their own rounds, so every final worker
+	// only resets its own spill mode, the other final workers stay in spill mode till their next round.
+	finalSpillModes []uint32
+
+	fieldTypes  []*types.FieldType
+	diskTracker *disk.Tracker
+
+	// partitions[i] stores the rows spilled by the partial workers for the i-th final worker.
+	partitions []struct {
+		sync.Mutex
+		list *chunk.ListInDisk
+	}
+	// partialWorkers is used to release the partial results after they are merged by all the final workers.
+	partialWorkers []HashAggPartialWorker
+	// numConsumers is the number of the final workers which are still merging the partial results.
+	numConsumers int32
+
+	mu struct {
+		sync.Mutex
+		closed bool
+		lists  []*chunk.ListInDisk
+	}
+}
+
+func newParallelHashAggSpillHelper(inSpillMode *uint32, fieldTypes []*types.FieldType, diskTracker *disk.Tracker,
+	partialWorkers []HashAggPartialWorker, finalConcurrency int) *parallelHashAggSpillHelper {
+	h := &parallelHashAggSpillHelper{
+		inSpillMode:     inSpillMode,
+		fieldTypes:      fieldTypes,
+		diskTracker:     diskTracker,
+		partialWorkers:  partialWorkers,
+		numConsumers:    int32(finalConcurrency),
+		finalSpillModes: make([]uint32, finalConcurrency),
+	}
+	h.partitions = make([]struct {
+		sync.Mutex
+		list *chunk.ListInDisk
+	}, finalConcurrency)
+	for i := range h.partitions {
+		// The disk file of ListInDisk is created lazily, so it's cheap to create the empty lists.
+		h.partitions[i].list, _ = h.newListInDisk()
+	}
+	return h
+}
+
+func (h *parallelHashAggSpillHelper) isInSpillMode() bool {
+	return atomic.LoadUint32(h.inSpillMode) == 1
+}
+
+// setSpillMode sets both the partial workers and the final workers to spill mode.
+func (h *parallelHashAggSpillHelper) setSpillMode() {
+	atomic.StoreUint32(h.inSpillMode, 1)
+	for i := range h.finalSpillModes {
+		atomic.StoreUint32(&h.finalSpillModes[i], 1)
+	}
+}
+
+// isAllInSpillMode returns true if setting spill mode can't reduce the memory usage any more.
+func (h *parallelHashAggSpillHelper) isAllInSpillMode() bool {
+	if !h.isInSpillMode() {
+		return false
+	}
+	for i := range h.finalSpillModes {
+		if !h.isFinalWorkerInSpillMode(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *parallelHashAggSpillHelper) isFinalWorkerInSpillMode(finalWorkerIdx int) bool {
+	return atomic.LoadUint32(&h.finalSpillModes[finalWorkerIdx]) == 1
+}
+
+func (h *parallelHashAggSpillHelper) resetFinalWorkerSpillMode(finalWorkerIdx int) {
+	atomic.StoreUint32(&h.finalSpillModes[finalWorkerIdx], 0)
+}
+
+func (h *parallelHashAggSpillHelper) newListInDisk() (*chunk.ListInDisk, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.mu.closed {
+		return nil, errors.New("the spilled data of HashAggExec has been closed")
+	}
+	list := chunk.NewListInDisk(h.fieldTypes)
+	list.GetDiskTracker().AttachTo(h.diskTracker)
+	h.mu.lists = append(h.mu.lists, list)
+	return list, nil
+}
+
+func (h *parallelHashAggSpillHelper) addSpilledChunk(partition int, chk *chunk.Chunk) error {
+	p := &h.partitions[partition]
+	p.Lock()
+	defer p.Unlock()
+	return p.list.Add(chk)
+}
+
+// intermDataConsumed is called after a final worker has merged all the partial results.
+// The partial results are released when all the final workers finish merging.
+func (h *parallelHashAggSpillHelper) intermDataConsumed() {
+	if atomic.AddInt32(&h.numConsumers, -1) > 0 {
+		return
+	}
+	for i := range h.partialWorkers {
+		h.partialWorkers[i].partialResultsMap = nil
+		h.partialWorkers[i].memTracker.ReplaceBytesUsed(0)
+	}
+}
+
+func (h *parallelHashAggSpillHelper) closeListInDisk(list *chunk.ListInDisk) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, l := range h.mu.lists {
+		if l == list {
+			h.mu.lists = append(h.mu.lists[:i], h.mu.lists[i+1:]...)
+			terror.Log(list.Close())
+			return
+		}
+	}
+}
+
+func (h *parallelHashAggSpillHelper) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, list := range h.mu.lists {
+		terror.Log(list.Close())
+	}
+	h.mu.lists, h.mu.closed = nil, true
+}
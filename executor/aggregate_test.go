@@ -917,6 +917,53 @@ func TestHaving(t *testing.T) {
 	tk.MustQuery("select 1 from t group by c1 having sum(abs(c2 + c3)) = c1").Check(testkit.Rows("1"))
 }
 
+func TestRollup(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b int, c int)")
+	tk.MustExec("insert into t values (1, 1, 1), (1, 2, 2), (2, 1, 3), (2, 1, 4)")
+
+	tk.MustQuery("select a, b, sum(c), grouping(a), grouping(b) from t group by a, b with rollup order by a, b").Check(testkit.Rows(
+		"<nil> <nil> 10 1 1",
+		"1 <nil> 3 0 1",
+		"1 1 1 0 0",
+		"1 2 2 0 0",
+		"2 <nil> 7 0 1",
+		"2 1 7 0 0"))
+	tk.MustQuery("select a, b, grouping(a, b), grouping(b, a) from t group by a, b with rollup order by a, b").Check(testkit.Rows(
+		"<nil> <nil> 3 3",
+		"1 <nil> 1 2",
+		"1 1 0 0",
+		"1 2 0 0",
+		"2 <nil> 1 2",
+		"2 1 0 0"))
+	tk.MustQuery("select count(*) from t group by a with rollup").Sort().Check(testkit.Rows("2", "2", "4"))
+	tk.MustQuery("select a + 1, count(*) from t group by a + 1 with rollup order by 1").Check(testkit.Rows("<nil> 4", "2 2", "3 2"))
+	tk.MustQuery("select a, sum(c) from t group by a with rollup having grouping(a) = 1").Check(testkit.Rows("<nil> 10"))
+	tk.MustQuery("select /*+ stream_agg() */ a, count(distinct b) from t group by a with rollup order by a").Check(testkit.Rows("<nil> 2", "1 2", "2 1"))
+
+	// the Expand is executed by TiDB when there is no TiFlash replica.
+	rows := tk.MustQuery("explain format = 'brief' select a, b, sum(c) from t group by a, b with rollup").Rows()
+	hasExpand := false
+	for _, row := range rows {
+		if strings.Contains(row[0].(string), "Expand") {
+			require.Equal(t, "root", row[2])
+			hasExpand = true
+		}
+	}
+	require.True(t, hasExpand)
+
+	// NULL values of the grouping columns are distinguished from the super-aggregate rows by GROUPING().
+	tk.MustExec("insert into t values (null, 1, 5)")
+	tk.MustQuery("select a, grouping(a), sum(c) from t group by a with rollup order by a, grouping(a)").Check(testkit.Rows(
+		"<nil> 0 5",
+		"<nil> 1 15",
+		"1 0 3",
+		"2 0 7"))
+}
+
 func TestAggEliminator(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -1503,6 +1550,70 @@ func TestAggInDisk(t *testing.T) {
 	tk.MustQuery("select /*+ HASH_AGG() */ count(c) from t group by c1;").Check(testkit.Rows())
 }
 
+func TestParallelAggInDisk(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set tidb_hashagg_final_concurrency = 4;")
+	tk.MustExec("set tidb_hashagg_partial_concurrency = 4;")
+	tk.MustExec("set tidb_mem_quota_query = 4194304")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int)")
+	sql := "insert into t values (0)"
+	for i := 1; i <= 200; i++ {
+		sql += fmt.Sprintf(",(%v)", i)
+	}
+	sql += ";"
+	tk.MustExec(sql)
+	rows := tk.MustQuery("desc analyze select /*+ HASH_AGG() */ avg(t1.a) from t t1 join t t2 group by t1.a, t2.a;").Rows()
+	for _, row := range rows {
+		length := len(row)
+		line := fmt.Sprintf("%v", row)
+		disk := fmt.Sprintf("%v", row[length-1])
+		if strings.Contains(line, "HashAgg") {
+			require.False(t, strings.Contains(disk, "0 Bytes"))
+			require.True(t, strings.Contains(disk, "MB") ||
+				strings.Contains(disk, "KB") ||
+				strings.Contains(disk, "Bytes"))
+		}
+	}
+
+	// The spilled groups are merged back by the final workers.
+	tk.MustExec("insert into t values(0)")
+	tk.MustQuery("select count(*), sum(tt.b), sum(tt.c) from ( select /*+ HASH_AGG() */ avg(t1.a) as b, count(*) as c from t t1 join t t2 group by t1.a, t2.a) as tt").Check(
+		testkit.Rows("40401 4040100.0000 40804"))
+	tk.MustQuery("select /*+ HASH_AGG() */ count(c) from (select 1 as c from t t1 join t t2) tt group by c").Check(testkit.Rows("40804"))
+}
+
+func TestParallelAggInDiskWithSplitGroups(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set tidb_hashagg_final_concurrency = 4")
+	tk.MustExec("set tidb_hashagg_partial_concurrency = 4")
+	tk.MustExec("set tidb_max_chunk_size = 32")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int)")
+	values := make([]string, 0, 3200)
+	for i := 0; i < 3200; i++ {
+		values = append(values, fmt.Sprintf("(%v)", i))
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+
+	// Every partial worker keeps the groups of its first chunk in memory and spills the
+	// others, so the rows of a group are both in memory and spilled by different workers.
+	// The union keeps the aggregation from being pushed down to the coprocessor.
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/executor/spillParallelHashAggAfterFirstChunk", "return(true)"))
+	defer func() {
+		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/executor/spillParallelHashAggAfterFirstChunk"))
+	}()
+	from := "from (select a from t union all select a from t where a < 0) tt"
+	tk.MustQuery("select count(*), sum(c), min(c), max(c), count(distinct b) from (select /*+ HASH_AGG() */ a % 100 as b, count(*) as c " + from + " group by b) tt").Check(
+		testkit.Rows("100 3200 32 32 100"))
+	tk.MustQuery("select /*+ HASH_AGG() */ a % 100 as b, count(*), sum(a) " + from + " group by b having b < 3 order by b").Check(
+		testkit.Rows("0 32 49600", "1 32 49632", "2 32 49664"))
+}
+
 func TestRandomPanicConsume(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
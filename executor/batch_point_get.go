@@ -56,6 +56,7 @@ type BatchPointGetExec struct {
 	txn         kv.Transaction
 	lock        bool
 	waitTime    int64
+	skipLocked  bool
 	inited      uint32
 	values      [][]byte
 	index       int
@@ -213,7 +214,9 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 	var indexKeys []kv.Key
 	var err error
 	batchGetter := e.batchGetter
-	rc := e.ctx.GetSessionVars().IsPessimisticReadConsistency()
+	// Only the existing keys are locked after reading them in read consistency isolation. SKIP LOCKED also locks the
+	// existing rows after reading them, so the rows locked by other transactions can be skipped.
+	lockExistKeysOnly := e.ctx.GetSessionVars().IsPessimisticReadConsistency() || e.skipLocked
 	if e.idxInfo != nil && !isCommonHandleRead(e.tblInfo, e.idxInfo) {
 		// `SELECT a, b FROM t WHERE (a, b) IN ((1, 2), (1, 2), (2, 1), (1, 2))` should not return duplicated rows
 		dedup := make(map[hack.MutableString]struct{})
@@ -264,7 +267,7 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 		// lock all keys in repeatable read isolation.
 		// for read consistency, only lock exist keys,
 		// indexKeys will be generated after getting handles.
-		if !rc {
+		if !lockExistKeysOnly {
 			indexKeys = toFetchIndexKeys
 		} else {
 			indexKeys = make([]kv.Key, 0, len(toFetchIndexKeys))
@@ -295,7 +298,7 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 				return err1
 			}
 			e.handles = append(e.handles, handle)
-			if rc {
+			if lockExistKeysOnly {
 				indexKeys = append(indexKeys, key)
 			}
 			if e.tblInfo.Partition != nil {
@@ -390,7 +393,7 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 
 	var values map[string][]byte
 	// Lock keys (include exists and non-exists keys) before fetch all values for Repeatable Read Isolation.
-	if e.lock && !rc {
+	if e.lock && !lockExistKeysOnly {
 		lockKeys := make([]kv.Key, len(keys)+len(indexKeys))
 		copy(lockKeys, keys)
 		copy(lockKeys[len(keys):], indexKeys)
@@ -406,7 +409,7 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 	}
 	handles := make([]kv.Handle, 0, len(values))
 	var existKeys []kv.Key
-	if e.lock && rc {
+	if e.lock && lockExistKeysOnly {
 		existKeys = make([]kv.Key, 0, 2*len(values))
 	}
 	e.values = make([][]byte, 0, len(values))
@@ -436,7 +439,7 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 		}
 		e.values = append(e.values, val)
 		handles = append(handles, e.handles[i])
-		if e.lock && rc {
+		if e.lock && lockExistKeysOnly {
 			existKeys = append(existKeys, key)
 			// when e.handles is set in builder directly, index should be primary key and the plan is CommonHandleRead
 			// with clustered index enabled, indexKeys is empty in this situation
@@ -447,7 +450,10 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 		}
 	}
 	// Lock exists keys only for Read Committed Isolation.
-	if e.lock && rc {
+	if e.lock && lockExistKeysOnly {
+		if e.skipLocked {
+			return e.lockRowsSkipLocked(ctx, handles, existKeys)
+		}
 		err = LockKeys(ctx, e.ctx, e.waitTime, existKeys...)
 		if err != nil {
 			return err
@@ -457,6 +463,30 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 	return nil
 }
 
+// lockRowsSkipLocked locks the keys of each existing row together, and removes the rows locked by other transactions.
+// existKeys contains the row keys of the handles, each of which may be followed by its unique index key.
+func (e *BatchPointGetExec) lockRowsSkipLocked(ctx context.Context, handles []kv.Handle, existKeys []kv.Key) error {
+	e.handles = handles[:0]
+	if len(handles) == 0 {
+		return nil
+	}
+	keysPerRow := len(existKeys) / len(handles)
+	locker := newSkipLockedLocker(e.ctx)
+	values := e.values[:0]
+	for i, handle := range handles {
+		locked, err := locker.lockRow(ctx, existKeys[i*keysPerRow:(i+1)*keysPerRow])
+		if err != nil {
+			return err
+		}
+		if locked {
+			e.handles = append(e.handles, handle)
+			values = append(values, e.values[i])
+		}
+	}
+	e.values = values
+	return nil
+}
+
 // LockKeys locks the keys for pessimistic transaction.
 func LockKeys(ctx context.Context, sctx sessionctx.Context, lockWaitTime int64, keys ...kv.Key) error {
 	txnCtx := sctx.GetSessionVars().TxnCtx
@@ -263,10 +263,14 @@ func (b *executorBuilder) build(p plannercore.Plan) Executor {
 		return b.buildStreamAgg(v)
 	case *plannercore.PhysicalProjection:
 		return b.buildProjection(v)
+	case *plannercore.PhysicalExpand:
+		return b.buildExpand(v)
 	case *plannercore.PhysicalMemTable:
 		return b.buildMemTable(v)
 	case *plannercore.PhysicalTableDual:
 		return b.buildTableDual(v)
+	case *plannercore.PhysicalJSONTable:
+		return b.buildJSONTable(v)
 	case *plannercore.PhysicalApply:
 		return b.buildApply(v)
 	case *plannercore.PhysicalMaxOneRow:
@@ -494,16 +498,27 @@ func (b *executorBuilder) buildCheckTable(v *plannercore.CheckTable) Executor {
 	}
 	if b.ctx.GetSessionVars().FastCheckTable && noMVIndexOrPrefixIndex {
 		e := &FastCheckTableExec{
-			baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
-			dbName:       v.DBName,
-			table:        v.Table,
-			indexInfos:   v.IndexInfos,
-			is:           b.is,
-			err:          &atomic.Pointer[error]{},
+			baseExecutor:   newBaseExecutor(b.ctx, v.Schema(), v.ID()),
+			dbName:         v.DBName,
+			table:          v.Table,
+			indexInfos:     v.IndexInfos,
+			is:             b.is,
+			err:            &atomic.Pointer[error]{},
+			partitionNames: v.PartitionNames,
 		}
 		return e
 	}
 
+	var partitionIDs []int64
+	for _, name := range v.PartitionNames {
+		ids, err := tables.FindPartitionIDsByName(v.Table.Meta(), name.L)
+		if err != nil {
+			b.err = err
+			return nil
+		}
+		partitionIDs = append(partitionIDs, ids...)
+	}
+
 	readerExecs := make([]*IndexLookUpExecutor, 0, len(v.IndexLookUpReaders))
 	for _, readerPlan := range v.IndexLookUpReaders {
 		readerExec, err := buildNoRangeIndexLookUpReader(b, readerPlan)
@@ -517,15 +532,17 @@ func (b *executorBuilder) buildCheckTable(v *plannercore.CheckTable) Executor {
 	}
 
 	e := &CheckTableExec{
-		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
-		dbName:       v.DBName,
-		table:        v.Table,
-		indexInfos:   v.IndexInfos,
-		is:           b.is,
-		srcs:         readerExecs,
-		exitCh:       make(chan struct{}),
-		retCh:        make(chan error, len(readerExecs)),
-		checkIndex:   v.CheckIndex,
+		baseExecutor:   newBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		dbName:         v.DBName,
+		table:          v.Table,
+		indexInfos:     v.IndexInfos,
+		is:             b.is,
+		srcs:           readerExecs,
+		exitCh:         make(chan struct{}),
+		retCh:          make(chan error, len(readerExecs)),
+		checkIndex:     v.CheckIndex,
+		partitionNames: v.PartitionNames,
+		partitionIDs:   partitionIDs,
 	}
 	return e
 }
@@ -904,6 +921,13 @@ func (b *executorBuilder) buildSimple(v *plannercore.Simple) Executor {
 			workloadType: s.Tp,
 			optionList:   s.DynamicCalibrateResourceOptionList,
 		}
+	case *ast.XAStmt:
+		if s.Tp == ast.XARecover {
+			return &xaRecoverExec{
+				baseExecutor: newBaseExecutor(b.ctx, v.Schema(), 0),
+				convertXID:   s.ConvertXID,
+			}
+		}
 	case *ast.LoadDataActionStmt:
 		return &LoadDataActionExec{
 			baseExecutor: newBaseExecutor(b.ctx, nil, 0),
@@ -1807,6 +1831,24 @@ func (b *executorBuilder) buildSelection(v *plannercore.PhysicalSelection) Execu
 	return e
 }
 
+func (b *executorBuilder) buildExpand(v *plannercore.PhysicalExpand) Executor {
+	childExec := b.build(v.Children()[0])
+	if b.err != nil {
+		return nil
+	}
+	levelEvaluatorSuits := make([]*expression.EvaluatorSuite, 0, len(v.LevelExprs))
+	for _, exprs := range v.LevelExprs {
+		// All the levels are evaluated on the same child chunk, so the columns must be copied
+		// rather than referenced by the output chunk.
+		levelEvaluatorSuits = append(levelEvaluatorSuits, expression.NewEvaluatorSuite(exprs, true))
+	}
+	e := &ExpandExec{
+		baseExecutor:        newBaseExecutor(b.ctx, v.Schema(), v.ID(), childExec),
+		levelEvaluatorSuits: levelEvaluatorSuits,
+	}
+	return e
+}
+
 func (b *executorBuilder) buildProjection(v *plannercore.PhysicalProjection) Executor {
 	childExec := b.build(v.Children()[0])
 	if b.err != nil {
@@ -1848,6 +1890,61 @@ func (b *executorBuilder) buildTableDual(v *plannercore.PhysicalTableDual) Execu
 	return e
 }
 
+func (b *executorBuilder) buildJSONTable(v *plannercore.PhysicalJSONTable) Executor {
+	e := &JSONTableExec{
+		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		jsonExpr:     v.JSONExpr,
+		curRow:       make([]types.Datum, v.Schema().Len()),
+	}
+	offset := 0
+	e.root, b.err = buildJSONTablePath(v.Path, v.Columns, v.Schema(), &offset)
+	if b.err != nil {
+		return nil
+	}
+	return e
+}
+
+// buildJSONTablePath builds the row path and its nested paths, the offsets of
+// the columns are assigned in depth-first order as the planner does.
+func buildJSONTablePath(path string, cols []*ast.JSONTableColumn, schema *expression.Schema, offset *int) (*jsonTablePath, error) {
+	var err error
+	p := &jsonTablePath{}
+	if p.path, err = types.ParseJSONPathExpr(path); err != nil {
+		return nil, err
+	}
+	for _, col := range cols {
+		if col.Tp == ast.JSONTableColumnNested {
+			nested, err := buildJSONTablePath(col.Path, col.NestedColumns, schema, offset)
+			if err != nil {
+				return nil, err
+			}
+			p.nested = append(p.nested, nested)
+			p.offsets = append(p.offsets, nested.offsets...)
+			continue
+		}
+		c := &jsonTableColumn{JSONTableColumn: col, offset: *offset, tp: schema.Columns[*offset].RetType}
+		*offset++
+		p.offsets = append(p.offsets, c.offset)
+		if col.Tp != ast.JSONTableColumnOrdinality {
+			if c.path, err = types.ParseJSONPathExpr(col.Path); err != nil {
+				return nil, err
+			}
+		}
+		if col.OnEmpty != nil && col.OnEmpty.Tp == ast.JSONTableOnResponseDefault {
+			if c.onEmptyDefault, err = types.ParseBinaryJSONFromString(col.OnEmpty.Default); err != nil {
+				return nil, err
+			}
+		}
+		if col.OnError != nil && col.OnError.Tp == ast.JSONTableOnResponseDefault {
+			if c.onErrorDefault, err = types.ParseBinaryJSONFromString(col.OnError.Default); err != nil {
+				return nil, err
+			}
+		}
+		p.columns = append(p.columns, c)
+	}
+	return p, nil
+}
+
 // `getSnapshotTS` returns for-update-ts if in insert/update/delete/lock statement otherwise the isolation read ts
 // Please notice that in RC isolation, the above two ts are the same
 func (b *executorBuilder) getSnapshotTS() (ts uint64, err error) {
@@ -2025,6 +2122,8 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) Executo
 			strings.ToLower(infoschema.TableEngines),
 			strings.ToLower(infoschema.TableCollations),
 			strings.ToLower(infoschema.TableAnalyzeStatus),
+			strings.ToLower(infoschema.TableAutoAnalyzeQueue),
+			strings.ToLower(infoschema.TableInstancePlanCache),
 			strings.ToLower(infoschema.TableClusterInfo),
 			strings.ToLower(infoschema.TableProfiling),
 			strings.ToLower(infoschema.TableCharacterSets),
@@ -3621,6 +3720,16 @@ func getPartitionKeyColOffsets(keyColIDs []int64, pt table.PartitionedTable) []i
 			return nil
 		}
 	}
+	// The subpartitioning columns are also needed to locate the subpartitions.
+	if st, ok := pt.(interface {
+		SubPartitionExpr() *tables.PartitionExpr
+	}); ok && st.SubPartitionExpr() != nil {
+		for _, offset := range st.SubPartitionExpr().ColumnOffset {
+			if _, ok := offsetMap[offset]; !ok {
+				return nil
+			}
+		}
+	}
 	return keyColOffsets
 }
 
@@ -4846,7 +4955,7 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) Executor {
 	partialResults := make([]aggfuncs.PartialResult, 0, len(v.WindowFuncDescs))
 	resultColIdx := v.Schema().Len() - len(v.WindowFuncDescs)
 	for _, desc := range v.WindowFuncDescs {
-		aggDesc, err := aggregation.NewAggFuncDescForWindowFunc(b.ctx, desc, false)
+		aggDesc, err := aggregation.NewAggFuncDescForWindowFunc(b.ctx, desc, desc.HasDistinct)
 		if err != nil {
 			b.err = err
 			return nil
@@ -4887,6 +4996,10 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) Executor {
 				exec.orderByCols = orderByCols
 				exec.expectedCmpResult = cmpResult
 				exec.isRangeFrame = true
+			} else if v.Frame.Type == ast.Groups {
+				exec.orderByCols = orderByCols
+				exec.cmpFuncs = groupsFrameCmpFuncs(v.Frame)
+				exec.isGroupsFrame = true
 			}
 		}
 		return exec
@@ -4904,6 +5017,15 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) Executor {
 			start:          v.Frame.Start,
 			end:            v.Frame.End,
 		}
+	} else if v.Frame.Type == ast.Groups {
+		processor = &groupsFrameWindowProcessor{
+			windowFuncs:    windowFuncs,
+			partialResults: partialResults,
+			start:          v.Frame.Start,
+			end:            v.Frame.End,
+			orderByCols:    orderByCols,
+			cmpFuncs:       groupsFrameCmpFuncs(v.Frame),
+		}
 	} else {
 		cmpResult := int64(-1)
 		if len(v.OrderBy) > 0 && v.OrderBy[0].Desc {
@@ -4925,6 +5047,15 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) Executor {
 	}
 }
 
+// groupsFrameCmpFuncs returns the compare functions which are used to find the peer groups of a `Groups` frame.
+// An unbounded frame bound doesn't carry them, so they are taken from the other bound.
+func groupsFrameCmpFuncs(frame *plannercore.WindowFrame) []expression.CompareFunc {
+	if frame.Start.UnBounded {
+		return frame.End.CmpFuncs
+	}
+	return frame.Start.CmpFuncs
+}
+
 func (b *executorBuilder) buildShuffle(v *plannercore.PhysicalShuffle) *ShuffleExec {
 	base := newBaseExecutor(b.ctx, v.Schema(), v.ID())
 	shuffle := &ShuffleExec{
@@ -5095,6 +5226,7 @@ func (b *executorBuilder) buildBatchPointGet(plan *plannercore.BatchPointGetPlan
 		desc:         plan.Desc,
 		lock:         plan.Lock,
 		waitTime:     plan.LockWaitTime,
+		skipLocked:   plan.SkipLocked,
 		partExpr:     plan.PartitionExpr,
 		partPos:      plan.PartitionColPos,
 		planPhysIDs:  plan.PartitionIDs,
@@ -1615,6 +1615,73 @@ func TestAlterTTLInfo(t *testing.T) {
 	tk.MustGetErrMsg("ALTER TABLE t TTL_JOB_INTERVAL = '1h'", "[ddl:8150]Cannot set TTL_JOB_INTERVAL on a table without TTL config")
 }
 
+func TestTTLOnUnixTimestampColumn(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("CREATE TABLE t (created_at bigint, updated_at int unsigned, dt datetime, f double) TTL = `created_at` + INTERVAL 30 DAY TTL_TIMESTAMP_UNIT = 'millisecond'")
+	tk.MustQuery("SHOW CREATE TABLE t").Check(testkit.Rows("t CREATE TABLE `t` (\n  `created_at` bigint(20) DEFAULT NULL,\n  `updated_at` int(10) unsigned DEFAULT NULL,\n  `dt` datetime DEFAULT NULL,\n  `f` double DEFAULT NULL\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 30 DAY */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */ /*T![ttl] TTL_TIMESTAMP_UNIT='MILLISECOND' */"))
+
+	// the timestamp unit is kept when the TTL column is changed to another integer column
+	tk.MustExec("ALTER TABLE t TTL = `updated_at` + INTERVAL 1 YEAR")
+	tk.MustQuery("SHOW CREATE TABLE t").Check(testkit.Rows("t CREATE TABLE `t` (\n  `created_at` bigint(20) DEFAULT NULL,\n  `updated_at` int(10) unsigned DEFAULT NULL,\n  `dt` datetime DEFAULT NULL,\n  `f` double DEFAULT NULL\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`updated_at` + INTERVAL 1 YEAR */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */ /*T![ttl] TTL_TIMESTAMP_UNIT='MILLISECOND' */"))
+	tk.MustExec("ALTER TABLE t TTL_TIMESTAMP_UNIT = 'SECOND'")
+	tk.MustQuery("SHOW CREATE TABLE t").Check(testkit.Rows("t CREATE TABLE `t` (\n  `created_at` bigint(20) DEFAULT NULL,\n  `updated_at` int(10) unsigned DEFAULT NULL,\n  `dt` datetime DEFAULT NULL,\n  `f` double DEFAULT NULL\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`updated_at` + INTERVAL 1 YEAR */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */ /*T![ttl] TTL_TIMESTAMP_UNIT='SECOND' */"))
+	tk.MustExec("ALTER TABLE t MODIFY updated_at bigint unsigned")
+	tk.MustGetErrMsg("ALTER TABLE t MODIFY updated_at varchar(20)", "[ddl:8148]Field 'updated_at' is of a not supported type for TTL config, expect DATETIME, DATE, TIMESTAMP or an integer with TTL_TIMESTAMP_UNIT")
+
+	// the timestamp unit is removed when the TTL column is changed to a time column
+	tk.MustExec("ALTER TABLE t TTL = `dt` + INTERVAL 1 YEAR")
+	tk.MustQuery("SHOW CREATE TABLE t").Check(testkit.Rows("t CREATE TABLE `t` (\n  `created_at` bigint(20) DEFAULT NULL,\n  `updated_at` bigint(20) unsigned DEFAULT NULL,\n  `dt` datetime DEFAULT NULL,\n  `f` double DEFAULT NULL\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`dt` + INTERVAL 1 YEAR */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */"))
+	tk.MustGetErrMsg("ALTER TABLE t TTL_TIMESTAMP_UNIT = 'SECOND'", "[ddl:8175]Cannot set TTL_TIMESTAMP_UNIT for the TTL column 'dt', it's only allowed for an integer column")
+	tk.MustGetErrMsg("ALTER TABLE t TTL = `created_at` + INTERVAL 1 YEAR", "[ddl:8148]Field 'created_at' is of a not supported type for TTL config, expect DATETIME, DATE, TIMESTAMP or an integer with TTL_TIMESTAMP_UNIT")
+	tk.MustGetErrMsg("ALTER TABLE t TTL = `f` + INTERVAL 1 YEAR TTL_TIMESTAMP_UNIT = 'SECOND'", "[ddl:8148]Field 'f' is of a not supported type for TTL config, expect DATETIME, DATE, TIMESTAMP or an integer with TTL_TIMESTAMP_UNIT")
+	tk.MustExec("ALTER TABLE t TTL = `created_at` + INTERVAL 1 YEAR TTL_TIMESTAMP_UNIT = 'MICROSECOND'")
+	tk.MustQuery("SHOW CREATE TABLE t").Check(testkit.Rows("t CREATE TABLE `t` (\n  `created_at` bigint(20) DEFAULT NULL,\n  `updated_at` bigint(20) unsigned DEFAULT NULL,\n  `dt` datetime DEFAULT NULL,\n  `f` double DEFAULT NULL\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 1 YEAR */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */ /*T![ttl] TTL_TIMESTAMP_UNIT='MICROSECOND' */"))
+
+	tk.MustExec("ALTER TABLE t REMOVE TTL")
+	tk.MustGetErrMsg("ALTER TABLE t TTL_TIMESTAMP_UNIT = 'SECOND'", "[ddl:8150]Cannot set TTL_TIMESTAMP_UNIT on a table without TTL config")
+	tk.MustGetErrMsg("CREATE TABLE t1 (created_at bigint) TTL_TIMESTAMP_UNIT = 'SECOND'", "[ddl:8150]Cannot set TTL_TIMESTAMP_UNIT on a table without TTL config")
+	tk.MustGetErrMsg("CREATE TABLE t1 (created_at datetime) TTL = `created_at` + INTERVAL 1 DAY TTL_TIMESTAMP_UNIT = 'SECOND'", "[ddl:8175]Cannot set TTL_TIMESTAMP_UNIT for the TTL column 'created_at', it's only allowed for an integer column")
+}
+
+func TestTTLArchiveTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create database archive")
+
+	tk.MustExec("CREATE TABLE t_history (id int, created_at datetime, v varchar(32), archived_at timestamp DEFAULT CURRENT_TIMESTAMP)")
+	tk.MustExec("CREATE TABLE archive.t_history (id bigint, created_at datetime, v text)")
+	tk.MustExec("CREATE TABLE t (id int primary key, created_at datetime, v varchar(32)) TTL = `created_at` + INTERVAL 1 DAY TTL_ARCHIVE_TABLE = t_history")
+	tk.MustQuery("SHOW CREATE TABLE t").Check(testkit.Rows("t CREATE TABLE `t` (\n  `id` int(11) NOT NULL,\n  `created_at` datetime DEFAULT NULL,\n  `v` varchar(32) DEFAULT NULL,\n  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 1 DAY */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */ /*T![ttl] TTL_ARCHIVE_TABLE=`test`.`t_history` */"))
+
+	// the archive table is kept when the TTL config is changed
+	tk.MustExec("ALTER TABLE t TTL = `created_at` + INTERVAL 1 MONTH")
+	tk.MustQuery("SHOW CREATE TABLE t").Check(testkit.Rows("t CREATE TABLE `t` (\n  `id` int(11) NOT NULL,\n  `created_at` datetime DEFAULT NULL,\n  `v` varchar(32) DEFAULT NULL,\n  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 1 MONTH */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */ /*T![ttl] TTL_ARCHIVE_TABLE=`test`.`t_history` */"))
+	tk.MustExec("ALTER TABLE t TTL_ARCHIVE_TABLE = archive.t_history")
+	tk.MustQuery("SHOW CREATE TABLE t").Check(testkit.Rows("t CREATE TABLE `t` (\n  `id` int(11) NOT NULL,\n  `created_at` datetime DEFAULT NULL,\n  `v` varchar(32) DEFAULT NULL,\n  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 1 MONTH */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */ /*T![ttl] TTL_ARCHIVE_TABLE=`archive`.`t_history` */"))
+	tk.MustExec("ALTER TABLE t TTL_ARCHIVE_TABLE = NULL")
+	tk.MustQuery("SHOW CREATE TABLE t").Check(testkit.Rows("t CREATE TABLE `t` (\n  `id` int(11) NOT NULL,\n  `created_at` datetime DEFAULT NULL,\n  `v` varchar(32) DEFAULT NULL,\n  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 1 MONTH */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */"))
+
+	// the archive table should be able to store all columns of the TTL table
+	tk.MustExec("CREATE TABLE t_missing (id int, created_at datetime)")
+	tk.MustExec("CREATE TABLE t_generated (id int, created_at datetime, v varchar(32) AS (concat(id, '')))")
+	tk.MustExec("CREATE GLOBAL TEMPORARY TABLE t_temp (id int, created_at datetime, v varchar(32)) ON COMMIT DELETE ROWS")
+	tk.MustExec("CREATE VIEW t_view AS SELECT * FROM t_history")
+	tk.MustGetErrMsg("ALTER TABLE t TTL_ARCHIVE_TABLE = t_missing", "[ddl:8176]Cannot use 'test.t_missing' as the TTL archive table, column 'v' doesn't exist in it")
+	tk.MustGetErrMsg("ALTER TABLE t TTL_ARCHIVE_TABLE = t_generated", "[ddl:8176]Cannot use 'test.t_generated' as the TTL archive table, column 'v' is a generated column in it")
+	tk.MustGetErrMsg("ALTER TABLE t TTL_ARCHIVE_TABLE = t_temp", "[ddl:8176]Cannot use 'test.t_temp' as the TTL archive table, it's a temporary table")
+	tk.MustGetErrMsg("ALTER TABLE t TTL_ARCHIVE_TABLE = t_view", "[ddl:8176]Cannot use 'test.t_view' as the TTL archive table, it's not a base table")
+	tk.MustGetErrMsg("ALTER TABLE t TTL_ARCHIVE_TABLE = t", "[ddl:8176]Cannot use 'test.t' as the TTL archive table, it's the TTL table itself")
+	tk.MustGetErrCode("ALTER TABLE t TTL_ARCHIVE_TABLE = t_not_exist", errno.ErrNoSuchTable)
+
+	tk.MustExec("ALTER TABLE t REMOVE TTL")
+	tk.MustGetErrMsg("ALTER TABLE t TTL_ARCHIVE_TABLE = t_history", "[ddl:8150]Cannot set TTL_ARCHIVE_TABLE on a table without TTL config")
+	tk.MustGetErrMsg("CREATE TABLE t1 (created_at datetime) TTL_ARCHIVE_TABLE = t_history", "[ddl:8150]Cannot set TTL_ARCHIVE_TABLE on a table without TTL config")
+}
+
 func TestDisableTTLForTempTable(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -1623,28 +1690,28 @@ func TestDisableTTLForTempTable(t *testing.T) {
 	tk.MustGetDBError("CREATE TEMPORARY TABLE t (created_at datetime) TTL = `created_at` + INTERVAL 5 DAY", dbterror.ErrTempTableNotAllowedWithTTL)
 }
 
-func TestDisableTTLForFKParentTable(t *testing.T) {
+func TestTTLForFKParentTable(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
 	tk.MustExec("use test")
 
-	// alter ttl for a FK parent table is not allowed
+	// alter ttl for a FK parent table is allowed
 	tk.MustExec("set global tidb_enable_foreign_key='ON'")
 	tk.MustExec("CREATE TABLE t (id int primary key, created_at datetime)")
 	tk.MustExec("CREATE TABLE t_1 (t_id int, foreign key fk_t_id(t_id) references t(id))")
-	tk.MustGetDBError("ALTER TABLE t TTL = created_at + INTERVAL 5 YEAR", dbterror.ErrUnsupportedTTLReferencedByFK)
-	tk.MustExec("drop table t,t_1")
+	tk.MustExec("ALTER TABLE t TTL = created_at + INTERVAL 5 YEAR")
+	tk.MustExec("drop table t_1,t")
 
-	// refuse to reference TTL key when create table
+	// reference TTL table when create table
 	tk.MustExec("CREATE TABLE t (id int primary key, created_at datetime) TTL = created_at + INTERVAL 5 YEAR")
-	tk.MustGetDBError("CREATE TABLE t_1 (t_id int, foreign key fk_t_id(t_id) references t(id))", dbterror.ErrUnsupportedTTLReferencedByFK)
-	tk.MustExec("drop table t")
+	tk.MustExec("CREATE TABLE t_1 (t_id int, foreign key fk_t_id(t_id) references t(id) on delete cascade)")
+	tk.MustExec("drop table t_1,t")
 
-	// refuse to add foreign key reference TTL table
+	// add foreign key reference TTL table
 	tk.MustExec("CREATE TABLE t (id int primary key, created_at datetime) TTL = created_at + INTERVAL 5 YEAR")
 	tk.MustExec("CREATE TABLE t_1 (t_id int)")
-	tk.MustGetDBError("ALTER TABLE t_1 ADD FOREIGN KEY fk_t_id(t_id) references t(id)", dbterror.ErrUnsupportedTTLReferencedByFK)
-	tk.MustExec("drop table t,t_1")
+	tk.MustExec("ALTER TABLE t_1 ADD FOREIGN KEY fk_t_id(t_id) references t(id) on delete set null")
+	tk.MustExec("drop table t_1,t")
 }
 
 func TestCheckPrimaryKeyForTTLTable(t *testing.T) {
@@ -52,6 +52,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/sessiontxn"
+	derr "github.com/pingcap/tidb/store/driver/error"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/tablecodec"
@@ -973,6 +974,9 @@ type CheckTableExec struct {
 	exitCh     chan struct{}
 	retCh      chan error
 	checkIndex bool
+	// partitionNames are the checked partitions, all the partitions are checked if it's empty.
+	partitionNames []model.CIStr
+	partitionIDs   []int64
 }
 
 // Open implements the Executor Open interface.
@@ -1056,7 +1060,11 @@ func (e *CheckTableExec) Next(ctx context.Context, req *chunk.Chunk) error {
 		}
 		idxNames = append(idxNames, idx.Name.O)
 	}
-	greater, idxOffset, err := admin.CheckIndicesCount(e.ctx, e.dbName, e.table.Meta().Name.O, idxNames)
+	partitionNames := make([]string, 0, len(e.partitionNames))
+	for _, name := range e.partitionNames {
+		partitionNames = append(partitionNames, name.O)
+	}
+	greater, idxOffset, err := admin.CheckIndicesCount(e.ctx, e.dbName, e.table.Meta().Name.O, partitionNames, idxNames)
 	if err != nil {
 		// For admin check index statement, for speed up and compatibility, doesn't do below checks.
 		if e.checkIndex {
@@ -1143,6 +1151,9 @@ func (e *CheckTableExec) checkTableRecord(ctx context.Context, idxOffset int) er
 	info := e.table.Meta().GetPartitionInfo()
 	for _, def := range info.Definitions {
 		pid := def.ID
+		if len(e.partitionIDs) > 0 && !slices.Contains(e.partitionIDs, pid) {
+			continue
+		}
 		partition := e.table.(table.PartitionedTable).GetPartition(pid)
 		idx := tables.NewIndex(def.ID, e.table.Meta(), idxInfo)
 		if err := admin.CheckRecordAndIndex(ctx, e.ctx, txn, partition, idx); err != nil {
@@ -1246,6 +1257,11 @@ type SelectLockExec struct {
 	// due to issues with chunk handling between the TableReaderExecutor and the
 	// SelectReader result.
 	tblID2PhysTblIDColIdx map[int64]int
+
+	// childResult, childCursor and skipLocked are only used for SKIP LOCKED.
+	childResult *chunk.Chunk
+	childCursor int
+	skipLocked  *skipLockedLocker
 }
 
 // Open implements the Executor Open interface.
@@ -1264,55 +1280,37 @@ func (e *SelectLockExec) Open(ctx context.Context) error {
 			}
 		}
 	}
+	e.childResult, e.childCursor, e.skipLocked = nil, 0, nil
 	return e.baseExecutor.Open(ctx)
 }
 
 // Next implements the Executor Next interface.
 func (e *SelectLockExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	// If there's no handle or it's not a `SELECT FOR UPDATE` statement.
+	if len(e.tblID2Handle) == 0 || (!plannercore.IsSelectForUpdateLockType(e.Lock.LockType)) {
+		req.GrowAndReset(e.maxChunkSize)
+		return Next(ctx, e.children[0], req)
+	}
+	if plannercore.IsSelectSkipLockedLockType(e.Lock.LockType) {
+		return e.nextSkipLocked(ctx, req)
+	}
+
 	req.GrowAndReset(e.maxChunkSize)
 	err := Next(ctx, e.children[0], req)
 	if err != nil {
 		return err
 	}
-	// If there's no handle or it's not a `SELECT FOR UPDATE` statement.
-	if len(e.tblID2Handle) == 0 || (!plannercore.IsSelectForUpdateLockType(e.Lock.LockType)) {
-		return nil
-	}
-
 	if req.NumRows() > 0 {
 		iter := chunk.NewIterator4Chunk(req)
 		for row := iter.Begin(); row != iter.End(); row = iter.Next() {
-			for tblID, cols := range e.tblID2Handle {
-				for _, col := range cols {
-					handle, err := col.BuildHandle(row)
-					if err != nil {
-						return err
-					}
-					physTblID := tblID
-					if physTblColIdx, ok := e.tblID2PhysTblIDColIdx[tblID]; ok {
-						physTblID = row.GetInt64(physTblColIdx)
-						if physTblID == 0 {
-							// select * from t1 left join t2 on t1.c = t2.c for update
-							// The join right side might be added NULL in left join
-							// In that case, physTblID is 0, so skip adding the lock.
-							//
-							// Note, we can't distinguish whether it's the left join case,
-							// or a bug that TiKV return without correct physical ID column.
-							continue
-						}
-					}
-					e.keys = append(e.keys, tablecodec.EncodeRowKeyWithHandle(physTblID, handle))
-				}
+			e.keys, err = e.appendRowKeys(e.keys, row)
+			if err != nil {
+				return err
 			}
 		}
 		return nil
 	}
-	lockWaitTime := e.ctx.GetSessionVars().LockWaitTimeout
-	if e.Lock.LockType == ast.SelectLockForUpdateNoWait {
-		lockWaitTime = tikvstore.LockNoWait
-	} else if e.Lock.LockType == ast.SelectLockForUpdateWaitN {
-		lockWaitTime = int64(e.Lock.WaitSec) * 1000
-	}
+	lockWaitTime := plannercore.GetSelectLockWaitTime(e.ctx, e.Lock)
 
 	for id := range e.tblID2Handle {
 		e.updateDeltaForTableID(id)
@@ -1324,6 +1322,132 @@ func (e *SelectLockExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	return doLockKeys(ctx, e.ctx, lockCtx, e.keys...)
 }
 
+// nextSkipLocked locks the rows before returning them for SKIP LOCKED, and filters out the rows locked by other
+// transactions. Only the rows required by the parent are fetched from the child, so the rows beyond the LIMIT are
+// not locked.
+func (e *SelectLockExec) nextSkipLocked(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.skipLocked == nil {
+		for id := range e.tblID2Handle {
+			e.updateDeltaForTableID(id)
+		}
+		e.skipLocked = newSkipLockedLocker(e.ctx)
+		e.childResult = tryNewCacheChunk(e.children[0])
+	}
+	for !req.IsFull() {
+		// The child may return more rows than required, the rest rows are locked in the next call.
+		if e.childCursor >= e.childResult.NumRows() {
+			e.childResult.SetRequiredRows(req.RequiredRows()-req.NumRows(), e.maxChunkSize)
+			if err := Next(ctx, e.children[0], e.childResult); err != nil {
+				return err
+			}
+			if e.childResult.NumRows() == 0 {
+				return nil
+			}
+			e.childCursor = 0
+		}
+		row := e.childResult.GetRow(e.childCursor)
+		e.childCursor++
+		keys, err := e.appendRowKeys(e.keys[:0], row)
+		if err != nil {
+			return err
+		}
+		e.keys = keys
+		locked, err := e.skipLocked.lockRow(ctx, keys)
+		if err != nil {
+			return err
+		}
+		if locked {
+			req.AppendRow(row)
+		}
+	}
+	return nil
+}
+
+// appendRowKeys appends the keys to be locked of the row.
+func (e *SelectLockExec) appendRowKeys(keys []kv.Key, row chunk.Row) ([]kv.Key, error) {
+	for tblID, cols := range e.tblID2Handle {
+		for _, col := range cols {
+			handle, err := col.BuildHandle(row)
+			if err != nil {
+				return nil, err
+			}
+			physTblID := tblID
+			if physTblColIdx, ok := e.tblID2PhysTblIDColIdx[tblID]; ok {
+				physTblID = row.GetInt64(physTblColIdx)
+				if physTblID == 0 {
+					// select * from t1 left join t2 on t1.c = t2.c for update
+					// The join right side might be added NULL in left join
+					// In that case, physTblID is 0, so skip adding the lock.
+					//
+					// Note, we can't distinguish whether it's the left join case,
+					// or a bug that TiKV return without correct physical ID column.
+					continue
+				}
+			}
+			keys = append(keys, tablecodec.EncodeRowKeyWithHandle(physTblID, handle))
+		}
+	}
+	return keys, nil
+}
+
+// skipLockedLocker locks the rows for SKIP LOCKED. The keys of a row are locked together by one NOWAIT request, and
+// the rows whose keys are locked by other transactions are skipped. A failed request is all-or-nothing: the client
+// rolls back every key it has acquired in the request asynchronously, so the other keys of a skipped row are not
+// left locked. These keys are never locked again in the same statement, otherwise the rollback may release the lock
+// acquired later.
+// The rows can't share a request, because a NOWAIT failure doesn't tell which key is locked by others, and all the
+// keys of the request are rolled back with it.
+type skipLockedLocker struct {
+	sctx   sessionctx.Context
+	locked map[string]struct{}
+	failed map[string]struct{}
+}
+
+func newSkipLockedLocker(sctx sessionctx.Context) *skipLockedLocker {
+	return &skipLockedLocker{
+		sctx:   sctx,
+		locked: make(map[string]struct{}),
+		failed: make(map[string]struct{}),
+	}
+}
+
+// lockRow locks the keys of a row, and returns false if any key is locked by other transactions.
+func (l *skipLockedLocker) lockRow(ctx context.Context, keys []kv.Key) (bool, error) {
+	for _, key := range keys {
+		if _, ok := l.failed[string(key)]; ok {
+			return false, nil
+		}
+	}
+	lockCtx, err := newLockCtx(l.sctx, tikvstore.LockNoWait, len(keys))
+	if err != nil {
+		return false, err
+	}
+	err = doLockKeys(ctx, l.sctx, lockCtx, keys...)
+	if isSkipLockedErr(err) {
+		// The keys locked by the previous rows are not sent in the request, so they're kept.
+		for _, key := range keys {
+			if _, ok := l.locked[string(key)]; !ok {
+				l.failed[string(key)] = struct{}{}
+			}
+		}
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, key := range keys {
+		l.locked[string(key)] = struct{}{}
+	}
+	return true, nil
+}
+
+// isSkipLockedErr checks if the error means the lock is held by other transactions, so the row can be skipped by
+// SKIP LOCKED.
+func isSkipLockedErr(err error) bool {
+	return err != nil && derr.ErrLockAcquireFailAndNoWaitSet.Equal(err)
+}
+
 func newLockCtx(sctx sessionctx.Context, lockWaitTime int64, numKeys int) (*tikvstore.LockCtx, error) {
 	seVars := sctx.GetSessionVars()
 	forUpdateTS, err := sessiontxn.GetTxnManager(sctx).GetStmtForUpdateTS()
@@ -2378,6 +2502,21 @@ type FastCheckTableExec struct {
 	err        *atomic.Pointer[error]
 	wg         sync.WaitGroup
 	contextCtx context.Context
+	// partitionNames are the checked partitions, all the partitions are checked if it's empty.
+	partitionNames []model.CIStr
+}
+
+// tableSource returns the table read by the check queries, which is limited to the checked partitions.
+func (e *FastCheckTableExec) tableSource() string {
+	name := TableName(e.dbName, e.table.Meta().Name.String())
+	if len(e.partitionNames) == 0 {
+		return name
+	}
+	partitions := make([]string, 0, len(e.partitionNames))
+	for _, partitionName := range e.partitionNames {
+		partitions = append(partitions, ColumnName(partitionName.O))
+	}
+	return fmt.Sprintf("%s partition(%s)", name, strings.Join(partitions, ", "))
 }
 
 // Open implements the Executor Open interface.
@@ -2548,8 +2687,8 @@ func (w *checkIndexWorker) HandleTask(task checkIndexTask) {
 		}
 		checkOnce = true
 
-		tblQuery := fmt.Sprintf("select /*+ read_from_storage(tikv[%s]) */ bit_xor(%s), %s, count(*) from %s use index() where %s = 0 group by %s", TableName(w.e.dbName, w.e.table.Meta().Name.String()), md5HandleAndIndexCol.String(), groupByKey, TableName(w.e.dbName, w.e.table.Meta().Name.String()), whereKey, groupByKey)
-		idxQuery := fmt.Sprintf("select bit_xor(%s), %s, count(*) from %s use index(`%s`) where %s = 0 group by %s", md5HandleAndIndexCol.String(), groupByKey, TableName(w.e.dbName, w.e.table.Meta().Name.String()), idxInfo.Name, whereKey, groupByKey)
+		tblQuery := fmt.Sprintf("select /*+ read_from_storage(tikv[%s]) */ bit_xor(%s), %s, count(*) from %s use index() where %s = 0 group by %s", TableName(w.e.dbName, w.e.table.Meta().Name.String()), md5HandleAndIndexCol.String(), groupByKey, w.e.tableSource(), whereKey, groupByKey)
+		idxQuery := fmt.Sprintf("select bit_xor(%s), %s, count(*) from %s use index(`%s`) where %s = 0 group by %s", md5HandleAndIndexCol.String(), groupByKey, w.e.tableSource(), idxInfo.Name, whereKey, groupByKey)
 
 		logutil.BgLogger().Info("fast check table by group", zap.String("table name", w.table.Meta().Name.String()), zap.String("index name", idxInfo.Name.String()), zap.Int("times", times), zap.Int("current offset", offset), zap.Int("current mod", mod), zap.String("table sql", tblQuery), zap.String("index sql", idxQuery))
 
@@ -2633,8 +2772,8 @@ func (w *checkIndexWorker) HandleTask(task checkIndexTask) {
 
 	if meetError {
 		groupByKey := fmt.Sprintf("((%s - %d) %% %d)", md5Handle.String(), offset, mod)
-		indexSQL := fmt.Sprintf("select %s, %s, %s from %s use index(`%s`) where %s = 0 order by %s", handleColumnField, indexColumnField.String(), md5HandleAndIndexCol.String(), TableName(w.e.dbName, w.e.table.Meta().Name.String()), idxInfo.Name, groupByKey, handleColumnField)
-		tableSQL := fmt.Sprintf("select /*+ read_from_storage(tikv[%s]) */ %s, %s, %s from %s use index() where %s = 0 order by %s", TableName(w.e.dbName, w.e.table.Meta().Name.String()), handleColumnField, indexColumnField.String(), md5HandleAndIndexCol.String(), TableName(w.e.dbName, w.e.table.Meta().Name.String()), groupByKey, handleColumnField)
+		indexSQL := fmt.Sprintf("select %s, %s, %s from %s use index(`%s`) where %s = 0 order by %s", handleColumnField, indexColumnField.String(), md5HandleAndIndexCol.String(), w.e.tableSource(), idxInfo.Name, groupByKey, handleColumnField)
+		tableSQL := fmt.Sprintf("select /*+ read_from_storage(tikv[%s]) */ %s, %s, %s from %s use index() where %s = 0 order by %s", TableName(w.e.dbName, w.e.table.Meta().Name.String()), handleColumnField, indexColumnField.String(), md5HandleAndIndexCol.String(), w.e.tableSource(), groupByKey, handleColumnField)
 
 		idxRow, err := queryToRow(se, indexSQL)
 		if err != nil {
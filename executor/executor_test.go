@@ -309,6 +309,7 @@ func TestShow(t *testing.T) {
 		"RESTRICTED_CONNECTION_ADMIN Server Admin ",
 		"RESTRICTED_REPLICA_WRITER_ADMIN Server Admin ",
 		"RESOURCE_GROUP_ADMIN Server Admin ",
+		"XA_RECOVER_ADMIN Server Admin ",
 	))
 	require.Len(t, tk.MustQuery("show table status").Rows(), 1)
 }
@@ -2798,6 +2799,74 @@ func TestSelectForUpdateOf(t *testing.T) {
 	tk1.MustExec("rollback")
 }
 
+func TestSelectForUpdateSkipLocked(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, v int, unique key uk(v))")
+	tk.MustExec("insert t values (1, 1), (2, 2), (3, 3), (4, 4), (5, 5)")
+
+	// the limit is kept above the lock
+	rows := tk.MustQuery("explain format = 'brief' select * from t order by id limit 2 for update skip locked").Rows()
+	require.Regexp(t, "^Limit", rows[0][0])
+	require.Regexp(t, "SelectLock", rows[1][0])
+	require.Equal(t, "for update skip locked 0", rows[1][4])
+
+	tk1.MustExec("begin pessimistic")
+	tk1.MustQuery("select * from t where id in (2, 4) for update").Check(testkit.Rows("2 2", "4 4"))
+
+	tk.MustExec("begin pessimistic")
+	tk.MustQuery("select * from t order by id limit 2 for update skip locked").Check(testkit.Rows("1 1", "3 3"))
+	// the rows beyond the limit are not locked
+	tk2.MustExec("begin pessimistic")
+	tk2.MustQuery("select * from t where id = 5 for update nowait").Check(testkit.Rows("5 5"))
+	tk2.MustExec("rollback")
+	tk.MustQuery("select * from t order by id limit 1, 2 for update skip locked").Check(testkit.Rows("3 3", "5 5"))
+	tk.MustQuery("select * from t where v > 0 for update skip locked").Sort().Check(testkit.Rows("1 1", "3 3", "5 5"))
+	// point get and batch point get
+	tk.MustQuery("select * from t where id = 2 for update skip locked").Check(testkit.Rows())
+	tk.MustQuery("select * from t where id = 3 for update skip locked").Check(testkit.Rows("3 3"))
+	tk.MustQuery("select * from t where id in (1, 2, 3, 4) for update skip locked").Sort().Check(testkit.Rows("1 1", "3 3"))
+	tk.MustQuery("select * from t where v in (2, 3, 4) for update skip locked").Check(testkit.Rows("3 3"))
+	err := tk.ExecToErr("select * from t where id = 2 for update nowait")
+	require.True(t, terror.ErrorEqual(err, error2.ErrLockAcquireFailAndNoWaitSet), fmt.Sprintf("err: %v", err))
+	tk.MustExec("rollback")
+
+	// the other keys of a skipped row are not left locked
+	tk.MustExec("create table t1 (id int primary key, tid int)")
+	tk.MustExec("insert t1 values (10, 1), (20, 2)")
+	tk.MustExec("begin pessimistic")
+	tk.MustQuery("select t1.id, t.id from t1 join t on t1.tid = t.id order by t1.id for update skip locked").Check(testkit.Rows("10 1"))
+	tk2.MustExec("begin pessimistic")
+	tk2.MustQuery("select * from t1 where id = 20 for update nowait").Check(testkit.Rows("20 2"))
+	tk2.MustExec("rollback")
+	tk.MustExec("rollback")
+
+	tk1.MustExec("rollback")
+
+	// NOWAIT and SKIP LOCKED are not supported by FOR SHARE, even if it's enabled as a noop function
+	for _, noop := range []string{"OFF", "ON"} {
+		tk.MustExec("set @@tidb_enable_noop_functions = " + noop)
+		for _, sql := range []string{
+			"select * from t for share nowait",
+			"select * from t for share skip locked",
+			"select * from t where id = 1 for share nowait",
+			"select * from t where id in (1, 2) for share skip locked",
+			"select * from t where id in (select id from t1 for share skip locked)",
+		} {
+			err = tk.ExecToErr(sql)
+			require.True(t, terror.ErrorEqual(err, plannercore.ErrNotSupportedYet), fmt.Sprintf("sql: %s, err: %v", sql, err))
+		}
+	}
+}
+
 func TestEmptyEnum(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 
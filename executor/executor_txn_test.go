@@ -22,7 +22,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/sessionctx/binloginfo"
 	"github.com/pingcap/tidb/testkit"
 	"github.com/pingcap/tipb/go-binlog"
@@ -789,6 +791,119 @@ func TestSavepointWithBinlog(t *testing.T) {
 	tk.MustQuery("select * from t").Check(testkit.Rows("1 1"))
 }
 
+func TestXATransaction(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk1 := testkit.NewTestKit(t, store)
+	tk2 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	tk2.MustExec("use test")
+	tk1.MustExec("create table t(id int primary key, v int)")
+	tk1.MustExec("insert into t values (1, 1)")
+
+	// The prepared branch can be committed by another session, and its locks are kept until then.
+	tk1.MustExec("xa start 'x1', 'b1'")
+	tk1.MustExec("update t set v = 2 where id = 1")
+	tk1.MustExec("xa end 'x1', 'b1'")
+	tk1.MustExec("xa prepare 'x1', 'b1'")
+	tk1.MustQuery("select * from t").Check(testkit.Rows("1 1"))
+	tk2.MustExec("begin pessimistic")
+	tk2.MustGetErrCode("select * from t where id = 1 for update nowait", errno.ErrLockAcquireFailAndNoWaitSet)
+	tk2.MustExec("rollback")
+	tk2.MustQuery("xa recover").Check(testkit.Rows("1 2 2 x1b1"))
+	tk2.MustQuery("xa recover convert xid").Check(testkit.Rows("1 2 2 0x78316231"))
+	tk2.MustExec("xa commit 'x1', 'b1'")
+	tk1.MustQuery("select * from t").Check(testkit.Rows("1 2"))
+	tk1.MustQuery("xa recover").Check(testkit.Rows())
+	tk1.MustGetErrCode("xa commit 'x1', 'b1'", errno.ErrXaerNota)
+
+	// The prepared branch survives the session.
+	tk1.MustExec("xa start 'x2'")
+	tk1.MustExec("insert into t values (2, 2)")
+	tk1.MustExec("xa end 'x2'")
+	tk1.MustExec("xa prepare 'x2'")
+	tk1.Session().Close()
+	tk1.RefreshSession()
+	tk1.MustExec("use test")
+	tk1.MustExec("xa rollback 'x2'")
+	tk1.MustQuery("select * from t").Check(testkit.Rows("1 2"))
+	tk1.MustQuery("xa recover").Check(testkit.Rows())
+
+	// The IDLE branch can be committed in one phase or rolled back.
+	tk1.MustExec("xa start 'x3'")
+	tk1.MustExec("insert into t values (3, 3)")
+	tk1.MustExec("xa end 'x3'")
+	tk1.MustExec("xa commit 'x3' one phase")
+	tk1.MustExec("xa start 'x4'")
+	tk1.MustExec("insert into t values (4, 4)")
+	tk1.MustExec("xa end 'x4'")
+	tk1.MustExec("xa rollback 'x4'")
+	tk1.MustQuery("select * from t").Check(testkit.Rows("1 2", "3 3"))
+}
+
+func TestXATransactionState(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id int primary key)")
+
+	tk.MustExec("xa start 'x1'")
+	tk.MustGetErrMsg("xa start 'x2'", "[executor:1399]XAERRMFAIL: The command cannot be executed when global transaction is in the  ACTIVE state")
+	for _, sql := range []string{"begin", "commit", "rollback", "create table t1(id int)", "xa prepare 'x1'", "xa commit 'x1'"} {
+		tk.MustGetErrCode(sql, errno.ErrXaerRmfail)
+	}
+	tk.MustGetErrCode("xa end 'x2'", errno.ErrXaerNota)
+	tk.MustExec("insert into t values (1)")
+	tk.MustExec("xa end 'x1'")
+	tk.MustGetErrMsg("select * from t", "[executor:1399]XAERRMFAIL: The command cannot be executed when global transaction is in the  IDLE state")
+	tk.MustGetErrCode("xa end 'x1'", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("xa commit 'x1'", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("xa prepare 'x2'", errno.ErrXaerNota)
+	tk.MustExec("xa rollback 'x1'")
+	tk.MustQuery("select * from t").Check(testkit.Rows())
+
+	tk.MustGetErrMsg("xa end 'x1'", "[executor:1399]XAERRMFAIL: The command cannot be executed when global transaction is in the  NON-EXISTING state")
+	tk.MustGetErrCode("xa commit 'x1'", errno.ErrXaerNota)
+	tk.MustGetErrCode("xa rollback 'x1'", errno.ErrXaerNota)
+	tk.MustGetErrCode(fmt.Sprintf("xa start '%s'", strings.Repeat("a", 65)), errno.ErrXaerInval)
+	tk.MustExec("begin")
+	tk.MustGetErrCode("xa start 'x1'", errno.ErrXaerOutside)
+	tk.MustExec("rollback")
+
+	tk.MustExec("xa start 'x1'")
+	tk.MustExec("xa end 'x1'")
+	tk.MustExec("xa prepare 'x1'")
+	tk.MustGetErrCode("xa start 'x1'", errno.ErrXaerDupid)
+	tk.MustExec("xa start 'x1', 'b1'")
+	tk.MustExec("xa end 'x1', 'b1'")
+	tk.MustExec("xa prepare 'x1', 'b1'")
+	tk.MustQuery("xa recover").Sort().Check(testkit.Rows("1 2 0 x1", "1 2 2 x1b1"))
+	tk.MustExec("xa rollback 'x1'")
+	tk.MustExec("xa commit 'x1', 'b1'")
+	tk.MustQuery("xa recover").Check(testkit.Rows())
+}
+
+func TestXARecoverPrivilege(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create user u1, u2")
+	tk.MustExec("grant all on test.* to u1, u2")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustExec("xa start 'x1'")
+	tk1.MustExec("xa end 'x1'")
+	tk1.MustExec("xa prepare 'x1'")
+	tk1.MustQuery("xa recover").Check(testkit.Rows("1 2 0 x1"))
+
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "u2", Hostname: "%"}, nil, nil, nil))
+	tk2.MustQuery("xa recover").Check(testkit.Rows())
+	tk.MustExec("grant XA_RECOVER_ADMIN on *.* to u2")
+	tk2.MustQuery("xa recover").Check(testkit.Rows("1 2 0 x1"))
+	tk2.MustExec("xa rollback 'x1'")
+	tk1.MustQuery("xa recover").Check(testkit.Rows())
+}
+
 type mockPumpClient struct{}
 
 func (m mockPumpClient) WriteBinlog(ctx context.Context, in *binlog.WriteBinlogReq, opts ...grpc.CallOption) (*binlog.WriteBinlogResp, error) {
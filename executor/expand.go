@@ -0,0 +1,86 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/memory"
+)
+
+// ExpandExec replicates the rows of its child to feed the different grouping
+// sets, every level projection produces one copy of a child chunk, in which
+// the grouping set columns not needed by the level are filled with NULL and
+// the grouping id is appended.
+type ExpandExec struct {
+	baseExecutor
+
+	levelEvaluatorSuits []*expression.EvaluatorSuite
+
+	childResult *chunk.Chunk
+	// curLevel is the offset of the level projection to be evaluated on childResult.
+	curLevel int
+
+	memTracker *memory.Tracker
+}
+
+// Open implements the Executor Open interface.
+func (e *ExpandExec) Open(ctx context.Context) error {
+	if err := e.baseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	if e.memTracker != nil {
+		e.memTracker.Reset()
+	} else {
+		e.memTracker = memory.NewTracker(e.id, -1)
+	}
+	e.memTracker.AttachTo(e.ctx.GetSessionVars().StmtCtx.MemTracker)
+	e.childResult = tryNewCacheChunk(e.children[0])
+	e.memTracker.Consume(e.childResult.MemoryUsage())
+	// There is no child chunk to be expanded yet.
+	e.curLevel = len(e.levelEvaluatorSuits)
+	return nil
+}
+
+// Next implements the Executor Next interface.
+func (e *ExpandExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.GrowAndReset(e.maxChunkSize)
+	if e.curLevel >= len(e.levelEvaluatorSuits) {
+		mSize := e.childResult.MemoryUsage()
+		err := Next(ctx, e.children[0], e.childResult)
+		e.memTracker.Consume(e.childResult.MemoryUsage() - mSize)
+		if err != nil {
+			return err
+		}
+		if e.childResult.NumRows() == 0 {
+			return nil
+		}
+		e.curLevel = 0
+	}
+	err := e.levelEvaluatorSuits[e.curLevel].Run(e.ctx, e.childResult, req)
+	e.curLevel++
+	return err
+}
+
+// Close implements the Executor Close interface.
+func (e *ExpandExec) Close() error {
+	if e.childResult != nil {
+		e.memTracker.Consume(-e.childResult.MemoryUsage())
+		e.childResult = nil
+	}
+	return e.baseExecutor.Close()
+}
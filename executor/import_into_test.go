@@ -156,13 +156,37 @@ func TestImportIntoOptionsNegativeCase(t *testing.T) {
 
 	sqlTemplate = "import into t from '/file.csv' format '%s' with %s"
 	for _, c := range nonCSVCases {
-		for _, format := range []string{importer.DataFormatParquet, importer.DataFormatSQL} {
+		for _, format := range []string{importer.DataFormatParquet, importer.DataFormatSQL, importer.DataFormatNDJSON} {
 			sql := fmt.Sprintf(sqlTemplate, format, c.OptionStr)
 			err := tk.ExecToErr(sql)
 			require.ErrorIs(t, err, c.Err, sql)
 		}
 	}
 
+	ndjsonCases := []struct {
+		OptionStr string
+		Err       error
+	}{
+		{OptionStr: "json_missing_key=null", Err: exeerrors.ErrInvalidOptionVal},
+		{OptionStr: "json_missing_key=1", Err: exeerrors.ErrInvalidOptionVal},
+		{OptionStr: "json_missing_key='ignore'", Err: exeerrors.ErrInvalidOptionVal},
+		{OptionStr: "json_extra_key=null", Err: exeerrors.ErrInvalidOptionVal},
+		{OptionStr: "json_extra_key=true", Err: exeerrors.ErrInvalidOptionVal},
+		{OptionStr: "json_extra_key='null'", Err: exeerrors.ErrInvalidOptionVal},
+	}
+	for _, c := range ndjsonCases {
+		sql := fmt.Sprintf(sqlTemplate, importer.DataFormatNDJSON, c.OptionStr)
+		err := tk.ExecToErr(sql)
+		require.ErrorIs(t, err, c.Err, sql)
+	}
+	for _, optionStr := range []string{"json_missing_key='error'", "json_extra_key='error'"} {
+		for _, format := range []string{importer.DataFormatCSV, importer.DataFormatParquet, importer.DataFormatSQL} {
+			sql := fmt.Sprintf(sqlTemplate, format, optionStr)
+			err := tk.ExecToErr(sql)
+			require.ErrorIs(t, err, exeerrors.ErrLoadDataUnsupportedOption, sql)
+		}
+	}
+
 	parameterCheck := []struct {
 		sql string
 		Err error
@@ -69,6 +69,8 @@ const (
 	DataFormatSQL = "sql"
 	// DataFormatParquet represents the data source file of IMPORT INTO is parquet.
 	DataFormatParquet = "parquet"
+	// DataFormatNDJSON represents the data source file of IMPORT INTO is newline-delimited JSON.
+	DataFormatNDJSON = "ndjson"
 
 	// DefaultDiskQuota is the default disk quota for IMPORT INTO
 	DefaultDiskQuota = config.ByteSize(50 << 30) // 50GiB
@@ -91,6 +93,8 @@ const (
 	recordErrorsOption          = "record_errors"
 	detachedOption              = "detached"
 	disableTiKVImportModeOption = "disable_tikv_import_mode"
+	jsonMissingKeyOption        = "json_missing_key"
+	jsonExtraKeyOption          = "json_extra_key"
 )
 
 var (
@@ -111,6 +115,8 @@ var (
 		recordErrorsOption:          true,
 		detachedOption:              false,
 		disableTiKVImportModeOption: false,
+		jsonMissingKeyOption:        true,
+		jsonExtraKeyOption:          true,
 	}
 
 	csvOnlyOptions = map[string]struct{}{
@@ -124,6 +130,11 @@ var (
 		splitFileOption:           {},
 	}
 
+	ndjsonOnlyOptions = map[string]struct{}{
+		jsonMissingKeyOption: {},
+		jsonExtraKeyOption:   {},
+	}
+
 	// LoadDataReadBlockSize is exposed for test.
 	LoadDataReadBlockSize = int64(config.ReadBlockSize)
 )
@@ -174,6 +185,9 @@ type Plan struct {
 	// FieldsOptEnclosed is not used in either IMPORT INTO or LOAD DATA
 	plannercore.LineFieldsInfo
 	IgnoreLines uint64
+	// used for NDJSON format of IMPORT INTO
+	JSONMissingKey string
+	JSONExtraKey   string
 
 	DiskQuota             config.ByteSize
 	Checksum              config.PostOpLevel
@@ -433,7 +447,8 @@ func (e *LoadDataController) checkFieldParams() error {
 		return exeerrors.ErrLoadDataEmptyPath
 	}
 	if e.InImportInto {
-		if e.Format != DataFormatCSV && e.Format != DataFormatParquet && e.Format != DataFormatSQL {
+		if e.Format != DataFormatCSV && e.Format != DataFormatParquet && e.Format != DataFormatSQL &&
+			e.Format != DataFormatNDJSON {
 			return exeerrors.ErrLoadDataUnsupportedFormat.GenWithStackByArgs(e.Format)
 		}
 	} else {
@@ -473,6 +488,8 @@ func (p *Plan) initDefaultOptions() {
 	p.MaxRecordedErrors = 100
 	p.Detached = false
 	p.DisableTiKVImportMode = false
+	p.JSONMissingKey = config.JSONMissingKeyDefault
+	p.JSONExtraKey = config.JSONExtraKeyIgnore
 
 	v := "utf8mb4"
 	p.Charset = &v
@@ -503,6 +520,13 @@ func (p *Plan) initOptions(seCtx sessionctx.Context, options []*plannercore.Load
 			}
 		}
 	}
+	if p.Format != DataFormatNDJSON {
+		for k := range ndjsonOnlyOptions {
+			if _, ok := specifiedOptions[k]; ok {
+				return exeerrors.ErrLoadDataUnsupportedOption.FastGenByArgs(k, "non-NDJSON format")
+			}
+		}
+	}
 
 	optAsString := func(opt *plannercore.LoadDataOpt) (string, error) {
 		if opt.Value.GetType().GetType() != mysql.TypeVarString {
@@ -630,6 +654,28 @@ func (p *Plan) initOptions(seCtx sessionctx.Context, options []*plannercore.Load
 	if _, ok := specifiedOptions[disableTiKVImportModeOption]; ok {
 		p.DisableTiKVImportMode = true
 	}
+	if opt, ok := specifiedOptions[jsonMissingKeyOption]; ok {
+		v, err := optAsString(opt)
+		if err != nil {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		v = strings.ToLower(v)
+		if v != config.JSONMissingKeyDefault && v != config.JSONMissingKeyNull && v != config.JSONMissingKeyError {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		p.JSONMissingKey = v
+	}
+	if opt, ok := specifiedOptions[jsonExtraKeyOption]; ok {
+		v, err := optAsString(opt)
+		if err != nil {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		v = strings.ToLower(v)
+		if v != config.JSONExtraKeyIgnore && v != config.JSONExtraKeyError {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		p.JSONExtraKey = v
+	}
 
 	p.adjustOptions()
 	return nil
@@ -821,6 +867,23 @@ func (e *LoadDataController) GenerateCSVConfig() *config.CSVConfig {
 	return csvConfig
 }
 
+// GenerateNDJSONColumns generates the columns of NDJSON parser from the field mappings, the keys of the JSON objects
+// are mapped to the columns or user variables by name.
+func (e *LoadDataController) GenerateNDJSONColumns() (columns, jsonColumns []string) {
+	columns = make([]string, 0, len(e.FieldMappings))
+	for _, fieldMapping := range e.FieldMappings {
+		if fieldMapping.Column == nil {
+			columns = append(columns, fieldMapping.UserVar.Name)
+			continue
+		}
+		columns = append(columns, fieldMapping.Column.Name.L)
+		if fieldMapping.Column.GetType() == mysql.TypeJSON {
+			jsonColumns = append(jsonColumns, fieldMapping.Column.Name.L)
+		}
+	}
+	return columns, jsonColumns
+}
+
 // InitDataFiles initializes the data store and load data files.
 func (e *LoadDataController) InitDataFiles(ctx context.Context) error {
 	u, err2 := storage.ParseRawURL(e.Path)
@@ -840,7 +903,7 @@ func (e *LoadDataController) InitDataFiles(ctx context.Context) error {
 		}
 		// we add this check for security, we don't want user import any sensitive system files,
 		// most of which is readable text file and don't have a suffix, such as /etc/passwd
-		if !slices.Contains([]string{".csv", ".sql", ".parquet"}, strings.ToLower(filepath.Ext(e.Path))) {
+		if !slices.Contains([]string{".csv", ".sql", ".parquet", ".ndjson", ".jsonl"}, strings.ToLower(filepath.Ext(e.Path))) {
 			return exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs("the file suffix is not supported when import from server disk")
 		}
 		dir := filepath.Dir(e.Path)
@@ -948,6 +1011,8 @@ func (e *LoadDataController) getSourceType() mydump.SourceType {
 	switch e.Format {
 	case DataFormatParquet:
 		return mydump.SourceTypeParquet
+	case DataFormatNDJSON:
+		return mydump.SourceTypeNDJSON
 	case DataFormatDelimitedData, DataFormatCSV:
 		return mydump.SourceTypeCSV
 	default:
@@ -1026,6 +1091,17 @@ func (e *LoadDataController) GetParser(
 			reader,
 			dataFileInfo.Remote.Path,
 		)
+	case DataFormatNDJSON:
+		columns, jsonColumns := e.GenerateNDJSONColumns()
+		parser = mydump.NewNDJSONParser(
+			ctx,
+			&config.JSONConfig{MissingKey: e.JSONMissingKey, ExtraKey: e.JSONExtraKey},
+			reader,
+			LoadDataReadBlockSize,
+			nil,
+			columns,
+			jsonColumns,
+		)
 	}
 	if err != nil {
 		return nil, exeerrors.ErrLoadDataWrongFormatConfig.GenWithStack(err.Error())
@@ -45,6 +45,8 @@ func TestInitDefaultOptions(t *testing.T) {
 	require.Equal(t, false, plan.Detached)
 	require.Equal(t, "utf8mb4", *plan.Charset)
 	require.Equal(t, false, plan.DisableTiKVImportMode)
+	require.Equal(t, config.JSONMissingKeyDefault, plan.JSONMissingKey)
+	require.Equal(t, config.JSONExtraKeyIgnore, plan.JSONExtraKey)
 
 	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/executor/importer/mockNumCpu", "return(10)"))
 	plan.initDefaultOptions()
@@ -108,6 +110,15 @@ func TestInitOptionsPositiveCase(t *testing.T) {
 	require.Equal(t, int64(123), plan.MaxRecordedErrors, sql)
 	require.True(t, plan.Detached, sql)
 	require.True(t, plan.DisableTiKVImportMode, sql)
+
+	plan = &Plan{Format: DataFormatNDJSON}
+	sql = fmt.Sprintf(sqlTemplate, jsonMissingKeyOption+"='ERROR', "+jsonExtraKeyOption+"='error'")
+	stmt, err = p.ParseOneStmt(sql, "", "")
+	require.NoError(t, err, sql)
+	err = plan.initOptions(ctx, convertOptions(stmt.(*ast.ImportIntoStmt).Options))
+	require.NoError(t, err, sql)
+	require.Equal(t, config.JSONMissingKeyError, plan.JSONMissingKey, sql)
+	require.Equal(t, config.JSONExtraKeyError, plan.JSONExtraKey, sql)
 }
 
 func TestAdjustOptions(t *testing.T) {
@@ -114,7 +114,7 @@ func (en *tableKVEncoder) parserData2TableData(parserData []types.Datum, rowID i
 		// User variable names are not case-sensitive
 		// https://dev.mysql.com/doc/refman/8.0/en/user-variables.html
 		name = strings.ToLower(name)
-		if col == nil || col.IsNull() {
+		if col == nil || col.IsNull() || encode.IsMissing(col) {
 			sessionVars.UnsetUserVar(name)
 		} else {
 			sessionVars.SetUserVarVal(name, *col)
@@ -171,6 +171,10 @@ func (en *tableKVEncoder) getRow(vals []types.Datum, rowID int64) ([]types.Datum
 	row := make([]types.Datum, len(en.Columns))
 	hasValue := make([]bool, len(en.Columns))
 	for i := 0; i < len(en.insertColumns); i++ {
+		// the column missing in the row uses the default value, see encode.SetMissing.
+		if encode.IsMissing(&vals[i]) {
+			continue
+		}
 		casted, err := table.CastValue(en.SessionCtx, vals[i], en.insertColumns[i].ToInfo(), false, false)
 		if err != nil {
 			return nil, err
@@ -127,6 +127,10 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.dataForTiDBClusterInfo(sctx)
 		case infoschema.TableAnalyzeStatus:
 			err = e.setDataForAnalyzeStatus(ctx, sctx)
+		case infoschema.TableAutoAnalyzeQueue:
+			err = e.setDataForAutoAnalyzeQueue(sctx)
+		case infoschema.TableInstancePlanCache:
+			e.setDataForInstancePlanCache(sctx)
 		case infoschema.TableTiDBIndexes:
 			e.setDataFromIndexes(sctx, dbs)
 		case infoschema.TableViews:
@@ -982,6 +986,27 @@ func (e *memtableRetriever) setDataFromPartitions(ctx context.Context, sctx sess
 						partitionExpr = buf.String()
 					}
 
+					partitionName, partitionPos := pi.Name.O, i+1
+					var subPartitionName, subPartitionPos, subPartitionMethod, subPartitionExpr interface{}
+					if sub := table.Partition.Sub; sub != nil {
+						partitionName, partitionPos = pi.ParentName.O, i/int(sub.Num)+1
+						subPartitionName, subPartitionPos = pi.Name.O, i%int(sub.Num)+1
+						subPartitionMethod = sub.Type.String()
+						subPartitionExpr = sub.Expr
+						if len(sub.Columns) > 0 {
+							buf := bytes.NewBuffer(nil)
+							for i, col := range sub.Columns {
+								if i > 0 {
+									buf.WriteString(",")
+								}
+								buf.WriteString("`")
+								buf.WriteString(col.String())
+								buf.WriteString("`")
+							}
+							subPartitionExpr = buf.String()
+						}
+					}
+
 					var policyName interface{}
 					if pi.PlacementPolicyRef != nil {
 						policyName = pi.PlacementPolicyRef.Name.O
@@ -990,14 +1015,14 @@ func (e *memtableRetriever) setDataFromPartitions(ctx context.Context, sctx sess
 						infoschema.CatalogVal, // TABLE_CATALOG
 						schema.Name.O,         // TABLE_SCHEMA
 						table.Name.O,          // TABLE_NAME
-						pi.Name.O,             // PARTITION_NAME
-						nil,                   // SUBPARTITION_NAME
-						i+1,                   // PARTITION_ORDINAL_POSITION
-						nil,                   // SUBPARTITION_ORDINAL_POSITION
+						partitionName,         // PARTITION_NAME
+						subPartitionName,      // SUBPARTITION_NAME
+						partitionPos,          // PARTITION_ORDINAL_POSITION
+						subPartitionPos,       // SUBPARTITION_ORDINAL_POSITION
 						partitionMethod,       // PARTITION_METHOD
-						nil,                   // SUBPARTITION_METHOD
+						subPartitionMethod,    // SUBPARTITION_METHOD
 						partitionExpr,         // PARTITION_EXPRESSION
-						nil,                   // SUBPARTITION_EXPRESSION
+						subPartitionExpr,      // SUBPARTITION_EXPRESSION
 						partitionDesc,         // PARTITION_DESCRIPTION
 						rowCount,              // TABLE_ROWS
 						avgRowLength,          // AVG_ROW_LENGTH
@@ -2132,6 +2157,64 @@ func (e *memtableRetriever) setDataForAnalyzeStatus(ctx context.Context, sctx se
 	return
 }
 
+func (e *memtableRetriever) setDataForAutoAnalyzeQueue(sctx sessionctx.Context) error {
+	const sql = "SELECT table_schema, table_name, partition_name, table_id, priority, change_ratio, row_count, " +
+		"CONVERT_TZ(last_analyze_time, @@TIME_ZONE, '+00:00'), CONVERT_TZ(last_used_time, @@TIME_ZONE, '+00:00'), " +
+		"CONVERT_TZ(last_failed_time, @@TIME_ZONE, '+00:00'), reason, state, CONVERT_TZ(update_time, @@TIME_ZONE, '+00:00') " +
+		"FROM mysql.auto_analyze_queue ORDER BY priority DESC, table_id"
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	kctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+	chunkRows, _, err := exec.ExecRestrictedSQL(kctx, nil, sql)
+	if err != nil {
+		return err
+	}
+	checker := privilege.GetPrivilegeManager(sctx)
+	loc := sctx.GetSessionVars().TimeZone
+	// The time is stored in the time zone of the internal session, so it's converted to UTC and then to the current time zone.
+	getTime := func(row chunk.Row, idx int) (interface{}, error) {
+		if row.IsNull(idx) {
+			return nil, nil
+		}
+		t, err := row.GetTime(idx).GoTime(time.UTC)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewTime(types.FromGoTime(t.In(loc)), mysql.TypeDatetime, 0), nil
+	}
+	rows := make([][]types.Datum, 0, len(chunkRows))
+	for _, chunkRow := range chunkRows {
+		dbName, tableName := chunkRow.GetString(0), chunkRow.GetString(1)
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, dbName, tableName, "", mysql.AllPrivMask) {
+			continue
+		}
+		times := make([]interface{}, 0, 4)
+		for _, idx := range []int{7, 8, 9, 12} {
+			t, err := getTime(chunkRow, idx)
+			if err != nil {
+				return err
+			}
+			times = append(times, t)
+		}
+		rows = append(rows, types.MakeDatums(
+			dbName,                 // TABLE_SCHEMA
+			tableName,              // TABLE_NAME
+			chunkRow.GetString(2),  // PARTITION_NAME
+			chunkRow.GetInt64(3),   // TABLE_ID
+			chunkRow.GetFloat64(4), // PRIORITY
+			chunkRow.GetFloat64(5), // CHANGE_RATIO
+			chunkRow.GetInt64(6),   // ROW_COUNT
+			times[0],               // LAST_ANALYZE_TIME
+			times[1],               // LAST_USED_TIME
+			times[2],               // LAST_FAILED_TIME
+			chunkRow.GetString(10), // REASON
+			chunkRow.GetString(11), // STATE
+			times[3],               // UPDATE_TIME
+		))
+	}
+	e.rows = rows
+	return nil
+}
+
 // setDataForPseudoProfiling returns pseudo data for table profiling when system variable `profiling` is set to `ON`.
 func (e *memtableRetriever) setDataForPseudoProfiling(sctx sessionctx.Context) {
 	if v, ok := sctx.GetSessionVars().GetSystemVar("profiling"); ok && variable.TiDBOptOn(v) {
@@ -2343,6 +2426,26 @@ func (e *memtableRetriever) setDataForTrxSummary(ctx sessionctx.Context) error {
 	return nil
 }
 
+func (e *memtableRetriever) setDataForInstancePlanCache(ctx sessionctx.Context) {
+	// the digest text may contain the statements of other users
+	if !hasPriv(ctx, mysql.ProcessPriv) {
+		return
+	}
+	stats := plannercore.GetInstancePlanCache().DigestStats()
+	rows := make([][]types.Datum, 0, len(stats))
+	for _, s := range stats {
+		rows = append(rows, types.MakeDatums(
+			s.SQLDigest,
+			s.NormalizedSQL,
+			s.PlanNum,
+			s.MemoryUsage,
+			s.Hits,
+			s.Misses,
+		))
+	}
+	e.rows = rows
+}
+
 func (e *memtableRetriever) setDataForClusterTrxSummary(ctx sessionctx.Context) error {
 	err := e.setDataForTrxSummary(ctx)
 	if err != nil {
@@ -0,0 +1,229 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/util/memory"
+)
+
+// jsonTablePath is a row path of JSON_TABLE. Each value matched by the path
+// produces rows for its columns and nested paths.
+type jsonTablePath struct {
+	path    types.JSONPathExpression
+	columns []*jsonTableColumn
+	nested  []*jsonTablePath
+	// offsets are the output offsets of all the columns of this path,
+	// including the columns of the nested paths.
+	offsets []int
+}
+
+// jsonTableColumn is a non-nested column of JSON_TABLE.
+type jsonTableColumn struct {
+	*ast.JSONTableColumn
+
+	offset int
+	tp     *types.FieldType
+	path   types.JSONPathExpression
+	// onEmptyDefault and onErrorDefault are the parsed values of `DEFAULT json_string`.
+	onEmptyDefault types.BinaryJSON
+	onErrorDefault types.BinaryJSON
+}
+
+// JSONTableExec evaluates the JSON_TABLE table function. The JSON document
+// is evaluated in Open, so it's reopened for every outer row when it's the
+// inner side of an apply.
+type JSONTableExec struct {
+	baseExecutor
+
+	jsonExpr expression.Expression
+	root     *jsonTablePath
+
+	curRow []types.Datum
+	rows   [][]types.Datum
+	cursor int
+
+	memTracker *memory.Tracker
+}
+
+// Open implements the Executor Open interface.
+func (e *JSONTableExec) Open(ctx context.Context) error {
+	e.rows = e.rows[:0]
+	e.cursor = 0
+	if e.memTracker != nil {
+		e.memTracker.Reset()
+	} else {
+		e.memTracker = memory.NewTracker(e.id, -1)
+	}
+	e.memTracker.AttachTo(e.ctx.GetSessionVars().StmtCtx.MemTracker)
+	doc, isNull, err := e.jsonExpr.EvalJSON(e.ctx, chunk.Row{})
+	if err != nil || isNull {
+		return err
+	}
+	for i := range e.curRow {
+		e.curRow[i].SetNull()
+	}
+	_, err = e.scan(e.root, doc)
+	return err
+}
+
+// Next implements the Executor Next interface.
+func (e *JSONTableExec) Next(_ context.Context, req *chunk.Chunk) error {
+	req.GrowAndReset(e.maxChunkSize)
+	for ; e.cursor < len(e.rows) && !req.IsFull(); e.cursor++ {
+		for i := range e.rows[e.cursor] {
+			req.AppendDatum(i, &e.rows[e.cursor][i])
+		}
+	}
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *JSONTableExec) Close() error {
+	e.rows = nil
+	if e.memTracker != nil {
+		e.memTracker.Consume(-e.memTracker.BytesConsumed())
+	}
+	return e.baseExecutor.Close()
+}
+
+// scan produces the rows of the path for the JSON document and returns the
+// number of produced rows. The values of the sibling nested paths are not
+// joined together, every nested path produces its own rows while the columns
+// of its siblings are NULL. If none of the nested paths matches, a row with
+// NULL nested columns is produced like an outer join.
+func (e *JSONTableExec) scan(p *jsonTablePath, doc types.BinaryJSON) (int, error) {
+	cnt := 0
+	for i, val := range doc.ExtractAll(p.path) {
+		if err := e.fillColumns(p, val, i+1); err != nil {
+			return 0, err
+		}
+		nestedCnt := 0
+		for _, nested := range p.nested {
+			n, err := e.scan(nested, val)
+			if err != nil {
+				return 0, err
+			}
+			nestedCnt += n
+			for _, offset := range nested.offsets {
+				e.curRow[offset].SetNull()
+			}
+		}
+		if nestedCnt == 0 {
+			row := make([]types.Datum, len(e.curRow))
+			copy(row, e.curRow)
+			e.rows = append(e.rows, row)
+			e.memTracker.Consume(types.EstimatedMemUsage(row, 1))
+			nestedCnt = 1
+		}
+		cnt += nestedCnt
+	}
+	return cnt, nil
+}
+
+func (e *JSONTableExec) fillColumns(p *jsonTablePath, val types.BinaryJSON, ordinality int) (err error) {
+	for _, col := range p.columns {
+		d := &e.curRow[col.offset]
+		switch col.Tp {
+		case ast.JSONTableColumnOrdinality:
+			d.SetUint64(uint64(ordinality))
+		case ast.JSONTableColumnExists:
+			exists := int64(0)
+			if len(val.ExtractAll(col.path)) > 0 {
+				exists = 1
+			}
+			var v types.Datum
+			v.SetInt64(exists)
+			if *d, err = v.ConvertTo(e.ctx.GetSessionVars().StmtCtx, col.tp); err != nil {
+				return err
+			}
+		default:
+			if *d, err = e.evalPathColumn(col, val); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// evalPathColumn evaluates the `PATH` column with its ON EMPTY and ON ERROR clauses.
+func (e *JSONTableExec) evalPathColumn(col *jsonTableColumn, val types.BinaryJSON) (types.Datum, error) {
+	vals := val.ExtractAll(col.path)
+	if len(vals) == 0 {
+		if col.OnEmpty == nil {
+			return types.Datum{}, nil
+		}
+		switch col.OnEmpty.Tp {
+		case ast.JSONTableOnResponseError:
+			return types.Datum{}, exeerrors.ErrMissingJSONTableValue.GenWithStackByArgs(col.Name.O)
+		case ast.JSONTableOnResponseDefault:
+			return e.convertJSON(col, col.onEmptyDefault)
+		}
+		return types.Datum{}, nil
+	}
+	var (
+		d   types.Datum
+		err error
+	)
+	if len(vals) > 1 {
+		err = types.ErrInvalidJSONPathMultipleSelection
+	} else {
+		d, err = e.convertJSON(col, vals[0])
+	}
+	if err == nil {
+		return d, nil
+	}
+	// NULL ON ERROR is the default behavior.
+	if col.OnError == nil {
+		return types.Datum{}, nil
+	}
+	switch col.OnError.Tp {
+	case ast.JSONTableOnResponseError:
+		return types.Datum{}, err
+	case ast.JSONTableOnResponseDefault:
+		return e.convertJSON(col, col.onErrorDefault)
+	}
+	return types.Datum{}, nil
+}
+
+// convertJSON converts the JSON value to the type of the column.
+func (e *JSONTableExec) convertJSON(col *jsonTableColumn, val types.BinaryJSON) (types.Datum, error) {
+	var d types.Datum
+	if col.tp.GetType() == mysql.TypeJSON {
+		d.SetMysqlJSON(val)
+		return d, nil
+	}
+	switch val.TypeCode {
+	case types.JSONTypeCodeObject, types.JSONTypeCodeArray:
+		return d, exeerrors.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O)
+	case types.JSONTypeCodeLiteral:
+		if val.Value[0] == types.JSONLiteralNil {
+			return d, nil
+		}
+		d.SetMysqlJSON(val)
+	case types.JSONTypeCodeString:
+		d.SetString(string(val.GetString()), col.tp.GetCollate())
+	default:
+		d.SetMysqlJSON(val)
+	}
+	return d.ConvertTo(e.ctx.GetSessionVars().StmtCtx, col.tp)
+}
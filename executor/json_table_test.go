@@ -0,0 +1,110 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/util/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": "x"}, {"a": 2}]', '$[*]' columns (
+		id for ordinality,
+		a int path '$.a',
+		b varchar(10) path '$.b' default '"none"' on empty,
+		c int exists path '$.b')) as jt`).Check(testkit.Rows("1 1 x 1", "2 2 none 0"))
+	tk.MustQuery(`select * from json_table('[1, 2, 3]', '$[1 to 2]' columns (a int path '$')) jt`).Check(testkit.Rows("2", "3"))
+	tk.MustQuery(`select * from json_table('{}', '$.a[*]' columns (a int path '$')) jt`).Check(testkit.Rows())
+	tk.MustQuery(`select * from json_table(null, '$[*]' columns (a int path '$')) jt`).Check(testkit.Rows())
+	tk.MustQuery(`select * from json_table('[{"a": {"b": 1}}, {"a": null}]', '$[*]' columns (a json path '$.a', b int path '$.a')) jt`).
+		Check(testkit.Rows(`{"b": 1} <nil>`, "null <nil>"))
+
+	// nested paths
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": [11, 111]}, {"a": 2, "b": [22]}, {"a": 3}]', '$[*]' columns (
+		a int path '$.a',
+		nested path '$.b[*]' columns (id for ordinality, b int path '$'))) as jt`).
+		Check(testkit.Rows("1 1 11", "1 2 111", "2 1 22", "3 <nil> <nil>"))
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": [11, 111], "c": [5]}]', '$[*]' columns (
+		a int path '$.a',
+		nested path '$.b[*]' columns (b int path '$'),
+		nested '$.c[*]' columns (c int path '$'))) as jt`).
+		Check(testkit.Rows("1 11 <nil>", "1 111 <nil>", "1 <nil> 5"))
+	tk.MustQuery(`select * from json_table('[{"a": [{"b": [1, 2]}, {"b": []}]}]', '$[*]' columns (
+		nested path '$.a[*]' columns (x for ordinality, nested path '$.b[*]' columns (b int path '$')))) as jt`).
+		Check(testkit.Rows("1 1", "1 2", "2 <nil>"))
+
+	// ON EMPTY and ON ERROR
+	tk.MustQuery(`select * from json_table('[{"a": [1]}, {"a": 2}, {}]', '$[*]' columns (
+		a int path '$.a' default '0' on empty default '9' on error)) as jt`).Check(testkit.Rows("9", "2", "0"))
+	tk.MustQuery(`select * from json_table('[{"a": [1]}]', '$[*]' columns (a int path '$.a' null on error)) as jt`).Check(testkit.Rows("<nil>"))
+	tk.MustQuery(`select * from json_table('[{"a": [1]}]', '$[*]' columns (a int path '$.a')) as jt`).Check(testkit.Rows("<nil>"))
+	tk.MustGetErrCode(`select * from json_table('[{"a": [1]}]', '$[*]' columns (a int path '$.a' error on error)) as jt`, errno.ErrWrongJSONTableValue)
+	tk.MustGetErrCode(`select * from json_table('[{}]', '$[*]' columns (a int path '$.a' error on empty)) as jt`, errno.ErrMissingJSONTableValue)
+
+	tk.MustGetErrCode(`select * from json_table('[]', '$[' columns (a int path '$')) as jt`, errno.ErrInvalidJSONPath)
+	tk.MustGetErrCode(`select * from json_table('[]', '$[*]' columns (a int path '$', a int path '$')) as jt`, errno.ErrDupFieldName)
+	tk.MustGetErrCode(`select * from json_table('[', '$[*]' columns (a int path '$')) as jt`, errno.ErrInvalidJSONText)
+}
+
+func TestJSONTableLateral(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int, j json)")
+	tk.MustExec(`insert into t values (1, '[1, 2]'), (2, '[3]'), (3, '[]'), (4, null)`)
+
+	tk.MustQuery("select t.id, jt.a from t, json_table(t.j, '$[*]' columns (a int path '$')) as jt order by t.id, jt.a").
+		Check(testkit.Rows("1 1", "1 2", "2 3"))
+	tk.MustQuery("select t.id, jt.a from t join json_table(t.j, '$[*]' columns (a int path '$')) as jt on jt.a > 1 order by t.id, jt.a").
+		Check(testkit.Rows("1 2", "2 3"))
+	tk.MustQuery("select t.id, jt.a from t left join json_table(t.j, '$[*]' columns (a int path '$')) as jt on true order by t.id, jt.a").
+		Check(testkit.Rows("1 1", "1 2", "2 3", "3 <nil>", "4 <nil>"))
+	tk.MustQuery("select t.id, (select sum(a) from json_table(t.j, '$[*]' columns (a int path '$')) as jt) from t order by t.id").
+		Check(testkit.Rows("1 3", "2 3", "3 <nil>", "4 <nil>"))
+	tk.MustGetErrCode("select * from t right join json_table(t.j, '$[*]' columns (a int path '$')) as jt on true", errno.ErrTFForbiddenJoinType)
+
+	rows := tk.MustQuery("explain format = 'brief' select t.id, jt.a from t, json_table(t.j, '$[*]' columns (a int path '$', nested path '$.b' columns (b json path '$'))) as jt").Rows()
+	var hasApply, hasJSONTable bool
+	for _, row := range rows {
+		id := row[0].(string)
+		hasApply = hasApply || strings.Contains(id, "Apply")
+		if strings.Contains(id, "JSONTable") {
+			require.Equal(t, "json_table(test.t.j, '$[*]' columns(a int path '$', nested path '$.b' columns(b json path '$')))", row[4])
+			hasJSONTable = true
+		}
+	}
+	require.True(t, hasApply)
+	require.True(t, hasJSONTable)
+}
+
+func TestJSONTableMemoryQuota(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	defer tk.MustExec("SET GLOBAL tidb_mem_oom_action = DEFAULT")
+	tk.MustExec("SET GLOBAL tidb_mem_oom_action='CANCEL'")
+	doc := "[" + strings.Repeat("1, ", 10000) + "1]"
+	sql := "select count(*) from json_table('" + doc + "', '$[*]' columns (a int path '$')) as jt"
+	tk.MustQuery(sql).Check(testkit.Rows("10001"))
+	tk.MustExec("set @@tidb_mem_quota_query=100000")
+	tk.MustContainErrMsg(sql, memory.PanicMemoryExceedWarnMsg+memory.WarnMsgSuffixForSingleQuery)
+}
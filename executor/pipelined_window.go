@@ -66,12 +66,20 @@ type PipelinedWindowExec struct {
 	orderByCols    []*expression.Column
 	// expectedCmpResult is used to decide if one value is included in the frame.
 	expectedCmpResult int64
+	// cmpFuncs is used to decide if two rows belong to the same peer group in a `Groups` frame.
+	cmpFuncs []expression.CompareFunc
+	// groupStarts keeps the first row index of the peer groups starting from the groupOffset-th one.
+	groupStarts  []uint64
+	groupOffset  uint64
+	curGroupIdx  uint64
+	groupScanned uint64
 
 	// rows keeps rows starting from curStartRow
 	rows                     []chunk.Row
 	rowCnt                   uint64
 	whole                    bool
 	isRangeFrame             bool
+	isGroupsFrame            bool
 	emptyFrame               bool
 	initializedSlidingWindow bool
 }
@@ -252,10 +260,86 @@ func (e *PipelinedWindowExec) finish() {
 	e.whole = true
 }
 
+// scanPeerGroups splits the rows fetched so far into peer groups, and moves curGroupIdx to the group of the current row.
+func (e *PipelinedWindowExec) scanPeerGroups(ctx sessionctx.Context) error {
+	for ; e.groupScanned < e.rowCnt; e.groupScanned++ {
+		if e.groupScanned == 0 {
+			e.groupStarts = append(e.groupStarts, 0)
+			continue
+		}
+		isPeer, err := isPeerRow(ctx, e.orderByCols, e.cmpFuncs, e.getRow(e.groupScanned-1), e.getRow(e.groupScanned))
+		if err != nil {
+			return err
+		}
+		if !isPeer {
+			e.groupStarts = append(e.groupStarts, e.groupScanned)
+		}
+	}
+	for e.curGroupIdx+1-e.groupOffset < uint64(len(e.groupStarts)) && e.groupStarts[e.curGroupIdx+1-e.groupOffset] <= e.curRowIdx {
+		e.curGroupIdx++
+	}
+	return nil
+}
+
+// getGroupStart returns the first row index of the idx-th peer group.
+// If the group has not been seen yet, rowCnt is returned.
+func (e *PipelinedWindowExec) getGroupStart(idx uint64) uint64 {
+	if idx-e.groupOffset < uint64(len(e.groupStarts)) {
+		return e.groupStarts[idx-e.groupOffset]
+	}
+	return e.rowCnt
+}
+
+// getGroupsFrameOffset returns the row offset of a `Groups` frame bound, isEnd means the bound is the end of the frame.
+func (e *PipelinedWindowExec) getGroupsFrameOffset(ctx sessionctx.Context, bound *core.FrameBound, isEnd bool) (uint64, error) {
+	if err := e.scanPeerGroups(ctx); err != nil {
+		return 0, err
+	}
+	var extra uint64
+	if isEnd {
+		// The end of a group is the start of the next one.
+		extra = 1
+	}
+	switch bound.Type {
+	case ast.Preceding:
+		if e.curGroupIdx >= bound.Num {
+			return e.getGroupStart(e.curGroupIdx - bound.Num + extra), nil
+		}
+		return 0, nil
+	case ast.Following:
+		// Each group has at least one row, so there are never more than rowCnt groups.
+		if bound.Num >= e.rowCnt {
+			return e.rowCnt, nil
+		}
+		return e.getGroupStart(e.curGroupIdx + bound.Num + extra), nil
+	default: // ast.CurrentRow
+		return e.getGroupStart(e.curGroupIdx + extra), nil
+	}
+}
+
+// dropPeerGroups drops the peer groups that will never be used by the following rows in the partition.
+func (e *PipelinedWindowExec) dropPeerGroups() {
+	var numPreceding uint64
+	if e.start.Type == ast.Preceding && !e.start.UnBounded {
+		numPreceding = e.start.Num
+	}
+	if e.end.Type == ast.Preceding && e.end.Num > numPreceding {
+		numPreceding = e.end.Num
+	}
+	if e.curGroupIdx > e.groupOffset+numPreceding {
+		numDrop := e.curGroupIdx - e.groupOffset - numPreceding
+		e.groupStarts = e.groupStarts[numDrop:]
+		e.groupOffset += numDrop
+	}
+}
+
 func (e *PipelinedWindowExec) getStart(ctx sessionctx.Context) (uint64, error) {
 	if e.start.UnBounded {
 		return 0, nil
 	}
+	if e.isGroupsFrame {
+		return e.getGroupsFrameOffset(ctx, e.start, false)
+	}
 	if e.isRangeFrame {
 		var start uint64
 		for start = mathutil.Max(e.lastStartRow, e.stagedStartRow); start < e.rowCnt; start++ {
@@ -296,6 +380,9 @@ func (e *PipelinedWindowExec) getEnd(ctx sessionctx.Context) (uint64, error) {
 	if e.end.UnBounded {
 		return e.rowCnt, nil
 	}
+	if e.isGroupsFrame {
+		return e.getGroupsFrameOffset(ctx, e.end, true)
+	}
 	if e.isRangeFrame {
 		var end uint64
 		for end = mathutil.Max(e.lastEndRow, e.stagedEndRow); end < e.rowCnt; end++ {
@@ -413,6 +500,13 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 		remained--
 	}
 	extend := mathutil.Min(e.curRowIdx, e.lastEndRow, e.lastStartRow)
+	if e.isGroupsFrame {
+		// The last scanned row is needed to decide whether the next row starts a new peer group.
+		if e.groupScanned > 0 {
+			extend = mathutil.Min(extend, e.groupScanned-1)
+		}
+		e.dropPeerGroups()
+	}
 	if extend > e.rowStart {
 		numDrop := extend - e.rowStart
 		e.dropped += numDrop
@@ -454,6 +548,10 @@ func (e *PipelinedWindowExec) reset() {
 	e.rows = e.rows[numDrop:]
 	e.rowStart = 0
 	e.rowCnt = 0
+	e.groupStarts = e.groupStarts[:0]
+	e.groupOffset = 0
+	e.curGroupIdx = 0
+	e.groupScanned = 0
 	e.initializedSlidingWindow = false
 	for i, windowFunc := range e.windowFuncs {
 		windowFunc.ResetPartialResult(e.partialResults[i])
@@ -132,6 +132,7 @@ type PointGetExecutor struct {
 	done             bool
 	lock             bool
 	lockWaitTime     int64
+	skipLocked       bool
 	rowDecoder       *rowcodec.ChunkDecoder
 
 	columns []*model.ColumnInfo
@@ -156,10 +157,12 @@ func (e *PointGetExecutor) Init(p *plannercore.PointGetPlan) {
 	if e.tblInfo.TempTableType == model.TempTableNone {
 		e.lock = p.Lock
 		e.lockWaitTime = p.LockWaitTime
+		e.skipLocked = p.SkipLocked
 	} else {
 		// Temporary table should not do any lock operations
 		e.lock = false
 		e.lockWaitTime = 0
+		e.skipLocked = false
 	}
 	e.rowDecoder = decoder
 	e.partInfo = p.PartitionInfo
@@ -212,15 +215,23 @@ func (e *PointGetExecutor) Close() error {
 }
 
 // Next implements the Executor interface.
-func (e *PointGetExecutor) Next(ctx context.Context, req *chunk.Chunk) error {
+func (e *PointGetExecutor) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 	req.Reset()
 	if e.done {
 		return nil
 	}
 	e.done = true
+	if e.skipLocked {
+		defer func() {
+			// The row is skipped if the lock is held by other transactions.
+			if isSkipLockedErr(err) {
+				req.Reset()
+				err = nil
+			}
+		}()
+	}
 
 	var tblID int64
-	var err error
 	if e.partInfo != nil {
 		tblID = e.partInfo.ID
 	} else {
@@ -173,7 +173,7 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 			case mysql.TypeNewDecimal:
 				s.fieldBuf = append(s.fieldBuf, row.GetMyDecimal(j).String()...)
 			case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
-				mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+				mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeGeometry:
 				s.fieldBuf = append(s.fieldBuf, row.GetBytes(j)...)
 			case mysql.TypeBit:
 				// bit value won't be escaped anyway (verified on MySQL, test case added)
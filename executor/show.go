@@ -1036,6 +1036,9 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 			if mysql.HasNotNullFlag(col.GetFlag()) {
 				buf.WriteString(" NOT NULL")
 			}
+			if srid, ok := col.FieldType.GetSRID(); ok {
+				fmt.Fprintf(buf, " /*!80003 SRID %d */", srid)
+			}
 			// default values are not shown for generated columns in MySQL
 			if !mysql.HasNoDefaultValueFlag(col.GetFlag()) && !col.IsGenerated() {
 				defaultValue := col.GetDefaultValue()
@@ -1354,6 +1357,36 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 		if err != nil {
 			return err
 		}
+
+		if len(tableInfo.TTLInfo.TimestampUnit) > 0 {
+			restoreCtx.WritePlain(" ")
+			err = restoreCtx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+				restoreCtx.WriteKeyWord("TTL_TIMESTAMP_UNIT")
+				restoreCtx.WritePlain("=")
+				restoreCtx.WriteString(tableInfo.TTLInfo.TimestampUnit)
+				return nil
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if archiveTable := tableInfo.TTLInfo.ArchiveTable; archiveTable != nil {
+			restoreCtx.WritePlain(" ")
+			err = restoreCtx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+				restoreCtx.WriteKeyWord("TTL_ARCHIVE_TABLE")
+				restoreCtx.WritePlain("=")
+				restoreCtx.WriteName(archiveTable.Schema.O)
+				restoreCtx.WritePlain(".")
+				restoreCtx.WriteName(archiveTable.Table.O)
+				return nil
+			})
+
+			if err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
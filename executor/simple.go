@@ -183,6 +183,8 @@ func (e *SimpleExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 		err = e.executeReleaseSavepoint(x)
 	case *ast.RollbackStmt:
 		err = e.executeRollback(x)
+	case *ast.XAStmt:
+		err = e.executeXA(ctx, x)
 	case *ast.CreateUserStmt:
 		err = e.executeCreateUser(ctx, x)
 	case *ast.AlterUserStmt:
@@ -2823,6 +2825,7 @@ func (e *SimpleExec) executeAdminFlushPlanCache(s *ast.AdminStmt) error {
 		// Record the timestamp. When other sessions want to use the plan cache,
 		// it will check the timestamp first to decide whether the plan cache should be flushed.
 		domain.GetDomain(e.ctx).SetExpiredTimeStamp4PC(now)
+		core.GetInstancePlanCache().DeleteAll()
 	}
 	return nil
 }
@@ -0,0 +1,87 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestSpatialColumn(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, g geometry, p point not null srid 4326, c geomcollection)")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `g` geometry DEFAULT NULL,\n" +
+		"  `p` point NOT NULL /*!80003 SRID 4326 */,\n" +
+		"  `c` geomcollection DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	tk.MustExec("insert into t values (1, st_geomfromtext('LINESTRING(0 0,1 1)'), st_geomfromtext('POINT(45 -120)', 4326), st_geomfromtext('MULTIPOINT(1 1,2 2)'))")
+	tk.MustExec("insert into t values (2, st_geomfromtext('POINT(1 1)', 4326), st_geomfromtext('POINT(-45 120)', 4326), null)")
+	tk.MustQuery("select id, st_astext(g), st_srid(g), st_astext(p), st_srid(p), st_astext(c) from t order by id").Check(testkit.Rows(
+		"1 LINESTRING(0 0,1 1) 0 POINT(45 -120) 4326 MULTIPOINT((1 1),(2 2))",
+		"2 POINT(1 1) 4326 POINT(-45 120) 4326 <nil>"))
+
+	// The value must be a geometry of the column type with the column SRID.
+	tk.MustGetErrCode("insert into t values (3, null, st_geomfromtext('POINT(1 1)'), null)", errno.ErrWrongSRIDForColumn)
+	tk.MustGetErrCode("insert into t values (3, null, st_geomfromtext('LINESTRING(0 0,1 1)', 4326), null)", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("insert into t values (3, null, st_geomfromtext('POINT(1 1)', 4326), st_geomfromtext('POINT(1 1)'))", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("insert into t values (3, 'abc', st_geomfromtext('POINT(1 1)', 4326), null)", errno.ErrCantCreateGeometryObject)
+
+	tk.MustGetErrCode("create table t1 (a int srid 0)", errno.ErrWrongUsage)
+	tk.MustGetErrCode("create table t1 (a point srid 1)", errno.ErrSRSNotFound)
+	tk.MustGetErrCode("create table t1 (a point, index idx(a))", errno.ErrBlobKeyWithoutLength)
+
+	// The type can be changed to a less restrictive one without reorganization.
+	tk.MustExec("alter table t modify p geometry not null srid 4326")
+	tk.MustGetErrCode("alter table t modify g point", errno.ErrCantCreateGeometryObject)
+	tk.MustExec("delete from t where id = 1")
+	tk.MustExec("alter table t modify g point")
+	tk.MustQuery("select id, st_astext(g) from t").Check(testkit.Rows("2 POINT(1 1)"))
+}
+
+func TestSpatialFunctions(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+
+	tk.MustQuery("select st_astext(st_geomfromtext('POINT(1 2)')), st_astext(st_geomfromtext('GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))'))").
+		Check(testkit.Rows("POINT(1 2) GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))"))
+	tk.MustQuery("select st_astext(st_geomfromtext(null)), st_srid(st_geomfromtext('POINT(1 2)', 4326))").Check(testkit.Rows("<nil> 4326"))
+	tk.MustGetErrCode("select st_geomfromtext('POINT(1)')", errno.ErrGISInvalidData)
+	tk.MustGetErrCode("select st_geomfromtext('POINT(1 2)', 1)", errno.ErrSRSNotFound)
+	tk.MustGetErrCode("select st_geomfromtext('POINT(100 0)', 4326)", errno.ErrLatitudeOutOfRange)
+	tk.MustGetErrCode("select st_astext('abc')", errno.ErrGISInvalidData)
+
+	tk.MustQuery("select st_contains(st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))'), st_geomfromtext('POINT(1 1)')), " +
+		"st_contains(st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))'), st_geomfromtext('POINT(0 5)')), " +
+		"st_within(st_geomfromtext('POINT(1 1)'), st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))')), " +
+		"st_within(st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))'), st_geomfromtext('POINT(1 1)'))").
+		Check(testkit.Rows("1 0 1 0"))
+	tk.MustGetErrCode("select st_contains(st_geomfromtext('POINT(1 1)'), st_geomfromtext('POINT(1 1)', 4326))", errno.ErrGISDifferentSRIDs)
+	tk.MustGetErrCode("select st_contains(st_geomfromtext('POINT(1 1)'), st_geomfromtext('LINESTRING(0 0,1 1)'))", errno.ErrGISUnsupportedArgument)
+
+	tk.MustQuery("select round(st_distance_sphere(st_geomfromtext('POINT(-87.6770458 41.9631174)'), st_geomfromtext('POINT(-73.9898293 40.7628267)')), 2), " +
+		"round(st_distance_sphere(st_geomfromtext('POINT(0 0)'), st_geomfromtext('POINT(180 0)'), 1), 6)").
+		Check(testkit.Rows("1148798.72 3.141593"))
+	tk.MustGetErrCode("select st_distance_sphere(st_geomfromtext('POINT(0 0)'), st_geomfromtext('POINT(1 1)'), 0)", errno.ErrNonPositiveRadius)
+	tk.MustGetErrCode("select st_distance_sphere(st_geomfromtext('POINT(0 0)'), st_geomfromtext('POINT(0 100)'))", errno.ErrLatitudeOutOfRange)
+	tk.MustGetErrCode("select st_distance_sphere(st_geomfromtext('POINT(0 0)'), st_geomfromtext('LINESTRING(0 0,1 1)'))", errno.ErrGISUnsupportedArgument)
+}
@@ -1127,6 +1127,48 @@ func TestAdminCheckPartitionTableFailed(t *testing.T) {
 	}
 }
 
+func TestAlterTableCheckPartition(t *testing.T) {
+	store, domain := testkit.CreateMockStoreAndDomain(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table admin_test_p (c1 int key, c2 int, c3 int, index idx(c2)) partition by range (c1) (partition p0 values less than (10), partition p1 values less than (20), partition p2 values less than (maxvalue))")
+	tk.MustExec("insert admin_test_p (c1, c2, c3) values (1,1,1), (5,5,5), (11,11,11), (12,12,12), (21,21,21)")
+	tk.MustExec("alter table admin_test_p check partition p0, p1")
+	tk.MustExec("alter table admin_test_p check partition all")
+
+	// Make the index of p1 corrupted.
+	ctx := mock.NewContext()
+	ctx.Store = store
+	tbl, err := domain.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("admin_test_p"))
+	require.NoError(t, err)
+	tblInfo := tbl.Meta()
+	indexOpr := tables.NewIndex(tblInfo.GetPartitionInfo().Definitions[1].ID, tblInfo, tblInfo.Indices[0])
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	err = indexOpr.Delete(ctx.GetSessionVars().StmtCtx, txn, types.MakeDatums(12), kv.IntHandle(12))
+	require.NoError(t, err)
+	require.NoError(t, txn.Commit(context.Background()))
+
+	for _, fastCheck := range []string{"ON", "OFF"} {
+		tk.MustExec("set @@tidb_enable_fast_table_check = " + fastCheck)
+		tk.MustExec("alter table admin_test_p check partition p0, p2")
+		require.Error(t, tk.ExecToErr("alter table admin_test_p check partition p1"))
+		require.Error(t, tk.ExecToErr("alter table admin_test_p check partition p2, p1"))
+		require.Error(t, tk.ExecToErr("alter table admin_test_p check partition all"))
+	}
+
+	// Rebuilding the partition rebuilds its index.
+	tk.MustExec("alter table admin_test_p rebuild partition p1")
+	tk.MustExec("alter table admin_test_p check partition p1")
+	tk.MustExec("admin check table admin_test_p")
+
+	tk.MustGetErrCode("alter table admin_test_p check partition p3", mysql.ErrUnknownPartition)
+	tk.MustExec("create table admin_test (c1 int key, c2 int, index idx(c2))")
+	tk.MustGetErrCode("alter table admin_test check partition p0", mysql.ErrPartitionMgmtOnNonpartitioned)
+	tk.MustGetErrCode("alter table admin_test_p check partition p0, add column c4 int", mysql.ErrUnsupportedDDLOperation)
+}
+
 const dbName, tblName = "test", "admin_test"
 
 type inconsistencyTestKit struct {
@@ -1015,6 +1015,7 @@ func TestSavedAnalyzeOptions(t *testing.T) {
 	require.Nil(t, h.DumpStatsDeltaToKV(handle.DumpAll))
 	require.Nil(t, h.Update(is))
 	h.HandleAutoAnalyze(is)
+	h.WaitAutoAnalyzeJobs()
 	tbl = h.GetTableStats(tableInfo)
 	require.Greater(t, tbl.Version, lastVersion)
 	lastVersion = tbl.Version
@@ -1369,6 +1370,7 @@ func TestSavedAnalyzeColumnOptions(t *testing.T) {
 	require.Nil(t, h.Update(is))
 	// auto analyze uses the saved option(predicate columns).
 	h.HandleAutoAnalyze(is)
+	h.WaitAutoAnalyzeJobs()
 	tblStats = h.GetTableStats(tblInfo)
 	require.Less(t, lastVersion, tblStats.Version)
 	lastVersion = tblStats.Version
@@ -2230,6 +2232,7 @@ func testKillAutoAnalyze(t *testing.T, ver int) {
 				}()
 			}
 			require.True(t, h.HandleAutoAnalyze(is), comment)
+			h.WaitAutoAnalyzeJobs()
 			currentVersion := h.GetTableStats(tableInfo).Version
 			if status == "finished" {
 				// If we kill a finished job, after kill command the status is still finished and the table stats are updated.
@@ -2303,6 +2306,7 @@ func TestKillAutoAnalyzeIndex(t *testing.T) {
 				}()
 			}
 			require.True(t, h.HandleAutoAnalyze(dom.InfoSchema()), comment)
+			h.WaitAutoAnalyzeJobs()
 			currentVersion := h.GetTableStats(tblInfo).Version
 			if status == "finished" {
 				// If we kill a finished job, after kill command the status is still finished and the index stats are updated.
@@ -2913,6 +2917,7 @@ PARTITION BY RANGE ( id ) (
 	}()
 	is := dom.InfoSchema()
 	h.HandleAutoAnalyze(is)
+	h.WaitAutoAnalyzeJobs()
 	tk.MustExec("create index idxa on t (a)")
 	tk.MustExec("create index idxb on t (b)")
 	table, err := is.TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
@@ -2948,6 +2953,7 @@ PARTITION BY RANGE ( id ) (
 	}()
 	is := dom.InfoSchema()
 	h.HandleAutoAnalyze(is)
+	h.WaitAutoAnalyzeJobs()
 	tk.MustExec("alter table t add column a int")
 	tk.MustExec("alter table t add column b int")
 	table, err := is.TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
@@ -3043,6 +3049,7 @@ func TestAutoAnalyzeAwareGlobalVariableChange(t *testing.T) {
 	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/executor/injectBaseCount", "return(3)"))
 	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/executor/injectBaseModifyCount", "return(0)"))
 	require.True(t, h.HandleAutoAnalyze(dom.InfoSchema()))
+	h.WaitAutoAnalyzeJobs()
 	// Check the count / modify_count changes during the analyze are not lost.
 	tk.MustQuery(fmt.Sprintf("select count, modify_count from mysql.stats_meta where table_id = %d", tid)).Check(testkit.Rows(
 		"6 3",
@@ -3202,6 +3209,7 @@ func TestGlobalMemoryControlForAutoAnalyze(t *testing.T) {
 	require.Len(t, childTrackers, 0)
 
 	h.HandleAutoAnalyze(dom.InfoSchema())
+	h.WaitAutoAnalyzeJobs()
 	rs := tk.MustQuery("select fail_reason from mysql.analyze_jobs where table_name=? and state=? limit 1", "t", "failed")
 	failReason := rs.Rows()[0][0].(string)
 	require.True(t, strings.Contains(failReason, memory.PanicMemoryExceedWarnMsg+memory.WarnMsgSuffixForInstance))
@@ -3271,5 +3279,6 @@ func TestAutoAnalyzeSkipColumnTypes(t *testing.T) {
 		handle.AutoAnalyzeMinCnt = originalVal
 	}()
 	require.True(t, h.HandleAutoAnalyze(dom.InfoSchema()))
+	h.WaitAutoAnalyzeJobs()
 	tk.MustQuery("select job_info from mysql.analyze_jobs where job_info like '%auto analyze table%'").Check(testkit.Rows("auto analyze table columns a, b, d with 256 buckets, 500 topn, 1 samplerate"))
 }
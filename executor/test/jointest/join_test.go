@@ -24,6 +24,7 @@ import (
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/config"
+	"github.com/pingcap/tidb/errno"
 	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/session"
 	"github.com/pingcap/tidb/sessionctx/variable"
@@ -1113,6 +1114,49 @@ func TestSubqueryInJoinOn(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestLateralDerivedTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2")
+	tk.MustExec("create table t1 (a int primary key, b int)")
+	tk.MustExec("create table t2 (a int, b int)")
+	tk.MustExec("insert into t1 values (1, 10), (2, 20), (3, 30)")
+	tk.MustExec("insert into t2 values (1, 1), (1, 2), (1, 3), (2, 4)")
+
+	// top-N per group
+	sql := "select t1.a, dt.b from t1, lateral (select t2.b from t2 where t2.a = t1.a order by t2.b desc limit 2) dt order by t1.a, dt.b"
+	tk.MustQuery(sql).Check(testkit.Rows("1 2", "1 3", "2 4"))
+	require.True(t, tk.HasPlan(sql, "Apply"))
+	tk.MustQuery("select t1.a, dt.b from t1 left join lateral (select t2.b from t2 where t2.a = t1.a order by t2.b desc limit 2) dt on true order by t1.a, dt.b").
+		Check(testkit.Rows("1 2", "1 3", "2 4", "3 <nil>"))
+	tk.MustQuery("select t1.a, dt.b from t1 join lateral (select t2.b from t2 where t2.a = t1.a order by t2.b limit 2) dt on dt.b > 1 order by t1.a, dt.b").
+		Check(testkit.Rows("1 2", "2 4"))
+	tk.MustQuery("select t1.a, x.b, dt.c from t1, t2 x, lateral (select t1.b + x.b as c) dt where t1.a = x.a order by t1.a, x.b").
+		Check(testkit.Rows("1 1 11", "1 2 12", "1 3 13", "2 4 24"))
+
+	// decorrelated into joins
+	sql = "select t1.a, dt.b from t1, lateral (select t2.b from t2 where t2.a = t1.a) dt order by t1.a, dt.b"
+	tk.MustQuery(sql).Check(testkit.Rows("1 1", "1 2", "1 3", "2 4"))
+	require.False(t, tk.HasPlan(sql, "Apply"))
+	sql = "select t1.a, dt.c from t1, lateral (select count(*) c from t2 where t2.a = t1.a) dt order by t1.a"
+	tk.MustQuery(sql).Check(testkit.Rows("1 3", "2 1", "3 0"))
+	require.False(t, tk.HasPlan(sql, "Apply"))
+	sql = "select t1.a, dt.s from t1, lateral (select sum(t2.b) s from t2 where t2.a = t1.a) dt order by t1.a"
+	tk.MustQuery(sql).Check(testkit.Rows("1 6", "2 4", "3 <nil>"))
+	require.False(t, tk.HasPlan(sql, "Apply"))
+	tk.MustQuery("select t1.a, dt.c from t1 left join lateral (select count(*) c from t2 where t2.a = t1.a) dt on dt.c > 0 order by t1.a").
+		Check(testkit.Rows("1 3", "2 1", "3 <nil>"))
+	tk.MustQuery("select t1.a, dt.c from t1 join lateral (select count(*) c from t2 where t2.a = t1.a) dt on dt.c > 1 order by t1.a").
+		Check(testkit.Rows("1 3"))
+	tk.MustQuery("select t1.a, dt.c from t1, lateral (select 1 c) dt order by t1.a").Check(testkit.Rows("1 1", "2 1", "3 1"))
+
+	err := tk.ExecToErr("select * from t1 right join lateral (select t2.b from t2 where t2.a = t1.a) dt on true")
+	require.True(t, plannercore.ErrTFForbiddenJoinType.Equal(err))
+	tk.MustGetErrCode("select * from t1, (select t1.b) dt", errno.ErrBadField)
+	tk.MustGetErrCode("select * from t1, lateral (select t1.b)", errno.ErrDerivedMustHaveAlias)
+}
+
 func TestIssue5255(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -531,3 +531,188 @@ func (p *rangeFrameWindowProcessor) resetPartialResult() {
 	p.lastStartOffset = 0
 	p.lastEndOffset = 0
 }
+
+type groupsFrameWindowProcessor struct {
+	windowFuncs    []aggfuncs.AggFunc
+	partialResults []aggfuncs.PartialResult
+	start          *core.FrameBound
+	end            *core.FrameBound
+	curRowIdx      uint64
+	curGroupIdx    uint64
+	orderByCols    []*expression.Column
+	cmpFuncs       []expression.CompareFunc
+	// groupStarts stores the first row index of each peer group in the current partition,
+	// the number of rows is appended at last, so the end of group `i` is `groupStarts[i+1]`.
+	groupStarts []uint64
+}
+
+// isPeerRow checks whether two rows are equal on all the order by columns, which means they belong to the same peer group.
+func isPeerRow(ctx sessionctx.Context, orderByCols []*expression.Column, cmpFuncs []expression.CompareFunc, lhs, rhs chunk.Row) (bool, error) {
+	for i, col := range orderByCols {
+		res, _, err := cmpFuncs[i](ctx, col, col, lhs, rhs)
+		if err != nil {
+			return false, err
+		}
+		if res != 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (p *groupsFrameWindowProcessor) splitIntoPeerGroups(ctx sessionctx.Context, rows []chunk.Row) error {
+	p.groupStarts = append(p.groupStarts, 0)
+	for i := 1; i < len(rows); i++ {
+		isPeer, err := isPeerRow(ctx, p.orderByCols, p.cmpFuncs, rows[i-1], rows[i])
+		if err != nil {
+			return err
+		}
+		if !isPeer {
+			p.groupStarts = append(p.groupStarts, uint64(i))
+		}
+	}
+	p.groupStarts = append(p.groupStarts, uint64(len(rows)))
+	return nil
+}
+
+func (p *groupsFrameWindowProcessor) getStartOffset(numRows uint64) uint64 {
+	if p.start.UnBounded {
+		return 0
+	}
+	numGroups := uint64(len(p.groupStarts)) - 1
+	switch p.start.Type {
+	case ast.Preceding:
+		if p.curGroupIdx >= p.start.Num {
+			return p.groupStarts[p.curGroupIdx-p.start.Num]
+		}
+		return 0
+	case ast.Following:
+		if p.start.Num >= numGroups-p.curGroupIdx {
+			return numRows
+		}
+		return p.groupStarts[p.curGroupIdx+p.start.Num]
+	case ast.CurrentRow:
+		return p.groupStarts[p.curGroupIdx]
+	}
+	// It will never reach here.
+	return 0
+}
+
+func (p *groupsFrameWindowProcessor) getEndOffset(numRows uint64) uint64 {
+	if p.end.UnBounded {
+		return numRows
+	}
+	numGroups := uint64(len(p.groupStarts)) - 1
+	switch p.end.Type {
+	case ast.Preceding:
+		if p.curGroupIdx >= p.end.Num {
+			return p.groupStarts[p.curGroupIdx-p.end.Num+1]
+		}
+		return 0
+	case ast.Following:
+		if p.end.Num >= numGroups-p.curGroupIdx {
+			return numRows
+		}
+		return p.groupStarts[p.curGroupIdx+p.end.Num+1]
+	case ast.CurrentRow:
+		return p.groupStarts[p.curGroupIdx+1]
+	}
+	// It will never reach here.
+	return 0
+}
+
+func (p *groupsFrameWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
+	// consumeGroupRows may be called several times for the same partition, the peer groups only need to be built once.
+	if len(p.groupStarts) == 0 {
+		if err := p.splitIntoPeerGroups(ctx, rows); err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
+func (p *groupsFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+	numRows := uint64(len(rows))
+	var (
+		err                      error
+		initializedSlidingWindow bool
+		start                    uint64
+		end                      uint64
+		lastStart                uint64
+		lastEnd                  uint64
+		shiftStart               uint64
+		shiftEnd                 uint64
+	)
+	slidingWindowAggFuncs := make([]aggfuncs.SlidingWindowAggFunc, len(p.windowFuncs))
+	for i, windowFunc := range p.windowFuncs {
+		if slidingWindowAggFunc, ok := windowFunc.(aggfuncs.SlidingWindowAggFunc); ok {
+			slidingWindowAggFuncs[i] = slidingWindowAggFunc
+		}
+	}
+	for ; remained > 0; lastStart, lastEnd = start, end {
+		for p.groupStarts[p.curGroupIdx+1] <= p.curRowIdx {
+			p.curGroupIdx++
+		}
+		start = p.getStartOffset(numRows)
+		end = p.getEndOffset(numRows)
+		p.curRowIdx++
+		remained--
+		shiftStart = start - lastStart
+		shiftEnd = end - lastEnd
+		if start >= end {
+			for i, windowFunc := range p.windowFuncs {
+				slidingWindowAggFunc := slidingWindowAggFuncs[i]
+				if slidingWindowAggFunc != nil && initializedSlidingWindow {
+					err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
+						return rows[u]
+					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					if err != nil {
+						return nil, err
+					}
+				}
+				err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
+				if err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		for i, windowFunc := range p.windowFuncs {
+			slidingWindowAggFunc := slidingWindowAggFuncs[i]
+			if slidingWindowAggFunc != nil && initializedSlidingWindow {
+				err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
+					return rows[u]
+				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+			} else {
+				if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
+					minMaxSlidingWindowAggFunc.SetWindowStart(start)
+				}
+				_, err = windowFunc.UpdatePartialResult(ctx, rows[start:end], p.partialResults[i])
+			}
+			if err != nil {
+				return nil, err
+			}
+			err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
+			if err != nil {
+				return nil, err
+			}
+			if slidingWindowAggFunc == nil {
+				windowFunc.ResetPartialResult(p.partialResults[i])
+			}
+		}
+		if !initializedSlidingWindow {
+			initializedSlidingWindow = true
+		}
+	}
+	for i, windowFunc := range p.windowFuncs {
+		windowFunc.ResetPartialResult(p.partialResults[i])
+	}
+	return rows, nil
+}
+
+func (p *groupsFrameWindowProcessor) resetPartialResult() {
+	p.curRowIdx = 0
+	p.curGroupIdx = 0
+	p.groupStarts = p.groupStarts[:0]
+}
@@ -217,6 +217,42 @@ func doTestWindowFunctions(tk *testkit.TestKit) {
 	tk.MustQuery("select row_number() over w, sum(b) over w from t window w as (rows between 1 preceding and 1 following)").
 		Check(testkit.Rows("1 3", "2 4", "3 5", "4 3"))
 
+	tk.MustExec("drop table if exists t_null")
+	tk.MustExec("create table t_null(id int, v int)")
+	tk.MustExec("insert into t_null values (1, null), (2, 10), (3, null), (4, 20), (5, 10), (6, null)")
+	tk.MustQuery("select id, first_value(v) ignore nulls over w, last_value(v) ignore nulls over w from t_null window w as (order by id rows between unbounded preceding and current row) order by id").
+		Check(testkit.Rows("1 <nil> <nil>", "2 10 10", "3 10 10", "4 10 20", "5 10 10", "6 10 10"))
+	tk.MustQuery("select id, nth_value(v, 2) ignore nulls over (order by id rows between unbounded preceding and current row) from t_null order by id").
+		Check(testkit.Rows("1 <nil>", "2 <nil>", "3 <nil>", "4 20", "5 20", "6 20"))
+	tk.MustQuery("select id, nth_value(v, 2) from last over w, nth_value(v, 2) from last ignore nulls over w from t_null window w as (order by id rows between unbounded preceding and unbounded following) order by id").
+		Check(testkit.Rows("1 10 20", "2 10 20", "3 10 20", "4 10 20", "5 10 20", "6 10 20"))
+	tk.MustQuery("select id, nth_value(v, 1) from last ignore nulls over (order by id rows between 1 preceding and 1 following) from t_null order by id").
+		Check(testkit.Rows("1 10", "2 10", "3 20", "4 10", "5 10", "6 10"))
+	tk.MustQuery("select id, lead(v) ignore nulls over w, lag(v, 1, -1) ignore nulls over w from t_null window w as (order by id) order by id").
+		Check(testkit.Rows("1 10 -1", "2 20 -1", "3 20 10", "4 10 10", "5 <nil> 20", "6 <nil> 10"))
+	tk.MustQuery("select id, count(distinct v) over (), sum(distinct v) over (order by id rows between unbounded preceding and current row) from t_null order by id").
+		Check(testkit.Rows("1 2 <nil>", "2 2 10", "3 2 10", "4 2 30", "5 2 30", "6 2 30"))
+	tk.MustQuery("select id, count(distinct v) over (order by id rows between 1 preceding and 1 following) from t_null order by id").
+		Check(testkit.Rows("1 1", "2 1", "3 2", "4 2", "5 2", "6 1"))
+
+	tk.MustExec("drop table if exists t_groups")
+	tk.MustExec("create table t_groups(d date, v int)")
+	tk.MustExec("insert into t_groups values (20190201, 1), (20190201, 2), (20190202, 3), (20190204, 4), (20190204, 5), (20190205, 6), (null, 7)")
+	tk.MustQuery("select d, v, sum(v) over (order by d groups between 2 preceding and current row) from t_groups order by d, v").
+		Check(testkit.Rows("<nil> 7 7", "2019-02-01 1 10", "2019-02-01 2 10", "2019-02-02 3 13", "2019-02-04 4 15", "2019-02-04 5 15", "2019-02-05 6 18"))
+	tk.MustQuery("select d, v, count(v) over (order by d desc groups between 1 preceding and 1 following) from t_groups order by d, v").
+		Check(testkit.Rows("<nil> 7 3", "2019-02-01 1 4", "2019-02-01 2 4", "2019-02-02 3 5", "2019-02-04 4 4", "2019-02-04 5 4", "2019-02-05 6 3"))
+	tk.MustQuery("select d, v, max(v) over (order by d groups between 1 following and 2 following) from t_groups order by d, v").
+		Check(testkit.Rows("<nil> 7 3", "2019-02-01 1 5", "2019-02-01 2 5", "2019-02-02 3 6", "2019-02-04 4 6", "2019-02-04 5 6", "2019-02-05 6 <nil>"))
+	tk.MustQuery("select v, sum(v) over (partition by v % 2 order by d groups between unbounded preceding and 1 preceding) from t_groups order by v").
+		Check(testkit.Rows("1 7", "2 <nil>", "3 8", "4 2", "5 11", "6 6", "7 <nil>"))
+	tk.MustQuery("select v, group_concat(v order by v desc separator '') over (partition by v % 2) from t_groups order by v").
+		Check(testkit.Rows("1 7531", "2 642", "3 7531", "4 642", "5 7531", "6 642", "7 7531"))
+	tk.MustQuery("select v, group_concat(distinct v % 3 order by 1 desc) over (order by v rows between 1 preceding and 1 following) from t_groups order by v").
+		Check(testkit.Rows("1 2,1", "2 2,1,0", "3 2,1,0", "4 2,1,0", "5 2,1,0", "6 2,1,0", "7 1,0"))
+	tk.MustQuery("select v, group_concat(d) over (order by v rows between 1 following and 2 following) from t_groups order by v").
+		Check(testkit.Rows("1 2019-02-01,2019-02-02", "2 2019-02-02,2019-02-04", "3 2019-02-04,2019-02-04", "4 2019-02-04,2019-02-05", "5 2019-02-05", "6 <nil>", "7 <nil>"))
+
 	tk.Session().GetSessionVars().MaxChunkSize = 1
 	tk.MustQuery("select a, row_number() over (partition by a) from t").Sort().
 		Check(testkit.Rows("1 1", "1 2", "2 1", "2 2"))
@@ -449,6 +485,30 @@ func baseTestSlidingWindowFunctions(tk *testkit.TestKit) {
 		Check(testkit.Rows("<nil> 11", "<nil> 11", "M 5", "F 5", "F 4", "F 3", "M 2"))
 }
 
+func TestGroupConcatSlidingWindow(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int, a varchar(10), b varchar(10))")
+	tk.MustExec("insert into t values (1, 'a', 'x'), (2, null, 'y'), (3, 'ccc', 'z'), (4, 'dd', null), (5, 'e', 'w')")
+
+	for _, pipelined := range []int{0, 1} {
+		tk.MustExec(fmt.Sprintf("set @@tidb_enable_pipelined_window_function = %d", pipelined))
+		tk.MustQuery("select id, group_concat(a) over (order by id rows between 1 preceding and 1 following) from t").
+			Check(testkit.Rows("1 a", "2 a,ccc", "3 ccc,dd", "4 ccc,dd,e", "5 dd,e"))
+		tk.MustQuery("select id, group_concat(a, b separator '-') over (order by id rows between 2 preceding and current row) from t").
+			Check(testkit.Rows("1 ax", "2 ax", "3 ax-cccz", "4 cccz", "5 cccz-ew"))
+		tk.MustQuery("select id, group_concat(a separator '') over (order by id rows between 2 following and 3 following) from t").
+			Check(testkit.Rows("1 cccdd", "2 dde", "3 e", "4 <nil>", "5 <nil>"))
+
+		tk.MustExec("set @@group_concat_max_len = 4")
+		tk.MustQuery("select id, group_concat(a) over (order by id rows between 1 preceding and 1 following) from t").
+			Check(testkit.Rows("1 a", "2 a,cc", "3 ccc,", "4 ccc,", "5 dd,e"))
+		tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1260 Some rows were cut by GROUPCONCAT(test.t.a)"))
+		tk.MustExec("set @@group_concat_max_len = default")
+	}
+}
+
 func TestIssue24264(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
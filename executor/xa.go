@@ -0,0 +1,99 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessiontxn"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+func getXAController(e *baseExecutor) (sessiontxn.XAController, error) {
+	xa, ok := e.ctx.(sessiontxn.XAController)
+	if !ok {
+		return nil, errors.New("XA transactions are not supported in the session")
+	}
+	return xa, nil
+}
+
+func (e *SimpleExec) executeXA(ctx context.Context, s *ast.XAStmt) error {
+	xa, err := getXAController(&e.baseExecutor)
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	switch s.Tp {
+	case ast.XAStart:
+		return xa.XAStart(ctx, s.XID)
+	case ast.XAEnd:
+		return xa.XAEnd(s.XID)
+	case ast.XAPrepare:
+		return xa.XAPrepare(ctx, s.XID)
+	case ast.XACommit:
+		return xa.XACommit(ctx, s.XID, s.OnePhase)
+	case ast.XARollback:
+		return xa.XARollback(ctx, s.XID)
+	default:
+		return errors.Errorf("unexpected XA statement type: %d", s.Tp)
+	}
+}
+
+// xaRecoverExec executes XA RECOVER, it shows the prepared XA transaction branches.
+type xaRecoverExec struct {
+	baseExecutor
+	convertXID bool
+	done       bool
+}
+
+// Next implements the Executor Next interface.
+func (e *xaRecoverExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+
+	xa, err := getXAController(&e.baseExecutor)
+	if err != nil {
+		return err
+	}
+	// Only the users with XA_RECOVER_ADMIN privilege can see the branches prepared by other users.
+	allUsers := true
+	if pm := privilege.GetPrivilegeManager(e.ctx); pm != nil {
+		allUsers = pm.RequestDynamicVerification(e.ctx.GetSessionVars().ActiveRoles, "XA_RECOVER_ADMIN", false)
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	xids, err := xa.XARecover(ctx, allUsers)
+	if err != nil {
+		return err
+	}
+	for _, xid := range xids {
+		req.AppendInt64(0, int64(xid.FormatID))
+		req.AppendInt64(1, int64(len(xid.GTRID)))
+		req.AppendInt64(2, int64(len(xid.BQUAL)))
+		if e.convertXID {
+			req.AppendString(3, "0x"+hex.EncodeToString([]byte(xid.GTRID+xid.BQUAL)))
+		} else {
+			req.AppendString(3, xid.GTRID+xid.BQUAL)
+		}
+	}
+	return nil
+}
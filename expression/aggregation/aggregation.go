@@ -200,6 +200,10 @@ func CheckAggPushDown(aggFunc *AggFuncDesc, storeType kv.StoreType) bool {
 	if aggFunc.Name == ast.AggFuncApproxPercentile {
 		return false
 	}
+	// the extension aggregate functions are only implemented in TiDB
+	if _, ok := expression.GetExtensionAggFunc(aggFunc.Name); ok {
+		return false
+	}
 	ret := true
 	switch storeType {
 	case kv.TiFlash:
@@ -22,6 +22,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/extension"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/mysql"
@@ -122,6 +123,9 @@ func (a *baseFuncDesc) TypeInfer(ctx sessionctx.Context) error {
 	case ast.AggFuncJsonObjectAgg:
 		return a.typeInfer4JsonObjectAgg(ctx)
 	default:
+		if def, ok := expression.GetExtensionAggFunc(a.Name); ok {
+			return a.typeInfer4ExtensionFunc(ctx, def)
+		}
 		return errors.Errorf("unsupported agg function: %s", a.Name)
 	}
 	return nil
@@ -303,6 +307,62 @@ func (a *baseFuncDesc) typeInfer4JsonObjectAgg(ctx sessionctx.Context) error {
 	return nil
 }
 
+// typeInfer4ExtensionFunc infers the return type by the `EvalTp` of the extension aggregate function. The args are
+// cast to the `ArgTps` in WrapCastForAggArgs.
+func (a *baseFuncDesc) typeInfer4ExtensionFunc(ctx sessionctx.Context, def *extension.AggFunctionDef) error {
+	if len(a.Args) != len(def.ArgTps) {
+		return expression.ErrIncorrectParameterCount.GenWithStackByArgs(a.Name)
+	}
+	if err := expression.CheckExtensionAggFuncPrivileges(ctx, def); err != nil {
+		return err
+	}
+
+	switch def.EvalTp {
+	case types.ETInt:
+		a.RetTp = types.NewFieldType(mysql.TypeLonglong)
+		a.RetTp.SetFlen(mysql.MaxIntWidth)
+		types.SetBinChsClnFlag(a.RetTp)
+	case types.ETReal:
+		a.RetTp = types.NewFieldType(mysql.TypeDouble)
+		a.RetTp.SetFlen(mysql.MaxRealWidth)
+		a.RetTp.SetDecimal(types.UnspecifiedLength)
+		types.SetBinChsClnFlag(a.RetTp)
+	case types.ETDecimal:
+		a.RetTp = types.NewFieldType(mysql.TypeNewDecimal)
+		a.RetTp.SetFlen(mysql.MaxDecimalWidth)
+		a.RetTp.SetDecimal(types.UnspecifiedLength)
+		types.SetBinChsClnFlag(a.RetTp)
+	case types.ETString:
+		a.RetTp = types.NewFieldType(mysql.TypeVarString)
+		charset, collate := charset.GetDefaultCharsetAndCollate()
+		a.RetTp.SetCharset(charset)
+		a.RetTp.SetCollate(collate)
+		a.RetTp.SetFlen(mysql.MaxFieldVarCharLength)
+		a.RetTp.SetDecimal(types.UnspecifiedLength)
+	case types.ETDatetime, types.ETTimestamp:
+		tp := mysql.TypeDatetime
+		if def.EvalTp == types.ETTimestamp {
+			tp = mysql.TypeTimestamp
+		}
+		a.RetTp = types.NewFieldType(tp)
+		a.RetTp.SetFlen(mysql.MaxDatetimeWidthWithFsp)
+		a.RetTp.SetDecimal(types.MaxFsp)
+		types.SetBinChsClnFlag(a.RetTp)
+	case types.ETDuration:
+		a.RetTp = types.NewFieldType(mysql.TypeDuration)
+		a.RetTp.SetFlen(mysql.MaxDurationWidthWithFsp)
+		a.RetTp.SetDecimal(types.MaxFsp)
+		types.SetBinChsClnFlag(a.RetTp)
+	case types.ETJson:
+		a.RetTp = types.NewFieldType(mysql.TypeJSON)
+		a.RetTp.SetFlen(mysql.MaxBlobWidth)
+		types.SetBinChsClnFlag(a.RetTp)
+	default:
+		return errors.Errorf("unsupported extension function ret type: '%v'", def.EvalTp)
+	}
+	return nil
+}
+
 func (a *baseFuncDesc) typeInfer4NumberFuncs() {
 	a.RetTp = types.NewFieldType(mysql.TypeLonglong)
 	a.RetTp.SetFlen(21)
@@ -400,6 +460,10 @@ func (a *baseFuncDesc) WrapCastForAggArgs(ctx sessionctx.Context) {
 	if _, ok := noNeedCastAggFuncs[a.Name]; ok {
 		return
 	}
+	if def, ok := expression.GetExtensionAggFunc(a.Name); ok {
+		a.wrapCastForExtensionFuncArgs(ctx, def)
+		return
+	}
 	var castFunc func(ctx sessionctx.Context, expr expression.Expression) expression.Expression
 	switch retTp := a.RetTp; retTp.EvalType() {
 	case types.ETInt:
@@ -433,6 +497,30 @@ func (a *baseFuncDesc) WrapCastForAggArgs(ctx sessionctx.Context) {
 	}
 }
 
+// wrapCastForExtensionFuncArgs wraps the args of an extension aggregate function with the cast functions to its `ArgTps`.
+func (a *baseFuncDesc) wrapCastForExtensionFuncArgs(ctx sessionctx.Context, def *extension.AggFunctionDef) {
+	for i := range a.Args {
+		switch def.ArgTps[i] {
+		case types.ETInt:
+			a.Args[i] = expression.WrapWithCastAsInt(ctx, a.Args[i])
+		case types.ETReal:
+			a.Args[i] = expression.WrapWithCastAsReal(ctx, a.Args[i])
+		case types.ETDecimal:
+			a.Args[i] = expression.WrapWithCastAsDecimal(ctx, a.Args[i])
+		case types.ETString:
+			a.Args[i] = expression.WrapWithCastAsString(ctx, a.Args[i])
+		case types.ETDatetime:
+			a.Args[i] = expression.WrapWithCastAsTime(ctx, a.Args[i], types.NewFieldType(mysql.TypeDatetime))
+		case types.ETTimestamp:
+			a.Args[i] = expression.WrapWithCastAsTime(ctx, a.Args[i], types.NewFieldType(mysql.TypeTimestamp))
+		case types.ETDuration:
+			a.Args[i] = expression.WrapWithCastAsDuration(ctx, a.Args[i])
+		case types.ETJson:
+			a.Args[i] = expression.WrapWithCastAsJSON(ctx, a.Args[i])
+		}
+	}
+}
+
 // MemoryUsage return the memory usage of baseFuncDesc
 func (a *baseFuncDesc) MemoryUsage() (sum int64) {
 	if a == nil {
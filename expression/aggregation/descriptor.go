@@ -44,6 +44,10 @@ type AggFuncDesc struct {
 	OrderByItems []*util.ByItems
 	// GroupingID is used for distinguishing with not-set 0, starting from 1.
 	GroupingID int
+	// IgnoreNull represents whether the window function skips NULL values (IGNORE NULLS).
+	IgnoreNull bool
+	// FromLast represents whether NTH_VALUE counts rows from the end of the frame (FROM LAST).
+	FromLast bool
 }
 
 // NewAggFuncDesc creates an aggregation function signature descriptor.
@@ -59,9 +63,21 @@ func NewAggFuncDesc(ctx sessionctx.Context, name string, args []expression.Expre
 // NewAggFuncDescForWindowFunc creates an aggregation function from window functions, where baseFuncDesc may be ready.
 func NewAggFuncDescForWindowFunc(ctx sessionctx.Context, Desc *WindowFuncDesc, hasDistinct bool) (*AggFuncDesc, error) {
 	if Desc.RetTp == nil { // safety check
-		return NewAggFuncDesc(ctx, Desc.Name, Desc.Args, hasDistinct)
+		aggDesc, err := NewAggFuncDesc(ctx, Desc.Name, Desc.Args, hasDistinct)
+		if err != nil {
+			return nil, err
+		}
+		aggDesc.IgnoreNull, aggDesc.FromLast = Desc.IgnoreNull, Desc.FromLast
+		aggDesc.OrderByItems = Desc.OrderByItems
+		return aggDesc, nil
 	}
-	return &AggFuncDesc{baseFuncDesc: baseFuncDesc{Desc.Name, Desc.Args, Desc.RetTp}, HasDistinct: hasDistinct}, nil
+	return &AggFuncDesc{
+		baseFuncDesc: baseFuncDesc{Desc.Name, Desc.Args, Desc.RetTp},
+		HasDistinct:  hasDistinct,
+		OrderByItems: Desc.OrderByItems,
+		IgnoreNull:   Desc.IgnoreNull,
+		FromLast:     Desc.FromLast,
+	}, nil
 }
 
 // String implements the fmt.Stringer interface.
@@ -92,7 +108,7 @@ func (a *AggFuncDesc) String() string {
 
 // Equal checks whether two aggregation function signatures are equal.
 func (a *AggFuncDesc) Equal(ctx sessionctx.Context, other *AggFuncDesc) bool {
-	if a.HasDistinct != other.HasDistinct {
+	if a.HasDistinct != other.HasDistinct || a.IgnoreNull != other.IgnoreNull || a.FromLast != other.FromLast {
 		return false
 	}
 	if len(a.OrderByItems) != len(other.OrderByItems) {
@@ -327,7 +343,10 @@ func (a *AggFuncDesc) UpdateNotNullFlag4RetType(hasGroupBy, allAggsFirstRow bool
 			removeNotNull = true
 		}
 	default:
-		return errors.Errorf("unsupported agg function: %s", a.Name)
+		if _, ok := expression.GetExtensionAggFunc(a.Name); !ok {
+			return errors.Errorf("unsupported agg function: %s", a.Name)
+		}
+		// the return type of the extension aggregate functions is always nullable
 	}
 	if removeNotNull {
 		a.RetTp = a.RetTp.Clone()
@@ -342,7 +361,7 @@ func (a *AggFuncDesc) MemoryUsage() (sum int64) {
 		return
 	}
 
-	sum = a.baseFuncDesc.MemoryUsage() + size.SizeOfInt + size.SizeOfBool
+	sum = a.baseFuncDesc.MemoryUsage() + size.SizeOfInt + size.SizeOfBool*3
 	for _, item := range a.OrderByItems {
 		sum += item.MemoryUsage()
 	}
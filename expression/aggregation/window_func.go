@@ -15,18 +15,29 @@
 package aggregation
 
 import (
+	"bytes"
 	"strings"
 
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/planner/util"
 	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/util/size"
 	"github.com/pingcap/tipb/go-tipb"
 )
 
 // WindowFuncDesc describes a window function signature, only used in planner.
 type WindowFuncDesc struct {
 	baseFuncDesc
+	// HasDistinct represents whether the aggregate window function only consumes distinct values.
+	HasDistinct bool
+	// IgnoreNull represents whether the value window function skips NULL values (IGNORE NULLS).
+	IgnoreNull bool
+	// FromLast represents whether NTH_VALUE counts rows from the end of the frame (FROM LAST).
+	FromLast bool
+	// OrderByItems represents the order by clause used in GROUP_CONCAT.
+	OrderByItems []*util.ByItems
 }
 
 // NewWindowFuncDesc creates a window function signature descriptor.
@@ -61,7 +72,7 @@ func NewWindowFuncDesc(ctx sessionctx.Context, name string, args []expression.Ex
 	if err != nil {
 		return nil, err
 	}
-	return &WindowFuncDesc{base}, nil
+	return &WindowFuncDesc{baseFuncDesc: base}, nil
 }
 
 // noFrameWindowFuncs is the functions that operate on the entire partition,
@@ -101,7 +112,63 @@ func NeedFrame(name string) bool {
 
 // Clone makes a copy of SortItem.
 func (s *WindowFuncDesc) Clone() *WindowFuncDesc {
-	return &WindowFuncDesc{*s.baseFuncDesc.clone()}
+	clone := *s
+	clone.baseFuncDesc = *s.baseFuncDesc.clone()
+	clone.OrderByItems = make([]*util.ByItems, len(s.OrderByItems))
+	for i, byItem := range s.OrderByItems {
+		clone.OrderByItems[i] = byItem.Clone()
+	}
+	return &clone
+}
+
+// String implements the fmt.Stringer interface.
+func (s *WindowFuncDesc) String() string {
+	buffer := bytes.NewBufferString(s.Name)
+	buffer.WriteString("(")
+	if s.HasDistinct {
+		buffer.WriteString("distinct ")
+	}
+	for i, arg := range s.Args {
+		if s.Name == ast.AggFuncGroupConcat && i == len(s.Args)-1 {
+			if len(s.OrderByItems) > 0 {
+				buffer.WriteString(" order by ")
+			}
+			for j, item := range s.OrderByItems {
+				buffer.WriteString(item.Expr.String())
+				if item.Desc {
+					buffer.WriteString(" desc")
+				}
+				if j+1 != len(s.OrderByItems) {
+					buffer.WriteString(", ")
+				}
+			}
+			buffer.WriteString(" separator ")
+		} else if i != 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteString(arg.String())
+	}
+	buffer.WriteString(")")
+	if s.FromLast {
+		buffer.WriteString(" from last")
+	}
+	if s.IgnoreNull {
+		buffer.WriteString(" ignore nulls")
+	}
+	return buffer.String()
+}
+
+// MemoryUsage the memory usage of WindowFuncDesc
+func (s *WindowFuncDesc) MemoryUsage() (sum int64) {
+	if s == nil {
+		return
+	}
+
+	sum = s.baseFuncDesc.MemoryUsage() + size.SizeOfBool*3 + size.SizeOfSlice
+	for _, item := range s.OrderByItems {
+		sum += item.MemoryUsage()
+	}
+	return
 }
 
 // WindowFuncToPBExpr converts aggregate function to pb.
@@ -125,6 +192,10 @@ func WindowFuncToPBExpr(sctx sessionctx.Context, client kv.Client, desc *WindowF
 
 // CanPushDownToTiFlash control whether a window function desc can be push down to tiflash.
 func (s *WindowFuncDesc) CanPushDownToTiFlash(ctx sessionctx.Context) bool {
+	// modifiers are only supported by TiDB now
+	if s.HasDistinct || s.IgnoreNull || s.FromLast {
+		return false
+	}
 	// args
 	if !expression.CanExprsPushDown(ctx.GetSessionVars().StmtCtx, s.Args, ctx.GetClient(), kv.TiFlash) {
 		return false
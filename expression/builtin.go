@@ -372,6 +372,10 @@ func (b *baseBuiltinFunc) getCtx() sessionctx.Context {
 	return b.ctx
 }
 
+func (b *baseBuiltinFunc) setCtx(ctx sessionctx.Context) {
+	b.ctx = ctx
+}
+
 func (b *baseBuiltinFunc) cloneFrom(from *baseBuiltinFunc) {
 	b.args = make([]Expression, 0, len(b.args))
 	for _, arg := range from.args {
@@ -485,6 +489,8 @@ type builtinFunc interface {
 	equal(builtinFunc) bool
 	// getCtx returns this function's context.
 	getCtx() sessionctx.Context
+	// setCtx binds this function to another context, it's only used on a cloned function.
+	setCtx(ctx sessionctx.Context)
 	// getRetTp returns the return type of the built-in function.
 	getRetTp() *types.FieldType
 	// setPbCode sets pbCode for signature.
@@ -896,6 +902,17 @@ var funcs = map[string]functionClass{
 	ast.JSONKeys:          &jsonKeysFunctionClass{baseFunctionClass{ast.JSONKeys, 1, 2}},
 	ast.JSONLength:        &jsonLengthFunctionClass{baseFunctionClass{ast.JSONLength, 1, 2}},
 
+	ast.JSONSchemaValid:            &jsonSchemaValidFunctionClass{baseFunctionClass{ast.JSONSchemaValid, 2, 2}},
+	ast.JSONSchemaValidationReport: &jsonSchemaValidFunctionClass{baseFunctionClass{ast.JSONSchemaValidationReport, 2, 2}},
+
+	// spatial functions
+	ast.STAsText:         &stAsTextFunctionClass{baseFunctionClass{ast.STAsText, 1, 1}},
+	ast.STContains:       &stContainsFunctionClass{baseFunctionClass{ast.STContains, 2, 2}},
+	ast.STDistanceSphere: &stDistanceSphereFunctionClass{baseFunctionClass{ast.STDistanceSphere, 2, 3}},
+	ast.STGeomFromText:   &stGeomFromTextFunctionClass{baseFunctionClass{ast.STGeomFromText, 1, 2}},
+	ast.STSRID:           &stSRIDFunctionClass{baseFunctionClass{ast.STSRID, 1, 1}},
+	ast.STWithin:         &stContainsFunctionClass{baseFunctionClass{ast.STWithin, 2, 2}},
+
 	// TiDB internal function.
 	ast.TiDBDecodeKey: &tidbDecodeKeyFunctionClass{baseFunctionClass{ast.TiDBDecodeKey, 1, 1}},
 	// This function is used to show tidb-server version info.
@@ -117,6 +117,7 @@ func (b *BuiltinGroupingImplSig) Clone() builtinFunc {
 	newSig.cloneFrom(&b.baseBuiltinFunc)
 	newSig.mode = b.mode
 	newSig.groupingMarks = b.groupingMarks
+	newSig.isMetaInited = b.isMetaInited
 	return newSig
 }
 
@@ -250,6 +251,10 @@ func (b *BuiltinGroupingImplSig) groupingVec(groupingIds *chunk.Column, rowNum i
 	}
 }
 
+func (*BuiltinGroupingImplSig) vectorized() bool {
+	return true
+}
+
 func (b *BuiltinGroupingImplSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
 	if !b.isMetaInited {
 		return errors.Errorf("Meta data is not initialzied")
@@ -19,12 +19,15 @@ import (
 	goJSON "encoding/json"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/hack"
@@ -59,6 +62,8 @@ var (
 	_ functionClass = &jsonDepthFunctionClass{}
 	_ functionClass = &jsonKeysFunctionClass{}
 	_ functionClass = &jsonLengthFunctionClass{}
+	_ functionClass = &jsonSchemaValidFunctionClass{}
+	_ functionClass = &jsonValueFunctionClass{}
 
 	_ builtinFunc = &builtinJSONTypeSig{}
 	_ builtinFunc = &builtinJSONQuoteSig{}
@@ -85,6 +90,9 @@ var (
 	_ builtinFunc = &builtinJSONValidJSONSig{}
 	_ builtinFunc = &builtinJSONValidStringSig{}
 	_ builtinFunc = &builtinJSONValidOthersSig{}
+	_ builtinFunc = &builtinJSONSchemaValidSig{}
+	_ builtinFunc = &builtinJSONSchemaValidationReportSig{}
+	_ builtinFunc = &builtinJSONValueSig{}
 )
 
 type jsonTypeFunctionClass struct {
@@ -1798,3 +1806,392 @@ func (b *builtinJSONLengthSig) evalInt(row chunk.Row) (res int64, isNull bool, e
 	}
 	return int64(obj.GetElemCount()), false, nil
 }
+
+type jsonSchemaValidFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *jsonSchemaValidFunctionClass) verifyArgs(args []Expression) error {
+	if err := c.baseFunctionClass.verifyArgs(args); err != nil {
+		return err
+	}
+	for i, arg := range args {
+		if evalType := arg.GetType().EvalType(); evalType != types.ETString && evalType != types.ETJson {
+			return ErrInvalidTypeForJSON.GenWithStackByArgs(i+1, c.funcName)
+		}
+	}
+	return nil
+}
+
+func (c *jsonSchemaValidFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	if c.funcName == ast.JSONSchemaValidationReport {
+		bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETJson, types.ETJson, types.ETJson)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONSchemaValidationReportSig{jsonSchemaBaseSig{baseBuiltinFunc: bf}}, nil
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETJson, types.ETJson)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(1)
+	return &builtinJSONSchemaValidSig{jsonSchemaBaseSig{baseBuiltinFunc: bf}}, nil
+}
+
+// jsonSchemaBaseSig validates the JSON document in the second argument against
+// the JSON Schema in the first argument. The compiled schema is memorized if
+// the schema is a constant.
+type jsonSchemaBaseSig struct {
+	baseBuiltinFunc
+
+	once            sync.Once
+	memorizedErr    error
+	memorizedSchema *types.JSONSchema
+}
+
+func (b *jsonSchemaBaseSig) cloneSchemaSigTo(newSig *jsonSchemaBaseSig) {
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	newSig.memorizedErr = b.memorizedErr
+	newSig.memorizedSchema = b.memorizedSchema
+}
+
+func (b *jsonSchemaBaseSig) validate(row chunk.Row, funcName string) (report types.JSONSchemaValidationReport, isNull bool, err error) {
+	schemaJSON, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return report, isNull, err
+	}
+	doc, isNull, err := b.args[1].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return report, isNull, err
+	}
+	var schema *types.JSONSchema
+	if b.args[0].ConstItem(b.ctx.GetSessionVars().StmtCtx) {
+		b.once.Do(func() {
+			if b.memorizedSchema == nil && b.memorizedErr == nil {
+				b.memorizedSchema, b.memorizedErr = types.CompileJSONSchema(schemaJSON, funcName)
+			}
+		})
+		schema, err = b.memorizedSchema, b.memorizedErr
+	} else {
+		schema, err = types.CompileJSONSchema(schemaJSON, funcName)
+	}
+	if err != nil {
+		return report, true, err
+	}
+	return schema.Validate(doc), false, nil
+}
+
+type builtinJSONSchemaValidSig struct {
+	jsonSchemaBaseSig
+}
+
+func (b *builtinJSONSchemaValidSig) Clone() builtinFunc {
+	newSig := &builtinJSONSchemaValidSig{}
+	b.cloneSchemaSigTo(&newSig.jsonSchemaBaseSig)
+	return newSig
+}
+
+// evalInt evals JSON_SCHEMA_VALID(schema, document).
+// See https://dev.mysql.com/doc/refman/8.0/en/json-validation-functions.html#function_json-schema-valid
+func (b *builtinJSONSchemaValidSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	report, isNull, err := b.validate(row, ast.JSONSchemaValid)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	if report.Valid {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
+
+// JSONSchemaValidationFailure returns the reason why the row fails the
+// expression if it's JSON_SCHEMA_VALID(schema, document), it's used to report
+// the details of the violated CHECK constraints.
+func JSONSchemaValidationFailure(expr Expression, row chunk.Row) (string, bool) {
+	sf, ok := expr.(*ScalarFunction)
+	if !ok {
+		return "", false
+	}
+	sig, ok := sf.Function.(*builtinJSONSchemaValidSig)
+	if !ok {
+		return "", false
+	}
+	report, isNull, err := sig.validate(row, ast.JSONSchemaValid)
+	if isNull || err != nil || report.Valid {
+		return "", false
+	}
+	return report.Reason(), true
+}
+
+type builtinJSONSchemaValidationReportSig struct {
+	jsonSchemaBaseSig
+}
+
+func (b *builtinJSONSchemaValidationReportSig) Clone() builtinFunc {
+	newSig := &builtinJSONSchemaValidationReportSig{}
+	b.cloneSchemaSigTo(&newSig.jsonSchemaBaseSig)
+	return newSig
+}
+
+// evalJSON evals JSON_SCHEMA_VALIDATION_REPORT(schema, document).
+// See https://dev.mysql.com/doc/refman/8.0/en/json-validation-functions.html#function_json-schema-validation-report
+func (b *builtinJSONSchemaValidationReportSig) evalJSON(row chunk.Row) (res types.BinaryJSON, isNull bool, err error) {
+	report, isNull, err := b.validate(row, ast.JSONSchemaValidationReport)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return report.ToBinaryJSON(), false, nil
+}
+
+// JSONValueArgs returns the arguments of the json_value function. The ON EMPTY
+// and ON ERROR clauses are appended as constants if any of them is specified,
+// every clause is a pair of the response type and the default value.
+func JSONValueArgs(doc, path Expression, onEmpty, onError *ast.JSONTableOnResponse) []Expression {
+	args := []Expression{doc, path}
+	if onEmpty == nil && onError == nil {
+		return args
+	}
+	for _, resp := range []*ast.JSONTableOnResponse{onEmpty, onError} {
+		tp, def := ast.JSONTableOnResponseNull, ""
+		if resp != nil {
+			tp, def = resp.Tp, resp.Default
+		}
+		args = append(args,
+			DatumToConstant(types.NewIntDatum(int64(tp)), mysql.TypeLonglong, 0),
+			DatumToConstant(types.NewStringDatum(def), mysql.TypeVarString, 0))
+	}
+	return args
+}
+
+// BuildJSONValueFunction builds the json_value ScalarFunction. Like CAST, the
+// returned type can't be inferred from the arguments, so it's specified by tp.
+func BuildJSONValueFunction(ctx sessionctx.Context, args []Expression, tp *types.FieldType) (Expression, error) {
+	fc := &jsonValueFunctionClass{baseFunctionClass{ast.JSONValue, 2, 6}, tp}
+	f, err := fc.getFunction(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	res := &ScalarFunction{
+		FuncName: model.NewCIStr(ast.JSONValue),
+		RetType:  tp,
+		Function: f,
+	}
+	return FoldConstant(res), nil
+}
+
+type jsonValueFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *jsonValueFunctionClass) verifyArgs(args []Expression) error {
+	if len(args) != 2 && len(args) != 6 {
+		return ErrIncorrectParameterCount.GenWithStackByArgs(c.funcName)
+	}
+	return nil
+}
+
+func (c *jsonValueFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	args[0] = WrapWithCastAsJSON(ctx, args[0])
+	args[1] = WrapWithCastAsString(ctx, args[1])
+	// The value is NULL if the path doesn't exist or the value can't be converted.
+	c.tp.DelFlag(mysql.NotNullFlag)
+	bf, err := newBaseBuiltinFuncWithFieldType(ctx, c.tp, args)
+	if err != nil {
+		return nil, err
+	}
+	// The collation of the returned string is specified by the RETURNING clause.
+	if c.tp.EvalType() == types.ETString {
+		bf.SetCoercibility(CoercibilityImplicit)
+		bf.SetRepertoire(UNICODE)
+	} else {
+		bf.SetCoercibility(CoercibilityNumeric)
+		bf.SetRepertoire(ASCII)
+	}
+	return &builtinJSONValueSig{bf}, nil
+}
+
+// builtinJSONValueSig evals JSON_VALUE(doc, path [RETURNING type] [on_empty] [on_error]).
+// See https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-value
+type builtinJSONValueSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinJSONValueSig) Clone() builtinFunc {
+	newSig := &builtinJSONValueSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// equal implements the builtinFunc interface, json_value functions with the
+// same arguments are different if they return different types.
+func (b *builtinJSONValueSig) equal(fun builtinFunc) bool {
+	return b.baseBuiltinFunc.equal(fun) && b.tp.Equal(fun.getRetTp())
+}
+
+func (b *builtinJSONValueSig) evalResponse(row chunk.Row, offset int) (tp ast.JSONTableOnResponseType, def string, err error) {
+	if len(b.args) <= offset {
+		return ast.JSONTableOnResponseNull, "", nil
+	}
+	val, _, err := b.args[offset].EvalInt(b.ctx, row)
+	if err != nil {
+		return tp, def, err
+	}
+	def, _, err = b.args[offset+1].EvalString(b.ctx, row)
+	return ast.JSONTableOnResponseType(val), def, err
+}
+
+// evalDatum evaluates the value at the path and converts it to the returned
+// type, the ON EMPTY and ON ERROR clauses are applied if the path doesn't exist
+// or the value can't be converted.
+func (b *builtinJSONValueSig) evalDatum(row chunk.Row) (d types.Datum, err error) {
+	doc, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return d, err
+	}
+	pathStr, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return d, err
+	}
+	path, err := types.ParseJSONPathExpr(pathStr)
+	if err != nil {
+		return d, err
+	}
+	if path.CouldMatchMultipleValues() {
+		return d, types.ErrInvalidJSONPathMultipleSelection
+	}
+	// The conversion ignores the sql mode, so an invalid value is always
+	// reported as an error and handled by the ON ERROR clause.
+	sc := &stmtctx.StatementContext{TimeZone: b.ctx.GetSessionVars().Location()}
+	val, found := doc.Extract([]types.JSONPathExpression{path})
+	if !found {
+		tp, def, err := b.evalResponse(row, 2)
+		if err != nil {
+			return d, err
+		}
+		switch tp {
+		case ast.JSONTableOnResponseError:
+			return d, errMissingJSONValue.GenWithStackByArgs(ast.JSONValue)
+		case ast.JSONTableOnResponseDefault:
+			return b.convertDefault(sc, def)
+		}
+		return d, nil
+	}
+	d, convErr := b.convert(sc, val)
+	if convErr == nil {
+		return d, nil
+	}
+	tp, def, err := b.evalResponse(row, 4)
+	if err != nil {
+		return d, err
+	}
+	switch tp {
+	case ast.JSONTableOnResponseError:
+		return d, convErr
+	case ast.JSONTableOnResponseDefault:
+		return b.convertDefault(sc, def)
+	}
+	return types.Datum{}, nil
+}
+
+// convert converts the JSON value to the returned type, only the scalar values
+// can be converted to a non-JSON type.
+func (b *builtinJSONValueSig) convert(sc *stmtctx.StatementContext, val types.BinaryJSON) (d types.Datum, err error) {
+	if b.tp.GetType() == mysql.TypeJSON {
+		d.SetMysqlJSON(val)
+		return d, nil
+	}
+	switch val.TypeCode {
+	case types.JSONTypeCodeObject, types.JSONTypeCodeArray:
+		return d, types.ErrTruncatedWrongVal.GenWithStackByArgs(types.TypeStr(b.tp.GetType()), val.String())
+	case types.JSONTypeCodeLiteral:
+		if val.Value[0] == types.JSONLiteralNil {
+			return d, nil
+		}
+		d.SetMysqlJSON(val)
+	case types.JSONTypeCodeString:
+		d.SetString(string(val.GetString()), b.tp.GetCollate())
+	default:
+		d.SetMysqlJSON(val)
+	}
+	return d.ConvertTo(sc, b.tp)
+}
+
+// convertDefault converts the default value of the ON EMPTY or ON ERROR clause
+// to the returned type.
+func (b *builtinJSONValueSig) convertDefault(sc *stmtctx.StatementContext, def string) (d types.Datum, err error) {
+	if b.tp.GetType() == mysql.TypeJSON {
+		val, err := types.ParseBinaryJSONFromString(def)
+		if err != nil {
+			return d, err
+		}
+		d.SetMysqlJSON(val)
+		return d, nil
+	}
+	d.SetString(def, b.tp.GetCollate())
+	return d.ConvertTo(sc, b.tp)
+}
+
+func (b *builtinJSONValueSig) evalInt(row chunk.Row) (int64, bool, error) {
+	d, err := b.evalDatum(row)
+	if err != nil || d.IsNull() {
+		return 0, true, err
+	}
+	return d.GetInt64(), false, nil
+}
+
+func (b *builtinJSONValueSig) evalReal(row chunk.Row) (float64, bool, error) {
+	d, err := b.evalDatum(row)
+	if err != nil || d.IsNull() {
+		return 0, true, err
+	}
+	return d.GetFloat64(), false, nil
+}
+
+func (b *builtinJSONValueSig) evalDecimal(row chunk.Row) (*types.MyDecimal, bool, error) {
+	d, err := b.evalDatum(row)
+	if err != nil || d.IsNull() {
+		return nil, true, err
+	}
+	return d.GetMysqlDecimal(), false, nil
+}
+
+func (b *builtinJSONValueSig) evalString(row chunk.Row) (string, bool, error) {
+	d, err := b.evalDatum(row)
+	if err != nil || d.IsNull() {
+		return "", true, err
+	}
+	return d.GetString(), false, nil
+}
+
+func (b *builtinJSONValueSig) evalTime(row chunk.Row) (types.Time, bool, error) {
+	d, err := b.evalDatum(row)
+	if err != nil || d.IsNull() {
+		return types.ZeroTime, true, err
+	}
+	return d.GetMysqlTime(), false, nil
+}
+
+func (b *builtinJSONValueSig) evalDuration(row chunk.Row) (types.Duration, bool, error) {
+	d, err := b.evalDatum(row)
+	if err != nil || d.IsNull() {
+		return types.ZeroDuration, true, err
+	}
+	return d.GetMysqlDuration(), false, nil
+}
+
+func (b *builtinJSONValueSig) evalJSON(row chunk.Row) (types.BinaryJSON, bool, error) {
+	d, err := b.evalDatum(row)
+	if err != nil || d.IsNull() {
+		return types.BinaryJSON{}, true, err
+	}
+	return d.GetMysqlJSON(), false, nil
+}
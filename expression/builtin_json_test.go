@@ -1342,3 +1342,138 @@ func TestJSONMergePatch(t *testing.T) {
 		}
 	}
 }
+
+func TestJSONSchemaValid(t *testing.T) {
+	ctx := createContext(t)
+	schema := `{"type": "object", "properties": {"latitude": {"type": "number", "minimum": -90, "maximum": 90}}, "required": ["latitude"]}`
+	tbl := []struct {
+		input   []interface{}
+		valid   interface{}
+		report  interface{}
+		success bool
+	}{
+		{[]interface{}{schema, `{"latitude": 63.444697}`}, int64(1), `{"valid": true}`, true},
+		{[]interface{}{schema, `{"latitude": 91}`}, int64(0), `{"valid": false, "reason": "The JSON document location '#/latitude' failed requirement 'maximum' at JSON Schema location '#/properties/latitude'", "schema-location": "#/properties/latitude", "document-location": "#/latitude", "schema-failed-keyword": "maximum"}`, true},
+		{[]interface{}{schema, `{}`}, int64(0), `{"valid": false, "reason": "The JSON document location '#' failed requirement 'required' at JSON Schema location '#'", "schema-location": "#", "document-location": "#", "schema-failed-keyword": "required"}`, true},
+		{[]interface{}{nil, `{}`}, nil, nil, true},
+		{[]interface{}{schema, nil}, nil, nil, true},
+		// The schema must be an object.
+		{[]interface{}{`[]`, `{}`}, nil, nil, false},
+		// Invalid json text
+		{[]interface{}{schema, `{"latitude": 1`}, nil, nil, false},
+	}
+	for _, tt := range tbl {
+		args := types.MakeDatums(tt.input...)
+		f, err := funcs[ast.JSONSchemaValid].getFunction(ctx, datumsToConstants(args))
+		require.NoError(t, err)
+		require.True(t, mysql.HasIsBooleanFlag(f.getRetTp().GetFlag()))
+		d, err := evalBuiltinFunc(f, chunk.Row{})
+		if !tt.success {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		if tt.valid == nil {
+			require.True(t, d.IsNull())
+		} else {
+			require.Equal(t, tt.valid, d.GetInt64())
+		}
+
+		f, err = funcs[ast.JSONSchemaValidationReport].getFunction(ctx, datumsToConstants(args))
+		require.NoError(t, err)
+		d, err = evalBuiltinFunc(f, chunk.Row{})
+		require.NoError(t, err)
+		if tt.report == nil {
+			require.True(t, d.IsNull())
+		} else {
+			j, err := types.ParseBinaryJSONFromString(tt.report.(string))
+			require.NoError(t, err)
+			require.Equal(t, j.String(), d.GetMysqlJSON().String())
+		}
+	}
+
+	_, err := funcs[ast.JSONSchemaValid].getFunction(ctx, datumsToConstants(types.MakeDatums(1, `{}`)))
+	require.True(t, ErrInvalidTypeForJSON.Equal(err))
+}
+
+func TestJSONValue(t *testing.T) {
+	ctx := createContext(t)
+	doc := `{"a": 1, "b": "x", "c": [1], "d": "2023-01-02", "e": "hello", "f": null}`
+	varchar := types.NewFieldTypeBuilder().SetType(mysql.TypeVarString).SetFlen(512).SetCharset("utf8mb4").SetCollate("utf8mb4_bin").BuildP()
+	signed := types.NewFieldTypeBuilder().SetType(mysql.TypeLonglong).SetFlag(mysql.BinaryFlag).BuildP()
+	decimal := types.NewFieldTypeBuilder().SetType(mysql.TypeNewDecimal).SetFlen(4).SetDecimal(2).SetFlag(mysql.BinaryFlag).BuildP()
+	date := types.NewFieldTypeBuilder().SetType(mysql.TypeDate).SetFlag(mysql.BinaryFlag).BuildP()
+	char3 := types.NewFieldTypeBuilder().SetType(mysql.TypeVarString).SetFlen(3).SetCharset("utf8mb4").SetCollate("utf8mb4_bin").BuildP()
+	json := types.NewFieldTypeBuilder().SetType(mysql.TypeJSON).SetFlag(mysql.BinaryFlag).BuildP()
+	errResp := &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseError}
+	defaultResp := &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseDefault, Default: "3"}
+	tbl := []struct {
+		path     string
+		tp       *types.FieldType
+		onEmpty  *ast.JSONTableOnResponse
+		onError  *ast.JSONTableOnResponse
+		expected interface{}
+		success  bool
+	}{
+		{"$.b", varchar, nil, nil, "x", true},
+		{"$.a", varchar, nil, nil, "1", true},
+		{"$.a", signed, nil, nil, "1", true},
+		{"$.a", decimal, nil, nil, "1.00", true},
+		{"$.d", date, nil, nil, "2023-01-02", true},
+		{"$.c", json, nil, nil, "[1]", true},
+		{"$.f", signed, nil, nil, nil, true},
+		// ON EMPTY
+		{"$.x", signed, nil, nil, nil, true},
+		{"$.x", signed, defaultResp, nil, "3", true},
+		{"$.x", signed, errResp, nil, nil, false},
+		// ON ERROR
+		{"$.b", signed, nil, nil, nil, true},
+		{"$.b", signed, nil, defaultResp, "3", true},
+		{"$.b", signed, nil, errResp, nil, false},
+		{"$.c", signed, nil, nil, nil, true},
+		{"$.c", signed, nil, errResp, nil, false},
+		{"$.e", char3, nil, nil, nil, true},
+		{"$.e", char3, nil, errResp, nil, false},
+		// The path can't match multiple values.
+		{"$[*]", signed, nil, nil, nil, false},
+	}
+	for _, tt := range tbl {
+		args := datumsToConstants(types.MakeDatums(doc, tt.path))
+		f, err := BuildJSONValueFunction(ctx, JSONValueArgs(args[0], args[1], tt.onEmpty, tt.onError), tt.tp.Clone())
+		require.NoError(t, err)
+		d, err := f.Eval(chunk.Row{})
+		if !tt.success {
+			require.Error(t, err, tt.path)
+			continue
+		}
+		require.NoError(t, err, tt.path)
+		if tt.expected == nil {
+			require.True(t, d.IsNull(), tt.path)
+		} else {
+			s, err := d.ToString()
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, s, tt.path)
+		}
+	}
+
+	args := datumsToConstants(types.MakeDatums(doc, "$.x"))
+	f, err := BuildJSONValueFunction(ctx, JSONValueArgs(args[0], args[1], errResp, nil), signed.Clone())
+	require.NoError(t, err)
+	_, err = f.Eval(chunk.Row{})
+	require.True(t, errMissingJSONValue.Equal(err))
+
+	// The json_value functions returning different types are different.
+	col := &Column{RetType: types.NewFieldType(mysql.TypeJSON), Index: 0}
+	path := datumsToConstants(types.MakeDatums("$.a"))[0]
+	f1, err := BuildJSONValueFunction(ctx, JSONValueArgs(col, path, nil, nil), signed.Clone())
+	require.NoError(t, err)
+	f2, err := BuildJSONValueFunction(ctx, JSONValueArgs(col, path, nil, nil), signed.Clone())
+	require.NoError(t, err)
+	require.True(t, f1.Equal(ctx, f2))
+	f2, err = BuildJSONValueFunction(ctx, JSONValueArgs(col, path, nil, nil), varchar.Clone())
+	require.NoError(t, err)
+	require.False(t, f1.Equal(ctx, f2))
+	f2, err = BuildJSONValueFunction(ctx, JSONValueArgs(col, path, errResp, nil), signed.Clone())
+	require.NoError(t, err)
+	require.False(t, f1.Equal(ctx, f2))
+}
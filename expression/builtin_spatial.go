@@ -0,0 +1,324 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	ptypes "github.com/pingcap/tidb/parser/types"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/hack"
+)
+
+var (
+	_ functionClass = &stGeomFromTextFunctionClass{}
+	_ functionClass = &stAsTextFunctionClass{}
+	_ functionClass = &stSRIDFunctionClass{}
+	_ functionClass = &stContainsFunctionClass{}
+	_ functionClass = &stDistanceSphereFunctionClass{}
+
+	_ builtinFunc = &builtinSTGeomFromTextSig{}
+	_ builtinFunc = &builtinSTAsTextSig{}
+	_ builtinFunc = &builtinSTSRIDSig{}
+	_ builtinFunc = &builtinSTContainsSig{}
+	_ builtinFunc = &builtinSTDistanceSphereSig{}
+)
+
+// evalGeometry evaluates the argument as a geometry in the internal storage format.
+func evalGeometry(ctx sessionctx.Context, arg Expression, row chunk.Row, funcName string) (g types.Geometry, isNull bool, err error) {
+	val, isNull, err := arg.EvalString(ctx, row)
+	if isNull || err != nil {
+		return g, isNull, err
+	}
+	g, ok := types.DecodeGeometry(hack.Slice(val))
+	if !ok {
+		return g, false, types.ErrGISInvalidData.GenWithStackByArgs(funcName)
+	}
+	return g, false, nil
+}
+
+// evalGeometryPair evaluates the first two arguments of a binary spatial
+// function, the geometries must have the same SRID.
+func evalGeometryPair(ctx sessionctx.Context, args []Expression, row chunk.Row, funcName string) (g1, g2 types.Geometry, isNull bool, err error) {
+	g1, isNull, err = evalGeometry(ctx, args[0], row, funcName)
+	if isNull || err != nil {
+		return g1, g2, isNull, err
+	}
+	g2, isNull, err = evalGeometry(ctx, args[1], row, funcName)
+	if isNull || err != nil {
+		return g1, g2, isNull, err
+	}
+	if g1.SRID != g2.SRID {
+		return g1, g2, false, types.ErrGISDifferentSRIDs.GenWithStackByArgs(funcName, g1.SRID, g2.SRID)
+	}
+	return g1, g2, false, nil
+}
+
+type stGeomFromTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeomFromTextFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString}
+	if len(args) == 2 {
+		argTps = append(argTps, types.ETInt)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetType(mysql.TypeGeometry)
+	bf.tp.SetGeometryType(ptypes.GeometryTypeGeometry)
+	bf.tp.SetFlen(mysql.MaxBlobWidth)
+	types.SetBinChsClnFlag(bf.tp)
+	sig := &builtinSTGeomFromTextSig{bf}
+	return sig, nil
+}
+
+type builtinSTGeomFromTextSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTGeomFromTextSig) Clone() builtinFunc {
+	newSig := &builtinSTGeomFromTextSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_GeomFromText(wkt[, srid]).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-wkt-functions.html#function_st-geomfromtext
+func (b *builtinSTGeomFromTextSig) evalString(row chunk.Row) (string, bool, error) {
+	wkt, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srid := uint32(types.SRIDCartesian)
+	if len(b.args) == 2 {
+		val, isNull, err := b.args[1].EvalInt(b.ctx, row)
+		if isNull || err != nil {
+			return "", isNull, err
+		}
+		if val < 0 || val > int64(^uint32(0)) || !types.IsKnownSRID(uint32(val)) {
+			return "", false, types.ErrSRSNotFound.GenWithStackByArgs(val)
+		}
+		srid = uint32(val)
+	}
+	g, ok := types.ParseGeometryFromText(wkt, srid)
+	if !ok {
+		return "", false, types.ErrGISInvalidData.GenWithStackByArgs(ast.STGeomFromText)
+	}
+	if err := g.CheckGeographicRange(ast.STGeomFromText); err != nil {
+		return "", false, err
+	}
+	return string(g.Encode()), false, nil
+}
+
+type stAsTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsTextFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	chs, collate := ctx.GetSessionVars().GetCharsetInfo()
+	bf.tp.SetCharset(chs)
+	bf.tp.SetCollate(collate)
+	bf.tp.SetFlen(mysql.MaxBlobWidth)
+	sig := &builtinSTAsTextSig{bf}
+	return sig, nil
+}
+
+type builtinSTAsTextSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTAsTextSig) Clone() builtinFunc {
+	newSig := &builtinSTAsTextSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_AsText(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-format-conversion-functions.html#function_st-astext
+func (b *builtinSTAsTextSig) evalString(row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, ast.STAsText)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return g.AsText(), false, nil
+}
+
+type stSRIDFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stSRIDFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.AddFlag(mysql.UnsignedFlag)
+	bf.tp.SetFlen(10)
+	sig := &builtinSTSRIDSig{bf}
+	return sig, nil
+}
+
+type builtinSTSRIDSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTSRIDSig) Clone() builtinFunc {
+	newSig := &builtinSTSRIDSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals ST_SRID(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-srid
+func (b *builtinSTSRIDSig) evalInt(row chunk.Row) (int64, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, ast.STSRID)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return int64(g.SRID), false, nil
+}
+
+type stContainsFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stContainsFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(1)
+	sig := &builtinSTContainsSig{bf, c.funcName == ast.STWithin}
+	return sig, nil
+}
+
+// builtinSTContainsSig evals both ST_Contains and ST_Within, ST_Within(g1, g2)
+// is the same as ST_Contains(g2, g1).
+type builtinSTContainsSig struct {
+	baseBuiltinFunc
+
+	within bool
+}
+
+func (b *builtinSTContainsSig) Clone() builtinFunc {
+	newSig := &builtinSTContainsSig{within: b.within}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals ST_Contains(g1, g2) or ST_Within(g1, g2).
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html
+func (b *builtinSTContainsSig) evalInt(row chunk.Row) (int64, bool, error) {
+	funcName := ast.STContains
+	if b.within {
+		funcName = ast.STWithin
+	}
+	g1, g2, isNull, err := evalGeometryPair(b.ctx, b.args, row, funcName)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if b.within {
+		g1, g2 = g2, g1
+	}
+	contains, supported := g1.Contains(&g2)
+	if !supported {
+		return 0, false, types.ErrGISUnsupportedArgument.GenWithStackByArgs(funcName)
+	}
+	if contains {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
+
+type stDistanceSphereFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceSphereFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString}
+	if len(args) == 3 {
+		argTps = append(argTps, types.ETReal)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSTDistanceSphereSig{bf}
+	return sig, nil
+}
+
+type builtinSTDistanceSphereSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTDistanceSphereSig) Clone() builtinFunc {
+	newSig := &builtinSTDistanceSphereSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals ST_Distance_Sphere(g1, g2[, radius]).
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-convenience-functions.html#function_st-distance-sphere
+func (b *builtinSTDistanceSphereSig) evalReal(row chunk.Row) (float64, bool, error) {
+	g1, g2, isNull, err := evalGeometryPair(b.ctx, b.args, row, ast.STDistanceSphere)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	radius := types.DefaultSphereRadius
+	if len(b.args) == 3 {
+		radius, isNull, err = b.args[2].EvalReal(b.ctx, row)
+		if isNull || err != nil {
+			return 0, isNull, err
+		}
+		if radius <= 0 {
+			return 0, false, types.ErrNonPositiveRadius.GenWithStackByArgs(ast.STDistanceSphere)
+		}
+	}
+	if err := g1.CheckSphereRange(ast.STDistanceSphere); err != nil {
+		return 0, false, err
+	}
+	if err := g2.CheckSphereRange(ast.STDistanceSphere); err != nil {
+		return 0, false, err
+	}
+	dist, supported := g1.DistanceSphere(&g2, radius)
+	if !supported {
+		return 0, false, types.ErrGISUnsupportedArgument.GenWithStackByArgs(ast.STDistanceSphere)
+	}
+	return dist, false, nil
+}
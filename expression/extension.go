@@ -21,6 +21,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/extension"
+	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/privilege"
@@ -52,6 +53,10 @@ func registerExtensionFunc(def *extension.FunctionDef) error {
 		return err
 	}
 
+	if _, ok := extensionAggFuncs.Load(lowerName); ok {
+		return errors.Errorf("duplicated extension function name '%s'", def.Name)
+	}
+
 	_, exist := extensionFuncs.LoadOrStore(lowerName, class)
 	if exist {
 		return errors.Errorf("duplicated extension function name '%s'", def.Name)
@@ -68,30 +73,49 @@ type extensionFuncClass struct {
 	baseFunctionClass
 	funcDef extension.FunctionDef
 	flen    int
+	decimal int
 }
 
 func newExtensionFuncClass(def *extension.FunctionDef) (*extensionFuncClass, error) {
-	var flen int
+	var flen, decimal int
+	var hasEvalFunc bool
 	switch def.EvalTp {
 	case types.ETString:
-		flen = mysql.MaxFieldVarCharLength
-		if def.EvalStringFunc == nil {
-			return nil, errors.New("eval function is nil")
-		}
+		flen, decimal = mysql.MaxFieldVarCharLength, types.UnspecifiedLength
+		hasEvalFunc = def.EvalStringFunc != nil
 	case types.ETInt:
 		flen = mysql.MaxIntWidth
-		if def.EvalIntFunc == nil {
-			return nil, errors.New("eval function is nil")
-		}
+		hasEvalFunc = def.EvalIntFunc != nil
+	case types.ETReal:
+		flen, decimal = mysql.MaxRealWidth, types.UnspecifiedLength
+		hasEvalFunc = def.EvalRealFunc != nil
+	case types.ETDecimal:
+		// the scale of the result is decided by the function, so keep it unspecified
+		flen, decimal = mysql.MaxDecimalWidth, types.UnspecifiedLength
+		hasEvalFunc = def.EvalDecimalFunc != nil
+	case types.ETDatetime, types.ETTimestamp:
+		flen, decimal = mysql.MaxDatetimeWidthWithFsp, types.MaxFsp
+		hasEvalFunc = def.EvalTimeFunc != nil
+	case types.ETDuration:
+		flen, decimal = mysql.MaxDurationWidthWithFsp, types.MaxFsp
+		hasEvalFunc = def.EvalDurationFunc != nil
+	case types.ETJson:
+		flen = mysql.MaxBlobWidth
+		hasEvalFunc = def.EvalJSONFunc != nil
 	default:
 		return nil, errors.Errorf("unsupported extension function ret type: '%v'", def.EvalTp)
 	}
 
+	if !hasEvalFunc {
+		return nil, errors.New("eval function is nil")
+	}
+
 	maxArgs := len(def.ArgTps)
 	minArgs := maxArgs - def.OptionalArgsLen
 	return &extensionFuncClass{
 		baseFunctionClass: baseFunctionClass{def.Name, minArgs, maxArgs},
 		flen:              flen,
+		decimal:           decimal,
 		funcDef:           *def,
 	}, nil
 }
@@ -109,12 +133,16 @@ func (c *extensionFuncClass) getFunction(ctx sessionctx.Context, args []Expressi
 		return nil, err
 	}
 	bf.tp.SetFlen(c.flen)
+	bf.tp.SetDecimal(c.decimal)
 	sig := &extensionFuncSig{context.TODO(), bf, c.funcDef}
 	return sig, nil
 }
 
 func (c *extensionFuncClass) checkPrivileges(ctx sessionctx.Context) error {
-	fn := c.funcDef.RequireDynamicPrivileges
+	return checkExtensionFuncPrivileges(ctx, c.funcDef.RequireDynamicPrivileges)
+}
+
+func checkExtensionFuncPrivileges(ctx sessionctx.Context, fn func(sem bool) []string) error {
 	if fn == nil {
 		return nil
 	}
@@ -150,7 +178,7 @@ type extensionFuncSig struct {
 }
 
 func (b *extensionFuncSig) Clone() builtinFunc {
-	newSig := &extensionFuncSig{}
+	newSig := &extensionFuncSig{Context: b.Context}
 	newSig.cloneFrom(&b.baseBuiltinFunc)
 	newSig.FunctionDef = b.FunctionDef
 	return newSig
@@ -170,6 +198,124 @@ func (b *extensionFuncSig) evalInt(row chunk.Row) (int64, bool, error) {
 	return b.baseBuiltinFunc.evalInt(row)
 }
 
+func (b *extensionFuncSig) evalReal(row chunk.Row) (float64, bool, error) {
+	if b.EvalTp == types.ETReal {
+		return b.EvalRealFunc(b, row)
+	}
+	return b.baseBuiltinFunc.evalReal(row)
+}
+
+func (b *extensionFuncSig) evalDecimal(row chunk.Row) (*types.MyDecimal, bool, error) {
+	if b.EvalTp == types.ETDecimal {
+		return b.EvalDecimalFunc(b, row)
+	}
+	return b.baseBuiltinFunc.evalDecimal(row)
+}
+
+func (b *extensionFuncSig) evalTime(row chunk.Row) (types.Time, bool, error) {
+	if b.EvalTp == types.ETDatetime || b.EvalTp == types.ETTimestamp {
+		return b.EvalTimeFunc(b, row)
+	}
+	return b.baseBuiltinFunc.evalTime(row)
+}
+
+func (b *extensionFuncSig) evalDuration(row chunk.Row) (types.Duration, bool, error) {
+	if b.EvalTp == types.ETDuration {
+		return b.EvalDurationFunc(b, row)
+	}
+	return b.baseBuiltinFunc.evalDuration(row)
+}
+
+func (b *extensionFuncSig) evalJSON(row chunk.Row) (types.BinaryJSON, bool, error) {
+	if b.EvalTp == types.ETJson {
+		return b.EvalJSONFunc(b, row)
+	}
+	return b.baseBuiltinFunc.evalJSON(row)
+}
+
+func (b *extensionFuncSig) vectorized() bool {
+	return b.EvalVecFunc != nil
+}
+
+// vecEval evaluates the args into the columns, and calls `EvalVecFunc` with them.
+func (b *extensionFuncSig) vecEval(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	args := make([]*chunk.Column, 0, len(b.args))
+	defer func() {
+		for _, buf := range args {
+			b.bufAllocator.put(buf)
+		}
+	}()
+	for _, arg := range b.args {
+		buf, err := b.bufAllocator.get()
+		if err != nil {
+			return err
+		}
+		args = append(args, buf)
+		if err := EvalExpr(b.ctx, arg, arg.GetType().EvalType(), input, buf); err != nil {
+			return err
+		}
+	}
+
+	result.Reset(b.EvalTp)
+	if err := b.EvalVecFunc(b, n, args, result); err != nil {
+		return err
+	}
+	if result.Rows() != n {
+		return errors.Errorf("extension function '%s' returns %d rows, but %d rows are expected", b.Name, result.Rows(), n)
+	}
+	return nil
+}
+
+func (b *extensionFuncSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	if b.EvalTp == types.ETString {
+		return b.vecEval(input, result)
+	}
+	return b.baseBuiltinFunc.vecEvalString(input, result)
+}
+
+func (b *extensionFuncSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	if b.EvalTp == types.ETInt {
+		return b.vecEval(input, result)
+	}
+	return b.baseBuiltinFunc.vecEvalInt(input, result)
+}
+
+func (b *extensionFuncSig) vecEvalReal(input *chunk.Chunk, result *chunk.Column) error {
+	if b.EvalTp == types.ETReal {
+		return b.vecEval(input, result)
+	}
+	return b.baseBuiltinFunc.vecEvalReal(input, result)
+}
+
+func (b *extensionFuncSig) vecEvalDecimal(input *chunk.Chunk, result *chunk.Column) error {
+	if b.EvalTp == types.ETDecimal {
+		return b.vecEval(input, result)
+	}
+	return b.baseBuiltinFunc.vecEvalDecimal(input, result)
+}
+
+func (b *extensionFuncSig) vecEvalTime(input *chunk.Chunk, result *chunk.Column) error {
+	if b.EvalTp == types.ETDatetime || b.EvalTp == types.ETTimestamp {
+		return b.vecEval(input, result)
+	}
+	return b.baseBuiltinFunc.vecEvalTime(input, result)
+}
+
+func (b *extensionFuncSig) vecEvalDuration(input *chunk.Chunk, result *chunk.Column) error {
+	if b.EvalTp == types.ETDuration {
+		return b.vecEval(input, result)
+	}
+	return b.baseBuiltinFunc.vecEvalDuration(input, result)
+}
+
+func (b *extensionFuncSig) vecEvalJSON(input *chunk.Chunk, result *chunk.Column) error {
+	if b.EvalTp == types.ETJson {
+		return b.vecEval(input, result)
+	}
+	return b.baseBuiltinFunc.vecEvalJSON(input, result)
+}
+
 func (b *extensionFuncSig) EvalArgs(row chunk.Row) ([]types.Datum, error) {
 	if len(b.args) == 0 {
 		return nil, nil
@@ -203,7 +349,92 @@ func (b *extensionFuncSig) CurrentDB() string {
 	return b.ctx.GetSessionVars().CurrentDB
 }
 
+var extensionAggFuncs sync.Map
+
+// builtinAggFuncs are the names of the builtin aggregate functions, which are parsed to `ast.AggregateFuncExpr`.
+var builtinAggFuncs = map[string]struct{}{
+	ast.AggFuncCount:               {},
+	ast.AggFuncSum:                 {},
+	ast.AggFuncAvg:                 {},
+	ast.AggFuncFirstRow:            {},
+	ast.AggFuncMax:                 {},
+	ast.AggFuncMin:                 {},
+	ast.AggFuncGroupConcat:         {},
+	ast.AggFuncBitOr:               {},
+	ast.AggFuncBitXor:              {},
+	ast.AggFuncBitAnd:              {},
+	ast.AggFuncVarPop:              {},
+	ast.AggFuncVarSamp:             {},
+	ast.AggFuncStddevPop:           {},
+	ast.AggFuncStddevSamp:          {},
+	ast.AggFuncJsonArrayagg:        {},
+	ast.AggFuncJsonObjectAgg:       {},
+	ast.AggFuncApproxCountDistinct: {},
+	ast.AggFuncApproxPercentile:    {},
+	"std":                          {},
+	"stddev":                       {},
+	"variance":                     {},
+}
+
+func registerExtensionAggFunc(def *extension.AggFunctionDef) error {
+	if def == nil {
+		return errors.New("extension function def is nil")
+	}
+
+	if err := def.Validate(); err != nil {
+		return err
+	}
+
+	for _, tp := range append([]types.EvalType{def.EvalTp}, def.ArgTps...) {
+		switch tp {
+		case types.ETInt, types.ETReal, types.ETDecimal, types.ETString,
+			types.ETDatetime, types.ETTimestamp, types.ETDuration, types.ETJson:
+		default:
+			return errors.Errorf("unsupported extension function type: '%v'", tp)
+		}
+	}
+
+	lowerName := strings.ToLower(def.Name)
+	if _, ok := funcs[lowerName]; ok {
+		return errors.Errorf("extension function name '%s' conflict with builtin", def.Name)
+	}
+	if _, ok := builtinAggFuncs[lowerName]; ok {
+		return errors.Errorf("extension function name '%s' conflict with builtin", def.Name)
+	}
+
+	if _, ok := extensionFuncs.Load(lowerName); ok {
+		return errors.Errorf("duplicated extension function name '%s'", def.Name)
+	}
+
+	_, exist := extensionAggFuncs.LoadOrStore(lowerName, def)
+	if exist {
+		return errors.Errorf("duplicated extension function name '%s'", def.Name)
+	}
+
+	return nil
+}
+
+func removeExtensionAggFunc(name string) {
+	extensionAggFuncs.Delete(strings.ToLower(name))
+}
+
+// GetExtensionAggFunc returns the definition of the extension aggregate function. The name should be in lower case.
+func GetExtensionAggFunc(name string) (*extension.AggFunctionDef, bool) {
+	def, ok := extensionAggFuncs.Load(name)
+	if !ok {
+		return nil, false
+	}
+	return def.(*extension.AggFunctionDef), true
+}
+
+// CheckExtensionAggFuncPrivileges checks whether the current user has the privileges to call the extension aggregate function.
+func CheckExtensionAggFuncPrivileges(ctx sessionctx.Context, def *extension.AggFunctionDef) error {
+	return checkExtensionFuncPrivileges(ctx, def.RequireDynamicPrivileges)
+}
+
 func init() {
 	extension.RegisterExtensionFunc = registerExtensionFunc
 	extension.RemoveExtensionFunc = removeExtensionFunc
+	extension.RegisterExtensionAggFunc = registerExtensionAggFunc
+	extension.RemoveExtensionAggFunc = removeExtensionAggFunc
 }
@@ -268,5 +268,6 @@ var booleanFunctions = map[string]struct{}{
 	ast.IsIPv4Mapped:       {},
 	ast.IsIPv6:             {},
 	ast.JSONValid:          {},
+	ast.JSONSchemaValid:    {},
 	ast.RegexpLike:         {},
 }
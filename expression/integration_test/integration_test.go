@@ -7884,6 +7884,30 @@ func TestJSONExtractRange(t *testing.T) {
 	tk.MustQuery(`select json_extract('[{"a": [1,2,3,4]}]', '$[0].a[0 to 2]')`).Check(testkit.Rows("[1, 2, 3]"))
 }
 
+func TestJSONValue(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustQuery(`select json_value('{"a": "x"}', '$.a'), json_value('{"a": 1.5}', '$.a' returning decimal(4, 2)), json_value('{"a": "2023-01-02"}', '$.a' returning date)`).
+		Check(testkit.Rows("x 1.50 2023-01-02"))
+	tk.MustQuery(`select json_value('{"a": [1]}', '$.a' returning json), json_value('{"a": [1]}', '$.a' returning signed), json_value('{"a": null}', '$.a')`).
+		Check(testkit.Rows("[1] <nil> <nil>"))
+	tk.MustQuery(`select json_value('{}', '$.a' returning signed default '1' on empty), json_value('{"a": "x"}', '$.a' returning signed default '2' on error)`).
+		Check(testkit.Rows("1 2"))
+	tk.MustGetErrCode(`select json_value('{}', '$.a' error on empty)`, errno.ErrMissingJSONValue)
+	tk.MustGetErrCode(`select json_value('{"a": "x"}', '$.a' returning signed error on error)`, errno.ErrTruncatedWrongValue)
+	tk.MustGetErrCode(`select json_value('[1, 2]', '$[*]')`, errno.ErrInvalidJSONPathMultipleSelection)
+
+	tk.MustExec("create table t (j json, a int as (json_value(j, '$.a' returning signed null on empty error on error)))")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `j` json DEFAULT NULL,\n" +
+		"  `a` int(11) GENERATED ALWAYS AS (json_value(`j`, _utf8mb4'$.a' returning signed null on empty error on error)) VIRTUAL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustExec(`insert into t(j) values ('{"a": 1}'), ('{}')`)
+	tk.MustQuery("select a from t").Sort().Check(testkit.Rows("1", "<nil>"))
+	tk.MustGetErrCode(`insert into t(j) values ('{"a": "x"}')`, errno.ErrTruncatedWrongValue)
+}
+
 func TestIfNullParamMarker(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
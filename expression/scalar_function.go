@@ -185,6 +185,8 @@ func newFunctionImpl(ctx sessionctx.Context, fold int, funcName string, retType
 		return BuildCastFunction(ctx, args[0], retType), nil
 	case ast.GetVar:
 		return BuildGetVarFunction(ctx, args[0], retType)
+	case ast.JSONValue:
+		return BuildJSONValueFunction(ctx, args, retType)
 	case InternalFuncFromBinary:
 		return BuildFromBinaryFunction(ctx, args[0], retType), nil
 	case InternalFuncToBinary:
@@ -559,9 +561,9 @@ func simpleCanonicalizedHashCode(sf *ScalarFunction, sc *stmtctx.StatementContex
 		for _, argCode := range argsHashCode {
 			sf.canonicalhashcode = append(sf.canonicalhashcode, argCode...)
 		}
-		// Cast is a special case. The RetType should also be considered as an argument.
+		// Cast and json_value are special cases. The RetType should also be considered as an argument.
 		// Please see `newFunctionImpl()` for detail.
-		if sf.FuncName.L == ast.Cast {
+		if sf.FuncName.L == ast.Cast || sf.FuncName.L == ast.JSONValue {
 			evalTp := sf.RetType.EvalType()
 			sf.canonicalhashcode = append(sf.canonicalhashcode, byte(evalTp))
 		}
@@ -576,9 +578,9 @@ func ReHashCode(sf *ScalarFunction, sc *stmtctx.StatementContext) {
 	for _, arg := range sf.GetArgs() {
 		sf.hashcode = append(sf.hashcode, arg.HashCode(sc)...)
 	}
-	// Cast is a special case. The RetType should also be considered as an argument.
+	// Cast and json_value are special cases. The RetType should also be considered as an argument.
 	// Please see `newFunctionImpl()` for detail.
-	if sf.FuncName.L == ast.Cast {
+	if sf.FuncName.L == ast.Cast || sf.FuncName.L == ast.JSONValue {
 		evalTp := sf.RetType.EvalType()
 		sf.hashcode = append(sf.hashcode, byte(evalTp))
 	}
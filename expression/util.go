@@ -1626,3 +1626,42 @@ func ExprsToStringsForDisplay(exprs []Expression) []string {
 	}
 	return strs
 }
+
+// CloneExprsWithNewCtx clones the expressions and binds the cloned expressions to the new session context. It's used
+// by the instance plan cache, whose cached plans are shared by sessions.
+func CloneExprsWithNewCtx(exprs []Expression, ctx sessionctx.Context) []Expression {
+	if exprs == nil {
+		return nil
+	}
+	cloned := make([]Expression, 0, len(exprs))
+	for _, expr := range exprs {
+		cloned = append(cloned, CloneWithNewCtx(expr, ctx))
+	}
+	return cloned
+}
+
+// CloneWithNewCtx clones the expression and binds the cloned expression to the new session context.
+func CloneWithNewCtx(expr Expression, ctx sessionctx.Context) Expression {
+	cloned := expr.Clone()
+	setCtx4ClonedExpr(cloned, ctx)
+	return cloned
+}
+
+func setCtx4ClonedExpr(expr Expression, ctx sessionctx.Context) {
+	switch x := expr.(type) {
+	case *ScalarFunction:
+		x.Function.setCtx(ctx)
+		for _, arg := range x.GetArgs() {
+			setCtx4ClonedExpr(arg, ctx)
+		}
+	case *Constant:
+		// `Constant.Clone` is a shallow copy, so the parameter marker and the deferred expression are replaced
+		// instead of modified.
+		if x.ParamMarker != nil {
+			x.ParamMarker = &ParamMarker{ctx: ctx, order: x.ParamMarker.order}
+		}
+		if x.DeferredExpr != nil {
+			x.DeferredExpr = CloneWithNewCtx(x.DeferredExpr, ctx)
+		}
+	}
+}
@@ -48,6 +48,20 @@ type FunctionDef struct {
 	EvalStringFunc func(ctx FunctionContext, row chunk.Row) (string, bool, error)
 	// EvalIntFunc is the eval function when `EvalTp` is `types.ETInt`
 	EvalIntFunc func(ctx FunctionContext, row chunk.Row) (int64, bool, error)
+	// EvalRealFunc is the eval function when `EvalTp` is `types.ETReal`
+	EvalRealFunc func(ctx FunctionContext, row chunk.Row) (float64, bool, error)
+	// EvalDecimalFunc is the eval function when `EvalTp` is `types.ETDecimal`
+	EvalDecimalFunc func(ctx FunctionContext, row chunk.Row) (*types.MyDecimal, bool, error)
+	// EvalTimeFunc is the eval function when `EvalTp` is `types.ETDatetime` or `types.ETTimestamp`
+	EvalTimeFunc func(ctx FunctionContext, row chunk.Row) (types.Time, bool, error)
+	// EvalDurationFunc is the eval function when `EvalTp` is `types.ETDuration`
+	EvalDurationFunc func(ctx FunctionContext, row chunk.Row) (types.Duration, bool, error)
+	// EvalJSONFunc is the eval function when `EvalTp` is `types.ETJson`
+	EvalJSONFunc func(ctx FunctionContext, row chunk.Row) (types.BinaryJSON, bool, error)
+	// EvalVecFunc is the optional vectorized eval function. `args` are the evaluated arguments of `n` rows, and
+	// `result` has been reset to `EvalTp`, the function should append exactly `n` values to it.
+	// The row-based eval function of `EvalTp` is still required, because not all the expressions are vectorized.
+	EvalVecFunc func(ctx FunctionContext, n int, args []*chunk.Column, result *chunk.Column) error
 	// RequireDynamicPrivileges is a function to return a list of dynamic privileges to check.
 	RequireDynamicPrivileges func(sem bool) []string
 }
@@ -70,3 +84,62 @@ var RegisterExtensionFunc func(*FunctionDef) error
 
 // RemoveExtensionFunc is to avoid dependency cycle
 var RemoveExtensionFunc func(string)
+
+// AggPartialResult is the partial result of a group of the custom aggregate function.
+type AggPartialResult any
+
+// AggFunction evaluates the custom aggregate function, its methods are the same as `executor/aggfuncs.AggFunc`.
+// The executor may call the methods concurrently with different partial results, so all the states of a group
+// should be kept in its partial result. The returned memDelta is the memory usage changed by the partial result.
+type AggFunction interface {
+	// AllocPartialResult allocates a partial result for a group.
+	AllocPartialResult() (pr AggPartialResult, memDelta int64)
+	// ResetPartialResult resets the partial result to the original state for a new group.
+	ResetPartialResult(pr AggPartialResult)
+	// UpdatePartialResult updates the partial result with the rows of the group, the arguments of a row can be
+	// evaluated by `ctx.EvalArgs`.
+	UpdatePartialResult(ctx FunctionContext, rowsInGroup []chunk.Row, pr AggPartialResult) (memDelta int64, err error)
+	// MergePartialResult merges the partial result `src` into `dst`, it's called when the partial results of
+	// the same group are calculated by different workers.
+	MergePartialResult(ctx FunctionContext, src, dst AggPartialResult) (memDelta int64, err error)
+	// AppendFinalResult appends exactly one value, which is the final result of the group, to `result`.
+	AppendFinalResult(ctx FunctionContext, pr AggPartialResult, result *chunk.Column) error
+}
+
+// AggFunctionDef is the definition for the custom aggregate function.
+// The function returns NULL when there is no row to aggregate, and it's never pushed down to the storage.
+type AggFunctionDef struct {
+	// Name is the function's name
+	Name string
+	// EvalTp is the type of the return value
+	EvalTp types.EvalType
+	// ArgTps is the argument types
+	ArgTps []types.EvalType
+	// Impl evaluates the function
+	Impl AggFunction
+	// RequireDynamicPrivileges is a function to return a list of dynamic privileges to check.
+	RequireDynamicPrivileges func(sem bool) []string
+}
+
+// Validate validates the aggregate function definition
+func (def *AggFunctionDef) Validate() error {
+	if def.Name == "" {
+		return errors.New("extension function name should not be empty")
+	}
+
+	if def.Impl == nil {
+		return errors.Errorf("extension aggregate function '%s' has no implementation", def.Name)
+	}
+
+	if len(def.ArgTps) == 0 {
+		return errors.Errorf("extension aggregate function '%s' should have at least one argument", def.Name)
+	}
+
+	return nil
+}
+
+// RegisterExtensionAggFunc is to avoid dependency cycle
+var RegisterExtensionAggFunc func(*AggFunctionDef) error
+
+// RemoveExtensionAggFunc is to avoid dependency cycle
+var RemoveExtensionAggFunc func(string)
@@ -18,12 +18,15 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/extension"
 	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/testkit"
 	"github.com/pingcap/tidb/types"
@@ -415,3 +418,317 @@ func TestExtensionFuncPrivilege(t *testing.T) {
 	tk1.MustQuery("select custom_only_sem_dyn_priv_func()").Check(testkit.Rows("def"))
 	tk1.MustQuery("select custom_both_dyn_priv_func()").Check(testkit.Rows("ghi"))
 }
+
+func TestExtensionFuncEvalTypes(t *testing.T) {
+	defer extension.Reset()
+	extension.Reset()
+
+	funcs := []*extension.FunctionDef{
+		{
+			Name:   "custom_real_func",
+			EvalTp: types.ETReal,
+			ArgTps: []types.EvalType{types.ETReal},
+			EvalRealFunc: func(ctx extension.FunctionContext, row chunk.Row) (float64, bool, error) {
+				args, err := ctx.EvalArgs(row)
+				if err != nil || args[0].IsNull() {
+					return 0, true, err
+				}
+				return args[0].GetFloat64() * 2, false, nil
+			},
+		},
+		{
+			Name:   "custom_decimal_func",
+			EvalTp: types.ETDecimal,
+			ArgTps: []types.EvalType{types.ETString},
+			EvalDecimalFunc: func(ctx extension.FunctionContext, row chunk.Row) (*types.MyDecimal, bool, error) {
+				args, err := ctx.EvalArgs(row)
+				if err != nil {
+					return nil, false, err
+				}
+				d := new(types.MyDecimal)
+				if err = d.FromString([]byte(args[0].GetString())); err != nil {
+					return nil, false, err
+				}
+				return d, false, nil
+			},
+		},
+		{
+			Name:   "custom_time_func",
+			EvalTp: types.ETDatetime,
+			ArgTps: []types.EvalType{types.ETInt},
+			EvalTimeFunc: func(ctx extension.FunctionContext, row chunk.Row) (types.Time, bool, error) {
+				args, err := ctx.EvalArgs(row)
+				if err != nil {
+					return types.ZeroTime, false, err
+				}
+				return types.NewTime(types.FromDate(int(args[0].GetInt64()), 1, 2, 3, 4, 5, 0), mysql.TypeDatetime, 0), false, nil
+			},
+		},
+		{
+			Name:   "custom_duration_func",
+			EvalTp: types.ETDuration,
+			EvalDurationFunc: func(ctx extension.FunctionContext, row chunk.Row) (types.Duration, bool, error) {
+				return types.Duration{Duration: time.Hour + 2*time.Minute}, false, nil
+			},
+		},
+		{
+			Name:   "custom_json_func",
+			EvalTp: types.ETJson,
+			ArgTps: []types.EvalType{types.ETString},
+			EvalJSONFunc: func(ctx extension.FunctionContext, row chunk.Row) (types.BinaryJSON, bool, error) {
+				args, err := ctx.EvalArgs(row)
+				if err != nil {
+					return types.BinaryJSON{}, false, err
+				}
+				return types.CreateBinaryJSON(map[string]interface{}{"a": args[0].GetString()}), false, nil
+			},
+		},
+	}
+	require.NoError(t, extension.Register("test", extension.WithCustomFunctions(funcs)))
+	require.NoError(t, extension.Setup())
+
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustQuery("select custom_real_func(1.25), custom_real_func(null)").Check(testkit.Rows("2.5 <nil>"))
+	tk.MustQuery("select custom_decimal_func('1.50'), custom_decimal_func('-2')").Check(testkit.Rows("1.50 -2"))
+	tk.MustQuery("select custom_time_func(2023), date(custom_time_func(2022))").Check(testkit.Rows("2023-01-02 03:04:05 2022-01-02"))
+	tk.MustQuery("select custom_duration_func(), hour(custom_duration_func())").Check(testkit.Rows("01:02:00 1"))
+	tk.MustQuery("select custom_json_func('x'), json_extract(custom_json_func('y'), '$.a')").Check(testkit.Rows(`{"a": "x"} "y"`))
+
+	// the function without the eval function of its type can't be registered
+	extension.Reset()
+	require.NoError(t, extension.Register("test", extension.WithCustomFunctions([]*extension.FunctionDef{
+		{Name: "custom_no_eval_func", EvalTp: types.ETJson, EvalStringFunc: customFunc1.EvalStringFunc},
+	})))
+	require.EqualError(t, extension.Setup(), "eval function is nil")
+}
+
+func TestExtensionFuncVectorized(t *testing.T) {
+	defer extension.Reset()
+	extension.Reset()
+
+	var vecCalled atomic.Int64
+	repeatFunc := func(ctx extension.FunctionContext, row chunk.Row) (string, bool, error) {
+		args, err := ctx.EvalArgs(row)
+		if err != nil || args[0].IsNull() || args[1].IsNull() {
+			return "", true, err
+		}
+		return strings.Repeat(args[1].GetString(), int(args[0].GetInt64())), false, nil
+	}
+	vecDef := &extension.FunctionDef{
+		Name:           "custom_vec_func",
+		EvalTp:         types.ETString,
+		ArgTps:         []types.EvalType{types.ETInt, types.ETString},
+		EvalStringFunc: repeatFunc,
+		EvalVecFunc: func(ctx extension.FunctionContext, n int, args []*chunk.Column, result *chunk.Column) error {
+			vecCalled.Add(1)
+			times := args[0].Int64s()
+			for i := 0; i < n; i++ {
+				if args[0].IsNull(i) || args[1].IsNull(i) {
+					result.AppendNull()
+					continue
+				}
+				result.AppendString(strings.Repeat(args[1].GetString(i), int(times[i])))
+			}
+			return nil
+		},
+	}
+	badVecDef := &extension.FunctionDef{
+		Name:           "custom_bad_vec_func",
+		EvalTp:         types.ETString,
+		ArgTps:         []types.EvalType{types.ETInt, types.ETString},
+		EvalStringFunc: repeatFunc,
+		EvalVecFunc: func(ctx extension.FunctionContext, n int, args []*chunk.Column, result *chunk.Column) error {
+			return nil
+		},
+	}
+	require.NoError(t, extension.Register("test", extension.WithCustomFunctions([]*extension.FunctionDef{vecDef, badVecDef})))
+	require.NoError(t, extension.Setup())
+
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id int primary key, a int, b varchar(10))")
+	tk.MustExec("insert into t values (1, 1, 'a'), (2, 2, 'bc'), (3, null, 'd'), (4, 3, null)")
+
+	tk.MustQuery("select id, custom_vec_func(a, b) from t order by id").Check(testkit.Rows("1 a", "2 bcbc", "3 <nil>", "4 <nil>"))
+	require.Greater(t, vecCalled.Load(), int64(0))
+	tk.MustGetErrMsg("select custom_bad_vec_func(a, b) from t", "extension function 'custom_bad_vec_func' returns 0 rows, but 4 rows are expected")
+
+	// the row-based function is used when the vectorized expression is disabled
+	tk.MustExec("set @@tidb_enable_vectorized_expression = off")
+	vecCalled.Store(0)
+	tk.MustQuery("select id, custom_vec_func(a, b) from t order by id").Check(testkit.Rows("1 a", "2 bcbc", "3 <nil>", "4 <nil>"))
+	tk.MustQuery("select id, custom_bad_vec_func(a, b) from t order by id").Check(testkit.Rows("1 a", "2 bcbc", "3 <nil>", "4 <nil>"))
+	require.Equal(t, int64(0), vecCalled.Load())
+}
+
+// customStrLenSum sums the lengths of the not null strings, it returns NULL if all the strings are NULL.
+type customStrLenSum struct{}
+
+type customStrLenSumResult struct {
+	sum   int64
+	count int64
+}
+
+func (customStrLenSum) AllocPartialResult() (extension.AggPartialResult, int64) {
+	return &customStrLenSumResult{}, 16
+}
+
+func (customStrLenSum) ResetPartialResult(pr extension.AggPartialResult) {
+	*pr.(*customStrLenSumResult) = customStrLenSumResult{}
+}
+
+func (customStrLenSum) UpdatePartialResult(ctx extension.FunctionContext, rowsInGroup []chunk.Row, pr extension.AggPartialResult) (int64, error) {
+	p := pr.(*customStrLenSumResult)
+	for _, row := range rowsInGroup {
+		args, err := ctx.EvalArgs(row)
+		if err != nil {
+			return 0, err
+		}
+		if args[0].IsNull() {
+			continue
+		}
+		p.sum += int64(len(args[0].GetString()))
+		p.count++
+	}
+	return 0, nil
+}
+
+func (customStrLenSum) MergePartialResult(_ extension.FunctionContext, src, dst extension.AggPartialResult) (int64, error) {
+	p1, p2 := src.(*customStrLenSumResult), dst.(*customStrLenSumResult)
+	p2.sum += p1.sum
+	p2.count += p1.count
+	return 0, nil
+}
+
+func (customStrLenSum) AppendFinalResult(_ extension.FunctionContext, pr extension.AggPartialResult, result *chunk.Column) error {
+	p := pr.(*customStrLenSumResult)
+	if p.count == 0 {
+		result.AppendNull()
+		return nil
+	}
+	result.AppendInt64(p.sum)
+	return nil
+}
+
+var customAggFunc = &extension.AggFunctionDef{
+	Name:   "custom_str_len_sum",
+	EvalTp: types.ETInt,
+	ArgTps: []types.EvalType{types.ETString},
+	Impl:   customStrLenSum{},
+}
+
+func TestInvokeExtensionAggFunc(t *testing.T) {
+	defer extension.Reset()
+	extension.Reset()
+
+	require.NoError(t, extension.Register("test", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{customAggFunc})))
+	require.NoError(t, extension.Setup())
+
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b varchar(10))")
+	tk.MustExec("insert into t values (1, 'a'), (1, 'bcd'), (2, null), (2, 'ef'), (3, null), (4, 1234)")
+
+	tk.MustQuery("select custom_str_len_sum(b) from t").Check(testkit.Rows("10"))
+	tk.MustQuery("select custom_str_len_sum(b) from t where a > 10").Check(testkit.Rows("<nil>"))
+	tk.MustQuery("select custom_str_len_sum(a) from t").Check(testkit.Rows("6"))
+	expected := testkit.Rows("1 4", "2 2", "3 <nil>", "4 4")
+	tk.MustQuery("select a, custom_str_len_sum(b) from t group by a order by a").Check(expected)
+	tk.MustQuery("select /*+ STREAM_AGG() */ a, custom_str_len_sum(b) from t group by a order by a").Check(expected)
+	tk.MustQuery("select a, custom_str_len_sum(b) s from t group by a having s > 3 order by a").Check(testkit.Rows("1 4", "4 4"))
+	tk.MustQuery("select a, custom_str_len_sum(b) + count(b) from t group by a order by a").Check(testkit.Rows("1 6", "2 3", "3 <nil>", "4 5"))
+
+	// the partial results are merged in the parallel hash aggregation
+	tk.MustExec("set @@tidb_hashagg_partial_concurrency = 4, @@tidb_hashagg_final_concurrency = 4")
+	tk.MustQuery("select /*+ HASH_AGG() */ a, custom_str_len_sum(b) from t group by a order by a").Check(expected)
+	tk.MustQuery("select /*+ HASH_AGG() */ custom_str_len_sum(b) from t").Check(testkit.Rows("10"))
+
+	// the function is never pushed down
+	for _, row := range tk.MustQuery("explain format = 'brief' select a, custom_str_len_sum(b) from t group by a").Rows() {
+		if strings.Contains(row[4].(string), "custom_str_len_sum") {
+			require.Equal(t, "root", row[2], row)
+		}
+	}
+
+	tk.MustExec("create view v as select a, custom_str_len_sum(b) as s from t group by a")
+	tk.MustQuery("select * from v order by a").Check(expected)
+	tk.MustExec("prepare stmt from 'select custom_str_len_sum(b) from t where a = ?'")
+	tk.MustExec("set @a = 1")
+	tk.MustQuery("execute stmt using @a").Check(testkit.Rows("4"))
+
+	require.EqualError(t, tk.ExecToErr("select custom_str_len_sum(a, b) from t"), "[expression:1582]Incorrect parameter count in the call to native function 'custom_str_len_sum'")
+	require.EqualError(t, tk.ExecToErr("select test.custom_str_len_sum(b) from t"), "[expression:1305]FUNCTION test.custom_str_len_sum does not exist")
+
+	extension.Reset()
+	require.EqualError(t, tk.ExecToErr("select custom_str_len_sum(b) from t"), "[expression:1305]FUNCTION test.custom_str_len_sum does not exist")
+}
+
+func TestRegisterExtensionAggFunc(t *testing.T) {
+	defer extension.Reset()
+
+	// dup name with builtin
+	for _, name := range []string{"count", "substring"} {
+		extension.Reset()
+		def := *customAggFunc
+		def.Name = name
+		require.NoError(t, extension.Register("test", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{&def})))
+		require.EqualError(t, extension.Setup(), fmt.Sprintf("extension function name '%s' conflict with builtin", name))
+	}
+
+	// dup name with the scalar function
+	extension.Reset()
+	def := *customAggFunc
+	def.Name = customFunc1.Name
+	require.NoError(t, extension.Register("test",
+		extension.WithCustomFunctions([]*extension.FunctionDef{customFunc1}),
+		extension.WithCustomAggFunctions([]*extension.AggFunctionDef{&def}),
+	))
+	require.EqualError(t, extension.Setup(), "duplicated extension function name 'custom_func1'")
+
+	// no implementation
+	extension.Reset()
+	def = *customAggFunc
+	def.Impl = nil
+	require.NoError(t, extension.Register("test", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{&def})))
+	require.EqualError(t, extension.Setup(), "extension aggregate function 'custom_str_len_sum' has no implementation")
+
+	// the function is removed if the setup fails
+	extension.Reset()
+	require.NoError(t, extension.Register("test", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{customAggFunc, customAggFunc})))
+	require.EqualError(t, extension.Setup(), "duplicated extension function name 'custom_str_len_sum'")
+	_, ok := expression.GetExtensionAggFunc("custom_str_len_sum")
+	require.False(t, ok)
+}
+
+func TestExtensionAggFuncPrivilege(t *testing.T) {
+	defer extension.Reset()
+	extension.Reset()
+
+	def := *customAggFunc
+	def.RequireDynamicPrivileges = func(sem bool) []string {
+		return []string{"CUSTOM_AGG_PRIV"}
+	}
+	require.NoError(t, extension.Register("test",
+		extension.WithCustomAggFunctions([]*extension.AggFunctionDef{&def}),
+		extension.WithCustomDynPrivs([]string{"CUSTOM_AGG_PRIV"}),
+	))
+	require.NoError(t, extension.Setup())
+
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(b varchar(10))")
+	tk.MustExec("insert into t values ('abc')")
+	tk.MustExec("create user u1@localhost, u2@localhost")
+	tk.MustExec("grant select on test.* to u1@localhost, u2@localhost")
+	tk.MustExec("grant CUSTOM_AGG_PRIV on *.* to u2@localhost")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "localhost"}, nil, nil, nil))
+	require.EqualError(t, tk1.ExecToErr("select custom_str_len_sum(b) from test.t"), "[expression:1227]Access denied; you need (at least one of) the SUPER or CUSTOM_AGG_PRIV privilege(s) for this operation")
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u2", Hostname: "localhost"}, nil, nil, nil))
+	tk1.MustQuery("select custom_str_len_sum(b) from test.t").Check(testkit.Rows("3"))
+}
@@ -297,6 +297,8 @@ func TestInfoTables(t *testing.T) {
 		"PLACEMENT_POLICIES",
 		"TRX_SUMMARY",
 		"RESOURCE_GROUPS",
+		"AUTO_ANALYZE_QUEUE",
+		"INSTANCE_PLAN_CACHE",
 	}
 	for _, tbl := range infoTables {
 		tb, err1 := is.TableByName(util.InformationSchemaName, model.NewCIStr(tbl))
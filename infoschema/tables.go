@@ -203,6 +203,10 @@ const (
 	TableMemoryUsageOpsHistory = "MEMORY_USAGE_OPS_HISTORY"
 	// TableResourceGroups is the metadata of resource groups.
 	TableResourceGroups = "RESOURCE_GROUPS"
+	// TableAutoAnalyzeQueue is the priority queue of the auto analyze jobs.
+	TableAutoAnalyzeQueue = "AUTO_ANALYZE_QUEUE"
+	// TableInstancePlanCache is the statistics of the statements in the instance plan cache.
+	TableInstancePlanCache = "INSTANCE_PLAN_CACHE"
 )
 
 const (
@@ -310,6 +314,8 @@ var tableIDMap = map[string]int64{
 	ClusterTableMemoryUsage:              autoid.InformationSchemaDBID + 86,
 	ClusterTableMemoryUsageOpsHistory:    autoid.InformationSchemaDBID + 87,
 	TableResourceGroups:                  autoid.InformationSchemaDBID + 88,
+	TableAutoAnalyzeQueue:                autoid.InformationSchemaDBID + 89,
+	TableInstancePlanCache:               autoid.InformationSchemaDBID + 90,
 }
 
 // columnInfo represents the basic column information of all kinds of INFORMATION_SCHEMA tables
@@ -1003,6 +1009,31 @@ var tableAnalyzeStatusCols = []columnInfo{
 	{name: "ESTIMATED_TOTAL_ROWS", tp: mysql.TypeLonglong, size: 64, flag: mysql.UnsignedFlag},
 }
 
+var tableAutoAnalyzeQueueCols = []columnInfo{
+	{name: "TABLE_SCHEMA", tp: mysql.TypeVarchar, size: 64},
+	{name: "TABLE_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "PARTITION_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "TABLE_ID", tp: mysql.TypeLonglong, size: 21},
+	{name: "PRIORITY", tp: mysql.TypeDouble, size: 22},
+	{name: "CHANGE_RATIO", tp: mysql.TypeDouble, size: 22},
+	{name: "ROW_COUNT", tp: mysql.TypeLonglong, size: 21},
+	{name: "LAST_ANALYZE_TIME", tp: mysql.TypeDatetime},
+	{name: "LAST_USED_TIME", tp: mysql.TypeDatetime},
+	{name: "LAST_FAILED_TIME", tp: mysql.TypeDatetime},
+	{name: "REASON", tp: mysql.TypeVarchar, size: 256},
+	{name: "STATE", tp: mysql.TypeVarchar, size: 16},
+	{name: "UPDATE_TIME", tp: mysql.TypeDatetime},
+}
+
+var tableInstancePlanCacheCols = []columnInfo{
+	{name: "SQL_DIGEST", tp: mysql.TypeVarchar, size: 64},
+	{name: "DIGEST_TEXT", tp: mysql.TypeBlob, size: types.UnspecifiedLength},
+	{name: "PLAN_NUM", tp: mysql.TypeLonglong, size: 21},
+	{name: "MEMORY_USAGE", tp: mysql.TypeLonglong, size: 21},
+	{name: "HITS", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "MISSES", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+}
+
 // TableTiKVRegionStatusCols is TiKV region status mem table columns.
 var TableTiKVRegionStatusCols = []columnInfo{
 	{name: "REGION_ID", tp: mysql.TypeLonglong, size: 21},
@@ -2117,6 +2148,8 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableMemoryUsage:                        tableMemoryUsageCols,
 	TableMemoryUsageOpsHistory:              tableMemoryUsageOpsHistoryCols,
 	TableResourceGroups:                     tableResourceGroupsCols,
+	TableAutoAnalyzeQueue:                   tableAutoAnalyzeQueueCols,
+	TableInstancePlanCache:                  tableInstancePlanCacheCols,
 }
 
 func createInfoSchemaTable(_ autoid.Allocators, meta *model.TableInfo) (table.Table, error) {
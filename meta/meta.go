@@ -1340,6 +1340,8 @@ var (
 	ColumnElementKey ElementKeyType = []byte("_col_")
 	// IndexElementKey is the key for index element.
 	IndexElementKey ElementKeyType = []byte("_idx_")
+	// ConstraintElementKey is the key for check constraint element.
+	ConstraintElementKey ElementKeyType = []byte("_con_")
 )
 
 const elementKeyLen = 5
@@ -1380,6 +1382,8 @@ func DecodeElement(b []byte) (*Element, error) {
 		tp = IndexElementKey
 	case string(ColumnElementKey):
 		tp = ColumnElementKey
+	case string(ConstraintElementKey):
+		tp = ConstraintElementKey
 	default:
 		return nil, errors.Errorf("invalid encoded element key prefix %q", prefix)
 	}
@@ -507,6 +507,7 @@ func TestElement(t *testing.T) {
 	checkElement(key, errors.Errorf(`invalid encoded element key prefix "_col\x00"`))
 	checkElement(meta.IndexElementKey, nil)
 	checkElement(meta.ColumnElementKey, nil)
+	checkElement(meta.ConstraintElementKey, nil)
 	key = []byte("inexistent")
 	checkElement(key, errors.Errorf("invalid encoded element key prefix %q", key[:5]))
 
@@ -525,6 +525,7 @@ const (
 	ColumnOptionColumnFormat
 	ColumnOptionStorage
 	ColumnOptionAutoRandom
+	ColumnOptionSRID // For spatial types only.
 )
 
 var (
@@ -543,6 +544,7 @@ type ColumnOption struct {
 	// Expr is used for ColumnOptionDefaultValue/ColumnOptionOnUpdateColumnOptionGenerated.
 	// For ColumnOptionDefaultValue or ColumnOptionOnUpdate, it's the target value.
 	// For ColumnOptionGenerated, it's the target expression.
+	// For ColumnOptionSRID, it's the SRID value.
 	Expr ExprNode
 	// Stored is only for ColumnOptionGenerated, default is false.
 	Stored bool
@@ -655,6 +657,11 @@ func (n *ColumnOption) Restore(ctx *format.RestoreCtx) error {
 			}
 			return nil
 		})
+	case ColumnOptionSRID:
+		ctx.WriteKeyWord("SRID ")
+		if err := n.Expr.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while splicing ColumnOption SRID Expr")
+		}
 	default:
 		return errors.New("An error occurred while splicing ColumnOption")
 	}
@@ -2288,6 +2295,8 @@ const (
 	TableOptionTTL
 	TableOptionTTLEnable
 	TableOptionTTLJobInterval
+	TableOptionTTLTimestampUnit
+	TableOptionTTLArchiveTable
 	TableOptionPlacementPolicy = TableOptionType(PlacementOptionPolicy)
 	TableOptionStatsBuckets    = TableOptionType(StatsOptionBuckets)
 	TableOptionStatsTopN       = TableOptionType(StatsOptionTopN)
@@ -2653,6 +2662,23 @@ func (n *TableOption) Restore(ctx *format.RestoreCtx) error {
 			ctx.WriteString(n.StrValue)
 			return nil
 		})
+	case TableOptionTTLTimestampUnit:
+		_ = ctx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+			ctx.WriteKeyWord("TTL_TIMESTAMP_UNIT ")
+			ctx.WritePlain("= ")
+			ctx.WriteString(n.StrValue)
+			return nil
+		})
+	case TableOptionTTLArchiveTable:
+		_ = ctx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+			ctx.WriteKeyWord("TTL_ARCHIVE_TABLE ")
+			ctx.WritePlain("= ")
+			if len(n.TableNames) == 0 {
+				ctx.WriteKeyWord("NULL")
+				return nil
+			}
+			return n.TableNames[0].Restore(ctx)
+		})
 	default:
 		return errors.Errorf("invalid TableOption: %d", n.Tp)
 	}
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/types"
 )
 
 var (
@@ -526,11 +527,15 @@ type TableSource struct {
 	node
 
 	// Source is the source of the data, can be a TableName,
-	// a SelectStmt, a SetOprStmt, or a JoinNode.
+	// a SelectStmt, a SetOprStmt, a JSONTable, or a JoinNode.
 	Source ResultSetNode
 
 	// AsName is the alias name of the table source.
 	AsName model.CIStr
+
+	// Lateral indicates the derived table is declared with `LATERAL`, it can
+	// reference the columns of the tables preceding it in the FROM clause.
+	Lateral bool
 }
 
 func (*TableSource) resultSet() {}
@@ -576,6 +581,9 @@ func (n *TableSource) Restore(ctx *format.RestoreCtx) error {
 			ctx.WritePlain(")")
 		}
 	} else {
+		if n.Lateral {
+			ctx.WriteKeyWord("LATERAL ")
+		}
 		if needParen {
 			ctx.WritePlain("(")
 		}
@@ -609,6 +617,159 @@ func (n *TableSource) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// JSONTableColumnType is the type of a column defined in JSON_TABLE.
+type JSONTableColumnType int
+
+// JSON_TABLE column types.
+const (
+	// JSONTableColumnPath is `name type PATH path [on_empty] [on_error]`.
+	JSONTableColumnPath JSONTableColumnType = iota
+	// JSONTableColumnOrdinality is `name FOR ORDINALITY`.
+	JSONTableColumnOrdinality
+	// JSONTableColumnExists is `name type EXISTS PATH path`.
+	JSONTableColumnExists
+	// JSONTableColumnNested is `NESTED [PATH] path COLUMNS (column_list)`.
+	JSONTableColumnNested
+)
+
+// JSONTableOnResponseType is the behavior of a JSON_TABLE column when the
+// path is missing or an error occurs.
+type JSONTableOnResponseType int
+
+// JSON_TABLE ON EMPTY / ON ERROR response types.
+const (
+	JSONTableOnResponseNull JSONTableOnResponseType = iota
+	JSONTableOnResponseError
+	JSONTableOnResponseDefault
+)
+
+// JSONTableOnResponse represents `{NULL | ERROR | DEFAULT json_string} ON {EMPTY | ERROR}`.
+type JSONTableOnResponse struct {
+	Tp JSONTableOnResponseType
+	// Default is the JSON string used when Tp is JSONTableOnResponseDefault.
+	Default string
+}
+
+// Restore restores the response without the trailing `ON EMPTY` or `ON ERROR`.
+func (n *JSONTableOnResponse) Restore(ctx *format.RestoreCtx) {
+	switch n.Tp {
+	case JSONTableOnResponseNull:
+		ctx.WriteKeyWord("NULL")
+	case JSONTableOnResponseError:
+		ctx.WriteKeyWord("ERROR")
+	case JSONTableOnResponseDefault:
+		ctx.WriteKeyWord("DEFAULT ")
+		ctx.WriteString(n.Default)
+	}
+}
+
+// JSONTableColumn represents a column definition in JSON_TABLE.
+// See https://dev.mysql.com/doc/refman/8.0/en/json-table-functions.html
+type JSONTableColumn struct {
+	Tp   JSONTableColumnType
+	Name model.CIStr
+	// FieldType is nil for ORDINALITY and NESTED columns.
+	FieldType *types.FieldType
+	Path      string
+	OnEmpty   *JSONTableOnResponse
+	OnError   *JSONTableOnResponse
+	// NestedColumns is only used by NESTED columns.
+	NestedColumns []*JSONTableColumn
+}
+
+// Restore restores the column definition.
+func (n *JSONTableColumn) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp == JSONTableColumnNested {
+		ctx.WriteKeyWord("NESTED PATH ")
+		ctx.WriteString(n.Path)
+		return restoreJSONTableColumns(ctx, n.NestedColumns)
+	}
+	ctx.WriteName(n.Name.O)
+	if n.Tp == JSONTableColumnOrdinality {
+		ctx.WriteKeyWord(" FOR ORDINALITY")
+		return nil
+	}
+	ctx.WritePlain(" ")
+	if err := n.FieldType.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTableColumn.FieldType")
+	}
+	if n.Tp == JSONTableColumnExists {
+		ctx.WriteKeyWord(" EXISTS")
+	}
+	ctx.WriteKeyWord(" PATH ")
+	ctx.WriteString(n.Path)
+	if n.OnEmpty != nil {
+		ctx.WritePlain(" ")
+		n.OnEmpty.Restore(ctx)
+		ctx.WriteKeyWord(" ON EMPTY")
+	}
+	if n.OnError != nil {
+		ctx.WritePlain(" ")
+		n.OnError.Restore(ctx)
+		ctx.WriteKeyWord(" ON ERROR")
+	}
+	return nil
+}
+
+func restoreJSONTableColumns(ctx *format.RestoreCtx, cols []*JSONTableColumn) error {
+	ctx.WriteKeyWord(" COLUMNS ")
+	ctx.WritePlain("(")
+	for i, col := range cols {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := col.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore JSONTable.Columns[%d]", i)
+		}
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// JSONTable represents the `JSON_TABLE(expr, path COLUMNS (column_list))` table function.
+// It is always used as the source of a TableSource, and it may reference the
+// columns of the tables preceding it in the FROM clause.
+type JSONTable struct {
+	node
+
+	Expr    ExprNode
+	Path    string
+	Columns []*JSONTableColumn
+}
+
+func (*JSONTable) resultSet() {}
+
+// Restore implements Node interface.
+func (n *JSONTable) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("JSON_TABLE")
+	ctx.WritePlain("(")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTable.Expr")
+	}
+	ctx.WritePlain(", ")
+	ctx.WriteString(n.Path)
+	if err := restoreJSONTableColumns(ctx, n.Columns); err != nil {
+		return err
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *JSONTable) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*JSONTable)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
 // SelectLockType is the lock type for SelectStmt.
 type SelectLockType int
 
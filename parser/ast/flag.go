@@ -62,6 +62,8 @@ func (f *flagSetter) Leave(in Node) (Node, bool) {
 		f.funcCall(x)
 	case *FuncCastExpr:
 		x.SetFlag(FlagHasFunc | x.Expr.GetFlag())
+	case *JSONValueExpr:
+		x.SetFlag(FlagHasFunc | x.Expr.GetFlag() | x.Path.GetFlag())
 	case *IsNullExpr:
 		x.SetFlag(x.Expr.GetFlag())
 	case *IsTruthExpr:
@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/types"
 )
 
@@ -29,6 +30,7 @@ var (
 	_ FuncNode = &AggregateFuncExpr{}
 	_ FuncNode = &FuncCallExpr{}
 	_ FuncNode = &FuncCastExpr{}
+	_ FuncNode = &JSONValueExpr{}
 	_ FuncNode = &WindowFuncExpr{}
 )
 
@@ -354,6 +356,18 @@ const (
 	JSONKeys          = "json_keys"
 	JSONLength        = "json_length"
 
+	JSONSchemaValid            = "json_schema_valid"
+	JSONSchemaValidationReport = "json_schema_validation_report"
+	JSONValue                  = "json_value"
+
+	// spatial functions
+	STAsText         = "st_astext"
+	STContains       = "st_contains"
+	STDistanceSphere = "st_distance_sphere"
+	STGeomFromText   = "st_geomfromtext"
+	STSRID           = "st_srid"
+	STWithin         = "st_within"
+
 	// TiDB internal function.
 	TiDBDecodeKey       = "tidb_decode_key"
 	TiDBDecodeBase64Key = "tidb_decode_base64_key"
@@ -673,6 +687,101 @@ func (n *FuncCastExpr) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// JSONValueExpr is the JSON_VALUE function, e.g, json_value(doc, '$.a' RETURNING signed DEFAULT '0' ON EMPTY).
+// See https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-value
+type JSONValueExpr struct {
+	funcNode
+	// Expr is the JSON document.
+	Expr ExprNode
+	// Path is the JSON path.
+	Path ExprNode
+	// Tp is the type of the RETURNING clause, it's nil if the clause is omitted.
+	Tp *types.FieldType
+	// ExplicitCharSet is true when charset is explicit indicated in the RETURNING clause.
+	ExplicitCharSet bool
+	// OnEmpty and OnError are nil if the clauses are omitted, which means NULL.
+	OnEmpty *JSONTableOnResponse
+	OnError *JSONTableOnResponse
+}
+
+// Restore implements Node interface.
+func (n *JSONValueExpr) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("JSON_VALUE")
+	ctx.WritePlain("(")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotatef(err, "An error occurred while restore JSONValueExpr.Expr")
+	}
+	ctx.WritePlain(", ")
+	if err := n.Path.Restore(ctx); err != nil {
+		return errors.Annotatef(err, "An error occurred while restore JSONValueExpr.Path")
+	}
+	if n.Tp != nil {
+		ctx.WriteKeyWord(" RETURNING ")
+		n.Tp.RestoreAsCastType(ctx, n.ExplicitCharSet)
+		// RestoreAsCastType omits the default charset, write it to keep the charset explicit.
+		if n.ExplicitCharSet && n.Tp.GetCharset() == mysql.DefaultCharset {
+			ctx.WriteKeyWord(" CHARSET ")
+			ctx.WriteKeyWord(n.Tp.GetCharset())
+		}
+	}
+	if n.OnEmpty != nil {
+		ctx.WritePlain(" ")
+		n.OnEmpty.Restore(ctx)
+		ctx.WriteKeyWord(" ON EMPTY")
+	}
+	if n.OnError != nil {
+		ctx.WritePlain(" ")
+		n.OnError.Restore(ctx)
+		ctx.WriteKeyWord(" ON ERROR")
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// Format the ExprNode into a Writer.
+func (n *JSONValueExpr) Format(w io.Writer) {
+	fmt.Fprint(w, "JSON_VALUE(")
+	n.Expr.Format(w)
+	fmt.Fprint(w, ", ")
+	n.Path.Format(w)
+	if n.Tp != nil {
+		fmt.Fprint(w, " RETURNING ")
+		n.Tp.FormatAsCastType(w, n.ExplicitCharSet)
+	}
+	formatResponse := func(resp *JSONTableOnResponse, on string) {
+		var sb strings.Builder
+		resp.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb))
+		fmt.Fprintf(w, " %s ON %s", sb.String(), on)
+	}
+	if n.OnEmpty != nil {
+		formatResponse(n.OnEmpty, "EMPTY")
+	}
+	if n.OnError != nil {
+		formatResponse(n.OnError, "ERROR")
+	}
+	fmt.Fprint(w, ")")
+}
+
+// Accept implements Node Accept interface.
+func (n *JSONValueExpr) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*JSONValueExpr)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	node, ok = n.Path.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Path = node.(ExprNode)
+	return v.Leave(n)
+}
+
 // TrimDirectionType is the type for trim direction.
 type TrimDirectionType int
 
@@ -908,6 +1017,8 @@ type WindowFuncExpr struct {
 	// FromLast indicates the calculation direction of this window function.
 	// MySQL only supports calculation from first, so we need to raise error if it is true.
 	FromLast bool
+	// Order is only used in GROUP_CONCAT.
+	Order *OrderByClause
 	// Spec is the specification of this window.
 	Spec WindowSpec
 }
@@ -916,14 +1027,39 @@ type WindowFuncExpr struct {
 func (n *WindowFuncExpr) Restore(ctx *format.RestoreCtx) error {
 	ctx.WriteKeyWord(n.F)
 	ctx.WritePlain("(")
-	for i, v := range n.Args {
-		if i != 0 {
-			ctx.WritePlain(", ")
-		} else if n.Distinct {
+	switch strings.ToLower(n.F) {
+	case "group_concat":
+		if n.Distinct {
 			ctx.WriteKeyWord("DISTINCT ")
 		}
-		if err := v.Restore(ctx); err != nil {
-			return errors.Annotatef(err, "An error occurred while restore WindowFuncExpr.Args[%d]", i)
+		for i := 0; i < len(n.Args)-1; i++ {
+			if i != 0 {
+				ctx.WritePlain(", ")
+			}
+			if err := n.Args[i].Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore WindowFuncExpr.Args[%d]", i)
+			}
+		}
+		if n.Order != nil {
+			ctx.WritePlain(" ")
+			if err := n.Order.Restore(ctx); err != nil {
+				return errors.Annotate(err, "An error occur while restore WindowFuncExpr.Args Order")
+			}
+		}
+		ctx.WriteKeyWord(" SEPARATOR ")
+		if err := n.Args[len(n.Args)-1].Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore WindowFuncExpr.Args SEPARATOR")
+		}
+	default:
+		for i, v := range n.Args {
+			if i != 0 {
+				ctx.WritePlain(", ")
+			} else if n.Distinct {
+				ctx.WriteKeyWord("DISTINCT ")
+			}
+			if err := v.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore WindowFuncExpr.Args[%d]", i)
+			}
 		}
 	}
 	ctx.WritePlain(")")
@@ -960,6 +1096,13 @@ func (n *WindowFuncExpr) Accept(v Visitor) (Node, bool) {
 		}
 		n.Args[i] = node.(ExprNode)
 	}
+	if n.Order != nil {
+		node, ok := n.Order.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Order = node.(*OrderByClause)
+	}
 	node, ok := n.Spec.Accept(v)
 	if !ok {
 		return n, false
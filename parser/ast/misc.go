@@ -60,6 +60,7 @@ var (
 	_ StmtNode = &PlanReplayerStmt{}
 	_ StmtNode = &CompactTableStmt{}
 	_ StmtNode = &SetResourceGroupStmt{}
+	_ StmtNode = &XAStmt{}
 
 	_ Node = &PrivElem{}
 	_ Node = &VariableAssignment{}
@@ -1048,6 +1049,132 @@ func (n *ReleaseSavepointStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// XAStmtType is the type of the XA statement.
+type XAStmtType int
+
+// XA statement types.
+const (
+	XAStart XAStmtType = iota
+	XAEnd
+	XAPrepare
+	XACommit
+	XARollback
+	XARecover
+)
+
+// DefaultXIDFormatID is the format ID of the XID if it's not specified.
+const DefaultXIDFormatID = 1
+
+// XID is the identifier of an XA transaction branch.
+// See https://dev.mysql.com/doc/refman/8.0/en/xa-statements.html
+type XID struct {
+	// GTRID is the global transaction identifier.
+	GTRID string
+	// BQUAL is the branch qualifier.
+	BQUAL string
+	// FormatID is the number which identifies the format of GTRID and BQUAL.
+	FormatID uint64
+}
+
+// Restore implements Node interface.
+func (x *XID) Restore(ctx *format.RestoreCtx) error {
+	restoreXIDPart(ctx, x.GTRID)
+	if x.BQUAL == "" && x.FormatID == DefaultXIDFormatID {
+		return nil
+	}
+	ctx.WritePlain(",")
+	restoreXIDPart(ctx, x.BQUAL)
+	if x.FormatID != DefaultXIDFormatID {
+		ctx.WritePlainf(",%d", x.FormatID)
+	}
+	return nil
+}
+
+// restoreXIDPart writes the binary parts of the XID as hexadecimal literals, so they can be restored losslessly.
+func restoreXIDPart(ctx *format.RestoreCtx, part string) {
+	for i := 0; i < len(part); i++ {
+		if part[i] < 0x20 || part[i] > 0x7e {
+			ctx.WritePlainf("0x%X", part)
+			return
+		}
+	}
+	ctx.WriteString(part)
+}
+
+// String implements fmt.Stringer interface.
+func (x *XID) String() string {
+	var sb strings.Builder
+	_ = x.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb))
+	return sb.String()
+}
+
+// XAStmt is the statement of XA START, XA END, XA PREPARE, XA COMMIT, XA ROLLBACK and XA RECOVER.
+type XAStmt struct {
+	stmtNode
+
+	Tp XAStmtType
+	// XID is nil for XA RECOVER.
+	XID *XID
+	// Join and Resume are the options of XA START, they are accepted but take no effect.
+	Join   bool
+	Resume bool
+	// Suspend and ForMigrate are the options of XA END, they are accepted but take no effect.
+	Suspend    bool
+	ForMigrate bool
+	// OnePhase indicates XA COMMIT ... ONE PHASE, which prepares and commits the branch in a single step.
+	OnePhase bool
+	// ConvertXID indicates XA RECOVER CONVERT XID, which shows the XIDs in hexadecimal.
+	ConvertXID bool
+}
+
+// Restore implements Node interface.
+func (n *XAStmt) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case XAStart:
+		ctx.WriteKeyWord("XA START ")
+	case XAEnd:
+		ctx.WriteKeyWord("XA END ")
+	case XAPrepare:
+		ctx.WriteKeyWord("XA PREPARE ")
+	case XACommit:
+		ctx.WriteKeyWord("XA COMMIT ")
+	case XARollback:
+		ctx.WriteKeyWord("XA ROLLBACK ")
+	case XARecover:
+		ctx.WriteKeyWord("XA RECOVER")
+		if n.ConvertXID {
+			ctx.WriteKeyWord(" CONVERT XID")
+		}
+		return nil
+	default:
+		return errors.Errorf("invalid XA statement type: %d", n.Tp)
+	}
+	if err := n.XID.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore XAStmt.XID")
+	}
+	switch {
+	case n.Join:
+		ctx.WriteKeyWord(" JOIN")
+	case n.Resume:
+		ctx.WriteKeyWord(" RESUME")
+	case n.Suspend:
+		ctx.WriteKeyWord(" SUSPEND")
+		if n.ForMigrate {
+			ctx.WriteKeyWord(" FOR MIGRATE")
+		}
+	case n.OnePhase:
+		ctx.WriteKeyWord(" ONE PHASE")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *XAStmt) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	n = newNode.(*XAStmt)
+	return v.Leave(n)
+}
+
 // SetStmt is the statement to set variables.
 type SetStmt struct {
 	stmtNode
@@ -333,6 +333,7 @@ var tokenMap = map[string]int{
 	"DYNAMIC":                  dynamic,
 	"ELSE":                     elseKwd,
 	"ELSEIF":                   elseIfKwd,
+	"EMPTY":                    emptyKwd,
 	"ENABLE":                   enable,
 	"ENABLED":                  enabled,
 	"ENCLOSED":                 enclosed,
@@ -391,6 +392,9 @@ var tokenMap = map[string]int{
 	"GC_TTL":                   gcTTL,
 	"GENERAL":                  general,
 	"GENERATED":                generated,
+	"GEOMCOLLECTION":           geomCollection,
+	"GEOMETRY":                 geometry,
+	"GEOMETRYCOLLECTION":       geometryCollection,
 	"GET_FORMAT":               getFormat,
 	"GLOBAL":                   global,
 	"GRANT":                    grant,
@@ -457,7 +461,9 @@ var tokenMap = map[string]int{
 	"JOB":                      job,
 	"JOBS":                     jobs,
 	"JOIN":                     join,
+	"JSON_TABLE":               jsonTable,
 	"JSON_ARRAYAGG":            jsonArrayagg,
+	"JSON_VALUE":               jsonValue,
 	"JSON_OBJECTAGG":           jsonObjectAgg,
 	"JSON":                     jsonType,
 	"KEY_BLOCK_SIZE":           keyBlockSize,
@@ -469,6 +475,7 @@ var tokenMap = map[string]int{
 	"LAST_BACKUP":              lastBackup,
 	"LAST":                     last,
 	"LASTVAL":                  lastval,
+	"LATERAL":                  lateral,
 	"LEADER":                   leader,
 	"LEADER_CONSTRAINTS":       leaderConstraints,
 	"LEADING":                  leading,
@@ -483,6 +490,7 @@ var tokenMap = map[string]int{
 	"LIMIT":                    limit,
 	"LINEAR":                   linear,
 	"LINES":                    lines,
+	"LINESTRING":               lineString,
 	"LIST":                     list,
 	"LOAD":                     load,
 	"LOCAL":                    local,
@@ -516,6 +524,7 @@ var tokenMap = map[string]int{
 	"MERGE":                    merge,
 	"METADATA":                 metadata,
 	"MICROSECOND":              microsecond,
+	"MIGRATE":                  migrate,
 	"MIN_ROWS":                 minRows,
 	"MIN":                      min,
 	"MINUTE_MICROSECOND":       minuteMicrosecond,
@@ -526,10 +535,14 @@ var tokenMap = map[string]int{
 	"MODE":                     mode,
 	"MODIFY":                   modify,
 	"MONTH":                    month,
+	"MULTILINESTRING":          multiLineString,
+	"MULTIPOINT":               multiPoint,
+	"MULTIPOLYGON":             multiPolygon,
 	"NAMES":                    names,
 	"NATIONAL":                 national,
 	"NATURAL":                  natural,
 	"NCHAR":                    ncharType,
+	"NESTED":                   nested,
 	"NEVER":                    never,
 	"NEXT_ROW_ID":              next_row_id,
 	"NEXT":                     next,
@@ -558,6 +571,7 @@ var tokenMap = map[string]int{
 	"OLTP_READ_ONLY":           oltpReadOnly,
 	"OLTP_READ_WRITE":          oltpReadWrite,
 	"OLTP_WRITE_ONLY":          oltpWriteOnly,
+	"ONE":                      one,
 	"ON_DUPLICATE":             onDuplicate,
 	"ON":                       on,
 	"ONLINE":                   online,
@@ -568,6 +582,7 @@ var tokenMap = map[string]int{
 	"OPTIMIZE":                 optimize,
 	"OPTION":                   option,
 	"OPTIONAL":                 optional,
+	"ORDINALITY":               ordinality,
 	"OPTIONALLY":               optionally,
 	"OR":                       or,
 	"ORDER":                    order,
@@ -582,17 +597,20 @@ var tokenMap = map[string]int{
 	"PARTITIONING":             partitioning,
 	"PARTITIONS":               partitions,
 	"PASSWORD":                 password,
+	"PATH":                     path,
 	"PAUSE":                    pause,
 	"PERCENT":                  percent,
 	"PER_DB":                   per_db,
 	"PER_TABLE":                per_table,
 	"PESSIMISTIC":              pessimistic,
+	"PHASE":                    phase,
 	"PLACEMENT":                placement,
 	"PLAN":                     plan,
 	"PLAN_CACHE":               planCache,
 	"PLUGINS":                  plugins,
 	"POINT":                    point,
 	"POLICY":                   policy,
+	"POLYGON":                  polygon,
 	"POSITION":                 position,
 	"PRE_SPLIT_REGIONS":        preSplitRegions,
 	"PRECEDING":                preceding,
@@ -667,6 +685,7 @@ var tokenMap = map[string]int{
 	"RTREE":                    rtree,
 	"HYPO":                     hypo,
 	"RESUME":                   resume,
+	"RETURNING":                returning,
 	"RUN":                      run,
 	"RUNNING":                  running,
 	"S3":                       s3,
@@ -729,6 +748,7 @@ var tokenMap = map[string]int{
 	"SQLEXCEPTION":             sqlexception,
 	"SQLSTATE":                 sqlstate,
 	"SQLWARNING":               sqlwarning,
+	"SRID":                     srid,
 	"SSL":                      ssl,
 	"STALENESS":                staleness,
 	"START":                    start,
@@ -768,6 +788,7 @@ var tokenMap = map[string]int{
 	"SUBSTRING":                substring,
 	"SUM":                      sum,
 	"SUPER":                    super,
+	"SUSPEND":                  suspend,
 	"SURVIVAL_PREFERENCES":     survivalPreferences,
 	"SWAPS":                    swaps,
 	"SWITCHES":                 switchesSym,
@@ -825,8 +846,10 @@ var tokenMap = map[string]int{
 	"TRUNCATE":                 truncate,
 	"TRUE_CARD_COST":           trueCardCost,
 	"TTL":                      ttl,
+	"TTL_ARCHIVE_TABLE":        ttlArchiveTable,
 	"TTL_ENABLE":               ttlEnable,
 	"TTL_JOB_INTERVAL":         ttlJobInterval,
+	"TTL_TIMESTAMP_UNIT":       ttlTimestampUnit,
 	"TYPE":                     tp,
 	"UNBOUNDED":                unbounded,
 	"UNCOMMITTED":              uncommitted,
@@ -878,6 +901,8 @@ var tokenMap = map[string]int{
 	"WRITE":                    write,
 	"WORKLOAD":                 workload,
 	"X509":                     x509,
+	"XA":                       xa,
+	"XID":                      xid,
 	"XOR":                      xor,
 	"YEAR_MONTH":               yearMonth,
 	"YEAR":                     yearType,
@@ -797,9 +797,9 @@ func (job *Job) NotStarted() bool {
 // MayNeedReorg indicates that this job may need to reorganize the data.
 func (job *Job) MayNeedReorg() bool {
 	switch job.Type {
-	case ActionAddIndex, ActionAddPrimaryKey, ActionReorganizePartition:
+	case ActionAddIndex, ActionAddPrimaryKey, ActionReorganizePartition, ActionAddCheckConstraint:
 		return true
-	case ActionModifyColumn:
+	case ActionModifyColumn, ActionAlterCheckConstraint:
 		if len(job.CtxVars) > 0 {
 			needReorg, ok := job.CtxVars[0].(bool)
 			return ok && needReorg
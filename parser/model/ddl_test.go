@@ -78,6 +78,7 @@ func TestMayNeedReorg(t *testing.T) {
 		model.ActionReorganizePartition,
 		model.ActionAddIndex,
 		model.ActionAddPrimaryKey,
+		model.ActionAddCheckConstraint,
 	}
 	generalJobTypes := []model.ActionType{
 		model.ActionCreateTable,
@@ -101,4 +102,10 @@ func TestMayNeedReorg(t *testing.T) {
 		job.Type = jobType
 		require.False(t, job.MayNeedReorg())
 	}
+	// Only enforcing a check constraint needs to validate the existing data.
+	job.Type = model.ActionAlterCheckConstraint
+	job.CtxVars = []interface{}{true}
+	require.True(t, job.MayNeedReorg())
+	job.CtxVars = []interface{}{false}
+	require.False(t, job.MayNeedReorg())
 }
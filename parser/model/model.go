@@ -1170,6 +1170,27 @@ type PartitionInfo struct {
 	Num    uint64           `json:"num"`
 	// Only used during ReorganizePartition so far
 	DDLState SchemaState `json:"ddl_state"`
+
+	// Sub is the subpartitioning of the table, it's nil if the table isn't subpartitioned.
+	// For a subpartitioned table, the definitions are the subpartitions, which hold the data.
+	// Every partition consists of Sub.Num consecutive definitions with the same ParentName.
+	Sub *SubPartitionInfo `json:"sub,omitempty"`
+}
+
+// SubPartitionInfo provides the subpartitioning info of a table.
+type SubPartitionInfo struct {
+	Type    PartitionType `json:"type"`
+	Expr    string        `json:"expr"`
+	Columns []CIStr       `json:"columns"`
+	Num     uint64        `json:"num"`
+}
+
+// Clone clones itself.
+func (spi *SubPartitionInfo) Clone() *SubPartitionInfo {
+	newSpi := *spi
+	newSpi.Columns = make([]CIStr, len(spi.Columns))
+	copy(newSpi.Columns, spi.Columns)
+	return &newSpi
 }
 
 // Clone clones itself.
@@ -1193,9 +1214,76 @@ func (pi *PartitionInfo) Clone() *PartitionInfo {
 		newPi.DroppingDefinitions[i] = pi.DroppingDefinitions[i].Clone()
 	}
 
+	if pi.Sub != nil {
+		newPi.Sub = pi.Sub.Clone()
+	}
+
+	return &newPi
+}
+
+// PartitionLevel returns the first level partitioning of a subpartitioned table,
+// each definition of the returned info is the first subpartition of a partition,
+// named after the partition. It returns pi itself if the table isn't subpartitioned.
+func (pi *PartitionInfo) PartitionLevel() *PartitionInfo {
+	if pi.Sub == nil {
+		return pi
+	}
+	newPi := *pi
+	newPi.Sub = nil
+	newPi.Definitions = pi.GetPartitionDefinitions(pi.Definitions)
+	newPi.AddingDefinitions = pi.GetPartitionDefinitions(pi.AddingDefinitions)
+	newPi.DroppingDefinitions = pi.GetPartitionDefinitions(pi.DroppingDefinitions)
+	newPi.Num = uint64(len(newPi.Definitions))
 	return &newPi
 }
 
+// SubPartitionLevel returns the subpartitioning of a subpartitioned table as the
+// partition info of a HASH or KEY partitioned table, whose definitions are the
+// subpartitions of the first partition.
+func (pi *PartitionInfo) SubPartitionLevel() *PartitionInfo {
+	return &PartitionInfo{
+		Type:        pi.Sub.Type,
+		Expr:        pi.Sub.Expr,
+		Columns:     pi.Sub.Columns,
+		Enable:      pi.Enable,
+		Definitions: pi.Definitions[:pi.Sub.Num],
+		Num:         pi.Sub.Num,
+	}
+}
+
+// GetPartitionDefinitions returns the definitions of the partitions which the
+// subpartitions defs belong to. The subpartitions of a partition must be consecutive.
+func (pi *PartitionInfo) GetPartitionDefinitions(defs []PartitionDefinition) []PartitionDefinition {
+	if pi.Sub == nil || len(defs) == 0 {
+		return defs
+	}
+	partDefs := make([]PartitionDefinition, 0, len(defs)/int(pi.Sub.Num))
+	for i := range defs {
+		if i > 0 && defs[i].ParentName.L == defs[i-1].ParentName.L {
+			continue
+		}
+		partDef := defs[i]
+		partDef.Name = defs[i].ParentName
+		partDef.ParentName = CIStr{}
+		partDefs = append(partDefs, partDef)
+	}
+	return partDefs
+}
+
+// GetSubPartitionDefinitions returns the subpartitions of the partition with the given name.
+func (pi *PartitionInfo) GetSubPartitionDefinitions(partitionName string) []PartitionDefinition {
+	if pi.Sub == nil {
+		return nil
+	}
+	lowerName := strings.ToLower(partitionName)
+	for i := range pi.Definitions {
+		if pi.Definitions[i].ParentName.L == lowerName {
+			return pi.Definitions[i : i+int(pi.Sub.Num)]
+		}
+	}
+	return nil
+}
+
 // GetNameByID gets the partition name by ID.
 func (pi *PartitionInfo) GetNameByID(id int64) string {
 	definitions := pi.Definitions
@@ -1279,6 +1367,9 @@ type PartitionDefinition struct {
 	InValues           [][]string     `json:"in_values"`
 	PlacementPolicyRef *PolicyRefInfo `json:"policy_ref_info"`
 	Comment            string         `json:"comment,omitempty"`
+	// ParentName is the name of the partition which the subpartition belongs to,
+	// it's only set for the subpartitions of a subpartitioned table.
+	ParentName CIStr `json:"parent_name"`
 }
 
 // Clone clones ConstraintInfo.
@@ -1297,7 +1388,7 @@ func (ci *PartitionDefinition) MemoryUsage() (sum int64) {
 		return
 	}
 
-	sum = emptyPartitionDefinitionSize + ci.Name.MemoryUsage()
+	sum = emptyPartitionDefinitionSize + ci.Name.MemoryUsage() + ci.ParentName.MemoryUsage()
 	if ci.PlacementPolicyRef != nil {
 		sum += int64(unsafe.Sizeof(ci.PlacementPolicyRef.ID)) + ci.PlacementPolicyRef.Name.MemoryUsage()
 	}
@@ -1773,11 +1864,34 @@ type TTLInfo struct {
 	// JobInterval is the interval between two TTL scan jobs.
 	// It's suggested to get a duration with `(*TTLInfo).GetJobInterval`
 	JobInterval string `json:"job_interval"`
+	// TimestampUnit is the unit of the TTL column when it's an integer column storing the Unix timestamp,
+	// it's empty when the TTL column is a time column.
+	TimestampUnit string `json:"timestamp_unit,omitempty"`
+	// ArchiveTable is the table to which the expired rows are moved before they're deleted,
+	// it's nil when the expired rows are deleted directly.
+	ArchiveTable *TTLArchiveTable `json:"archive_table,omitempty"`
+}
+
+// TTLArchiveTable is the archive table of a TTL table.
+type TTLArchiveTable struct {
+	Schema CIStr `json:"schema"`
+	Table  CIStr `json:"table"`
 }
 
+// The units of the Unix timestamp stored in an integer TTL column.
+const (
+	TTLTimestampUnitSecond      = "SECOND"
+	TTLTimestampUnitMillisecond = "MILLISECOND"
+	TTLTimestampUnitMicrosecond = "MICROSECOND"
+)
+
 // Clone clones TTLInfo
 func (t *TTLInfo) Clone() *TTLInfo {
 	cloned := *t
+	if t.ArchiveTable != nil {
+		archiveTable := *t.ArchiveTable
+		cloned.ArchiveTable = &archiveTable
+	}
 	return &cloned
 }
 
@@ -818,3 +818,50 @@ func TestTTLJobInterval(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, time.Hour*200, interval)
 }
+
+func TestSubPartitionLevels(t *testing.T) {
+	pi := &PartitionInfo{
+		Type:   PartitionTypeRange,
+		Expr:   "`a`",
+		Enable: true,
+		Num:    4,
+		Sub:    &SubPartitionInfo{Type: PartitionTypeHash, Expr: "`b`", Num: 2},
+		Definitions: []PartitionDefinition{
+			{ID: 1, Name: NewCIStr("p0sp0"), LessThan: []string{"10"}, ParentName: NewCIStr("p0")},
+			{ID: 2, Name: NewCIStr("p0sp1"), LessThan: []string{"10"}, ParentName: NewCIStr("p0")},
+			{ID: 3, Name: NewCIStr("p1sp0"), LessThan: []string{"MAXVALUE"}, ParentName: NewCIStr("p1")},
+			{ID: 4, Name: NewCIStr("p1sp1"), LessThan: []string{"MAXVALUE"}, ParentName: NewCIStr("p1")},
+		},
+	}
+
+	partLevel := pi.PartitionLevel()
+	require.Nil(t, partLevel.Sub)
+	require.Equal(t, uint64(2), partLevel.Num)
+	require.Len(t, partLevel.Definitions, 2)
+	require.Equal(t, "p0", partLevel.Definitions[0].Name.L)
+	require.Equal(t, int64(1), partLevel.Definitions[0].ID)
+	require.Equal(t, "p1", partLevel.Definitions[1].Name.L)
+	require.Equal(t, []string{"MAXVALUE"}, partLevel.Definitions[1].LessThan)
+	require.Equal(t, "", partLevel.Definitions[1].ParentName.L)
+	require.Equal(t, "p1sp0", pi.Definitions[2].Name.L)
+
+	subLevel := pi.SubPartitionLevel()
+	require.Equal(t, PartitionTypeHash, subLevel.Type)
+	require.Equal(t, "`b`", subLevel.Expr)
+	require.Equal(t, uint64(2), subLevel.Num)
+	require.Len(t, subLevel.Definitions, 2)
+
+	subDefs := pi.GetSubPartitionDefinitions("P1")
+	require.Len(t, subDefs, 2)
+	require.Equal(t, int64(3), subDefs[0].ID)
+	require.Equal(t, int64(4), subDefs[1].ID)
+	require.Nil(t, pi.GetSubPartitionDefinitions("p0sp0"))
+
+	cloned := pi.Clone()
+	cloned.Sub.Num = 3
+	require.Equal(t, uint64(2), pi.Sub.Num)
+
+	pi.Sub = nil
+	require.Same(t, pi, pi.PartitionLevel())
+	require.Nil(t, pi.GetSubPartitionDefinitions("p1"))
+}
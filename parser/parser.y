@@ -178,11 +178,13 @@ import (
 	int8Type          "INT8"
 	iterate           "ITERATE"
 	join              "JOIN"
+	jsonTable         "JSON_TABLE"
 	key               "KEY"
 	keys              "KEYS"
 	kill              "KILL"
 	lag               "LAG"
 	lastValue         "LAST_VALUE"
+	lateral           "LATERAL"
 	lead              "LEAD"
 	leading           "LEADING"
 	leave             "LEAVE"
@@ -404,6 +406,7 @@ import (
 	do                    "DO"
 	duplicate             "DUPLICATE"
 	dynamic               "DYNAMIC"
+	emptyKwd              "EMPTY"
 	enable                "ENABLE"
 	enabled               "ENABLED"
 	encryption            "ENCRYPTION"
@@ -435,6 +438,9 @@ import (
 	full                  "FULL"
 	function              "FUNCTION"
 	general               "GENERAL"
+	geomCollection        "GEOMCOLLECTION"
+	geometry              "GEOMETRY"
+	geometryCollection    "GEOMETRYCOLLECTION"
 	global                "GLOBAL"
 	grants                "GRANTS"
 	handler               "HANDLER"
@@ -468,6 +474,7 @@ import (
 	lastval               "LASTVAL"
 	less                  "LESS"
 	level                 "LEVEL"
+	lineString            "LINESTRING"
 	list                  "LIST"
 	local                 "LOCAL"
 	locked                "LOCKED"
@@ -486,15 +493,20 @@ import (
 	memory                "MEMORY"
 	merge                 "MERGE"
 	microsecond           "MICROSECOND"
+	migrate               "MIGRATE"
 	minRows               "MIN_ROWS"
 	minute                "MINUTE"
 	minValue              "MINVALUE"
 	mode                  "MODE"
 	modify                "MODIFY"
 	month                 "MONTH"
+	multiLineString       "MULTILINESTRING"
+	multiPoint            "MULTIPOINT"
+	multiPolygon          "MULTIPOLYGON"
 	names                 "NAMES"
 	national              "NATIONAL"
 	ncharType             "NCHAR"
+	nested                "NESTED"
 	never                 "NEVER"
 	next                  "NEXT"
 	nextval               "NEXTVAL"
@@ -514,11 +526,13 @@ import (
 	oltpReadOnly          "OLTP_READ_ONLY"
 	oltpReadWrite         "OLTP_READ_WRITE"
 	oltpWriteOnly         "OLTP_WRITE_ONLY"
+	one                   "ONE"
 	onDuplicate           "ON_DUPLICATE"
 	online                "ONLINE"
 	only                  "ONLY"
 	open                  "OPEN"
 	optional              "OPTIONAL"
+	ordinality            "ORDINALITY"
 	packKeys              "PACK_KEYS"
 	pageSym               "PAGE"
 	parser                "PARSER"
@@ -526,6 +540,8 @@ import (
 	partitioning          "PARTITIONING"
 	partitions            "PARTITIONS"
 	password              "PASSWORD"
+	phase                 "PHASE"
+	path                  "PATH"
 	pause                 "PAUSE"
 	percent               "PERCENT"
 	per_db                "PER_DB"
@@ -534,6 +550,7 @@ import (
 	plugins               "PLUGINS"
 	point                 "POINT"
 	policy                "POLICY"
+	polygon               "POLYGON"
 	preSplitRegions       "PRE_SPLIT_REGIONS"
 	preceding             "PRECEDING"
 	prepare               "PREPARE"
@@ -568,6 +585,7 @@ import (
 	restore               "RESTORE"
 	restores              "RESTORES"
 	resume                "RESUME"
+	returning             "RETURNING"
 	reuse                 "REUSE"
 	reverse               "REVERSE"
 	role                  "ROLE"
@@ -616,6 +634,7 @@ import (
 	sqlTsiSecond          "SQL_TSI_SECOND"
 	sqlTsiWeek            "SQL_TSI_WEEK"
 	sqlTsiYear            "SQL_TSI_YEAR"
+	srid                  "SRID"
 	start                 "START"
 	statsAutoRecalc       "STATS_AUTO_RECALC"
 	statsPersistent       "STATS_PERSISTENT"
@@ -627,6 +646,7 @@ import (
 	subpartition          "SUBPARTITION"
 	subpartitions         "SUBPARTITIONS"
 	super                 "SUPER"
+	suspend               "SUSPEND"
 	swaps                 "SWAPS"
 	switchesSym           "SWITCHES"
 	system                "SYSTEM"
@@ -650,8 +670,10 @@ import (
 	triggers              "TRIGGERS"
 	truncate              "TRUNCATE"
 	ttl                   "TTL"
+	ttlArchiveTable       "TTL_ARCHIVE_TABLE"
 	ttlEnable             "TTL_ENABLE"
 	ttlJobInterval        "TTL_JOB_INTERVAL"
+	ttlTimestampUnit      "TTL_TIMESTAMP_UNIT"
 	unbounded             "UNBOUNDED"
 	uncommitted           "UNCOMMITTED"
 	undefined             "UNDEFINED"
@@ -669,6 +691,8 @@ import (
 	without               "WITHOUT"
 	workload              "WORKLOAD"
 	x509                  "X509"
+	xa                    "XA"
+	xid                   "XID"
 	yearType              "YEAR"
 	wait                  "WAIT"
 	failedLoginAttempts   "FAILED_LOGIN_ATTEMPTS"
@@ -714,6 +738,7 @@ import (
 	internal              "INTERNAL"
 	jsonArrayagg          "JSON_ARRAYAGG"
 	jsonObjectAgg         "JSON_OBJECTAGG"
+	jsonValue             "JSON_VALUE"
 	leader                "LEADER"
 	leaderConstraints     "LEADER_CONSTRAINTS"
 	learner               "LEARNER"
@@ -1008,6 +1033,7 @@ import (
 	RollbackStmt               "ROLLBACK statement"
 	ReleaseSavepointStmt       "RELEASE SAVEPOINT statement"
 	SavepointStmt              "SAVEPOINT statement"
+	XAStmt                     "XA statement"
 	SplitRegionStmt            "Split index region statement"
 	SetStmt                    "Set variable statement"
 	ChangeStmt                 "Change statement"
@@ -1179,6 +1205,10 @@ import (
 	IntervalExpr                           "Interval expression"
 	JoinTable                              "join table"
 	JoinType                               "join type"
+	JSONTableColumn                        "JSON_TABLE column definition"
+	JSONTableColumnList                    "JSON_TABLE column definition list"
+	JSONTableOnEmptyOnErrorOpt             "JSON_TABLE ON EMPTY and ON ERROR clauses"
+	JSONTableOnResponse                    "JSON_TABLE ON EMPTY or ON ERROR response"
 	KillOrKillTiDB                         "Kill or Kill TiDB"
 	LocationLabelList                      "location label name list"
 	LikeTableWithOrWithoutParen            "LIKE table_name or ( LIKE table_name )"
@@ -1395,6 +1425,7 @@ import (
 	WindowSpec                             "WINDOW spec"
 	WindowSpecDetails                      "WINDOW spec details"
 	WithRollupClause                       "With rollup clause"
+	XAIdentifier                           "XA transaction identifier"
 	BetweenOrNotOp                         "Between predicate"
 	IsOrNotOp                              "Is predicate"
 	InOrNotOp                              "In predicate"
@@ -1411,6 +1442,8 @@ import (
 	BlobType                               "Blob types"
 	TextType                               "Text types"
 	DateAndTimeType                        "Date and Time types"
+	SpatialType                            "Spatial types"
+	SpatialTypeName                        "Spatial type name"
 	OptFieldLen                            "Field length or empty"
 	FieldLen                               "Field length"
 	FieldOpts                              "Field type definition option list"
@@ -1522,6 +1555,7 @@ import (
 	EncryptionOpt     "Encryption option 'Y' or 'N'"
 	FirstOrNext       "FIRST or NEXT"
 	RowOrRows         "ROW or ROWS"
+	XAStartSym        "XA START or XA BEGIN"
 
 %type	<ident>
 	Identifier                      "identifier or unreserved keyword"
@@ -1559,6 +1593,7 @@ import (
 	StringNameOrBRIEOptionKeyword   "string literal or identifier or keyword used for BRIE options"
 	Symbol                          "Constraint Symbol"
 	ProcedurceLabelOpt              "Optional Procedure label name"
+	XIDPart                         "part of XA transaction identifier"
 
 %precedence empty
 %precedence as
@@ -2195,8 +2230,6 @@ AlterTableSpec:
 	}
 |	"CHECK" "PARTITION" AllOrPartitionNameList
 	{
-		yylex.AppendError(yylex.Errorf("The CHECK PARTITIONING clause is parsed but not implement yet."))
-		parser.lastErrorAsWarn()
 		ret := &ast.AlterTableSpec{
 			Tp: ast.AlterTableCheckPartitions,
 		}
@@ -3528,6 +3561,10 @@ ColumnOption:
 	{
 		$$ = &ast.ColumnOption{Tp: ast.ColumnOptionAutoRandom, AutoRandOpt: $2.(ast.AutoRandomOption)}
 	}
+|	"SRID" LengthNum
+	{
+		$$ = &ast.ColumnOption{Tp: ast.ColumnOptionSRID, Expr: ast.NewValueExpr($2, "", "")}
+	}
 
 AutoRandomOpt:
 	{
@@ -6448,6 +6485,7 @@ UnReservedKeyword:
 |	"DO"
 |	"DUPLICATE"
 |	"DYNAMIC"
+|	"EMPTY"
 |	"ENCRYPTION"
 |	"END"
 |	"ENFORCED"
@@ -6622,6 +6660,7 @@ UnReservedKeyword:
 |	"SUBJECT"
 |	"ISSUER"
 |	"X509"
+|	"NESTED"
 |	"NEVER"
 |	"EXPIRE"
 |	"ACCOUNT"
@@ -6739,11 +6778,20 @@ UnReservedKeyword:
 |	"BERNOULLI"
 |	"SYSTEM"
 |	"PERCENT"
+|	"PATH"
 |	"PAUSE"
 |	"RESUME"
+|	"RETURNING"
 |	"OFF"
 |	"OPTIONAL"
+|	"ORDINALITY"
 |	"REQUIRED"
+|	"XA"
+|	"XID"
+|	"SUSPEND"
+|	"MIGRATE"
+|	"ONE"
+|	"PHASE"
 |	"PURGE"
 |	"SKIP"
 |	"LOCKED"
@@ -6755,6 +6803,8 @@ UnReservedKeyword:
 |	"TTL"
 |	"TTL_ENABLE"
 |	"TTL_JOB_INTERVAL"
+|	"TTL_TIMESTAMP_UNIT"
+|	"TTL_ARCHIVE_TABLE"
 |	"FAILED_LOGIN_ATTEMPTS"
 |	"PASSWORD_LOCK_TIME"
 |	"DIGEST"
@@ -6768,6 +6818,15 @@ UnReservedKeyword:
 |	"OLTP_READ_WRITE"
 |	"OLTP_READ_ONLY"
 |	"OLTP_WRITE_ONLY"
+|	"GEOMETRY"
+|	"POLYGON"
+|	"LINESTRING"
+|	"MULTIPOINT"
+|	"MULTILINESTRING"
+|	"MULTIPOLYGON"
+|	"GEOMETRYCOLLECTION"
+|	"GEOMCOLLECTION"
+|	"SRID"
 
 TiDBKeyword:
 	"ADMIN"
@@ -6889,6 +6948,7 @@ NotKeywordToken:
 |	"FLASHBACK"
 |	"JSON_OBJECTAGG"
 |	"JSON_ARRAYAGG"
+|	"JSON_VALUE"
 |	"TLS"
 |	"FOLLOWER"
 |	"FOLLOWERS"
@@ -7880,6 +7940,39 @@ FunctionCallNonKeyword:
 			},
 		}
 	}
+|	"JSON_VALUE" '(' Expression ',' Expression JSONTableOnEmptyOnErrorOpt ')'
+	{
+		// See https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-value
+		responses := $6.([]*ast.JSONTableOnResponse)
+		$$ = &ast.JSONValueExpr{
+			Expr:    $3,
+			Path:    $5,
+			OnEmpty: responses[0],
+			OnError: responses[1],
+		}
+	}
+|	"JSON_VALUE" '(' Expression ',' Expression "RETURNING" CastType JSONTableOnEmptyOnErrorOpt ')'
+	{
+		tp := $7.(*types.FieldType)
+		defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimalForCast(tp.GetType())
+		if tp.GetFlen() == types.UnspecifiedLength {
+			tp.SetFlen(defaultFlen)
+		}
+		if tp.GetDecimal() == types.UnspecifiedLength {
+			tp.SetDecimal(defaultDecimal)
+		}
+		explicitCharset := parser.explicitCharset
+		parser.explicitCharset = false
+		responses := $8.([]*ast.JSONTableOnResponse)
+		$$ = &ast.JSONValueExpr{
+			Expr:            $3,
+			Path:            $5,
+			Tp:              tp,
+			ExplicitCharSet: explicitCharset,
+			OnEmpty:         responses[0],
+			OnError:         responses[1],
+		}
+	}
 |	builtinPosition '(' BitExpr "IN" Expression ')'
 	{
 		$$ = &ast.FuncCallExpr{FnName: model.NewCIStr($1), Args: []ast.ExprNode{$3, $5}}
@@ -8116,9 +8209,13 @@ SumExpr:
 			$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}}
 		}
 	}
-|	builtinCount '(' DistinctKwd ExpressionList ')'
+|	builtinCount '(' DistinctKwd ExpressionList ')' OptWindowingClause
 	{
-		$$ = &ast.AggregateFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true}
+		if $6 != nil {
+			$$ = &ast.WindowFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true, Spec: *($6.(*ast.WindowSpec))}
+		} else {
+			$$ = &ast.AggregateFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true}
+		}
 	}
 |	builtinCount '(' "ALL" Expression ')' OptWindowingClause
 	{
@@ -8150,7 +8247,11 @@ SumExpr:
 		args := $4.([]ast.ExprNode)
 		args = append(args, $6.(ast.ExprNode))
 		if $8 != nil {
-			$$ = &ast.WindowFuncExpr{F: $1, Args: args, Distinct: $3.(bool), Spec: *($8.(*ast.WindowSpec))}
+			wf := &ast.WindowFuncExpr{F: $1, Args: args, Distinct: $3.(bool), Spec: *($8.(*ast.WindowSpec))}
+			if $5 != nil {
+				wf.Order = $5.(*ast.OrderByClause)
+			}
+			$$ = wf
 		} else {
 			agg := &ast.AggregateFuncExpr{F: $1, Args: args, Distinct: $3.(bool)}
 			if $5 != nil {
@@ -8791,6 +8892,85 @@ RollbackStmt:
 		$$ = &ast.RollbackStmt{SavepointName: $4}
 	}
 
+XAStmt:
+	"XA" XAStartSym XAIdentifier
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAStart, XID: $3.(*ast.XID)}
+	}
+|	"XA" XAStartSym XAIdentifier "JOIN"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAStart, XID: $3.(*ast.XID), Join: true}
+	}
+|	"XA" XAStartSym XAIdentifier "RESUME"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAStart, XID: $3.(*ast.XID), Resume: true}
+	}
+|	"XA" "END" XAIdentifier
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAEnd, XID: $3.(*ast.XID)}
+	}
+|	"XA" "END" XAIdentifier "SUSPEND"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAEnd, XID: $3.(*ast.XID), Suspend: true}
+	}
+|	"XA" "END" XAIdentifier "SUSPEND" "FOR" "MIGRATE"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAEnd, XID: $3.(*ast.XID), Suspend: true, ForMigrate: true}
+	}
+|	"XA" "PREPARE" XAIdentifier
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAPrepare, XID: $3.(*ast.XID)}
+	}
+|	"XA" "COMMIT" XAIdentifier
+	{
+		$$ = &ast.XAStmt{Tp: ast.XACommit, XID: $3.(*ast.XID)}
+	}
+|	"XA" "COMMIT" XAIdentifier "ONE" "PHASE"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XACommit, XID: $3.(*ast.XID), OnePhase: true}
+	}
+|	"XA" "ROLLBACK" XAIdentifier
+	{
+		$$ = &ast.XAStmt{Tp: ast.XARollback, XID: $3.(*ast.XID)}
+	}
+|	"XA" "RECOVER"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XARecover}
+	}
+|	"XA" "RECOVER" "CONVERT" "XID"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XARecover, ConvertXID: true}
+	}
+
+XAStartSym:
+	"START"
+|	"BEGIN"
+
+XAIdentifier:
+	XIDPart
+	{
+		$$ = &ast.XID{GTRID: $1, FormatID: ast.DefaultXIDFormatID}
+	}
+|	XIDPart ',' XIDPart
+	{
+		$$ = &ast.XID{GTRID: $1, BQUAL: $3, FormatID: ast.DefaultXIDFormatID}
+	}
+|	XIDPart ',' XIDPart ',' LengthNum
+	{
+		$$ = &ast.XID{GTRID: $1, BQUAL: $3, FormatID: $5.(uint64)}
+	}
+
+XIDPart:
+	stringLit
+|	hexLit
+	{
+		$$ = $1.(ast.BinaryLiteral).ToString()
+	}
+|	bitLit
+	{
+		$$ = $1.(ast.BinaryLiteral).ToString()
+	}
+
 CompletionTypeWithinTransaction:
 	"AND" "CHAIN" "NO" "RELEASE"
 	{
@@ -9491,12 +9671,94 @@ TableFactor:
 		resultNode := $1.(*ast.SubqueryExpr).Query
 		$$ = &ast.TableSource{Source: resultNode, AsName: $2.(model.CIStr)}
 	}
+|	"LATERAL" SubSelect TableAsNameOpt
+	{
+		resultNode := $2.(*ast.SubqueryExpr).Query
+		$$ = &ast.TableSource{Source: resultNode, AsName: $3.(model.CIStr), Lateral: true}
+	}
 |	'(' TableRefs ')'
 	{
 		j := $2.(*ast.Join)
 		j.ExplicitParens = true
 		$$ = $2
 	}
+|	"JSON_TABLE" '(' Expression ',' stringLit "COLUMNS" '(' JSONTableColumnList ')' ')' TableAsName
+	{
+		jt := &ast.JSONTable{Expr: $3, Path: $5, Columns: $8.([]*ast.JSONTableColumn)}
+		$$ = &ast.TableSource{Source: jt, AsName: $11.(model.CIStr)}
+	}
+
+JSONTableColumnList:
+	JSONTableColumn
+	{
+		$$ = []*ast.JSONTableColumn{$1.(*ast.JSONTableColumn)}
+	}
+|	JSONTableColumnList ',' JSONTableColumn
+	{
+		$$ = append($1.([]*ast.JSONTableColumn), $3.(*ast.JSONTableColumn))
+	}
+
+JSONTableColumn:
+	Identifier "FOR" "ORDINALITY"
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnOrdinality, Name: model.NewCIStr($1)}
+	}
+|	Identifier Type "PATH" stringLit JSONTableOnEmptyOnErrorOpt
+	{
+		responses := $5.([]*ast.JSONTableOnResponse)
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnPath,
+			Name:      model.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $4,
+			OnEmpty:   responses[0],
+			OnError:   responses[1],
+		}
+	}
+|	Identifier Type "EXISTS" "PATH" stringLit
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnExists, Name: model.NewCIStr($1), FieldType: $2.(*types.FieldType), Path: $5}
+	}
+|	"NESTED" "PATH" stringLit "COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $3, NestedColumns: $6.([]*ast.JSONTableColumn)}
+	}
+|	"NESTED" stringLit "COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $2, NestedColumns: $5.([]*ast.JSONTableColumn)}
+	}
+
+JSONTableOnEmptyOnErrorOpt:
+	/* empty */
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, nil}
+	}
+|	JSONTableOnResponse "ON" "EMPTY"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), nil}
+	}
+|	JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, $1.(*ast.JSONTableOnResponse)}
+	}
+|	JSONTableOnResponse "ON" "EMPTY" JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), $4.(*ast.JSONTableOnResponse)}
+	}
+
+JSONTableOnResponse:
+	"NULL"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseNull}
+	}
+|	"ERROR"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseError}
+	}
+|	"DEFAULT" stringLit
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseDefault, Default: $2}
+	}
 
 PartitionNameListOpt:
 	/* empty */
@@ -11868,6 +12130,7 @@ Statement:
 |	UseStmt
 |	UnlockTablesStmt
 |	LockTablesStmt
+|	XAStmt
 |	ShutdownStmt
 |	RestartStmt
 |	HelpStmt
@@ -12224,6 +12487,25 @@ TableOption:
 		}
 		$$ = &ast.TableOption{Tp: ast.TableOptionTTLJobInterval, StrValue: $3}
 	}
+|	"TTL_TIMESTAMP_UNIT" EqOpt stringLit
+	{
+		unit := strings.ToUpper($3)
+		switch unit {
+		case model.TTLTimestampUnitSecond, model.TTLTimestampUnitMillisecond, model.TTLTimestampUnitMicrosecond:
+		default:
+			yylex.AppendError(yylex.Errorf("The TTL_TIMESTAMP_UNIT option has to be set 'SECOND', 'MILLISECOND' or 'MICROSECOND'"))
+			return 1
+		}
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLTimestampUnit, StrValue: unit}
+	}
+|	"TTL_ARCHIVE_TABLE" EqOpt TableName
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLArchiveTable, TableNames: []*ast.TableName{$3.(*ast.TableName)}}
+	}
+|	"TTL_ARCHIVE_TABLE" EqOpt "NULL"
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLArchiveTable}
+	}
 
 ForceOpt:
 	/* empty */
@@ -12339,6 +12621,7 @@ Type:
 	NumericType
 |	StringType
 |	DateAndTimeType
+|	SpatialType
 
 NumericType:
 	IntegerType OptFieldLen FieldOpts
@@ -12862,6 +13145,55 @@ DateAndTimeType:
 		$$ = tp
 	}
 
+SpatialType:
+	SpatialTypeName
+	{
+		tp := types.NewFieldType(mysql.TypeGeometry)
+		tp.SetGeometryType($1.(byte))
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CharsetBin)
+		tp.AddFlag(mysql.BinaryFlag)
+		$$ = tp
+	}
+
+SpatialTypeName:
+	"GEOMETRY"
+	{
+		$$ = types.GeometryTypeGeometry
+	}
+|	"POINT"
+	{
+		$$ = types.GeometryTypePoint
+	}
+|	"LINESTRING"
+	{
+		$$ = types.GeometryTypeLineString
+	}
+|	"POLYGON"
+	{
+		$$ = types.GeometryTypePolygon
+	}
+|	"MULTIPOINT"
+	{
+		$$ = types.GeometryTypeMultiPoint
+	}
+|	"MULTILINESTRING"
+	{
+		$$ = types.GeometryTypeMultiLineString
+	}
+|	"MULTIPOLYGON"
+	{
+		$$ = types.GeometryTypeMultiPolygon
+	}
+|	"GEOMETRYCOLLECTION"
+	{
+		$$ = types.GeometryTypeGeometryCollection
+	}
+|	"GEOMCOLLECTION"
+	{
+		$$ = types.GeometryTypeGeometryCollection
+	}
+
 FieldLen:
 	'(' LengthNum ')'
 	{
@@ -99,7 +99,7 @@ func TestSimple(t *testing.T) {
 		"following", "preceding", "unbounded", "respect", "nulls", "current", "last", "against", "expansion",
 		"chain", "error", "general", "nvarchar", "pack_keys", "p", "shard_row_id_bits", "pre_split_regions",
 		"constraints", "role", "replicas", "policy", "s3", "strict", "running", "stop", "preserve", "placement", "attributes", "attribute", "resource",
-		"burstable", "calibrate", "rollup",
+		"burstable", "calibrate", "rollup", "xa", "xid", "suspend", "migrate", "one", "phase",
 	}
 	for _, kw := range unreservedKws {
 		src := fmt.Sprintf("SELECT %s FROM tbl;", kw)
@@ -555,6 +555,28 @@ func TestDMLStmt(t *testing.T) {
 		{"ROLLBACK TO X", true, "ROLLBACK TO X"},
 		{"ROLLBACK TO SAVEPOINT x", true, "ROLLBACK TO x"},
 
+		// xa statement
+		{"XA START 'x1'", true, "XA START 'x1'"},
+		{"XA BEGIN 'x1', 'b1' JOIN", true, "XA START 'x1','b1' JOIN"},
+		{"XA START 'x1', '', 1", true, "XA START 'x1'"},
+		{"XA START 'x1', 'b1', 5 RESUME", true, "XA START 'x1','b1',5 RESUME"},
+		{"XA START 0x0102, '', 3", true, "XA START 0x0102,'',3"},
+		{"XA START X'616263', b'01100100'", true, "XA START 'abc','d'"},
+		{"XA START 'x1', 'b1', -1", false, ""},
+		{"XA START", false, ""},
+		{"XA END 'x1'", true, "XA END 'x1'"},
+		{"XA END 'x1' SUSPEND", true, "XA END 'x1' SUSPEND"},
+		{"XA END 'x1' SUSPEND FOR MIGRATE", true, "XA END 'x1' SUSPEND FOR MIGRATE"},
+		{"XA END 'x1' FOR MIGRATE", false, ""},
+		{"XA PREPARE 'x1', 'b1'", true, "XA PREPARE 'x1','b1'"},
+		{"XA COMMIT 'x1'", true, "XA COMMIT 'x1'"},
+		{"XA COMMIT 'x1' ONE PHASE", true, "XA COMMIT 'x1' ONE PHASE"},
+		{"XA COMMIT 'x1' ONE", false, ""},
+		{"XA ROLLBACK 'x1', 'b1', 2", true, "XA ROLLBACK 'x1','b1',2"},
+		{"XA RECOVER", true, "XA RECOVER"},
+		{"XA RECOVER CONVERT XID", true, "XA RECOVER CONVERT XID"},
+		{"XA RECOVER 'x1'", false, ""},
+
 		// table statement
 		{"TABLE t", true, "TABLE `t`"},
 		{"(TABLE t)", true, "(TABLE `t`)"},
@@ -3626,6 +3648,17 @@ func TestDDL(t *testing.T) {
 		{"alter table t force auto_random_base = 50", true, "ALTER TABLE `t` FORCE AUTO_RANDOM_BASE = 50"},
 		{"alter table t auto_increment 30, force auto_random_base 40", true, "ALTER TABLE `t` AUTO_INCREMENT = 30, FORCE AUTO_RANDOM_BASE = 40"},
 
+		// for spatial types
+		{"create table t (a geometry, b point, c linestring, d polygon)", true, "CREATE TABLE `t` (`a` GEOMETRY,`b` POINT,`c` LINESTRING,`d` POLYGON)"},
+		{"create table t (a multipoint, b multilinestring, c multipolygon, d geometrycollection, e geomcollection)", true, "CREATE TABLE `t` (`a` MULTIPOINT,`b` MULTILINESTRING,`c` MULTIPOLYGON,`d` GEOMCOLLECTION,`e` GEOMCOLLECTION)"},
+		{"create table t (id int, pos point not null srid 4326, area polygon srid 0)", true, "CREATE TABLE `t` (`id` INT,`pos` POINT NOT NULL SRID 4326,`area` POLYGON SRID 0)"},
+		{"create table t (point point, polygon polygon, srid int)", true, "CREATE TABLE `t` (`point` POINT,`polygon` POLYGON,`srid` INT)"},
+		{"alter table t add column pos point srid 4326", true, "ALTER TABLE `t` ADD COLUMN `pos` POINT SRID 4326"},
+		{"alter table t modify column pos geometry", true, "ALTER TABLE `t` MODIFY COLUMN `pos` GEOMETRY"},
+		{"create table t (a point srid)", false, ""},
+		{"create table t (a point srid -1)", false, ""},
+		{"create table t (a point(10))", false, ""},
+
 		// for alter sequence
 		{"alter sequence seq", false, ""},
 		{"alter sequence seq comment=\"haha\"", false, ""},
@@ -4824,6 +4857,15 @@ func TestSubquery(t *testing.T) {
 		{"select exists((select 1));", true, "SELECT EXISTS (SELECT 1)"},
 		{"select * from ((SELECT 1 a,3 b) UNION (SELECT 2,1) ORDER BY (SELECT 2)) t order by a,b", true, "SELECT * FROM ((SELECT 1 AS `a`,3 AS `b`) UNION (SELECT 2,1) ORDER BY (SELECT 2)) AS `t` ORDER BY `a`,`b`"},
 		{"select (select * from t1 where a != t.a union all (select * from t2 where a != t.a) order by a limit 1) from t1 t", true, "SELECT (SELECT * FROM `t1` WHERE `a`!=`t`.`a` UNION ALL (SELECT * FROM `t2` WHERE `a`!=`t`.`a`) ORDER BY `a` LIMIT 1) FROM `t1` AS `t`"},
+
+		// for lateral derived table
+		{"select * from t1, lateral (select * from t2 where t2.a = t1.a) as dt", true, "SELECT * FROM (`t1`) JOIN LATERAL (SELECT * FROM `t2` WHERE `t2`.`a`=`t1`.`a`) AS `dt`"},
+		{"select * from t1 join lateral (select * from t2 where t2.a = t1.a order by b limit 2) dt on true", true, "SELECT * FROM `t1` JOIN LATERAL (SELECT * FROM `t2` WHERE `t2`.`a`=`t1`.`a` ORDER BY `b` LIMIT 2) AS `dt` ON TRUE"},
+		{"select * from t1 left join lateral (select max(b) from t2 where t2.a = t1.a) as dt on true", true, "SELECT * FROM `t1` LEFT JOIN LATERAL (SELECT MAX(`b`) FROM `t2` WHERE `t2`.`a`=`t1`.`a`) AS `dt` ON TRUE"},
+		{"select * from t1, lateral (select 1 union select 2) as dt", true, "SELECT * FROM (`t1`) JOIN LATERAL (SELECT 1 UNION SELECT 2) AS `dt`"},
+		{"select * from lateral t1", false, ""},
+		{"select * from t1, lateral t2", false, ""},
+		{"select lateral from t", false, ""},
 	}
 	RunTest(t, table, false)
 
@@ -5736,6 +5778,52 @@ func TestTableSample(t *testing.T) {
 	}
 }
 
+func TestJSONTable(t *testing.T) {
+	table := []testCase{
+		// positive test cases
+		{`select * from json_table('[1, 2]', '$[*]' columns (a int path '$')) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1, 2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{`select * from json_table('[1, 2]', '$[*]' columns (a int path '$')) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1, 2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (id for ordinality, b varchar(10) exists path '$.b')) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`id` FOR ORDINALITY, `b` VARCHAR(10) EXISTS PATH '$.b')) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a' default '0' on empty null on error)) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' DEFAULT '0' ON EMPTY NULL ON ERROR)) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a' error on empty)) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' ERROR ON EMPTY)) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a' error on error)) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' ERROR ON ERROR)) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a', nested path '$.b[*]' columns (b json path '$'), nested '$.c[*]' columns (c int path '$'))) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a', NESTED PATH '$.b[*]' COLUMNS (`b` JSON PATH '$'), NESTED PATH '$.c[*]' COLUMNS (`c` INT PATH '$'))) AS `jt`"},
+		{`select * from t, json_table(t.j, '$[*]' columns (path int path '$.path', nested int path '$.nested')) as jt`, true, "SELECT * FROM (`t`) JOIN JSON_TABLE(`t`.`j`, '$[*]' COLUMNS (`path` INT PATH '$.path', `nested` INT PATH '$.nested')) AS `jt`"},
+		{`select * from t left join json_table(t.j, '$[*]' columns (a int path '$')) as jt on true`, true, "SELECT * FROM `t` LEFT JOIN JSON_TABLE(`t`.`j`, '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt` ON TRUE"},
+		{`select ordinality, nested, path, empty from t`, true, "SELECT `ordinality`,`nested`,`path`,`empty` FROM `t`"},
+
+		// negative test cases
+		{`select * from json_table('[]', '$[*]' columns (a int path '$'))`, false, ""},
+		{`select * from json_table('[]', '$[*]' columns ()) as jt`, false, ""},
+		{`select * from json_table('[]', '$[*]' columns (a int)) as jt`, false, ""},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$' null on error null on empty)) as jt`, false, ""},
+		{`select * from json_table('[]', '$[*]' columns (a int exists path '$' null on empty)) as jt`, false, ""},
+		{`create table json_table (a int)`, false, ""},
+	}
+	RunTest(t, table, false)
+}
+
+func TestJSONValue(t *testing.T) {
+	table := []testCase{
+		// positive test cases
+		{`select json_value(j, '$.a') from t`, true, "SELECT JSON_VALUE(`j`, _UTF8MB4'$.a') FROM `t`"},
+		{`select json_value('{"a": 1}', '$.a' returning signed)`, true, "SELECT JSON_VALUE(_UTF8MB4'{\"a\": 1}', _UTF8MB4'$.a' RETURNING SIGNED)"},
+		{`select json_value(j, '$.a' returning decimal(4,2) default '0' on empty error on error) from t`, true, "SELECT JSON_VALUE(`j`, _UTF8MB4'$.a' RETURNING DECIMAL(4, 2) DEFAULT '0' ON EMPTY ERROR ON ERROR) FROM `t`"},
+		{`select json_value(j, '$.a' returning char(10) charset utf8mb4 null on error) from t`, true, "SELECT JSON_VALUE(`j`, _UTF8MB4'$.a' RETURNING CHAR(10) CHARSET UTF8MB4 NULL ON ERROR) FROM `t`"},
+		{`select json_value(j, '$.a' error on empty) from t`, true, "SELECT JSON_VALUE(`j`, _UTF8MB4'$.a' ERROR ON EMPTY) FROM `t`"},
+		{`select * from t where json_value(j, '$.a' returning unsigned) = 1`, true, "SELECT * FROM `t` WHERE JSON_VALUE(`j`, _UTF8MB4'$.a' RETURNING UNSIGNED)=1"},
+		{`create index idx on t ((json_value(j, '$.a' returning datetime)))`, true, "CREATE INDEX `idx` ON `t` ((JSON_VALUE(`j`, _UTF8MB4'$.a' RETURNING DATETIME)))"},
+		{`select returning, json_value from t`, true, "SELECT `returning`,`json_value` FROM `t`"},
+
+		// negative test cases
+		{`select json_value(j) from t`, false, ""},
+		{`select json_value(j, '$.a' returning) from t`, false, ""},
+		{`select json_value(j, '$.a' null on error null on empty) from t`, false, ""},
+		{`select json_value(j, '$.a' returning signed array) from t`, false, ""},
+	}
+	RunTest(t, table, false)
+}
+
 func TestGeneratedColumn(t *testing.T) {
 	tests := []struct {
 		input string
@@ -6088,6 +6176,10 @@ func TestWindowFunctions(t *testing.T) {
 		{`SELECT NTH_VALUE(val, 233) FROM LAST OVER w FROM t;`, true, "SELECT NTH_VALUE(`val`, 233) FROM LAST OVER `w` FROM `t`"},
 		{`SELECT NTH_VALUE(val, 233) FROM LAST IGNORE NULLS OVER w FROM t;`, true, "SELECT NTH_VALUE(`val`, 233) FROM LAST IGNORE NULLS OVER `w` FROM `t`"},
 		{`SELECT NTH_VALUE(val) OVER w FROM t;`, false, ""},
+		{`SELECT COUNT(DISTINCT val) OVER w FROM t;`, true, "SELECT COUNT(DISTINCT `val`) OVER `w` FROM `t`"},
+		{`SELECT COUNT(DISTINCT a, b) OVER (PARTITION BY c) FROM t;`, true, "SELECT COUNT(DISTINCT `a`, `b`) OVER (PARTITION BY `c`) FROM `t`"},
+		{`SELECT GROUP_CONCAT(val) OVER w FROM t;`, true, "SELECT GROUP_CONCAT(`val` SEPARATOR ',') OVER `w` FROM `t`"},
+		{`SELECT GROUP_CONCAT(DISTINCT a, b ORDER BY c DESC SEPARATOR ';') OVER (PARTITION BY d) FROM t;`, true, "SELECT GROUP_CONCAT(DISTINCT `a`, `b` ORDER BY `c` DESC SEPARATOR ';') OVER (PARTITION BY `d`) FROM `t`"},
 		{`SELECT NTILE(233) OVER (w) FROM t;`, true, "SELECT NTILE(233) OVER (`w`) FROM `t`"},
 		{`SELECT PERCENT_RANK() OVER (w) FROM t;`, true, "SELECT PERCENT_RANK() OVER (`w`) FROM `t`"},
 		{`SELECT RANK() OVER (w) FROM t;`, true, "SELECT RANK() OVER (`w`) FROM `t`"},
@@ -7201,6 +7293,10 @@ func TestTTLTableOption(t *testing.T) {
 		{"create table t (created_at datetime) TTL created_at + INTERVAL 1 YEAR TTL_ENABLE 'OFF'", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 1 YEAR TTL_ENABLE = 'OFF'"},
 		{"create table t (created_at datetime) TTL created_at + INTERVAL 1 YEAR TTL_ENABLE 'OFF' TTL_JOB_INTERVAL='8h'", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 1 YEAR TTL_ENABLE = 'OFF' TTL_JOB_INTERVAL = '8h'"},
 		{"create table t (created_at datetime) /*T![ttl] ttl=created_at + INTERVAL 1 YEAR ttl_enable='ON'*/", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 1 YEAR TTL_ENABLE = 'ON'"},
+		{"create table t (created_at bigint) TTL = created_at + INTERVAL 1 YEAR TTL_TIMESTAMP_UNIT = 'millisecond'", true, "CREATE TABLE `t` (`created_at` BIGINT) TTL = `created_at` + INTERVAL 1 YEAR TTL_TIMESTAMP_UNIT = 'MILLISECOND'"},
+		{"create table t (created_at bigint) /*T![ttl] ttl=created_at + INTERVAL 1 YEAR TTL_TIMESTAMP_UNIT='SECOND'*/", true, "CREATE TABLE `t` (`created_at` BIGINT) TTL = `created_at` + INTERVAL 1 YEAR TTL_TIMESTAMP_UNIT = 'SECOND'"},
+		{"create table t (created_at datetime) TTL = created_at + INTERVAL 1 YEAR TTL_ARCHIVE_TABLE = test.t_history", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 1 YEAR TTL_ARCHIVE_TABLE = `test`.`t_history`"},
+		{"create table t (created_at datetime) /*T![ttl] ttl=created_at + INTERVAL 1 YEAR TTL_ARCHIVE_TABLE=`t_history`*/", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 1 YEAR TTL_ARCHIVE_TABLE = `t_history`"},
 
 		// alter table with various temporal interval
 		{"alter table t TTL = created_at + INTERVAL 1 MONTH", true, "ALTER TABLE `t` TTL = `created_at` + INTERVAL 1 MONTH"},
@@ -7211,6 +7307,9 @@ func TestTTLTableOption(t *testing.T) {
 		{"alter table t /*T![ttl] ttl=created_at + INTERVAL 1 YEAR ttl_enable='ON'*/", true, "ALTER TABLE `t` TTL = `created_at` + INTERVAL 1 YEAR TTL_ENABLE = 'ON'"},
 		{"alter table t /*T![ttl] ttl=created_at + INTERVAL 1 YEAR ttl_enable='ON' TTL_JOB_INTERVAL='8h'*/", true, "ALTER TABLE `t` TTL = `created_at` + INTERVAL 1 YEAR TTL_ENABLE = 'ON' TTL_JOB_INTERVAL = '8h'"},
 		{"alter table t /*T![ttl] ttl=created_at + INTERVAL 1 YEAR ttl_enable='ON' TTL_JOB_INTERVAL='8.645124531235h'*/", true, "ALTER TABLE `t` TTL = `created_at` + INTERVAL 1 YEAR TTL_ENABLE = 'ON' TTL_JOB_INTERVAL = '8.645124531235h'"},
+		{"alter table t TTL_TIMESTAMP_UNIT 'MICROSECOND'", true, "ALTER TABLE `t` TTL_TIMESTAMP_UNIT = 'MICROSECOND'"},
+		{"alter table t TTL_ARCHIVE_TABLE t_history", true, "ALTER TABLE `t` TTL_ARCHIVE_TABLE = `t_history`"},
+		{"alter table t TTL_ARCHIVE_TABLE = NULL", true, "ALTER TABLE `t` TTL_ARCHIVE_TABLE = NULL"},
 
 		// alter table to remove ttl settings
 		{"alter table t remove ttl", true, "ALTER TABLE `t` REMOVE TTL"},
@@ -7220,10 +7319,18 @@ func TestTTLTableOption(t *testing.T) {
 		{"create table t (created_at datetime) /*T![ttl] TTL_ENABLE = 'test_case' */", false, ""},
 		{"alter table t /*T![ttl] TTL_ENABLE = 'test_case' */", false, ""},
 
+		// validate invalid TTL_ARCHIVE_TABLE settings
+		{"create table t (created_at datetime) TTL_ARCHIVE_TABLE = 't_history'", false, ""},
+		{"alter table t TTL_ARCHIVE_TABLE = (t_history)", false, ""},
+
 		// validate invalid TTL_JOB_INTERVAL settings
 		{"create table t (created_at datetime) TTL_JOB_INTERVAL = '@monthly'", false, ""},
 		{"create table t (created_at datetime) TTL_JOB_INTERVAL = '10hourxx'", false, ""},
 		{"create table t (created_at datetime) TTL_JOB_INTERVAL = '10.10.255h'", false, ""},
+
+		// validate invalid TTL_TIMESTAMP_UNIT settings
+		{"create table t (created_at bigint) TTL_TIMESTAMP_UNIT = 'DAY'", false, ""},
+		{"alter table t TTL_TIMESTAMP_UNIT = 'nanosecond'", false, ""},
 	}
 
 	RunTest(t, table, false)
@@ -40,6 +40,38 @@ func IsTypeChar(tp byte) bool {
 	return tp == mysql.TypeString || tp == mysql.TypeVarchar
 }
 
+// Subtypes of mysql.TypeGeometry. The values are the same as the geometry
+// type codes of WKB.
+const (
+	GeometryTypeGeometry byte = iota
+	GeometryTypePoint
+	GeometryTypeLineString
+	GeometryTypePolygon
+	GeometryTypeMultiPoint
+	GeometryTypeMultiLineString
+	GeometryTypeMultiPolygon
+	GeometryTypeGeometryCollection
+)
+
+var geometryType2Str = []string{
+	GeometryTypeGeometry:           "geometry",
+	GeometryTypePoint:              "point",
+	GeometryTypeLineString:         "linestring",
+	GeometryTypePolygon:            "polygon",
+	GeometryTypeMultiPoint:         "multipoint",
+	GeometryTypeMultiLineString:    "multilinestring",
+	GeometryTypeMultiPolygon:       "multipolygon",
+	GeometryTypeGeometryCollection: "geomcollection",
+}
+
+// GeometryTypeStr converts the geometry subtype to a string.
+func GeometryTypeStr(tp byte) string {
+	if int(tp) < len(geometryType2Str) {
+		return geometryType2Str[tp]
+	}
+	return geometryType2Str[GeometryTypeGeometry]
+}
+
 var type2Str = map[byte]string{
 	mysql.TypeBit:         "bit",
 	mysql.TypeBlob:        "text",
@@ -57,6 +57,12 @@ type FieldType struct {
 	elems            []string
 	elemsIsBinaryLit []bool
 	array            bool
+	// geometryType is the subtype of the geometry type, like POINT or POLYGON.
+	geometryType byte
+	// srid is the spatial reference system identifier of the geometry type, it's only
+	// valid when hasSRID is true.
+	srid    uint32
+	hasSRID bool
 	// Please keep in mind that jsonFieldType should be updated if you add a new field here.
 }
 
@@ -233,6 +239,28 @@ func (ft *FieldType) IsArray() bool {
 	return ft.array
 }
 
+// GetGeometryType returns the geometry subtype of the FieldType.
+func (ft *FieldType) GetGeometryType() byte {
+	return ft.geometryType
+}
+
+// SetGeometryType sets the geometry subtype of the FieldType.
+func (ft *FieldType) SetGeometryType(tp byte) {
+	ft.geometryType = tp
+}
+
+// GetSRID returns the SRID restriction of the geometry type. The second
+// return value is false if the column accepts values of any SRID.
+func (ft *FieldType) GetSRID() (uint32, bool) {
+	return ft.srid, ft.hasSRID
+}
+
+// SetSRID restricts the geometry type to the SRID.
+func (ft *FieldType) SetSRID(srid uint32) {
+	ft.srid = srid
+	ft.hasSRID = true
+}
+
 // ArrayType return the type of the array.
 func (ft *FieldType) ArrayType() *FieldType {
 	if !ft.array {
@@ -296,7 +324,9 @@ func (ft *FieldType) Equal(other *FieldType) bool {
 		ft.charset == other.charset &&
 		ft.collate == other.collate &&
 		flenEqual &&
-		mysql.HasUnsignedFlag(ft.flag) == mysql.HasUnsignedFlag(other.flag)
+		mysql.HasUnsignedFlag(ft.flag) == mysql.HasUnsignedFlag(other.flag) &&
+		ft.geometryType == other.geometryType &&
+		ft.hasSRID == other.hasSRID && ft.srid == other.srid
 	if !partialEqual || len(ft.elems) != len(other.elems) {
 		return false
 	}
@@ -370,6 +400,9 @@ func (ft *FieldType) Init(tp byte) {
 // This is used for showing column type in infoschema.
 func (ft *FieldType) CompactStr() string {
 	ts := TypeToStr(ft.GetType(), ft.charset)
+	if ft.GetType() == mysql.TypeGeometry {
+		ts = GeometryTypeStr(ft.geometryType)
+	}
 	suffix := ""
 
 	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
@@ -463,6 +496,10 @@ func (ft *FieldType) String() string {
 
 // Restore implements Node interface.
 func (ft *FieldType) Restore(ctx *format.RestoreCtx) error {
+	if ft.GetType() == mysql.TypeGeometry {
+		ctx.WriteKeyWord(GeometryTypeStr(ft.geometryType))
+		return nil
+	}
 	ctx.WriteKeyWord(TypeToStr(ft.GetType(), ft.charset))
 
 	precision := UnspecifiedLength
@@ -634,6 +671,9 @@ type jsonFieldType struct {
 	Elems            []string
 	ElemsIsBinaryLit []bool
 	Array            bool
+	GeometryType     byte   `json:",omitempty"`
+	SRID             uint32 `json:",omitempty"`
+	HasSRID          bool   `json:",omitempty"`
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -650,6 +690,9 @@ func (ft *FieldType) UnmarshalJSON(data []byte) error {
 		ft.elems = r.Elems
 		ft.elemsIsBinaryLit = r.ElemsIsBinaryLit
 		ft.array = r.Array
+		ft.geometryType = r.GeometryType
+		ft.srid = r.SRID
+		ft.hasSRID = r.HasSRID
 	}
 	return err
 }
@@ -666,6 +709,9 @@ func (ft *FieldType) MarshalJSON() ([]byte, error) {
 	r.Elems = ft.elems
 	r.ElemsIsBinaryLit = ft.elemsIsBinaryLit
 	r.Array = ft.array
+	r.GeometryType = ft.geometryType
+	r.SRID = ft.srid
+	r.HasSRID = ft.hasSRID
 	return json.Marshal(r)
 }
 
@@ -85,6 +85,8 @@ type CheckTable struct {
 	IndexInfos         []*model.IndexInfo
 	IndexLookUpReaders []*PhysicalIndexLookUpReader
 	CheckIndex         bool
+	// PartitionNames are the checked partitions, all the partitions are checked if it's empty.
+	PartitionNames []model.CIStr
 }
 
 // RecoverIndex is used for backfilling corrupted index data.
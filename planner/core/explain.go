@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/tidb/expression/aggregation"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/planner/property"
 	"github.com/pingcap/tidb/planner/util"
@@ -413,6 +414,51 @@ func (p *PhysicalTableDual) ExplainInfo() string {
 	return str.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainInfo() string {
+	return p.explainInfo(false)
+}
+
+// ExplainNormalizedInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainNormalizedInfo() string {
+	return p.explainInfo(true)
+}
+
+func (p *PhysicalJSONTable) explainInfo(normalized bool) string {
+	var str strings.Builder
+	str.WriteString("json_table(")
+	if normalized {
+		str.WriteString(p.JSONExpr.ExplainNormalizedInfo())
+	} else {
+		str.WriteString(p.JSONExpr.ExplainInfo())
+	}
+	str.WriteString(", ")
+	restoreCtx := format.NewRestoreCtx(format.RestoreKeyWordLowercase|format.RestoreStringSingleQuotes, &str)
+	restoreCtx.WriteString(p.Path)
+	explainJSONTableColumns(restoreCtx, p.Columns)
+	str.WriteString(")")
+	return str.String()
+}
+
+func explainJSONTableColumns(ctx *format.RestoreCtx, cols []*ast.JSONTableColumn) {
+	ctx.WritePlain(" columns(")
+	for i, col := range cols {
+		if i > 0 {
+			ctx.WritePlain(", ")
+		}
+		if col.Tp == ast.JSONTableColumnNested {
+			ctx.WriteKeyWord("nested path ")
+			ctx.WriteString(col.Path)
+			explainJSONTableColumns(ctx, col.NestedColumns)
+			continue
+		}
+		if err := col.Restore(ctx); err != nil {
+			ctx.WritePlain(col.Name.O)
+		}
+	}
+	ctx.WritePlain(")")
+}
+
 // ExplainInfo implements Plan interface.
 func (p *PhysicalSort) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
@@ -819,6 +865,8 @@ func (p *PhysicalWindow) ExplainInfo() string {
 		}
 		if p.Frame.Type == ast.Rows {
 			buffer.WriteString("rows")
+		} else if p.Frame.Type == ast.Groups {
+			buffer.WriteString("groups")
 		} else {
 			buffer.WriteString("range")
 		}
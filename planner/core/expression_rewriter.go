@@ -1242,6 +1242,8 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 
 		er.ctxStack[len(er.ctxStack)-1] = castFunction
 		er.ctxNameStk[len(er.ctxNameStk)-1] = types.EmptyName
+	case *ast.JSONValueExpr:
+		er.jsonValueToScalarFunc(v)
 	case *ast.PatternLikeOrIlikeExpr:
 		er.patternLikeOrIlikeToExpression(v)
 	case *ast.PatternRegexpExpr:
@@ -1551,6 +1553,37 @@ func (er *expressionRewriter) positionToScalarFunc(v *ast.PositionExpr) {
 	}
 }
 
+func (er *expressionRewriter) jsonValueToScalarFunc(v *ast.JSONValueExpr) {
+	stkLen := len(er.ctxStack)
+	doc, path := er.ctxStack[stkLen-2], er.ctxStack[stkLen-1]
+	if er.err = expression.CheckArgsNotMultiColumnRow(doc, path); er.err != nil {
+		return
+	}
+	var tp *types.FieldType
+	if v.Tp != nil {
+		if er.err = er.checkTimePrecision(v.Tp); er.err != nil {
+			return
+		}
+		tp = v.Tp.Clone()
+	} else {
+		// The returned type is VARCHAR(512) if the RETURNING clause is omitted.
+		tp = types.NewFieldTypeBuilder().SetType(mysql.TypeVarString).SetFlen(512).
+			SetCharset(charset.CharsetUTF8MB4).SetCollate(charset.CollationUTF8MB4).BuildP()
+	}
+	function, err := expression.BuildJSONValueFunction(er.sctx, expression.JSONValueArgs(doc, path, v.OnEmpty, v.OnError), tp)
+	if err != nil {
+		er.err = err
+		return
+	}
+	if tp.EvalType() == types.ETString {
+		function.SetCoercibility(expression.CoercibilityImplicit)
+	} else {
+		function.SetCoercibility(expression.CoercibilityNumeric)
+	}
+	er.ctxStackPop(2)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
 func (er *expressionRewriter) isTrueToScalarFunc(v *ast.IsTruthExpr) {
 	stkLen := len(er.ctxStack)
 	op := ast.IsTruthWithoutNull
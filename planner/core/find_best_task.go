@@ -174,6 +174,17 @@ func (p *LogicalShowDDLJobs) findBestTask(prop *property.PhysicalProperty, planC
 	return &rootTask{p: pShow}, 1, nil
 }
 
+func (p *LogicalJSONTable) findBestTask(prop *property.PhysicalProperty, planCounter *PlanCounterTp, opt *physicalOptimizeOp) (task, int64, error) {
+	if !prop.IsSortItemEmpty() || planCounter.Empty() {
+		return invalidTask, 0, nil
+	}
+	jt := PhysicalJSONTable{JSONExpr: p.JSONExpr, Path: p.Path, Columns: p.Columns}.Init(p.ctx, p.stats, p.blockOffset)
+	jt.SetSchema(p.schema)
+	planCounter.Dec(1)
+	opt.appendCandidate(p, jt, prop)
+	return &rootTask{p: jt}, 1, nil
+}
+
 // rebuildChildTasks rebuilds the childTasks to make the clock_th combination.
 func (p *baseLogicalPlan) rebuildChildTasks(childTasks *[]task, pp PhysicalPlan, childCnts []int64, planCounter int64, ts uint64, opt *physicalOptimizeOp) error {
 	// The taskMap of children nodes should be rolled back first.
@@ -284,6 +284,19 @@ func (p PhysicalShow) Init(ctx sessionctx.Context) *PhysicalShow {
 	return &p
 }
 
+// Init initializes LogicalJSONTable.
+func (p LogicalJSONTable) Init(ctx sessionctx.Context, offset int) *LogicalJSONTable {
+	p.baseLogicalPlan = newBaseLogicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	return &p
+}
+
+// Init initializes PhysicalJSONTable.
+func (p PhysicalJSONTable) Init(ctx sessionctx.Context, stats *property.StatsInfo, offset int) *PhysicalJSONTable {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	p.stats = stats
+	return &p
+}
+
 // Init initializes PhysicalShowDDLJobs.
 func (p PhysicalShowDDLJobs) Init(ctx sessionctx.Context) *PhysicalShowDDLJobs {
 	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeShowDDLJobs, &p, 0)
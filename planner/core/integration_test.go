@@ -5303,3 +5303,49 @@ func TestIssue43116(t *testing.T) {
 		"  └─TableFullScan 10000.00 cop[tikv] table:a keep order:false, stats:pseudo"))
 	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 Memory capacity of 111 bytes for 'tidb_opt_range_max_size' exceeded when building ranges. Less accurate ranges such as full range are chosen"))
 }
+
+func TestJSONValueExpressionIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, j json, " +
+		"index idx_a((json_value(j, '$.a' returning signed))), " +
+		"index idx_b((json_value(j, '$.b' returning char(10)))), " +
+		"index idx_c((json_value(j, '$.c' returning decimal(4, 2)))))")
+	tk.MustExec(`insert into t values (1, '{"a": 1, "b": "x", "c": 1.5}'), (2, '{"a": 2, "b": "y", "c": 2.5}')`)
+
+	sql := "select id from t where json_value(j, '$.a' returning signed) = 2"
+	tk.MustQuery(sql).Check(testkit.Rows("2"))
+	require.True(t, tk.MustUseIndex(sql, "idx_a"))
+	sql = "select id from t where json_value(j, '$.b' returning char(10)) = 'x'"
+	tk.MustQuery(sql).Check(testkit.Rows("1"))
+	require.True(t, tk.MustUseIndex(sql, "idx_b"))
+	sql = "select id from t where json_value(j, '$.c' returning decimal(4, 2)) > 2.0"
+	tk.MustQuery(sql).Check(testkit.Rows("2"))
+	require.True(t, tk.MustUseIndex(sql, "idx_c"))
+
+	// The returned type and the ON EMPTY and ON ERROR clauses must match the index.
+	require.False(t, tk.MustUseIndex("select id from t where json_value(j, '$.a' returning unsigned) = 2", "idx_a"))
+	require.False(t, tk.MustUseIndex("select id from t where json_value(j, '$.a' returning signed error on error) = 2", "idx_a"))
+	require.False(t, tk.MustUseIndex("select id from t where json_value(j, '$.b') = 'x'", "idx_b"))
+	require.False(t, tk.MustUseIndex("select id from t where json_value(j, '$.c' returning decimal(5, 2)) > 2.0", "idx_c"))
+
+	// json_value doesn't match the index on CAST, which converts objects and arrays, and truncates long strings.
+	tk.MustExec("create table t2 (id int primary key, j json, " +
+		"index idx_a((cast(json_extract(j, '$.a') as char(10)))), " +
+		"index idx_b((cast(json_unquote(json_extract(j, '$.b')) as char(10)))))")
+	tk.MustExec("set @@sql_mode = ''")
+	tk.MustExec(`insert into t2 values (1, '{"a": {"k": 1}, "b": "x"}'), (2, '{"a": [1, 2], "b": "abcdefghijkl"}')`)
+	tk.MustExec("set @@sql_mode = default")
+	for _, sql := range []string{
+		`select id from t2 where json_value(j, '$.a' returning char(10)) = '{"k": 1}'`,
+		`select id from t2 where json_value(j, '$.a' returning char(10)) = '[1, 2]'`,
+		`select id from t2 where json_value(j, '$.b' returning char(10)) = 'abcdefghij'`,
+	} {
+		tk.MustQuery(sql).Check(testkit.Rows())
+		require.False(t, tk.MustUseIndex(sql, "idx_a"))
+		require.False(t, tk.MustUseIndex(sql, "idx_b"))
+	}
+	tk.MustQuery("select id from t2 use index(idx_a) where cast(json_extract(j, '$.a') as char(10)) = '[1, 2]'").Check(testkit.Rows("2"))
+	tk.MustQuery("select id from t2 use index(idx_b) where cast(json_unquote(json_extract(j, '$.b')) as char(10)) = 'abcdefghij'").Check(testkit.Rows("2"))
+}
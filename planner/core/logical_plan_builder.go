@@ -503,6 +503,8 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 		case *ast.TableName:
 			p, err = b.buildDataSource(ctx, v, &x.AsName)
 			isTableName = true
+		case *ast.JSONTable:
+			p, err = b.buildJSONTable(ctx, v, x.AsName)
 		default:
 			err = ErrUnsupportedType.GenWithStackByArgs(v)
 		}
@@ -957,7 +959,19 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		return nil, err
 	}
 
-	rightPlan, err := b.buildResultSetNode(ctx, joinNode.Right, false)
+	var rightPlan LogicalPlan
+	isLateral := isLateralTableSource(joinNode.Right)
+	if isLateral {
+		// A lateral table source can reference the columns of the tables preceding it,
+		// so the left side is visible as an outer schema when building it.
+		b.outerSchemas = append(b.outerSchemas, leftPlan.Schema())
+		b.outerNames = append(b.outerNames, leftPlan.OutputNames())
+		rightPlan, err = b.buildResultSetNode(ctx, joinNode.Right, false)
+		b.outerSchemas = b.outerSchemas[0 : len(b.outerSchemas)-1]
+		b.outerNames = b.outerNames[0 : len(b.outerNames)-1]
+	} else {
+		rightPlan, err = b.buildResultSetNode(ctx, joinNode.Right, false)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -971,7 +985,23 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 	handleMap2 := b.handleHelper.popMap()
 	b.handleHelper.mergeAndPush(handleMap1, handleMap2)
 
-	joinPlan := LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+	var (
+		joinPlan   *LogicalJoin
+		resultPlan LogicalPlan
+	)
+	if isLateral && len(extractCorColumnsBySchema4LogicalPlan(rightPlan, leftPlan.Schema())) > 0 {
+		// The lateral table source references the left side, it has to be evaluated
+		// for every row of the left side, so we build an apply rather than a join.
+		if joinNode.Tp == ast.RightJoin {
+			return nil, ErrTFForbiddenJoinType.GenWithStackByArgs(joinNode.Right.(*ast.TableSource).AsName.O)
+		}
+		b.optFlag = b.optFlag | flagBuildKeyInfo | flagDecorrelate
+		ap := LogicalApply{LogicalJoin: LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}}.Init(b.ctx, b.getSelectOffset())
+		joinPlan, resultPlan = &ap.LogicalJoin, ap
+	} else {
+		joinPlan = LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+		resultPlan = joinPlan
+	}
 	joinPlan.SetChildren(leftPlan, rightPlan)
 	joinPlan.SetSchema(expression.MergeSchema(leftPlan.Schema(), rightPlan.Schema()))
 	joinPlan.names = make([]*types.FieldName, leftPlan.Schema().Len()+rightPlan.Schema().Len())
@@ -1059,11 +1089,11 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		}
 	} else if joinNode.On != nil {
 		b.curClause = onClause
-		onExpr, newPlan, err := b.rewrite(ctx, joinNode.On.Expr, joinPlan, nil, false)
+		onExpr, newPlan, err := b.rewrite(ctx, joinNode.On.Expr, resultPlan, nil, false)
 		if err != nil {
 			return nil, err
 		}
-		if newPlan != joinPlan {
+		if newPlan != resultPlan {
 			return nil, errors.New("ON condition doesn't support subqueries yet")
 		}
 		onCondition := expression.SplitCNFItems(onExpr)
@@ -1071,7 +1101,7 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		// possible decorrelate optimizations. The ON clause is actually treated as a WHERE clause now.
 		if joinPlan.JoinType == InnerJoin {
 			sel := LogicalSelection{Conditions: onCondition}.Init(b.ctx, b.getSelectOffset())
-			sel.SetChildren(joinPlan)
+			sel.SetChildren(resultPlan)
 			return sel, nil
 		}
 		joinPlan.AttachOnConds(onCondition)
@@ -1081,7 +1111,21 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		joinPlan.cartesianJoin = true
 	}
 
-	return joinPlan, nil
+	return resultPlan, nil
+}
+
+// isLateralTableSource checks whether the result set node can reference the
+// columns of the tables preceding it in the FROM clause.
+func isLateralTableSource(node ast.ResultSetNode) bool {
+	ts, ok := node.(*ast.TableSource)
+	if !ok {
+		return false
+	}
+	if ts.Lateral {
+		return true
+	}
+	_, ok = ts.Source.(*ast.JSONTable)
+	return ok
 }
 
 // buildUsingClause eliminate the redundant columns and ordering columns based
@@ -4503,6 +4547,110 @@ func (b *PlanBuilder) buildTableDual() *LogicalTableDual {
 	return LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
 }
 
+func (b *PlanBuilder) buildJSONTable(ctx context.Context, jt *ast.JSONTable, asName model.CIStr) (LogicalPlan, error) {
+	// The JSON document can only reference the columns of the tables preceding
+	// JSON_TABLE, which are in the outer schemas, so it's rewritten upon a dual.
+	dual := LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
+	expr, np, err := b.rewrite(ctx, jt.Expr, dual, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if np != dual {
+		return nil, errors.New("JSON_TABLE doesn't support subqueries yet")
+	}
+	// The string argument is parsed as a JSON document.
+	expr = expression.WrapWithCastAsJSON(b.ctx, expr)
+	if _, err = types.ParseJSONPathExpr(jt.Path); err != nil {
+		return nil, err
+	}
+	p := LogicalJSONTable{JSONExpr: expr, Path: jt.Path, Columns: jt.Columns}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema()
+	names := make([]*types.FieldName, 0, len(jt.Columns))
+	if names, err = b.buildJSONTableColumns(jt.Columns, asName, schema, names); err != nil {
+		return nil, err
+	}
+	p.SetSchema(schema)
+	p.names = names
+	b.handleHelper.pushMap(nil)
+	return p, nil
+}
+
+// buildJSONTableColumns appends the columns of JSON_TABLE to the schema in
+// depth-first order, the executor produces the columns in the same order.
+func (b *PlanBuilder) buildJSONTableColumns(cols []*ast.JSONTableColumn, asName model.CIStr,
+	schema *expression.Schema, names []*types.FieldName) ([]*types.FieldName, error) {
+	var err error
+	for _, col := range cols {
+		if col.Tp != ast.JSONTableColumnOrdinality {
+			if _, err = types.ParseJSONPathExpr(col.Path); err != nil {
+				return nil, err
+			}
+		}
+		var tp *types.FieldType
+		switch col.Tp {
+		case ast.JSONTableColumnNested:
+			if names, err = b.buildJSONTableColumns(col.NestedColumns, asName, schema, names); err != nil {
+				return nil, err
+			}
+			continue
+		case ast.JSONTableColumnOrdinality:
+			tp = types.NewFieldType(mysql.TypeLonglong)
+			tp.SetFlag(mysql.UnsignedFlag)
+			tp.SetFlen(mysql.MaxIntWidth)
+			tp.SetCharset(charset.CharsetBin)
+			tp.SetCollate(charset.CollationBin)
+		default:
+			if tp, err = buildJSONTableColumnType(col.FieldType); err != nil {
+				return nil, err
+			}
+		}
+		schema.Append(&expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  tp,
+			OrigName: col.Name.O,
+		})
+		names = append(names, &types.FieldName{
+			TblName:     asName,
+			ColName:     col.Name,
+			OrigColName: col.Name,
+		})
+	}
+	return names, nil
+}
+
+// buildJSONTableColumnType fills the charset, collation, flen and decimal of
+// the type of a JSON_TABLE column like the column definitions of CREATE TABLE.
+func buildJSONTableColumnType(ft *types.FieldType) (*types.FieldType, error) {
+	tp := ft.Clone()
+	switch tp.GetType() {
+	case mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString,
+		mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob,
+		mysql.TypeEnum, mysql.TypeSet:
+		// The default charset of the string columns is utf8mb4 as in MySQL.
+		if tp.GetCharset() == "" {
+			tp.SetCharset(charset.CharsetUTF8MB4)
+		}
+		if tp.GetCollate() == "" {
+			collate, err := charset.GetDefaultCollation(tp.GetCharset())
+			if err != nil {
+				return nil, err
+			}
+			tp.SetCollate(collate)
+		}
+	default:
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+	}
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+	if tp.GetFlen() == types.UnspecifiedLength {
+		tp.SetFlen(defaultFlen)
+	}
+	if tp.GetDecimal() == types.UnspecifiedLength {
+		tp.SetDecimal(defaultDecimal)
+	}
+	return tp, nil
+}
+
 func (ds *DataSource) newExtraHandleSchemaCol() *expression.Column {
 	tp := types.NewFieldType(mysql.TypeLonglong)
 	tp.SetFlag(mysql.NotNullFlag | mysql.PriKeyFlag)
@@ -4827,11 +4975,13 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 		if len(tn.PartitionNames) > 0 {
 			pids := make(map[int64]struct{}, len(tn.PartitionNames))
 			for _, name := range tn.PartitionNames {
-				pid, err := tables.FindPartitionByName(tableInfo, name.L)
+				partIDs, err := tables.FindPartitionIDsByName(tableInfo, name.L)
 				if err != nil {
 					return nil, err
 				}
-				pids[pid] = struct{}{}
+				for _, pid := range partIDs {
+					pids[pid] = struct{}{}
+				}
 			}
 			pt = tables.NewPartitionTableWithGivenSets(pt, pids)
 		}
@@ -5390,6 +5540,7 @@ func (b *PlanBuilder) BuildDataSourceFromView(ctx context.Context, dbName model.
 	if err != nil {
 		return nil, err
 	}
+	selectNode.Accept(extensionAggFuncConverter{})
 	originalVisitInfo := b.visitInfo
 	b.visitInfo = make([]visitInfo, 0)
 
@@ -6536,6 +6687,7 @@ func (b *PlanBuilder) buildByItemsForWindow(
 
 // buildWindowFunctionFrameBound builds the bounds of window function frames.
 // For type `Rows`, the bound expr must be an unsigned integer.
+// For type `Groups`, the bound expr must be an unsigned integer, and the order by items are used to find the peer groups.
 // For type `Range`, the bound expr must be temporal or numeric types.
 func (b *PlanBuilder) buildWindowFunctionFrameBound(_ context.Context, spec *ast.WindowSpec, orderByItems []property.SortItem, boundClause *ast.FrameBound) (*FrameBound, error) {
 	frameType := spec.Frame.Type
@@ -6553,6 +6705,21 @@ func (b *PlanBuilder) buildWindowFunctionFrameBound(_ context.Context, spec *ast
 		return bound, nil
 	}
 
+	if frameType == ast.Groups {
+		if bound.Type != ast.CurrentRow {
+			numGroups, _, _ := getUintFromNode(b.ctx, boundClause.Expr, false)
+			bound.Num = numGroups
+		}
+		// Two rows belong to the same peer group if they are equal on all the order by items.
+		bound.CalcFuncs = make([]expression.Expression, len(orderByItems))
+		bound.CmpFuncs = make([]expression.CompareFunc, len(orderByItems))
+		for i, item := range orderByItems {
+			bound.CalcFuncs[i] = item.Col
+			bound.CmpFuncs[i] = expression.GetCmpFunction(b.ctx, item.Col, item.Col)
+		}
+		return bound, nil
+	}
+
 	bound.CalcFuncs = make([]expression.Expression, len(orderByItems))
 	bound.CmpFuncs = make([]expression.CompareFunc, len(orderByItems))
 	if bound.Type == ast.CurrentRow {
@@ -6645,9 +6812,6 @@ func (b *PlanBuilder) buildWindowFunctionFrame(ctx context.Context, spec *ast.Wi
 func (b *PlanBuilder) checkWindowFuncArgs(ctx context.Context, p LogicalPlan, windowFuncExprs []*ast.WindowFuncExpr, windowAggMap map[*ast.AggregateFuncExpr]int) error {
 	checker := &expression.ParamMarkerInPrepareChecker{}
 	for _, windowFuncExpr := range windowFuncExprs {
-		if strings.ToLower(windowFuncExpr.F) == ast.AggFuncGroupConcat {
-			return ErrNotSupportedYet.GenWithStackByArgs("group_concat as window function")
-		}
 		args, err := b.buildArgs4WindowFunc(ctx, p, windowFuncExpr.Args, windowAggMap)
 		if err != nil {
 			return err
@@ -6733,6 +6897,12 @@ func (b *PlanBuilder) buildWindowFunctions(ctx context.Context, p LogicalPlan, g
 		spec, funcs := window.spec, window.funcs
 		for _, windowFunc := range funcs {
 			args = append(args, windowFunc.Args...)
+			// The order by items of GROUP_CONCAT are built into the projection together with the arguments.
+			orderByArgs, err := b.resolveWindowFuncOrderBy(windowFunc)
+			if err != nil {
+				return nil, nil, err
+			}
+			args = append(args, orderByArgs...)
 		}
 		np, partitionBy, orderBy, args, err := b.buildProjectionForWindow(ctx, p, spec, args, aggMap)
 		if err != nil {
@@ -6780,6 +6950,15 @@ func (b *PlanBuilder) buildWindowFunctions(ctx context.Context, p LogicalPlan, g
 				return nil, nil, ErrWrongArguments.GenWithStackByArgs(strings.ToLower(windowFunc.F))
 			}
 			preArgs += len(windowFunc.Args)
+			if windowFunc.Order != nil {
+				for _, byItem := range windowFunc.Order.Items {
+					desc.OrderByItems = append(desc.OrderByItems, &util.ByItems{Expr: args[preArgs], Desc: byItem.Desc})
+					preArgs++
+				}
+			}
+			desc.HasDistinct = windowFunc.Distinct
+			desc.IgnoreNull = windowFunc.IgnoreNull
+			desc.FromLast = windowFunc.FromLast
 			desc.WrapCastForAggArgs(b.ctx)
 			descs = append(descs, desc)
 			windowMap[windowFunc] = schema.Len()
@@ -6797,19 +6976,33 @@ func (b *PlanBuilder) buildWindowFunctions(ctx context.Context, p LogicalPlan, g
 	return p, windowMap, nil
 }
 
+// resolveWindowFuncOrderBy resolves the order by items of GROUP_CONCAT used as a window function,
+// the positions in the order by clause refer to the arguments of the function.
+func (b *PlanBuilder) resolveWindowFuncOrderBy(windowFunc *ast.WindowFuncExpr) ([]ast.ExprNode, error) {
+	if windowFunc.Order == nil {
+		return nil, nil
+	}
+	resolver := &aggOrderByResolver{
+		ctx:  b.ctx,
+		args: windowFunc.Args[:len(windowFunc.Args)-1], // the last argument is SEPARATOR, remove it.
+	}
+	orderByArgs := make([]ast.ExprNode, 0, len(windowFunc.Order.Items))
+	for _, byItem := range windowFunc.Order.Items {
+		resolver.exprDepth = 0
+		resolver.err = nil
+		retExpr, _ := byItem.Expr.Accept(resolver)
+		if resolver.err != nil {
+			return nil, errors.Trace(resolver.err)
+		}
+		orderByArgs = append(orderByArgs, retExpr.(ast.ExprNode))
+	}
+	return orderByArgs, nil
+}
+
 // checkOriginWindowFuncs checks the validity for original window specifications for a group of functions.
 // Because the grouped specification is different from them, we should especially check them before build window frame.
 func (b *PlanBuilder) checkOriginWindowFuncs(funcs []*ast.WindowFuncExpr, orderByItems []property.SortItem) error {
 	for _, f := range funcs {
-		if f.IgnoreNull {
-			return ErrNotSupportedYet.GenWithStackByArgs("IGNORE NULLS")
-		}
-		if f.Distinct {
-			return ErrNotSupportedYet.GenWithStackByArgs("<window function>(DISTINCT ..)")
-		}
-		if f.FromLast {
-			return ErrNotSupportedYet.GenWithStackByArgs("FROM LAST")
-		}
 		spec := &f.Spec
 		if f.Spec.Name.L != "" {
 			spec = b.windowSpecs[f.Spec.Name.L]
@@ -6826,9 +7019,6 @@ func (b *PlanBuilder) checkOriginWindowSpec(spec *ast.WindowSpec, orderByItems [
 	if spec.Frame == nil {
 		return nil
 	}
-	if spec.Frame.Type == ast.Groups {
-		return ErrNotSupportedYet.GenWithStackByArgs("GROUPS")
-	}
 	start, end := spec.Frame.Extent.Start, spec.Frame.Extent.End
 	if start.Type == ast.Following && start.UnBounded {
 		return ErrWindowFrameStartIllegal.GenWithStackByArgs(getWindowName(spec.Name.O))
@@ -6860,7 +7050,7 @@ func (b *PlanBuilder) checkOriginWindowFrameBound(bound *ast.FrameBound, spec *a
 	}
 
 	frameType := spec.Frame.Type
-	if frameType == ast.Rows {
+	if frameType == ast.Rows || frameType == ast.Groups {
 		if bound.Unit != ast.TimeUnitInvalid {
 			return ErrWindowRowsIntervalUse.GenWithStackByArgs(getWindowName(spec.Name.O))
 		}
@@ -60,6 +60,7 @@ var (
 	_ LogicalPlan = &LogicalLimit{}
 	_ LogicalPlan = &LogicalWindow{}
 	_ LogicalPlan = &LogicalExpand{}
+	_ LogicalPlan = &LogicalJSONTable{}
 )
 
 // JoinType contains CrossJoin, InnerJoin, LeftOuterJoin, RightOuterJoin, SemiJoin, AntiJoin.
@@ -2117,6 +2118,9 @@ func (p *LogicalWindow) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
 		for _, arg := range windowFunc.Args {
 			corCols = append(corCols, expression.ExtractCorColumns(arg)...)
 		}
+		for _, byItem := range windowFunc.OrderByItems {
+			corCols = append(corCols, expression.ExtractCorColumns(byItem.Expr)...)
+		}
 	}
 	if p.Frame != nil {
 		if p.Frame.Start != nil {
@@ -2242,6 +2246,25 @@ type LogicalShowDDLJobs struct {
 	JobNumber int64
 }
 
+// LogicalJSONTable represents the JSON_TABLE table function.
+// The JSON document may reference the columns of the tables preceding it in
+// the FROM clause by correlated columns, in which case it's the inner child of
+// a LogicalApply.
+type LogicalJSONTable struct {
+	logicalSchemaProducer
+
+	JSONExpr expression.Expression
+	Path     string
+	// Columns are the column definitions of JSON_TABLE. The schema of the plan
+	// is made up of the non-nested columns in depth-first order.
+	Columns []*ast.JSONTableColumn
+}
+
+// ExtractCorrelatedCols implements LogicalPlan interface.
+func (p *LogicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.JSONExpr)
+}
+
 // CTEClass holds the information and plan for a CTE. Most of the fields in this struct are the same as cteInfo.
 // But the cteInfo is used when building the plan, and CTEClass is used also for building the executor.
 type CTEClass struct {
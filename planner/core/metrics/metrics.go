@@ -30,6 +30,10 @@ var (
 	nonPreparedPlanCacheUnsupportedCounter prometheus.Counter
 	sessionPlanCacheInstancePlanNumCounter prometheus.Gauge
 	sessionPlanCacheInstanceMemoryUsage    prometheus.Gauge
+	instancePlanCacheHitCounter            prometheus.Counter
+	instancePlanCacheMissCounter           prometheus.Counter
+	instancePlanCachePlanNum               prometheus.Gauge
+	instancePlanCacheMemoryUsage           prometheus.Gauge
 )
 
 func init() {
@@ -48,6 +52,10 @@ func InitMetricsVars() {
 	nonPreparedPlanCacheUnsupportedCounter = metrics.PlanCacheMissCounter.WithLabelValues("non-prepared-unsupported")
 	sessionPlanCacheInstancePlanNumCounter = metrics.PlanCacheInstancePlanNumCounter.WithLabelValues(" session-plan-cache")
 	sessionPlanCacheInstanceMemoryUsage = metrics.PlanCacheInstanceMemoryUsage.WithLabelValues(" session-plan-cache")
+	instancePlanCacheHitCounter = metrics.PlanCacheCounter.WithLabelValues("instance")
+	instancePlanCacheMissCounter = metrics.PlanCacheMissCounter.WithLabelValues("instance")
+	instancePlanCachePlanNum = metrics.PlanCacheInstancePlanNumCounter.WithLabelValues("instance-plan-cache")
+	instancePlanCacheMemoryUsage = metrics.PlanCacheInstanceMemoryUsage.WithLabelValues("instance-plan-cache")
 }
 
 // GetPlanCacheHitCounter get different plan cache hit counter
@@ -80,3 +88,23 @@ func GetPlanCacheInstanceNumCounter() prometheus.Gauge {
 func GetPlanCacheInstanceMemoryUsage() prometheus.Gauge {
 	return sessionPlanCacheInstanceMemoryUsage
 }
+
+// GetInstancePlanCacheHitCounter get the hit counter of the instance plan cache
+func GetInstancePlanCacheHitCounter() prometheus.Counter {
+	return instancePlanCacheHitCounter
+}
+
+// GetInstancePlanCacheMissCounter get the miss counter of the instance plan cache
+func GetInstancePlanCacheMissCounter() prometheus.Counter {
+	return instancePlanCacheMissCounter
+}
+
+// GetInstancePlanCachePlanNum get the plan num of the instance plan cache
+func GetInstancePlanCachePlanNum() prometheus.Gauge {
+	return instancePlanCachePlanNum
+}
+
+// GetInstancePlanCacheMemoryUsage get the memory usage of the instance plan cache
+func GetInstancePlanCacheMemoryUsage() prometheus.Gauge {
+	return instancePlanCacheMemoryUsage
+}
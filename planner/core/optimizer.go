@@ -1241,12 +1241,15 @@ func eliminateUnionScanAndLock(sctx sessionctx.Context, p PhysicalPlan) Physical
 		if !lock {
 			return p
 		}
+		skipLocked := IsSelectSkipLockedLockType(physLock.Lock.LockType)
 		if pointGet != nil {
 			pointGet.Lock = lock
 			pointGet.LockWaitTime = waitTime
+			pointGet.SkipLocked = skipLocked
 		} else {
 			batchPointGet.Lock = lock
 			batchPointGet.LockWaitTime = waitTime
+			batchPointGet.SkipLocked = skipLocked
 		}
 	}
 	return transformPhysicalPlan(p, func(p PhysicalPlan) PhysicalPlan {
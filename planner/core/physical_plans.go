@@ -71,6 +71,7 @@ var (
 	_ PhysicalPlan = &PhysicalShuffleReceiverStub{}
 	_ PhysicalPlan = &BatchPointGetPlan{}
 	_ PhysicalPlan = &PhysicalTableSample{}
+	_ PhysicalPlan = &PhysicalJSONTable{}
 )
 
 type tableScanAndPartitionInfo struct {
@@ -1617,20 +1618,29 @@ func (p PhysicalExpand) Init(ctx sessionctx.Context, stats *property.StatsInfo,
 // Clone implements PhysicalPlan interface.
 func (p *PhysicalExpand) Clone() (PhysicalPlan, error) {
 	np := new(PhysicalExpand)
-	base, err := p.basePhysicalPlan.cloneWithSelf(np)
+	base, err := p.physicalSchemaProducer.cloneWithSelf(np)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	np.basePhysicalPlan = *base
+	np.physicalSchemaProducer = *base
 	// clone ID cols.
-	np.GroupingIDCol = p.GroupingIDCol.Clone().(*expression.Column)
+	if p.GroupingIDCol != nil {
+		np.GroupingIDCol = p.GroupingIDCol.Clone().(*expression.Column)
+	}
 
 	// clone grouping expressions.
 	clonedGroupingSets := make([]expression.GroupingSet, 0, len(p.GroupingSets))
 	for _, one := range p.GroupingSets {
 		clonedGroupingSets = append(clonedGroupingSets, one.Clone())
 	}
-	np.GroupingSets = p.GroupingSets
+	np.GroupingSets = clonedGroupingSets
+
+	// clone level projections.
+	np.LevelExprs = make([][]expression.Expression, 0, len(p.LevelExprs))
+	for _, oneLevel := range p.LevelExprs {
+		np.LevelExprs = append(np.LevelExprs, util.CloneExprs(oneLevel))
+	}
+	np.ExtraGroupingColNames = append(np.ExtraGroupingColNames, p.ExtraGroupingColNames...)
 	return np, nil
 }
 
@@ -2246,6 +2256,9 @@ func (p *PhysicalWindow) ExtractCorrelatedCols() []*expression.CorrelatedColumn
 		for _, arg := range windowFunc.Args {
 			corCols = append(corCols, expression.ExtractCorColumns(arg)...)
 		}
+		for _, byItem := range windowFunc.OrderByItems {
+			corCols = append(corCols, expression.ExtractCorColumns(byItem.Expr)...)
+		}
 	}
 	if p.Frame != nil {
 		if p.Frame.Start != nil {
@@ -2448,6 +2461,47 @@ func (p *PhysicalShowDDLJobs) MemoryUsage() (sum int64) {
 	return p.physicalSchemaProducer.MemoryUsage() + size.SizeOfInt64
 }
 
+// PhysicalJSONTable is the physical operator of the JSON_TABLE table function.
+type PhysicalJSONTable struct {
+	physicalSchemaProducer
+
+	JSONExpr expression.Expression
+	Path     string
+	Columns  []*ast.JSONTableColumn
+}
+
+// Clone implements PhysicalPlan interface.
+func (p *PhysicalJSONTable) Clone() (PhysicalPlan, error) {
+	cloned := new(PhysicalJSONTable)
+	base, err := p.physicalSchemaProducer.cloneWithSelf(cloned)
+	if err != nil {
+		return nil, err
+	}
+	cloned.physicalSchemaProducer = *base
+	cloned.JSONExpr = p.JSONExpr.Clone()
+	cloned.Path = p.Path
+	cloned.Columns = p.Columns
+	return cloned, nil
+}
+
+// ExtractCorrelatedCols implements PhysicalPlan interface.
+func (p *PhysicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.JSONExpr)
+}
+
+// MemoryUsage return the memory usage of PhysicalJSONTable
+func (p *PhysicalJSONTable) MemoryUsage() (sum int64) {
+	if p == nil {
+		return
+	}
+	sum = p.physicalSchemaProducer.MemoryUsage() + int64(len(p.Path)) + size.SizeOfString + size.SizeOfSlice +
+		int64(cap(p.Columns))*size.SizeOfPointer
+	if p.JSONExpr != nil {
+		sum += p.JSONExpr.MemoryUsage()
+	}
+	return
+}
+
 // BuildMergeJoinPlan builds a PhysicalMergeJoin from the given fields. Currently, it is only used for test purpose.
 func BuildMergeJoinPlan(ctx sessionctx.Context, joinType JoinType, leftKeys, rightKeys []*expression.Column) *PhysicalMergeJoin {
 	baseJoin := basePhysicalJoin{
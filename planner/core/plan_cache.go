@@ -186,6 +186,15 @@ func GetPlanFromSessionPlanCache(ctx context.Context, sctx sessionctx.Context,
 		}
 	}
 
+	// instanceCacheKey is only set when the instance plan cache is enabled
+	var instanceCacheKey kvcache.Key
+	if stmtCtx.UseCache && variable.EnableInstancePlanCache.Load() {
+		if instanceCacheKey, err = NewInstancePlanCacheKey(sctx.GetSessionVars(), stmt.StmtText,
+			stmt.StmtDB, stmtAst.SchemaVersion, latestSchemaVersion, bindSQL, expression.ExprPushDownBlackListReloadTimeStamp.Load()); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	if stmtCtx.UseCache && stmtAst.CachedPlan != nil { // special code path for fast point plan
 		if plan, names, ok, err := getCachedPointPlan(stmtAst, sessVars, stmtCtx); ok {
 			return plan, names, err
@@ -200,9 +209,14 @@ func GetPlanFromSessionPlanCache(ctx context.Context, sctx sessionctx.Context,
 		if plan, names, ok, err := getCachedPlan(sctx, isNonPrepared, cacheKey, bindSQL, is, stmt, matchOpts); err != nil || ok {
 			return plan, names, err
 		}
+		if instanceCacheKey != nil {
+			if plan, names, ok, err := getCachedPlanFromInstanceCache(sctx, instanceCacheKey, bindSQL, is, stmt, matchOpts); err != nil || ok {
+				return plan, names, err
+			}
+		}
 	}
 
-	return generateNewPlan(ctx, sctx, isNonPrepared, is, stmt, cacheKey, latestSchemaVersion, bindSQL, matchOpts)
+	return generateNewPlan(ctx, sctx, isNonPrepared, is, stmt, cacheKey, instanceCacheKey, latestSchemaVersion, bindSQL, matchOpts)
 }
 
 // parseParamTypes get parameters' types in PREPARE statement
@@ -288,10 +302,66 @@ func getCachedPlan(sctx sessionctx.Context, isNonPrepared bool, cacheKey kvcache
 	return cachedVal.Plan, cachedVal.OutPutNames, true, nil
 }
 
+// getCachedPlanFromInstanceCache tries to get a plan from the instance plan cache. The cached plan is a template
+// shared by sessions, so it's cloned and bound to the current session before being used.
+func getCachedPlanFromInstanceCache(sctx sessionctx.Context, cacheKey kvcache.Key, bindSQL string,
+	is infoschema.InfoSchema, stmt *PlanCacheStmt, matchOpts *utilpc.PlanCacheMatchOpts) (Plan,
+	[]*types.FieldName, bool, error) {
+	sessVars := sctx.GetSessionVars()
+	stmtCtx := sessVars.StmtCtx
+
+	template, exist := GetInstancePlanCache().Get(cacheKey, stmt.SQLDigest.String(), sessVars, matchOpts)
+	if !exist {
+		core_metrics.GetInstancePlanCacheMissCounter().Inc()
+		return nil, nil, false, nil
+	}
+	if err := CheckPreparedPriv(sctx, stmt, is); err != nil {
+		return nil, nil, false, err
+	}
+	for tblInfo, unionScan := range template.TblInfo2UnionScan {
+		if !unionScan && tableHasDirtyContent(sctx, tblInfo) {
+			// the plan with UnionScan can't be shared, so it'll be put into the session plan cache
+			return nil, nil, false, nil
+		}
+	}
+	plan, ok := clonePlanForInstancePlanCache(template.Plan, sctx)
+	if !ok || !RebuildPlan4CachedPlan(plan) {
+		return nil, nil, false, nil
+	}
+	sessVars.FoundInPlanCache = true
+	if len(bindSQL) > 0 {
+		sessVars.FoundInBinding = true
+	}
+	core_metrics.GetInstancePlanCacheHitCounter().Inc()
+	if stmt.PlanDigest == nil {
+		// the plan is generated by another session
+		stmt.NormalizedPlan, stmt.PlanDigest = NormalizePlan(plan)
+	}
+	stmtCtx.SetPlanDigest(stmt.NormalizedPlan, stmt.PlanDigest)
+	return plan, template.OutPutNames, true, nil
+}
+
+// putPlanIntoInstanceCache puts a template of the plan into the instance plan cache. It returns false if the instance
+// plan cache is disabled or the plan can't be shared by sessions.
+func putPlanIntoInstanceCache(sctx sessionctx.Context, cacheKey kvcache.Key, stmt *PlanCacheStmt,
+	cached *PlanCacheValue, matchOpts *utilpc.PlanCacheMatchOpts) bool {
+	if cacheKey == nil {
+		return false
+	}
+	// the template is bound to no session, it's never executed and doesn't keep the session alive
+	template, ok := clonePlanForInstancePlanCache(cached.Plan, nil)
+	if !ok {
+		return false
+	}
+	GetInstancePlanCache().Put(cacheKey, NewPlanCacheValue(template, cached.OutPutNames, cached.TblInfo2UnionScan, matchOpts),
+		stmt.SQLDigest.String(), stmt.NormalizedSQL, sctx.GetSessionVars(), matchOpts)
+	return true
+}
+
 // generateNewPlan call the optimizer to generate a new plan for current statement
 // and try to add it to cache
 func generateNewPlan(ctx context.Context, sctx sessionctx.Context, isNonPrepared bool, is infoschema.InfoSchema,
-	stmt *PlanCacheStmt, cacheKey kvcache.Key, latestSchemaVersion int64, bindSQL string,
+	stmt *PlanCacheStmt, cacheKey, instanceCacheKey kvcache.Key, latestSchemaVersion int64, bindSQL string,
 	matchOpts *utilpc.PlanCacheMatchOpts) (Plan, []*types.FieldName, error) {
 	stmtAst := stmt.PreparedAst
 	sessVars := sctx.GetSessionVars()
@@ -325,13 +395,22 @@ func generateNewPlan(ctx context.Context, sctx sessionctx.Context, isNonPrepared
 				stmtAst.SchemaVersion, latestSchemaVersion, bindSQL, expression.ExprPushDownBlackListReloadTimeStamp.Load()); err != nil {
 				return nil, nil, err
 			}
+			if instanceCacheKey != nil {
+				if instanceCacheKey, err = NewInstancePlanCacheKey(sessVars, stmt.StmtText, stmt.StmtDB,
+					stmtAst.SchemaVersion, latestSchemaVersion, bindSQL, expression.ExprPushDownBlackListReloadTimeStamp.Load()); err != nil {
+					return nil, nil, err
+				}
+			}
 			sessVars.IsolationReadEngines[kv.TiFlash] = struct{}{}
 		}
 		cached := NewPlanCacheValue(p, names, stmtCtx.TblInfo2UnionScan, matchOpts)
 		stmt.NormalizedPlan, stmt.PlanDigest = NormalizePlan(p)
 		stmtCtx.SetPlan(p)
 		stmtCtx.SetPlanDigest(stmt.NormalizedPlan, stmt.PlanDigest)
-		sctx.GetSessionPlanCache().Put(cacheKey, cached, matchOpts)
+		// the plans which can't be shared by sessions are still put into the session plan cache
+		if !putPlanIntoInstanceCache(sctx, instanceCacheKey, stmt, cached, matchOpts) {
+			sctx.GetSessionPlanCache().Put(cacheKey, cached, matchOpts)
+		}
 	}
 	sessVars.FoundInPlanCache = false
 	return p, names, err
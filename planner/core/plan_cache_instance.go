@@ -0,0 +1,233 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"container/list"
+
+	core_metrics "github.com/pingcap/tidb/planner/core/metrics"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util/kvcache"
+	utilpc "github.com/pingcap/tidb/util/plancache"
+	"github.com/pingcap/tidb/util/syncutil"
+	"golang.org/x/exp/slices"
+)
+
+var globalInstancePlanCache = NewInstancePlanCache(func() uint64 {
+	return variable.InstancePlanCacheMaxMemSize.Load()
+})
+
+// GetInstancePlanCache returns the plan cache shared by all sessions of this TiDB instance.
+func GetInstancePlanCache() *InstancePlanCache {
+	return globalInstancePlanCache
+}
+
+// instancePlanCacheEntry is the value of list.Element in InstancePlanCache.
+type instancePlanCacheEntry struct {
+	planCacheEntry
+	sqlDigest   string
+	memoryUsage int64
+}
+
+// InstancePlanCacheDigestStats is the statistics of a statement in the instance plan cache.
+type InstancePlanCacheDigestStats struct {
+	SQLDigest     string
+	NormalizedSQL string
+	PlanNum       int
+	MemoryUsage   int64
+	Hits          uint64
+	Misses        uint64
+}
+
+// InstancePlanCache is the plan cache shared by all sessions of a TiDB instance, it's enabled by
+// `tidb_enable_instance_plan_cache`.
+// The cached plans are templates which are never executed, every session clones the plan and binds the clone to its
+// own session context before using it, see `instancePlanCacheCloner` for details. The cache evicts the least recently
+// used plans when the memory usage exceeds `tidb_instance_plan_cache_max_mem_size`.
+type InstancePlanCache struct {
+	lock syncutil.Mutex
+	// buckets replace the map in general LRU
+	buckets map[string]map[*list.Element]struct{}
+	lruList *list.List
+
+	memoryUsageTotal int64
+	maxMemoryUsage   func() uint64
+	// digestStats records the hits and misses of the statements which have plans in the cache
+	digestStats map[string]*InstancePlanCacheDigestStats
+}
+
+// NewInstancePlanCache creates an InstancePlanCache object.
+func NewInstancePlanCache(maxMemoryUsage func() uint64) *InstancePlanCache {
+	return &InstancePlanCache{
+		buckets:        make(map[string]map[*list.Element]struct{}),
+		lruList:        list.New(),
+		maxMemoryUsage: maxMemoryUsage,
+		digestStats:    make(map[string]*InstancePlanCacheDigestStats),
+	}
+}
+
+// Get tries to find the plan template according to the given key. The returned plan must be cloned by
+// clonePlanForInstancePlanCache before being used.
+func (c *InstancePlanCache) Get(key kvcache.Key, sqlDigest string, vars *variable.SessionVars,
+	opts *utilpc.PlanCacheMatchOpts) (value *PlanCacheValue, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if bucket, bucketExist := c.buckets[strHashKey(key, false)]; bucketExist {
+		for element := range bucket {
+			plan := element.Value.(*instancePlanCacheEntry).PlanValue.(*PlanCacheValue)
+			if matchCachedPlan(vars, plan, opts) {
+				c.lruList.MoveToFront(element)
+				if stats, ok := c.digestStats[sqlDigest]; ok {
+					stats.Hits++
+				}
+				return plan, true
+			}
+		}
+	}
+	if stats, ok := c.digestStats[sqlDigest]; ok {
+		stats.Misses++
+	}
+	return nil, false
+}
+
+// Put puts the plan template into the cache. The plan template must be cloned by clonePlanForInstancePlanCache, and
+// must not be used by any session.
+func (c *InstancePlanCache) Put(key kvcache.Key, value *PlanCacheValue, sqlDigest, normalizedSQL string,
+	vars *variable.SessionVars, opts *utilpc.PlanCacheMatchOpts) {
+	// the memory usages are calculated and cached in the key and the value before they're visible to other sessions
+	entry := &instancePlanCacheEntry{
+		planCacheEntry: planCacheEntry{PlanKey: key, PlanValue: value},
+		sqlDigest:      sqlDigest,
+	}
+	entry.memoryUsage = entry.planCacheEntry.MemoryUsage()
+	maxMemoryUsage := c.maxMemoryUsage()
+	if uint64(entry.memoryUsage) > maxMemoryUsage {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	hash := strHashKey(key, true)
+	bucket, bucketExist := c.buckets[hash]
+	if bucketExist {
+		for element := range bucket {
+			// another session may have put a suitable plan after this session missed it
+			if matchCachedPlan(vars, element.Value.(*instancePlanCacheEntry).PlanValue.(*PlanCacheValue), opts) {
+				c.removeElement(element)
+				break
+			}
+		}
+		bucket = c.buckets[hash]
+	}
+	if bucket == nil {
+		bucket = make(map[*list.Element]struct{}, 1)
+		c.buckets[hash] = bucket
+	}
+
+	element := c.lruList.PushFront(entry)
+	bucket[element] = struct{}{}
+	c.memoryUsageTotal += entry.memoryUsage
+	stats, ok := c.digestStats[sqlDigest]
+	if !ok {
+		// the statement missed the cache before putting its first plan
+		stats = &InstancePlanCacheDigestStats{SQLDigest: sqlDigest, NormalizedSQL: normalizedSQL, Misses: 1}
+		c.digestStats[sqlDigest] = stats
+	}
+	stats.PlanNum++
+	stats.MemoryUsage += entry.memoryUsage
+	core_metrics.GetInstancePlanCachePlanNum().Inc()
+	core_metrics.GetInstancePlanCacheMemoryUsage().Add(float64(entry.memoryUsage))
+
+	for uint64(c.memoryUsageTotal) > maxMemoryUsage {
+		c.removeElement(c.lruList.Back())
+	}
+}
+
+// Delete deletes the plans of the key from the cache.
+func (c *InstancePlanCache) Delete(key kvcache.Key) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for element := range c.buckets[strHashKey(key, false)] {
+		c.removeElement(element)
+	}
+}
+
+// DeleteAll deletes all plans from the cache.
+func (c *InstancePlanCache) DeleteAll() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	core_metrics.GetInstancePlanCachePlanNum().Sub(float64(c.lruList.Len()))
+	core_metrics.GetInstancePlanCacheMemoryUsage().Sub(float64(c.memoryUsageTotal))
+	c.buckets = make(map[string]map[*list.Element]struct{})
+	c.lruList = list.New()
+	c.memoryUsageTotal = 0
+	c.digestStats = make(map[string]*InstancePlanCacheDigestStats)
+}
+
+// Size gets the number of plans in the cache.
+func (c *InstancePlanCache) Size() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.lruList.Len()
+}
+
+// MemoryUsage returns the memory usage of the cache.
+func (c *InstancePlanCache) MemoryUsage() int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.memoryUsageTotal
+}
+
+// DigestStats returns the statistics of the statements which have plans in the cache, ordered by the SQL digest.
+func (c *InstancePlanCache) DigestStats() []InstancePlanCacheDigestStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	stats := make([]InstancePlanCacheDigestStats, 0, len(c.digestStats))
+	for _, s := range c.digestStats {
+		stats = append(stats, *s)
+	}
+	slices.SortFunc(stats, func(i, j InstancePlanCacheDigestStats) bool {
+		return i.SQLDigest < j.SQLDigest
+	})
+	return stats
+}
+
+func (c *InstancePlanCache) removeElement(element *list.Element) {
+	entry := element.Value.(*instancePlanCacheEntry)
+	c.lruList.Remove(element)
+	hash := strHashKey(entry.PlanKey, false)
+	bucket := c.buckets[hash]
+	delete(bucket, element)
+	if len(bucket) == 0 {
+		delete(c.buckets, hash)
+	}
+	c.memoryUsageTotal -= entry.memoryUsage
+	if stats, ok := c.digestStats[entry.sqlDigest]; ok {
+		stats.PlanNum--
+		stats.MemoryUsage -= entry.memoryUsage
+		if stats.PlanNum == 0 {
+			delete(c.digestStats, entry.sqlDigest)
+		}
+	}
+	core_metrics.GetInstancePlanCachePlanNum().Dec()
+	core_metrics.GetInstancePlanCacheMemoryUsage().Sub(float64(entry.memoryUsage))
+}
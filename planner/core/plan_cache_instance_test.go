@@ -0,0 +1,125 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	utilpc "github.com/pingcap/tidb/util/plancache"
+	"github.com/stretchr/testify/require"
+)
+
+func newInstancePlanCacheValue(opts *utilpc.PlanCacheMatchOpts) *PlanCacheValue {
+	return NewPlanCacheValue(&PhysicalTableDual{}, nil, nil, opts)
+}
+
+func TestInstancePlanCachePutGet(t *testing.T) {
+	vars := MockContext().GetSessionVars()
+	pc := NewInstancePlanCache(func() uint64 { return 1 << 30 })
+
+	intOpts := &utilpc.PlanCacheMatchOpts{ParamTypes: []*types.FieldType{types.NewFieldType(mysql.TypeLonglong)}}
+	strOpts := &utilpc.PlanCacheMatchOpts{ParamTypes: []*types.FieldType{types.NewFieldType(mysql.TypeVarchar)}}
+	key := &planCacheKey{database: "test", schemaVersion: 1, privilegeCtx: "u1@%"}
+
+	_, ok := pc.Get(key, "d1", vars, intOpts)
+	require.False(t, ok)
+	require.Len(t, pc.DigestStats(), 0)
+
+	intVal := newInstancePlanCacheValue(intOpts)
+	pc.Put(key, intVal, "d1", "select ?", vars, intOpts)
+	require.Equal(t, 1, pc.Size())
+	require.Equal(t, intVal.MemoryUsage()+key.MemoryUsage(), pc.MemoryUsage())
+
+	// another plan of the same statement with different parameter types
+	_, ok = pc.Get(key, "d1", vars, strOpts)
+	require.False(t, ok)
+	pc.Put(key, newInstancePlanCacheValue(strOpts), "d1", "select ?", vars, strOpts)
+	require.Equal(t, 2, pc.Size())
+
+	val, ok := pc.Get(key, "d1", vars, intOpts)
+	require.True(t, ok)
+	require.Same(t, intVal, val)
+
+	// putting the plan again replaces the old one
+	pc.Put(key, newInstancePlanCacheValue(intOpts), "d1", "select ?", vars, intOpts)
+	require.Equal(t, 2, pc.Size())
+
+	// the plans of other users can't be used
+	_, ok = pc.Get(&planCacheKey{database: "test", schemaVersion: 1, privilegeCtx: "u2@%"}, "d1", vars, intOpts)
+	require.False(t, ok)
+
+	stats := pc.DigestStats()
+	require.Len(t, stats, 1)
+	require.Equal(t, InstancePlanCacheDigestStats{
+		SQLDigest:     "d1",
+		NormalizedSQL: "select ?",
+		PlanNum:       2,
+		MemoryUsage:   pc.MemoryUsage(),
+		Hits:          1,
+		Misses:        3,
+	}, stats[0])
+
+	pc.Delete(key)
+	require.Equal(t, 0, pc.Size())
+	require.Equal(t, int64(0), pc.MemoryUsage())
+	require.Len(t, pc.DigestStats(), 0)
+}
+
+func TestInstancePlanCacheEviction(t *testing.T) {
+	vars := MockContext().GetSessionVars()
+	opts := &utilpc.PlanCacheMatchOpts{ParamTypes: []*types.FieldType{types.NewFieldType(mysql.TypeLonglong)}}
+	entryMemoryUsage := func(key *planCacheKey) int64 {
+		return key.MemoryUsage() + newInstancePlanCacheValue(opts).MemoryUsage()
+	}
+	keys := []*planCacheKey{
+		{database: "test", schemaVersion: 1},
+		{database: "test", schemaVersion: 2},
+		{database: "test", schemaVersion: 3},
+	}
+	var maxMemoryUsage uint64
+	pc := NewInstancePlanCache(func() uint64 { return maxMemoryUsage })
+
+	// a plan larger than the limit is never cached
+	pc.Put(keys[0], newInstancePlanCacheValue(opts), "d0", "select 0", vars, opts)
+	require.Equal(t, 0, pc.Size())
+
+	maxMemoryUsage = uint64(entryMemoryUsage(keys[0]) + entryMemoryUsage(keys[1]))
+	pc.Put(keys[0], newInstancePlanCacheValue(opts), "d0", "select 0", vars, opts)
+	pc.Put(keys[1], newInstancePlanCacheValue(opts), "d1", "select 1", vars, opts)
+	require.Equal(t, 2, pc.Size())
+
+	// the least recently used plan is evicted
+	_, ok := pc.Get(keys[0], "d0", vars, opts)
+	require.True(t, ok)
+	pc.Put(keys[2], newInstancePlanCacheValue(opts), "d2", "select 2", vars, opts)
+	require.Equal(t, 2, pc.Size())
+	require.LessOrEqual(t, uint64(pc.MemoryUsage()), maxMemoryUsage)
+	_, ok = pc.Get(keys[1], "d1", vars, opts)
+	require.False(t, ok)
+	_, ok = pc.Get(keys[0], "d0", vars, opts)
+	require.True(t, ok)
+
+	stats := pc.DigestStats()
+	require.Len(t, stats, 2)
+	require.Equal(t, "d0", stats[0].SQLDigest)
+	require.Equal(t, "d2", stats[1].SQLDigest)
+
+	pc.DeleteAll()
+	require.Equal(t, 0, pc.Size())
+	require.Equal(t, int64(0), pc.MemoryUsage())
+	require.Len(t, pc.DigestStats(), 0)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/errors"
 	core_metrics "github.com/pingcap/tidb/planner/core/metrics"
 	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/util/hack"
 	"github.com/pingcap/tidb/util/kvcache"
 	"github.com/pingcap/tidb/util/logutil"
@@ -254,39 +255,43 @@ func (l *LRUPlanCache) memoryControl() {
 func (l *LRUPlanCache) pickFromBucket(bucket map[*list.Element]struct{}, matchOpts *utilpc.PlanCacheMatchOpts) (*list.Element, bool) {
 	for k := range bucket {
 		plan := k.Value.(*planCacheEntry).PlanValue.(*PlanCacheValue)
-		// check param types' compatibility
-		ok1 := checkTypesCompatibility4PC(plan.matchOpts.ParamTypes, matchOpts.ParamTypes)
-		if !ok1 {
-			continue
+		if matchCachedPlan(l.sctx.GetSessionVars(), plan, matchOpts) {
+			return k, true
 		}
+	}
+	return nil, false
+}
 
-		// check limit offset and key if equal and check switch if enabled
-		ok2 := checkUint64SliceIfEqual(plan.matchOpts.LimitOffsetAndCount, matchOpts.LimitOffsetAndCount)
-		if !ok2 {
-			continue
-		}
-		if len(plan.matchOpts.LimitOffsetAndCount) > 0 && !l.sctx.GetSessionVars().EnablePlanCacheForParamLimit {
-			// offset and key slice matched, but it is a plan with param limit and the switch is disabled
-			continue
-		}
-		// check subquery switch state
-		if plan.matchOpts.HasSubQuery && !l.sctx.GetSessionVars().EnablePlanCacheForSubquery {
-			continue
-		}
-		// table stats has changed
-		// this check can be disabled by turning off system variable tidb_plan_cache_invalidation_on_fresh_stats
-		if l.sctx.GetSessionVars().PlanCacheInvalidationOnFreshStats &&
-			plan.matchOpts.StatsVersionHash != matchOpts.StatsVersionHash {
-			continue
-		}
+// matchCachedPlan checks whether the cached plan is suitable for the current execution.
+func matchCachedPlan(vars *variable.SessionVars, plan *PlanCacheValue, matchOpts *utilpc.PlanCacheMatchOpts) bool {
+	// check param types' compatibility
+	ok1 := checkTypesCompatibility4PC(plan.matchOpts.ParamTypes, matchOpts.ParamTypes)
+	if !ok1 {
+		return false
+	}
 
-		// below are some SQL variables that can affect the plan
-		if plan.matchOpts.ForeignKeyChecks != matchOpts.ForeignKeyChecks {
-			continue
-		}
-		return k, true
+	// check limit offset and key if equal and check switch if enabled
+	ok2 := checkUint64SliceIfEqual(plan.matchOpts.LimitOffsetAndCount, matchOpts.LimitOffsetAndCount)
+	if !ok2 {
+		return false
 	}
-	return nil, false
+	if len(plan.matchOpts.LimitOffsetAndCount) > 0 && !vars.EnablePlanCacheForParamLimit {
+		// offset and key slice matched, but it is a plan with param limit and the switch is disabled
+		return false
+	}
+	// check subquery switch state
+	if plan.matchOpts.HasSubQuery && !vars.EnablePlanCacheForSubquery {
+		return false
+	}
+	// table stats has changed
+	// this check can be disabled by turning off system variable tidb_plan_cache_invalidation_on_fresh_stats
+	if vars.PlanCacheInvalidationOnFreshStats &&
+		plan.matchOpts.StatsVersionHash != matchOpts.StatsVersionHash {
+		return false
+	}
+
+	// below are some SQL variables that can affect the plan
+	return plan.matchOpts.ForeignKeyChecks == matchOpts.ForeignKeyChecks
 }
 
 func checkUint64SliceIfEqual(a, b []uint64) bool {
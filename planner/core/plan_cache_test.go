@@ -2467,3 +2467,44 @@ func BenchmarkNonPreparedPlanCacheDML(b *testing.B) {
 		tk.MustExec("delete from t where a = 2")
 	}
 }
+
+func TestInstancePlanCache(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk1 := testkit.NewTestKit(t, store)
+	tk2 := testkit.NewTestKit(t, store)
+	tk1.MustExec(`use test`)
+	tk2.MustExec(`use test`)
+	tk1.MustExec(`create table t (a int, b int, key(a))`)
+	tk1.MustExec(`insert into t values (1, 1), (2, 2), (3, 3)`)
+	tk1.MustExec(`set global tidb_enable_instance_plan_cache=1`)
+	defer tk1.MustExec(`set global tidb_enable_instance_plan_cache=0`)
+	tk1.MustExec(`admin flush instance plan_cache`)
+
+	tk1.MustExec(`prepare st from 'select b from t where a > ? order by b'`)
+	tk1.MustExec(`set @a=1`)
+	tk1.MustQuery(`execute st using @a`).Check(testkit.Rows("2", "3"))
+	tk1.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("0"))
+	tk1.MustExec(`set @a=2`)
+	tk1.MustQuery(`execute st using @a`).Check(testkit.Rows("3"))
+	tk1.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+
+	// the plan is shared by another session
+	tk2.MustExec(`prepare st from 'select b from t where a > ? order by b'`)
+	tk2.MustExec(`set @a=0`)
+	tk2.MustQuery(`execute st using @a`).Check(testkit.Rows("1", "2", "3"))
+	tk2.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+	tk1.MustQuery(`select plan_num, hits, misses from information_schema.instance_plan_cache where digest_text like 'select b from t%'`).
+		Check(testkit.Rows("1 2 1"))
+
+	// the dirty table can't use the shared plan
+	tk2.MustExec(`begin`)
+	tk2.MustExec(`insert into t values (4, 4)`)
+	tk2.MustQuery(`execute st using @a`).Check(testkit.Rows("1", "2", "3", "4"))
+	tk2.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("0"))
+	tk2.MustExec(`rollback`)
+
+	tk1.MustExec(`admin flush instance plan_cache`)
+	tk1.MustQuery(`select count(*) from information_schema.instance_plan_cache`).Check(testkit.Rows("0"))
+	tk2.MustQuery(`execute st using @a`).Check(testkit.Rows("1", "2", "3"))
+	tk2.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("0"))
+}
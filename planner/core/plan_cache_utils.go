@@ -18,6 +18,7 @@ import (
 	"context"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 	"unsafe"
 
@@ -217,6 +218,9 @@ type planCacheKey struct {
 	restrictedReadOnly       bool
 	TiDBSuperReadOnly        bool
 	ExprBlacklistTS          int64 // expr-pushdown-blacklist can affect query optimization, so we need to consider it in plan cache.
+	// privilegeCtx is only set for the instance plan cache, whose plans are shared by the sessions of the same user
+	// with the same active roles.
+	privilegeCtx string
 
 	memoryUsage int64 // Do not include in hash
 	hash        []byte
@@ -250,6 +254,7 @@ func (key *planCacheKey) Hash() []byte {
 		key.hash = append(key.hash, hack.Slice(strconv.FormatBool(key.restrictedReadOnly))...)
 		key.hash = append(key.hash, hack.Slice(strconv.FormatBool(key.TiDBSuperReadOnly))...)
 		key.hash = codec.EncodeInt(key.hash, key.ExprBlacklistTS)
+		key.hash = append(key.hash, hack.Slice(key.privilegeCtx)...)
 	}
 	return key.hash
 }
@@ -265,7 +270,7 @@ func (key *planCacheKey) MemoryUsage() (sum int64) {
 	if key.memoryUsage > 0 {
 		return key.memoryUsage
 	}
-	sum = emptyPlanCacheKeySize + int64(len(key.database)+len(key.stmtText)+len(key.bindSQL)) +
+	sum = emptyPlanCacheKeySize + int64(len(key.database)+len(key.stmtText)+len(key.bindSQL)+len(key.privilegeCtx)) +
 		int64(len(key.isolationReadEngines))*size.SizeOfUint8 + int64(cap(key.hash))
 	key.memoryUsage = sum
 	return
@@ -327,6 +332,38 @@ func NewPlanCacheKey(sessionVars *variable.SessionVars, stmtText, stmtDB string,
 	return key, nil
 }
 
+// NewInstancePlanCacheKey creates a new planCacheKey object for the instance plan cache.
+// Unlike the key of the session plan cache, it doesn't contain the connection ID, but contains the current user and
+// the active roles, so a cached plan is only shared by the sessions with the same privilege context.
+func NewInstancePlanCacheKey(sessionVars *variable.SessionVars, stmtText, stmtDB string, schemaVersion int64,
+	lastUpdatedSchemaVersion int64, bindSQL string, exprBlacklistTS int64) (kvcache.Key, error) {
+	key, err := NewPlanCacheKey(sessionVars, stmtText, stmtDB, schemaVersion, lastUpdatedSchemaVersion, bindSQL, exprBlacklistTS)
+	if err != nil {
+		return nil, err
+	}
+	instanceKey := key.(*planCacheKey)
+	instanceKey.connID = 0
+	instanceKey.privilegeCtx = privilegeCtx4PlanCache(sessionVars)
+	return instanceKey, nil
+}
+
+func privilegeCtx4PlanCache(sessionVars *variable.SessionVars) string {
+	var sb strings.Builder
+	if sessionVars.User != nil {
+		sb.WriteString(sessionVars.User.String())
+	}
+	roles := make([]string, 0, len(sessionVars.ActiveRoles))
+	for _, role := range sessionVars.ActiveRoles {
+		roles = append(roles, role.String())
+	}
+	slices.Sort(roles)
+	for _, role := range roles {
+		sb.WriteByte(',')
+		sb.WriteString(role)
+	}
+	return sb.String()
+}
+
 // PlanCacheValue stores the cached Statement and StmtNode.
 type PlanCacheValue struct {
 	Plan              Plan
@@ -367,6 +367,8 @@ func extractTableNames(node ast.ResultSetNode, names []*ast.TableName) ([]*ast.T
 				return nil, ok, reason
 			}
 		}
+	case *ast.JSONTable:
+		return names, false, "queries that have JSON_TABLE are not supported"
 	default:
 		return names, false, "queries that have sub-queries are not supported"
 	}
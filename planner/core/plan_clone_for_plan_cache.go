@@ -0,0 +1,354 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/expression/aggregation"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/planner/util"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+)
+
+// instancePlanCacheCloner is implemented by the physical plans which can be shared by sessions through the instance
+// plan cache.
+//
+// The plans and the expressions hold the session context they're built with, so a cached plan can't be executed by
+// another session directly. The instance plan cache keeps a template of the plan which is never executed, and every
+// session executes its own clone which is bound to its session context. Unlike `Clone`, the clone shares the read-only
+// parts (table info, statistics, schema...) with the template, and copies the parts which are rebuilt when the cached
+// plan is reused, like the expressions, the ranges and the point get values.
+type instancePlanCacheCloner interface {
+	cloneForInstancePlanCache(newCtx sessionctx.Context) (PhysicalPlan, bool)
+}
+
+// clonePlanForInstancePlanCache clones the plan and binds the cloned plan to the new session context. It returns false
+// if the plan can't be shared by sessions.
+func clonePlanForInstancePlanCache(p Plan, newCtx sessionctx.Context) (Plan, bool) {
+	physicalPlan, ok := p.(PhysicalPlan)
+	if !ok {
+		return nil, false
+	}
+	return clonePhysicalPlanForInstancePlanCache(physicalPlan, newCtx)
+}
+
+func clonePhysicalPlanForInstancePlanCache(p PhysicalPlan, newCtx sessionctx.Context) (PhysicalPlan, bool) {
+	cloner, ok := p.(instancePlanCacheCloner)
+	if !ok {
+		return nil, false
+	}
+	return cloner.cloneForInstancePlanCache(newCtx)
+}
+
+func (p *basePhysicalPlan) cloneBaseForInstancePlanCache(newSelf PhysicalPlan, newCtx sessionctx.Context) (*basePhysicalPlan, bool) {
+	// the probe parents are only set for the inner children of IndexJoin and Apply, which are not shared.
+	if len(p.probeParents) > 0 {
+		return nil, false
+	}
+	cloned := *p
+	cloned.ctx = newCtx
+	cloned.self = newSelf
+	cloned.children = make([]PhysicalPlan, 0, len(p.children))
+	for _, child := range p.children {
+		clonedChild, ok := clonePhysicalPlanForInstancePlanCache(child, newCtx)
+		if !ok {
+			return nil, false
+		}
+		cloned.children = append(cloned.children, clonedChild)
+	}
+	return &cloned, true
+}
+
+func (s *physicalSchemaProducer) cloneBaseForInstancePlanCache(newSelf PhysicalPlan, newCtx sessionctx.Context) (*physicalSchemaProducer, bool) {
+	base, ok := s.basePhysicalPlan.cloneBaseForInstancePlanCache(newSelf, newCtx)
+	if !ok {
+		return nil, false
+	}
+	return &physicalSchemaProducer{schema: s.schema, basePhysicalPlan: *base}, true
+}
+
+func cloneByItemsForInstancePlanCache(items []*util.ByItems, newCtx sessionctx.Context) []*util.ByItems {
+	if items == nil {
+		return nil
+	}
+	cloned := make([]*util.ByItems, 0, len(items))
+	for _, item := range items {
+		cloned = append(cloned, &util.ByItems{Expr: expression.CloneWithNewCtx(item.Expr, newCtx), Desc: item.Desc})
+	}
+	return cloned
+}
+
+func cloneConstantsForInstancePlanCache(constants []*expression.Constant, newCtx sessionctx.Context) []*expression.Constant {
+	if constants == nil {
+		return nil
+	}
+	cloned := make([]*expression.Constant, 0, len(constants))
+	for _, c := range constants {
+		if c == nil {
+			cloned = append(cloned, nil)
+			continue
+		}
+		cloned = append(cloned, expression.CloneWithNewCtx(c, newCtx).(*expression.Constant))
+	}
+	return cloned
+}
+
+func (p *PhysicalTableReader) cloneForInstancePlanCache(newCtx sessionctx.Context) (PhysicalPlan, bool) {
+	if p.StoreType != kv.TiKV || p.ReadReqType != Cop || len(p.PartitionInfos) > 0 {
+		return nil, false
+	}
+	cloned := new(PhysicalTableReader)
+	*cloned = *p
+	base, ok := p.physicalSchemaProducer.cloneBaseForInstancePlanCache(cloned, newCtx)
+	if !ok {
+		return nil, false
+	}
+	cloned.physicalSchemaProducer = *base
+	if cloned.tablePlan, ok = clonePhysicalPlanForInstancePlanCache(p.tablePlan, newCtx); !ok {
+		return nil, false
+	}
+	cloned.TablePlans = flattenPushDownPlan(cloned.tablePlan)
+	return cloned, true
+}
+
+func (p *PhysicalIndexReader) cloneForInstancePlanCache(newCtx sessionctx.Context) (PhysicalPlan, bool) {
+	cloned := new(PhysicalIndexReader)
+	*cloned = *p
+	base, ok := p.physicalSchemaProducer.cloneBaseForInstancePlanCache(cloned, newCtx)
+	if !ok {
+		return nil, false
+	}
+	cloned.physicalSchemaProducer = *base
+	if cloned.indexPlan, ok = clonePhysicalPlanForInstancePlanCache(p.indexPlan, newCtx); !ok {
+		return nil, false
+	}
+	cloned.IndexPlans = flattenPushDownPlan(cloned.indexPlan)
+	return cloned, true
+}
+
+func (p *PhysicalIndexLookUpReader) cloneForInstancePlanCache(newCtx sessionctx.Context) (PhysicalPlan, bool) {
+	cloned := new(PhysicalIndexLookUpReader)
+	*cloned = *p
+	base, ok := p.physicalSchemaProducer.cloneBaseForInstancePlanCache(cloned, newCtx)
+	if !ok {
+		return nil, false
+	}
+	cloned.physicalSchemaProducer = *base
+	if cloned.indexPlan, ok = clonePhysicalPlanForInstancePlanCache(p.indexPlan, newCtx); !ok {
+		return nil, false
+	}
+	if cloned.tablePlan, ok = clonePhysicalPlanForInstancePlanCache(p.tablePlan, newCtx); !ok {
+		return nil, false
+	}
+	cloned.IndexPlans = flattenPushDownPlan(cloned.indexPlan)
+	cloned.TablePlans = flattenPushDownPlan(cloned.tablePlan)
+	return cloned, true
+}
+
+func (ts *PhysicalTableScan) cloneForInstancePlanCache(newCtx sessionctx.Context) (PhysicalPlan, bool) {
+	if ts.isPartition || ts.Table.GetPartitionInfo() != nil || ts.SampleInfo != nil || ts.StoreType != kv.TiKV {
+		return nil, false
+	}
+	cloned := new(PhysicalTableScan)
+	*cloned = *ts
+	base, ok := ts.physicalSchemaProducer.cloneBaseForInstancePlanCache(cloned, newCtx)
+	if !ok {
+		return nil, false
+	}
+	cloned.physicalSchemaProducer = *base
+	cloned.AccessCondition = expression.CloneExprsWithNewCtx(ts.AccessCondition, newCtx)
+	cloned.filterCondition = expression.CloneExprsWithNewCtx(ts.filterCondition, newCtx)
+	cloned.lateMaterializationFilterCondition = expression.CloneExprsWithNewCtx(ts.lateMaterializationFilterCondition, newCtx)
+	cloned.ByItems = cloneByItemsForInstancePlanCache(ts.ByItems, newCtx)
+	return cloned, true
+}
+
+func (p *PhysicalIndexScan) cloneForInstancePlanCache(newCtx sessionctx.Context) (PhysicalPlan, bool) {
+	if p.isPartition || p.Table.GetPartitionInfo() != nil {
+		return nil, false
+	}
+	cloned := new(PhysicalIndexScan)
+	*cloned = *p
+	base, ok := p.physicalSchemaProducer.cloneBaseForInstancePlanCache(cloned, newCtx)
+	if !ok {
+		return nil, false
+	}
+	cloned.physicalSchemaProducer = *base
+	cloned.AccessCondition = expression.CloneExprsWithNewCtx(p.AccessCondition, newCtx)
+	cloned.ByItems = cloneByItemsForInstancePlanCache(p.ByItems, newCtx)
+	if p.GenExprs != nil {
+		cloned.GenExprs = make(map[model.TableItemID]expression.Expression, len(p.GenExprs))
+		for id, expr := range p.GenExprs {
+			cloned.GenExprs[id] = expression.CloneWithNewCtx(expr, newCtx)
+		}
+	}
+	return cloned, true
+}
+
+func (p *PhysicalSelection) cloneForInstancePlanCache(newCtx sessionctx.Context) (PhysicalPlan, bool) {
+	cloned := new(PhysicalSelection)
+	*cloned = *p
+	base, ok := p.basePhysicalPlan.cloneBaseForInstancePlanCache(cloned, newCtx)
+	if !ok {
+		return nil, false
+	}
+	cloned.basePhysicalPlan = *base
+	cloned.Conditions = expression.CloneExprsWithNewCtx(p.Conditions, newCtx)
+	return cloned, true
+}
+
+func (p *PhysicalProjection) cloneForInstancePlanCache(newCtx sessionctx.Context) (PhysicalPlan, bool) {
+	cloned := new(PhysicalProjection)
+	*cloned = *p
+	base, ok := p.basePhysicalPlan.cloneBaseForInstancePlanCache(cloned, newCtx)
+	if !ok {
+		return nil, false
+	}
+	cloned.basePhysicalPlan = *base
+	cloned.Exprs = expression.CloneExprsWithNewCtx(p.Exprs, newCtx)
+	return cloned, true
+}
+
+func (p *PhysicalLimit) cloneForInstancePlanCache(newCtx sessionctx.Context) (PhysicalPlan, bool) {
+	cloned := new(PhysicalLimit)
+	*cloned = *p
+	base, ok := p.physicalSchemaProducer.cloneBaseForInstancePlanCache(cloned, newCtx)
+	if !ok {
+		return nil, false
+	}
+	cloned.physicalSchemaProducer = *base
+	return cloned, true
+}
+
+func (lt *PhysicalTopN) cloneForInstancePlanCache(newCtx sessionctx.Context) (PhysicalPlan, bool) {
+	cloned := new(PhysicalTopN)
+	*cloned = *lt
+	base, ok := lt.basePhysicalPlan.cloneBaseForInstancePlanCache(cloned, newCtx)
+	if !ok {
+		return nil, false
+	}
+	cloned.basePhysicalPlan = *base
+	cloned.ByItems = cloneByItemsForInstancePlanCache(lt.ByItems, newCtx)
+	return cloned, true
+}
+
+func (ls *PhysicalSort) cloneForInstancePlanCache(newCtx sessionctx.Context) (PhysicalPlan, bool) {
+	cloned := new(PhysicalSort)
+	*cloned = *ls
+	base, ok := ls.basePhysicalPlan.cloneBaseForInstancePlanCache(cloned, newCtx)
+	if !ok {
+		return nil, false
+	}
+	cloned.basePhysicalPlan = *base
+	cloned.ByItems = cloneByItemsForInstancePlanCache(ls.ByItems, newCtx)
+	return cloned, true
+}
+
+func (p *basePhysicalAgg) cloneBaseAggForInstancePlanCache(newSelf PhysicalPlan, newCtx sessionctx.Context) (*basePhysicalAgg, bool) {
+	if p.MppRunMode != NoMpp {
+		return nil, false
+	}
+	cloned := *p
+	base, ok := p.physicalSchemaProducer.cloneBaseForInstancePlanCache(newSelf, newCtx)
+	if !ok {
+		return nil, false
+	}
+	cloned.physicalSchemaProducer = *base
+	cloned.GroupByItems = expression.CloneExprsWithNewCtx(p.GroupByItems, newCtx)
+	cloned.AggFuncs = make([]*aggregation.AggFuncDesc, 0, len(p.AggFuncs))
+	for _, aggFunc := range p.AggFuncs {
+		clonedAggFunc := *aggFunc
+		clonedAggFunc.Args = expression.CloneExprsWithNewCtx(aggFunc.Args, newCtx)
+		clonedAggFunc.OrderByItems = cloneByItemsForInstancePlanCache(aggFunc.OrderByItems, newCtx)
+		cloned.AggFuncs = append(cloned.AggFuncs, &clonedAggFunc)
+	}
+	return &cloned, true
+}
+
+func (p *PhysicalHashAgg) cloneForInstancePlanCache(newCtx sessionctx.Context) (PhysicalPlan, bool) {
+	cloned := new(PhysicalHashAgg)
+	*cloned = *p
+	base, ok := p.basePhysicalAgg.cloneBaseAggForInstancePlanCache(cloned, newCtx)
+	if !ok {
+		return nil, false
+	}
+	cloned.basePhysicalAgg = *base
+	return cloned, true
+}
+
+func (p *PhysicalStreamAgg) cloneForInstancePlanCache(newCtx sessionctx.Context) (PhysicalPlan, bool) {
+	cloned := new(PhysicalStreamAgg)
+	*cloned = *p
+	base, ok := p.basePhysicalAgg.cloneBaseAggForInstancePlanCache(cloned, newCtx)
+	if !ok {
+		return nil, false
+	}
+	cloned.basePhysicalAgg = *base
+	return cloned, true
+}
+
+func (p *PointGetPlan) cloneForInstancePlanCache(newCtx sessionctx.Context) (PhysicalPlan, bool) {
+	// the lock wait time is decided by the session which builds the plan
+	if p.PartitionInfo != nil || p.Lock || len(p.probeParents) > 0 {
+		return nil, false
+	}
+	cloned := new(PointGetPlan)
+	*cloned = *p
+	cloned.basePlan.ctx = newCtx
+	cloned.ctx = newCtx
+	if p.HandleConstant != nil {
+		cloned.HandleConstant = expression.CloneWithNewCtx(p.HandleConstant, newCtx).(*expression.Constant)
+	}
+	if p.IndexValues != nil {
+		cloned.IndexValues = make([]types.Datum, len(p.IndexValues))
+		copy(cloned.IndexValues, p.IndexValues)
+	}
+	cloned.IndexConstants = cloneConstantsForInstancePlanCache(p.IndexConstants, newCtx)
+	cloned.AccessConditions = expression.CloneExprsWithNewCtx(p.AccessConditions, newCtx)
+	return cloned, true
+}
+
+func (p *BatchPointGetPlan) cloneForInstancePlanCache(newCtx sessionctx.Context) (PhysicalPlan, bool) {
+	if p.TblInfo.GetPartitionInfo() != nil || p.Lock || len(p.probeParents) > 0 {
+		return nil, false
+	}
+	cloned := new(BatchPointGetPlan)
+	*cloned = *p
+	cloned.basePlan.ctx = newCtx
+	cloned.ctx = newCtx
+	if p.Handles != nil {
+		cloned.Handles = make([]kv.Handle, len(p.Handles))
+		copy(cloned.Handles, p.Handles)
+	}
+	cloned.HandleParams = cloneConstantsForInstancePlanCache(p.HandleParams, newCtx)
+	if p.IndexValues != nil {
+		cloned.IndexValues = make([][]types.Datum, 0, len(p.IndexValues))
+		for _, values := range p.IndexValues {
+			clonedValues := make([]types.Datum, len(values))
+			copy(clonedValues, values)
+			cloned.IndexValues = append(cloned.IndexValues, clonedValues)
+		}
+	}
+	if p.IndexValueParams != nil {
+		cloned.IndexValueParams = make([][]*expression.Constant, 0, len(p.IndexValueParams))
+		for _, params := range p.IndexValueParams {
+			cloned.IndexValueParams = append(cloned.IndexValueParams, cloneConstantsForInstancePlanCache(params, newCtx))
+		}
+	}
+	cloned.AccessConditions = expression.CloneExprsWithNewCtx(p.AccessConditions, newCtx)
+	return cloned, true
+}
@@ -70,6 +70,7 @@ import (
 	"github.com/pingcap/tidb/util/stmtsummary"
 	"github.com/tikv/client-go/v2/tikv"
 	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
 )
 
 type visitInfo struct {
@@ -843,7 +844,7 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
 		*ast.RenameUserStmt, *ast.NonTransactionalDMLStmt, *ast.SetSessionStatesStmt, *ast.SetResourceGroupStmt,
-		*ast.LoadDataActionStmt, *ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt:
+		*ast.LoadDataActionStmt, *ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.XAStmt:
 		return b.buildSimple(ctx, node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -1307,7 +1308,8 @@ func isForUpdateReadSelectLock(lock *ast.SelectLockInfo) bool {
 	}
 	return lock.LockType == ast.SelectLockForUpdate ||
 		lock.LockType == ast.SelectLockForUpdateNoWait ||
-		lock.LockType == ast.SelectLockForUpdateWaitN
+		lock.LockType == ast.SelectLockForUpdateWaitN ||
+		lock.LockType == ast.SelectLockForUpdateSkipLocked
 }
 
 // getLatestIndexInfo gets the index info of latest schema version from given table id,
@@ -1728,8 +1730,8 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan,
 		return &SQLBindPlan{SQLBindOp: OpCaptureBindings}, nil
 	case ast.AdminEvolveBindings:
 		var err error
-		// The 'baseline evolution' only work in the test environment before the feature is GA.
-		if !config.CheckTableBeforeDrop {
+		// The 'baseline evolution' is experimental, it only works in the test environment or when it's enabled by the config.
+		if !config.CheckTableBeforeDrop && !config.GetGlobalConfig().Experimental.EnablePlanBaselineEvolution {
 			err = errors.Errorf("Cannot enable baseline evolution feature, it is not generally available now")
 		}
 		return &SQLBindPlan{SQLBindOp: OpEvolveBindings}, err
@@ -1911,7 +1913,9 @@ func tryGetPkHandleCol(tblInfo *model.TableInfo, allColSchema *expression.Schema
 	return nil, nil, false
 }
 
-func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbName model.CIStr, tbl table.Table, indices []table.Index) ([]Plan, []*model.IndexInfo, error) {
+// buildPhysicalIndexLookUpReaders builds the readers of the indices. For partitioned tables, a reader is
+// built for each partition, and only the partitions in partitionIDs are read if it's not nil.
+func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbName model.CIStr, tbl table.Table, indices []table.Index, partitionIDs []int64) ([]Plan, []*model.IndexInfo, error) {
 	tblInfo := tbl.Meta()
 	// get index information
 	indexInfos := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
@@ -1959,6 +1963,9 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 		// For partition tables.
 		if pi := tbl.Meta().GetPartitionInfo(); pi != nil {
 			for _, def := range pi.Definitions {
+				if partitionIDs != nil && !slices.Contains(partitionIDs, def.ID) {
+					continue
+				}
 				t := tbl.(table.PartitionedTable).GetPartition(def.ID)
 				reader, err := b.buildPhysicalIndexLookUpReader(ctx, dbName, t, idxInfo)
 				if err != nil {
@@ -2012,9 +2019,9 @@ func (b *PlanBuilder) buildAdminCheckTable(ctx context.Context, as *ast.AdminStm
 			return nil, errors.Errorf("index %s state %s isn't public", as.Index, idx.Meta().State)
 		}
 		p.CheckIndex = true
-		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, []table.Index{idx})
+		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, []table.Index{idx}, nil)
 	} else {
-		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, tbl.Indices())
+		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, tbl.Indices(), nil)
 	}
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -2028,6 +2035,52 @@ func (b *PlanBuilder) buildAdminCheckTable(ctx context.Context, as *ast.AdminStm
 	return p, nil
 }
 
+// buildAlterTableCheckPartitions builds the plan of ALTER TABLE ... CHECK PARTITION, which checks the
+// consistency of the rows and indices in the partitions like ADMIN CHECK TABLE.
+func (b *PlanBuilder) buildAlterTableCheckPartitions(ctx context.Context, stmt *ast.AlterTableStmt) (*CheckTable, error) {
+	dbName := stmt.Table.Schema
+	if dbName.L == "" {
+		dbName = model.NewCIStr(b.ctx.GetSessionVars().CurrentDB)
+	}
+	tbl, err := b.is.TableByName(dbName, stmt.Table.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tblInfo := tbl.Meta()
+	if tblInfo.GetPartitionInfo() == nil {
+		return nil, errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
+	}
+	spec := stmt.Specs[0]
+	var partitionIDs []int64
+	if !spec.OnAllPartitions {
+		for _, name := range spec.PartitionNames {
+			ids, err := tables.FindPartitionIDsByName(tblInfo, name.L)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			partitionIDs = append(partitionIDs, ids...)
+		}
+	}
+	p := &CheckTable{
+		DBName: dbName.O,
+		Table:  tbl,
+	}
+	if partitionIDs != nil {
+		p.PartitionNames = spec.PartitionNames
+	}
+	readerPlans, indexInfos, err := b.buildPhysicalIndexLookUpReaders(ctx, dbName, tbl, tbl.Indices(), partitionIDs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	readers := make([]*PhysicalIndexLookUpReader, 0, len(readerPlans))
+	for _, plan := range readerPlans {
+		readers = append(readers, plan.(*PhysicalIndexLookUpReader))
+	}
+	p.IndexInfos = indexInfos
+	p.IndexLookUpReaders = readers
+	return p, nil
+}
+
 func (b *PlanBuilder) buildCheckIndexSchema(tn *ast.TableName, indexName string) (*expression.Schema, types.NameSlice, error) {
 	schema := expression.NewSchema()
 	var names types.NameSlice
@@ -2172,6 +2225,12 @@ func GetPhysicalIDsAndPartitionNames(tblInfo *model.TableInfo, partitionNames []
 				names = append(names, def.Name.O)
 				break
 			}
+			// A partition of a subpartitioned table stands for all of its subpartitions.
+			if def.ParentName.L == name.L {
+				found = true
+				ids = append(ids, def.ID)
+				names = append(names, def.Name.O)
+			}
 		}
 		if !found {
 			return nil, nil, fmt.Errorf("can not found the specified partition name %s in the table definition", name.O)
@@ -3276,6 +3335,16 @@ func buildCalibrateResourceSchema() (*expression.Schema, types.NameSlice) {
 	return schema.col2Schema(), schema.names
 }
 
+func buildXARecoverSchema() (*expression.Schema, types.NameSlice) {
+	longlongSize, _ := mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeLonglong)
+	schema := newColumnsWithNames(4)
+	schema.Append(buildColumnWithName("", "formatID", mysql.TypeLonglong, longlongSize))
+	schema.Append(buildColumnWithName("", "gtrid_length", mysql.TypeLonglong, longlongSize))
+	schema.Append(buildColumnWithName("", "bqual_length", mysql.TypeLonglong, longlongSize))
+	schema.Append(buildColumnWithName("", "data", mysql.TypeVarchar, 128*2+2))
+	return schema.col2Schema(), schema.names
+}
+
 func buildShowTelemetrySchema() (*expression.Schema, types.NameSlice) {
 	schema := newColumnsWithNames(1)
 	schema.Append(buildColumnWithName("", "TRACKING_ID", mysql.TypeVarchar, 64))
@@ -3543,6 +3612,10 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 		err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "RESOURCE_GROUP_ADMIN", false, err)
 		p.setSchemaAndNames(buildCalibrateResourceSchema())
+	case *ast.XAStmt:
+		if raw.Tp == ast.XARecover {
+			p.setSchemaAndNames(buildXARecoverSchema())
+		}
 	case *ast.GrantRoleStmt:
 		err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or ROLE_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "ROLE_ADMIN", false, err)
@@ -3866,11 +3939,13 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		givenPartitionSets := make(map[int64]struct{}, len(insert.PartitionNames))
 		// check partition by name.
 		for _, name := range insert.PartitionNames {
-			id, err := tables.FindPartitionByName(tableInfo, name.L)
+			ids, err := tables.FindPartitionIDsByName(tableInfo, name.L)
 			if err != nil {
 				return nil, err
 			}
-			givenPartitionSets[id] = struct{}{}
+			for _, id := range ids {
+				givenPartitionSets[id] = struct{}{}
+			}
 		}
 		pt := tableInPlan.(table.PartitionedTable)
 		insertPlan.Table = tables.NewPartitionTableWithGivenSets(pt, givenPartitionSets)
@@ -5017,6 +5092,9 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 		err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "RESOURCE_GROUP_ADMIN", false, err)
 	}
+	if v, ok := node.(*ast.AlterTableStmt); ok && len(v.Specs) == 1 && v.Specs[0].Tp == ast.AlterTableCheckPartitions {
+		return b.buildAlterTableCheckPartitions(ctx, v)
+	}
 	p := &DDL{Statement: node}
 	return p, nil
 }
@@ -82,6 +82,7 @@ type PointGetPlan struct {
 	Lock               bool
 	outputNames        []*types.FieldName
 	LockWaitTime       int64
+	SkipLocked         bool
 	partitionColumnPos int
 	Columns            []*model.ColumnInfo
 	cost               float64
@@ -196,6 +197,9 @@ func (p *PointGetPlan) OperatorInfo(normalized bool) string {
 		} else {
 			buffer.WriteString("lock")
 		}
+		if p.SkipLocked {
+			buffer.WriteString(", skip locked")
+		}
 	}
 	return buffer.String()
 }
@@ -325,6 +329,7 @@ type BatchPointGetPlan struct {
 	Desc             bool
 	Lock             bool
 	LockWaitTime     int64
+	SkipLocked       bool
 	Columns          []*model.ColumnInfo
 	cost             float64
 
@@ -429,6 +434,9 @@ func (p *BatchPointGetPlan) OperatorInfo(normalized bool) string {
 	buffer.WriteString(strconv.FormatBool(p.Desc))
 	if p.Lock {
 		buffer.WriteString(", lock")
+		if p.SkipLocked {
+			buffer.WriteString(", skip locked")
+		}
 	}
 	return buffer.String()
 }
@@ -562,6 +570,7 @@ func TryFastPlan(ctx sessionctx.Context, node ast.Node) (p Plan) {
 				return nil
 			}
 			fp.Lock, fp.LockWaitTime = getLockWaitTime(ctx, x.LockInfo)
+			fp.SkipLocked = fp.Lock && IsSelectSkipLockedLockType(x.LockInfo.LockType)
 			p = fp
 			return
 		}
@@ -580,6 +589,7 @@ func TryFastPlan(ctx sessionctx.Context, node ast.Node) (p Plan) {
 				return
 			}
 			fp.Lock, fp.LockWaitTime = getLockWaitTime(ctx, x.LockInfo)
+			fp.SkipLocked = fp.Lock && IsSelectSkipLockedLockType(x.LockInfo.LockType)
 			p = fp
 			return
 		}
@@ -596,12 +606,31 @@ func IsSelectForUpdateLockType(lockType ast.SelectLockType) bool {
 	if lockType == ast.SelectLockForUpdate ||
 		lockType == ast.SelectLockForShare ||
 		lockType == ast.SelectLockForUpdateNoWait ||
-		lockType == ast.SelectLockForUpdateWaitN {
+		lockType == ast.SelectLockForUpdateWaitN ||
+		lockType == ast.SelectLockForUpdateSkipLocked {
 		return true
 	}
 	return false
 }
 
+// IsSelectSkipLockedLockType checks if the select lock type skips the rows locked by other transactions.
+func IsSelectSkipLockedLockType(lockType ast.SelectLockType) bool {
+	return lockType == ast.SelectLockForUpdateSkipLocked
+}
+
+// GetSelectLockWaitTime returns the lock wait time of the select lock. The rows are locked with NOWAIT for
+// SKIP LOCKED, so the rows locked by other transactions can be skipped immediately.
+func GetSelectLockWaitTime(ctx sessionctx.Context, lockInfo *ast.SelectLockInfo) int64 {
+	switch lockInfo.LockType {
+	case ast.SelectLockForUpdateWaitN:
+		return int64(lockInfo.WaitSec * 1000)
+	case ast.SelectLockForUpdateNoWait, ast.SelectLockForUpdateSkipLocked:
+		return tikvstore.LockNoWait
+	default:
+		return ctx.GetSessionVars().LockWaitTimeout
+	}
+}
+
 func getLockWaitTime(ctx sessionctx.Context, lockInfo *ast.SelectLockInfo) (lock bool, waitTime int64) {
 	if lockInfo != nil {
 		if IsSelectForUpdateLockType(lockInfo.LockType) {
@@ -612,12 +641,7 @@ func getLockWaitTime(ctx sessionctx.Context, lockInfo *ast.SelectLockInfo) (lock
 			sessVars := ctx.GetSessionVars()
 			if !sessVars.IsAutocommit() || sessVars.InTxn() || config.GetGlobalConfig().PessimisticTxn.PessimisticAutoCommit.Load() {
 				lock = true
-				waitTime = sessVars.LockWaitTimeout
-				if lockInfo.LockType == ast.SelectLockForUpdateWaitN {
-					waitTime = int64(lockInfo.WaitSec * 1000)
-				} else if lockInfo.LockType == ast.SelectLockForUpdateNoWait {
-					waitTime = tikvstore.LockNoWait
-				}
+				waitTime = GetSelectLockWaitTime(ctx, lockInfo)
 			}
 		}
 	}
@@ -1623,11 +1647,13 @@ func buildPointUpdatePlan(ctx sessionctx.Context, pointPlan PhysicalPlan, dbName
 			if len(updateTable.PartitionNames) > 0 {
 				pids := make(map[int64]struct{}, len(updateTable.PartitionNames))
 				for _, name := range updateTable.PartitionNames {
-					pid, err := tables.FindPartitionByName(tbl, name.L)
+					partIDs, err := tables.FindPartitionIDsByName(tbl, name.L)
 					if err != nil {
 						return updatePlan
 					}
-					pids[pid] = struct{}{}
+					for _, pid := range partIDs {
+						pids[pid] = struct{}{}
+					}
 				}
 				pt = tables.NewPartitionTableWithGivenSets(pt, pids)
 			}
@@ -1941,6 +1967,10 @@ func getColumnPosInIndex(idx *model.IndexInfo, colName *model.CIStr) int {
 }
 
 func getPartitionExpr(ctx sessionctx.Context, tbl *model.TableInfo) *tables.PartitionExpr {
+	// The fast plans can't locate the subpartitions.
+	if pi := tbl.GetPartitionInfo(); pi != nil && pi.Sub != nil {
+		return nil
+	}
 	is := ctx.GetInfoSchema().(infoschema.InfoSchema)
 	table, ok := is.TableByID(tbl.ID)
 	if !ok {
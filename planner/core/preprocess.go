@@ -236,6 +236,7 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.stmtTp = TypeDelete
 	case *ast.SelectStmt:
 		p.stmtTp = TypeSelect
+		p.checkSelectLockGrammar(node)
 		if node.With != nil {
 			p.preprocessWith.cteStack = append(p.preprocessWith.cteStack, node.With.CTEs)
 		}
@@ -587,6 +588,9 @@ func (p *preprocessor) Leave(in ast.Node) (out ast.Node, ok bool) {
 			p.tableAliasInJoin = p.tableAliasInJoin[:len(p.tableAliasInJoin)-1]
 		}
 	case *ast.FuncCallExpr:
+		if aggFunc, ok := toExtensionAggFunc(x); ok {
+			return aggFunc, p.err == nil
+		}
 		// The arguments for builtin NAME_CONST should be constants
 		// See https://dev.mysql.com/doc/refman/5.7/en/miscellaneous-functions.html#function_name-const for details
 		if x.FnName.L == ast.NameConst {
@@ -815,6 +819,18 @@ func (p *preprocessor) checkFlashbackDatabaseGrammar(stmt *ast.FlashBackDatabase
 	}
 }
 
+// checkSelectLockGrammar checks the lock clause of the select statement. NOWAIT and SKIP LOCKED are only supported
+// by FOR UPDATE, because FOR SHARE doesn't really lock the rows.
+func (p *preprocessor) checkSelectLockGrammar(stmt *ast.SelectStmt) {
+	if stmt.LockInfo == nil {
+		return
+	}
+	switch stmt.LockInfo.LockType {
+	case ast.SelectLockForShareNoWait, ast.SelectLockForShareSkipLocked:
+		p.err = ErrNotSupportedYet.GenWithStackByArgs(strings.ToUpper(stmt.LockInfo.LockType.String()))
+	}
+}
+
 func (p *preprocessor) checkAdminCheckTableGrammar(stmt *ast.AdminStmt) {
 	for _, table := range stmt.Tables {
 		tableInfo, err := p.tableByName(table)
@@ -1918,3 +1934,37 @@ func (p *preprocessor) skipLockMDL() bool {
 	// because it's a batch process and will do both DML and DDL.
 	return p.flag&inImportInto > 0
 }
+
+// toExtensionAggFunc converts the call of an extension aggregate function, which is parsed as ast.FuncCallExpr,
+// to ast.AggregateFuncExpr.
+func toExtensionAggFunc(x *ast.FuncCallExpr) (*ast.AggregateFuncExpr, bool) {
+	if x.Schema.L != "" {
+		return nil, false
+	}
+	if _, ok := expression.GetExtensionAggFunc(x.FnName.L); !ok {
+		return nil, false
+	}
+	aggFunc := &ast.AggregateFuncExpr{F: x.FnName.L, Args: x.Args}
+	aggFunc.SetText(nil, x.OriginalText())
+	aggFunc.SetOriginTextPosition(x.OriginTextPosition())
+	return aggFunc, true
+}
+
+// extensionAggFuncConverter converts the calls of the extension aggregate functions in the statements which are not
+// preprocessed, such as the select statements of the views.
+type extensionAggFuncConverter struct{}
+
+// Enter implements ast.Visitor interface.
+func (extensionAggFuncConverter) Enter(in ast.Node) (ast.Node, bool) {
+	return in, false
+}
+
+// Leave implements ast.Visitor interface.
+func (extensionAggFuncConverter) Leave(in ast.Node) (ast.Node, bool) {
+	if x, ok := in.(*ast.FuncCallExpr); ok {
+		if aggFunc, ok := toExtensionAggFunc(x); ok {
+			return aggFunc, true
+		}
+	}
+	return in, true
+}
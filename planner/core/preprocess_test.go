@@ -226,6 +226,9 @@ func TestValidator(t *testing.T) {
 		{"CREATE VIEW V AS SELECT 5 INTO OUTFILE 'ttt'", true, dbterror.ErrViewSelectClause.GenWithStackByArgs("INFO")},
 		{"CREATE VIEW V AS SELECT 5 FOR UPDATE", false, nil},
 		{"CREATE VIEW V AS SELECT 5 LOCK IN SHARE MODE", false, nil},
+		{"SELECT 5 FOR UPDATE SKIP LOCKED", false, nil},
+		{"SELECT 5 FOR SHARE NOWAIT", false, core.ErrNotSupportedYet},
+		{"SELECT 5 FOR SHARE SKIP LOCKED", false, core.ErrNotSupportedYet},
 
 		// issue 9464
 		{"CREATE TABLE t1 (id INT NOT NULL, c1 VARCHAR(20) AS ('foo') VIRTUAL KEY NULL, PRIMARY KEY (id));", false, core.ErrUnsupportedOnGeneratedColumn},
@@ -358,9 +358,20 @@ func (s *decorrelateSolver) optimize(ctx context.Context, p LogicalPlan, opt *lo
 				// agg.buildProjectionIfNecessary()
 				return agg, nil
 			}
+			innerConds := len(apply.EqualConditions) + len(apply.RightConditions) + len(apply.OtherConditions)
+			if apply.JoinType == InnerJoin && len(agg.GroupByItems) == 0 && innerConds+len(apply.LeftConditions) == 0 {
+				// The aggregation without group by items always outputs exactly one row, so the inner apply
+				// without any join condition, e.g. the one of a lateral derived table, is the same as the left
+				// outer apply.
+				apply.JoinType = LeftOuterJoin
+				resetNotNullFlag(apply.schema, outerPlan.Schema().Len(), apply.schema.Len())
+			}
 			// We can pull up the equal conditions below the aggregation as the join key of the apply, if only
 			// the equal conditions contain the correlated column of this apply.
-			if sel, ok := agg.children[0].(*LogicalSelection); ok && apply.JoinType == LeftOuterJoin {
+			// The default values of the aggregate functions are filled after the join, so the join conditions
+			// must not reference them, e.g. `t1 left join lateral (select count(*) c ...) dt on dt.c > 0`.
+			if sel, ok := agg.children[0].(*LogicalSelection); ok && apply.JoinType == LeftOuterJoin &&
+				(innerConds == 0 || len(s.aggDefaultValueMap(agg)) == 0) {
 				var (
 					eqCondWithCorCol []*expression.ScalarFunction
 					remainedExpr     []expression.Expression
@@ -750,6 +750,107 @@ func (s *partitionProcessor) pruneListPartition(ctx sessionctx.Context, tbl tabl
 	return used, nil
 }
 
+// subPartitionTable is for those tables which implement subpartition.
+type subPartitionTable interface {
+	SubPartitionExpr() *tables.PartitionExpr
+}
+
+// partitionLevelTable presents one level of a subpartitioned table as a table partitioned
+// by that level only, so that the pruning of each level can reuse the pruners above.
+type partitionLevelTable struct {
+	table.PartitionedTable
+	meta     *model.TableInfo
+	partExpr *tables.PartitionExpr
+}
+
+func newPartitionLevelTable(tbl table.PartitionedTable, pi *model.PartitionInfo, partExpr *tables.PartitionExpr) *partitionLevelTable {
+	meta := *tbl.Meta()
+	meta.Partition = pi
+	return &partitionLevelTable{PartitionedTable: tbl, meta: &meta, partExpr: partExpr}
+}
+
+// Meta implements the table.Table interface.
+func (t *partitionLevelTable) Meta() *model.TableInfo {
+	return t.meta
+}
+
+// PartitionExpr implements the partitionTable interface.
+func (t *partitionLevelTable) PartitionExpr() *tables.PartitionExpr {
+	return t.partExpr
+}
+
+// pruneSubPartitions prunes the partitions by the conditions first, then prunes the subpartitions
+// of the used partitions. The return value is the idx of the used subpartitions in the partition
+// definitions, or FullRange if all the subpartitions are used.
+func (s *partitionProcessor) pruneSubPartitions(ctx sessionctx.Context, tbl table.PartitionedTable, partitionNames []model.CIStr,
+	conds []expression.Expression, columns []*expression.Column, names types.NameSlice) ([]int, error) {
+	pi := tbl.Meta().Partition
+	partTbl := newPartitionLevelTable(tbl, pi.PartitionLevel(), tbl.(partitionTable).PartitionExpr())
+	partPi := partTbl.meta.Partition
+	usedParts := []int{FullRange}
+	switch partPi.Type {
+	case model.PartitionTypeRange:
+		rangeOr, err := s.pruneRangePartition(ctx, partPi, partTbl, conds, columns, names)
+		if err != nil {
+			return nil, err
+		}
+		usedParts = s.convertToIntSlice(rangeOr, partPi, nil)
+	case model.PartitionTypeList:
+		var err error
+		usedParts, err = s.pruneListPartition(ctx, partTbl, nil, conds, columns)
+		if err != nil {
+			return nil, err
+		}
+	}
+	subTbl := newPartitionLevelTable(tbl, pi.SubPartitionLevel(), tbl.(subPartitionTable).SubPartitionExpr())
+	usedSubs, _, err := s.findUsedPartitions(ctx, subTbl, nil, conds, columns, names)
+	if err != nil {
+		return nil, err
+	}
+	isFull := func(used []int) bool {
+		return len(used) == 1 && used[0] == FullRange
+	}
+	if len(partitionNames) == 0 && isFull(usedParts) && isFull(usedSubs) {
+		return []int{FullRange}, nil
+	}
+	expandFull := func(used []int, num int) []int {
+		if !isFull(used) {
+			return used
+		}
+		used = make([]int, 0, num)
+		for i := 0; i < num; i++ {
+			used = append(used, i)
+		}
+		return used
+	}
+	usedParts = expandFull(usedParts, int(partPi.Num))
+	usedSubs = expandFull(usedSubs, int(pi.Sub.Num))
+	ret := make([]int, 0, len(usedParts)*len(usedSubs))
+	for _, part := range usedParts {
+		for _, sub := range usedSubs {
+			idx := part*int(pi.Sub.Num) + sub
+			def := &pi.Definitions[idx]
+			if len(partitionNames) > 0 && !s.findByName(partitionNames, def.Name.L) && !s.findByName(partitionNames, def.ParentName.L) {
+				continue
+			}
+			ret = append(ret, idx)
+		}
+	}
+	return ret, nil
+}
+
+func (s *partitionProcessor) processSubPartition(ds *DataSource, pi *model.PartitionInfo, opt *logicalOptimizeOp) (LogicalPlan, error) {
+	names, err := s.reconstructTableColNames(ds)
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.pruneSubPartitions(ds.SCtx(), ds.table.(table.PartitionedTable), ds.partitionNames, ds.allConds, ds.TblCols, names)
+	if err != nil {
+		return nil, err
+	}
+	return s.makeUnionAllChildren(ds, pi, convertToRangeOr(used, pi), opt)
+}
+
 func (s *partitionProcessor) prune(ds *DataSource, opt *logicalOptimizeOp) (LogicalPlan, error) {
 	pi := ds.tableInfo.GetPartitionInfo()
 	if pi == nil {
@@ -761,6 +862,9 @@ func (s *partitionProcessor) prune(ds *DataSource, opt *logicalOptimizeOp) (Logi
 	for i, cond := range ds.allConds {
 		ds.allConds[i] = expression.PushDownNot(ds.ctx, cond)
 	}
+	if pi.Sub != nil {
+		return s.processSubPartition(ds, pi, opt)
+	}
 	// Try to locate partition directly for hash partition.
 	switch pi.Type {
 	case model.PartitionTypeRange:
@@ -1702,8 +1806,10 @@ func (s *partitionProcessor) makeUnionAllChildren(ds *DataSource, pi *model.Part
 	for _, r := range or {
 		for i := r.start; i < r.end; i++ {
 			// This is for `table partition (p0,p1)` syntax, only union the specified partition if has specified partitions.
+			// For the subpartitioned tables, the specified partition can be either a subpartition or a partition.
 			if len(ds.partitionNames) != 0 {
-				if !s.findByName(ds.partitionNames, pi.Definitions[i].Name.L) {
+				if !s.findByName(ds.partitionNames, pi.Definitions[i].Name.L) &&
+					!s.findByName(ds.partitionNames, pi.Definitions[i].ParentName.L) {
 					continue
 				}
 			}
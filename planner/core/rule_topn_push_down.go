@@ -164,6 +164,18 @@ func (p *LogicalProjection) pushDownTopN(topN *LogicalTopN, opt *logicalOptimize
 }
 
 func (p *LogicalLock) pushDownTopN(topN *LogicalTopN, opt *logicalOptimizeOp) LogicalPlan {
+	if topN != nil && IsSelectSkipLockedLockType(p.Lock.LockType) {
+		// The rows skipped by SKIP LOCKED can't be counted by the limit, so the limit is kept above the lock.
+		// The order is still kept below the lock, then the rows are locked in order and the lock stops as soon as
+		// the limit is satisfied.
+		if !topN.isLimit() {
+			sort := LogicalSort{ByItems: topN.ByItems}.Init(p.ctx, topN.blockOffset)
+			sort.SetChildren(p.children[0])
+			p.children[0] = sort
+			topN.ByItems = nil
+		}
+		return topN.setChild(p, opt)
+	}
 	if topN != nil {
 		p.children[0] = p.children[0].pushDownTopN(topN, opt)
 	}
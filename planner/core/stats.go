@@ -111,6 +111,17 @@ func (p *LogicalShowDDLJobs) DeriveStats(_ []*property.StatsInfo, selfSchema *ex
 	return p.stats, nil
 }
 
+// DeriveStats implement LogicalPlan DeriveStats interface.
+func (p *LogicalJSONTable) DeriveStats(_ []*property.StatsInfo, selfSchema *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+	if p.stats != nil {
+		return p.stats, nil
+	}
+	// The number of rows can't be known before the JSON document is evaluated,
+	// so just use a fake count like LogicalShow.
+	p.stats = getFakeStats(selfSchema)
+	return p.stats, nil
+}
+
 // RecursiveDeriveStats4Test is a exporter just for test.
 func RecursiveDeriveStats4Test(p LogicalPlan) (*property.StatsInfo, error) {
 	return p.recursiveDeriveStats(nil)
@@ -1334,13 +1334,14 @@ func (p *PhysicalTopN) addPartialLimitForSubScans(copSubPlans []PhysicalPlan, fi
 
 func (p *PhysicalExpand) attach2Task(tasks ...task) task {
 	t := tasks[0].copy()
-	// current expand can only be run in MPP TiFlash mode.
+	// current expand can be run in MPP TiFlash mode or in TiDB, it can't be pushed down to TiKV.
 	if mpp, ok := t.(*mppTask); ok {
 		p.SetChildren(mpp.p)
 		mpp.p = p
 		return mpp
 	}
-	return invalidTask
+	t = t.convertToRootTask(p.ctx)
+	return attachPlan2Task(p, t)
 }
 
 func (p *PhysicalProjection) attach2Task(tasks ...task) task {
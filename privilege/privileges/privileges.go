@@ -66,6 +66,7 @@ var dynamicPrivs = []string{
 	"RESTRICTED_CONNECTION_ADMIN",     // Can not be killed by PROCESS/CONNECTION_ADMIN privilege
 	"RESTRICTED_REPLICA_WRITER_ADMIN", // Can write to the sever even when tidb_restriced_read_only is turned on.
 	"RESOURCE_GROUP_ADMIN",            // Create/Drop/Alter RESOURCE GROUP
+	"XA_RECOVER_ADMIN",                // Can see the prepared XA transactions of all users by XA RECOVER
 }
 var dynamicPrivLock sync.Mutex
 var defaultTokenLife = 15 * time.Minute
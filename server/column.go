@@ -90,7 +90,7 @@ func (column *ColumnInfo) dump(buffer []byte, d *resultEncoder, withDefault bool
 func isStringColumnType(tp byte) bool {
 	switch tp {
 	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBit,
-		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeGeometry,
 		mysql.TypeEnum, mysql.TypeSet, mysql.TypeJSON:
 		return true
 	}
@@ -49,6 +49,7 @@ import (
 	"github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/timer/tablestore"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/dbterror"
@@ -526,7 +527,10 @@ const (
 		current_job_ttl_expire timestamp NULL DEFAULT NULL,
 		current_job_state text DEFAULT NULL,
 		current_job_status varchar(64) DEFAULT NULL,
-  		current_job_status_update_time timestamp NULL DEFAULT NULL);`
+  		current_job_status_update_time timestamp NULL DEFAULT NULL,
+		last_job_archive_table varchar(256) DEFAULT NULL,
+		last_job_archived_rows bigint(64) DEFAULT NULL,
+		last_job_archive_error_rows bigint(64) DEFAULT NULL);`
 
 	// CreateTTLTask is a table about parallel ttl tasks
 	CreateTTLTask = `CREATE TABLE IF NOT EXISTS mysql.tidb_ttl_task (
@@ -617,6 +621,41 @@ const (
 		INDEX time_index(time) COMMENT "accelerate the speed when querying with active watch"
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
 
+	// CreateAutoAnalyzeQueue stores the priority queue of the auto analyze jobs.
+	CreateAutoAnalyzeQueue = `CREATE TABLE IF NOT EXISTS mysql.auto_analyze_queue (
+		table_id BIGINT(64) NOT NULL,
+		table_schema CHAR(64) NOT NULL,
+		table_name CHAR(64) NOT NULL,
+		partition_name CHAR(64) NOT NULL DEFAULT '',
+		priority DOUBLE NOT NULL,
+		change_ratio DOUBLE NOT NULL,
+		row_count BIGINT(64) NOT NULL,
+		last_analyze_time TIMESTAMP NULL DEFAULT NULL,
+		last_used_time TIMESTAMP NULL DEFAULT NULL,
+		last_failed_time TIMESTAMP NULL DEFAULT NULL,
+		reason VARCHAR(256) NOT NULL DEFAULT '',
+		state VARCHAR(16) NOT NULL,
+		update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		PRIMARY KEY (table_id),
+		KEY idx_priority (priority)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
+
+	// CreateBindEvolutionHistory stores the results of the plan baseline evolution.
+	CreateBindEvolutionHistory = `CREATE TABLE IF NOT EXISTS mysql.bind_evolution_history (
+		id BIGINT(64) NOT NULL AUTO_INCREMENT,
+		original_sql TEXT NOT NULL,
+		default_db TEXT NOT NULL,
+		bind_sql TEXT NOT NULL,
+		sql_digest VARCHAR(64) DEFAULT NULL,
+		baseline_time DOUBLE DEFAULT NULL COMMENT "the execution time of the accepted plan in seconds",
+		candidate_time DOUBLE DEFAULT NULL COMMENT "the execution time of the verified plan in seconds",
+		result VARCHAR(16) NOT NULL,
+		message TEXT DEFAULT NULL,
+		evolve_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (id),
+		INDEX time_index(evolve_time) COMMENT "accelerate the speed when querying and deleting the history"
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
+
 	// CreateRunawayQuarantineWatchTable stores the condition which is used to check whether query should be quarantined.
 	CreateRunawayQuarantineWatchTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_runaway_quarantined_watch (
 		resource_group_name varchar(32) not null,
@@ -649,6 +688,33 @@ const (
 		PRIMARY KEY (id),
 		KEY (created_by),
 		KEY (status));`
+
+	// CreateXAPrepared stores the prepared XA transaction branches. A record is deleted in the same transaction
+	// which commits the branch.
+	CreateXAPrepared = `CREATE TABLE IF NOT EXISTS mysql.tidb_xa_prepared (
+		format_id BIGINT(64) UNSIGNED NOT NULL,
+		gtrid VARBINARY(64) NOT NULL,
+		bqual VARBINARY(64) NOT NULL,
+		start_ts BIGINT(64) UNSIGNED NOT NULL,
+		for_update_ts BIGINT(64) UNSIGNED NOT NULL COMMENT "the keys written by the branch are unchanged before it",
+		owner VARCHAR(64) NOT NULL COMMENT "the ID of the TiDB instance which prepares the branch",
+		user VARCHAR(32) NOT NULL,
+		host VARCHAR(255) NOT NULL,
+		table_versions TEXT NOT NULL,
+		prepare_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (format_id, gtrid, bqual) CLUSTERED
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
+
+	// CreateXAPreparedMutations stores the mutations of the prepared XA transaction branches. The mutations of a branch
+	// are split into chunks ordered by seq, so a large branch doesn't exceed the entry size limit.
+	CreateXAPreparedMutations = `CREATE TABLE IF NOT EXISTS mysql.tidb_xa_prepared_mutations (
+		format_id BIGINT(64) UNSIGNED NOT NULL,
+		gtrid VARBINARY(64) NOT NULL,
+		bqual VARBINARY(64) NOT NULL,
+		seq BIGINT(64) UNSIGNED NOT NULL,
+		mutations LONGBLOB NOT NULL,
+		PRIMARY KEY (format_id, gtrid, bqual, seq) CLUSTERED
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
 )
 
 // bootstrap initiates system DB for a store.
@@ -942,11 +1008,19 @@ const (
 	version167 = 167
 	version168 = 168
 	version169 = 169
+	// version 170 add mysql.auto_analyze_queue
+	version170 = 170
+	// version 171 add columns about the TTL archive table to mysql.tidb_ttl_table_status
+	version171 = 171
+	// version 172 add mysql.tidb_timers and mysql.bind_evolution_history
+	version172 = 172
+	// version 173 add mysql.tidb_xa_prepared and mysql.tidb_xa_prepared_mutations
+	version173 = 173
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version169
+var currentBootstrapVersion int64 = version173
 
 // DDL owner key's expired time is ManagerSessionTTL seconds, we should wait the time and give more time to have a chance to finish it.
 var internalSQLTimeout = owner.ManagerSessionTTL + 15
@@ -954,6 +1028,9 @@ var internalSQLTimeout = owner.ManagerSessionTTL + 15
 // whether to run the sql file in bootstrap.
 var runBootstrapSQLFile = false
 
+// CreateTimers is a table to store all timers for tidb.
+var CreateTimers = tablestore.CreateTimerTableSQL(mysql.SystemDB, "tidb_timers")
+
 var (
 	bootstrapVersion = []func(Session, int64){
 		upgradeToVer2,
@@ -1084,6 +1161,10 @@ var (
 		upgradeToVer167,
 		upgradeToVer168,
 		upgradeToVer169,
+		upgradeToVer170,
+		upgradeToVer171,
+		upgradeToVer172,
+		upgradeToVer173,
 	}
 )
 
@@ -2731,6 +2812,38 @@ func upgradeToVer169(s Session, ver int64) {
 	mustExecute(s, CreateRunawayTable)
 }
 
+func upgradeToVer170(s Session, ver int64) {
+	if ver >= version170 {
+		return
+	}
+	mustExecute(s, CreateAutoAnalyzeQueue)
+}
+
+func upgradeToVer171(s Session, ver int64) {
+	if ver >= version171 {
+		return
+	}
+	doReentrantDDL(s, "ALTER TABLE mysql.tidb_ttl_table_status ADD COLUMN IF NOT EXISTS `last_job_archive_table` varchar(256) DEFAULT NULL")
+	doReentrantDDL(s, "ALTER TABLE mysql.tidb_ttl_table_status ADD COLUMN IF NOT EXISTS `last_job_archived_rows` bigint(64) DEFAULT NULL")
+	doReentrantDDL(s, "ALTER TABLE mysql.tidb_ttl_table_status ADD COLUMN IF NOT EXISTS `last_job_archive_error_rows` bigint(64) DEFAULT NULL")
+}
+
+func upgradeToVer172(s Session, ver int64) {
+	if ver >= version172 {
+		return
+	}
+	mustExecute(s, CreateTimers)
+	mustExecute(s, CreateBindEvolutionHistory)
+}
+
+func upgradeToVer173(s Session, ver int64) {
+	if ver >= version173 {
+		return
+	}
+	mustExecute(s, CreateXAPrepared)
+	mustExecute(s, CreateXAPreparedMutations)
+}
+
 func writeOOMAction(s Session) {
 	comment := "oom-action is `log` by default in v3.0.x, `cancel` by default in v4.0.11+"
 	mustExecute(s, `INSERT HIGH_PRIORITY INTO %n.%n VALUES (%?, %?, %?) ON DUPLICATE KEY UPDATE VARIABLE_VALUE= %?`,
@@ -2851,6 +2964,16 @@ func doDDLWorks(s Session) {
 	mustExecute(s, CreateRunawayQuarantineWatchTable)
 	// create runaway_queries
 	mustExecute(s, CreateRunawayTable)
+	// Create auto_analyze_queue
+	mustExecute(s, CreateAutoAnalyzeQueue)
+	// Create tidb_timers
+	mustExecute(s, CreateTimers)
+	// Create bind_evolution_history
+	mustExecute(s, CreateBindEvolutionHistory)
+	// Create tidb_xa_prepared
+	mustExecute(s, CreateXAPrepared)
+	// Create tidb_xa_prepared_mutations
+	mustExecute(s, CreateXAPreparedMutations)
 }
 
 // doBootstrapSQLFile executes SQL commands in a file as the last stage of bootstrap.
@@ -2313,7 +2313,7 @@ func (s *session) validateStatementInTxn(stmtNode ast.StmtNode) error {
 	if _, ok := stmtNode.(*ast.ImportIntoStmt); ok && vars.InTxn() {
 		return errors.New("cannot run IMPORT INTO in explicit transaction")
 	}
-	return nil
+	return s.validateStatementInXA(stmtNode)
 }
 
 func (s *session) validateStatementReadOnlyInStaleness(stmtNode ast.StmtNode) error {
@@ -336,6 +336,26 @@ func (txn *LazyTxn) changeToInvalid() {
 	}
 }
 
+// detach detaches the transaction from the LazyTxn without committing or rolling back it, the data in the statement
+// buffer is kept in the transaction. The LazyTxn becomes invalid after that.
+func (txn *LazyTxn) detach() kv.Transaction {
+	txn.flushStmtBuf()
+	txn.stagingHandle = kv.InvalidStagingHandle
+	t := txn.Transaction
+	txn.changeToInvalid()
+	txn.cleanup()
+	return t
+}
+
+// attach makes the LazyTxn valid with a transaction detached from another LazyTxn. The writes to the transaction go
+// into the membuffer directly, since no statement is executed on it.
+func (txn *LazyTxn) attach(t kv.Transaction) {
+	txn.Transaction = t
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	txn.resetTxnInfo(t.StartTS(), txninfo.TxnIdle, uint64(t.Len()), "", nil)
+}
+
 func (txn *LazyTxn) onStmtStart(currentSQLDigest string) {
 	if len(currentSQLDigest) == 0 {
 		return
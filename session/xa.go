@@ -0,0 +1,732 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/domain/infosync"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/sessiontxn"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/mathutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	tikvstore "github.com/tikv/client-go/v2/kv"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+// XA transactions are implemented on top of the pessimistic transactions.
+//
+// XA START begins a pessimistic transaction in the session, and XA END moves the branch to the IDLE state. XA PREPARE
+// persists the branch into mysql.tidb_xa_prepared and the mutations of the transaction into
+// mysql.tidb_xa_prepared_mutations, then it moves the transaction to an internal session, which keeps the pessimistic locks alive until the branch is committed or rolled
+// back. The record of the branch is locked and deleted by the transaction itself, so the record exists if and only
+// if the branch is neither committed nor rolled back.
+//
+// If the TiDB instance holding the prepared branch restarts, the locks of the branch are lost. The branch can still be
+// committed on any TiDB instance by applying the persisted mutations in a new pessimistic transaction, as long as the
+// tables written by the branch are unchanged since it is prepared. The keys are locked with the for_update_ts of the
+// branch, which is persisted when it's prepared, so the branch is rolled back with XA_RBROLLBACK if any key is written
+// by another transaction after the locks are lost.
+
+// xaMaxIDPartLength is the max length of GTRID and BQUAL in bytes.
+const xaMaxIDPartLength = 64
+
+// xaMutationsChunkSize is the max size of the mutations persisted in a row of mysql.tidb_xa_prepared_mutations, it
+// keeps the rows far below the default txn-entry-size-limit.
+const xaMutationsChunkSize = 1 << 20
+
+var _ sessiontxn.XAController = &session{}
+
+// xaPreparedBranch is a prepared branch held by this TiDB instance.
+type xaPreparedBranch struct {
+	// se is the internal session which holds the transaction of the branch.
+	se *session
+	// recordKeys are the keys of the record in mysql.tidb_xa_prepared.
+	recordKeys []kv.Key
+}
+
+var xaPreparedBranches = struct {
+	sync.Mutex
+	m map[ast.XID]*xaPreparedBranch
+}{m: make(map[ast.XID]*xaPreparedBranch)}
+
+func registerXAPreparedBranch(xid ast.XID, branch *xaPreparedBranch) {
+	xaPreparedBranches.Lock()
+	defer xaPreparedBranches.Unlock()
+	xaPreparedBranches.m[xid] = branch
+}
+
+// takeXAPreparedBranch removes the branch from this TiDB instance and returns it, it returns nil if the branch isn't
+// held by this TiDB instance.
+func takeXAPreparedBranch(xid ast.XID) *xaPreparedBranch {
+	xaPreparedBranches.Lock()
+	defer xaPreparedBranches.Unlock()
+	branch, ok := xaPreparedBranches.m[xid]
+	if !ok {
+		return nil
+	}
+	delete(xaPreparedBranches.m, xid)
+	return branch
+}
+
+func isXAPreparedBranchHeld(xid ast.XID) bool {
+	xaPreparedBranches.Lock()
+	defer xaPreparedBranches.Unlock()
+	_, ok := xaPreparedBranches.m[xid]
+	return ok
+}
+
+func (b *xaPreparedBranch) commit(ctx context.Context) error {
+	defer b.close()
+	for _, key := range b.recordKeys {
+		if err := b.se.txn.Delete(key); err != nil {
+			b.se.RollbackTxn(ctx)
+			return err
+		}
+	}
+	return b.se.txn.Commit(ctx)
+}
+
+func (b *xaPreparedBranch) rollback(ctx context.Context) {
+	b.se.RollbackTxn(ctx)
+	b.close()
+}
+
+func (b *xaPreparedBranch) close() {
+	infosync.DeleteInternalSession(b.se)
+	b.se.Close()
+}
+
+func validateXID(xid *ast.XID) error {
+	if len(xid.GTRID) > xaMaxIDPartLength || len(xid.BQUAL) > xaMaxIDPartLength {
+		return exeerrors.ErrXaerInval.GenWithStackByArgs()
+	}
+	return nil
+}
+
+// xaStateName returns the name of the state of the XA transaction branch in the session.
+func xaStateName(txnCtx *variable.TransactionContext) string {
+	if txnCtx.XID == nil {
+		return "NON-EXISTING"
+	}
+	if txnCtx.XAIdle {
+		return "IDLE"
+	}
+	return "ACTIVE"
+}
+
+// checkIdleXABranch checks whether the branch of the session is the IDLE branch identified by xid.
+func (s *session) checkIdleXABranch(xid *ast.XID) error {
+	txnCtx := s.sessionVars.TxnCtx
+	if txnCtx.XID == nil || !txnCtx.XAIdle {
+		return exeerrors.ErrXaerRmfail.GenWithStackByArgs(xaStateName(txnCtx))
+	}
+	if *txnCtx.XID != *xid {
+		return exeerrors.ErrXaerNota.GenWithStackByArgs()
+	}
+	return nil
+}
+
+// checkNoXABranch checks whether the session is out of any transaction, which is required to start a branch or finish
+// a prepared branch.
+func (s *session) checkNoXABranch() error {
+	if txnCtx := s.sessionVars.TxnCtx; txnCtx.XID != nil {
+		return exeerrors.ErrXaerRmfail.GenWithStackByArgs(xaStateName(txnCtx))
+	}
+	if s.sessionVars.InTxn() {
+		return exeerrors.ErrXaerOutside.GenWithStackByArgs()
+	}
+	return nil
+}
+
+// validateStatementInXA checks whether the statement is allowed in the XA transaction branch of the session. The
+// branch can't be finished by the statements of local transactions, and only the XA statements are allowed when the
+// branch is IDLE.
+func (s *session) validateStatementInXA(stmtNode ast.StmtNode) error {
+	txnCtx := s.sessionVars.TxnCtx
+	if txnCtx == nil || txnCtx.XID == nil {
+		return nil
+	}
+	switch x := stmtNode.(type) {
+	case *ast.XAStmt, *ast.ShowStmt:
+		return nil
+	case *ast.BeginStmt, *ast.CommitStmt, ast.DDLNode:
+		return exeerrors.ErrXaerRmfail.GenWithStackByArgs(xaStateName(txnCtx))
+	case *ast.RollbackStmt:
+		if x.SavepointName == "" {
+			return exeerrors.ErrXaerRmfail.GenWithStackByArgs(xaStateName(txnCtx))
+		}
+	}
+	if txnCtx.XAIdle {
+		return exeerrors.ErrXaerRmfail.GenWithStackByArgs(xaStateName(txnCtx))
+	}
+	return nil
+}
+
+// XAStart implements the sessiontxn.XAController interface.
+func (s *session) XAStart(ctx context.Context, xid *ast.XID) error {
+	if err := validateXID(xid); err != nil {
+		return err
+	}
+	if err := s.checkNoXABranch(); err != nil {
+		return err
+	}
+	if s.sessionVars.BinlogClient != nil {
+		return errors.New("XA transactions are not supported when binlog is enabled")
+	}
+	prepared, err := s.isXABranchPrepared(ctx, xid)
+	if err != nil {
+		return err
+	}
+	if prepared {
+		return exeerrors.ErrXaerDupid.GenWithStackByArgs()
+	}
+	err = sessiontxn.GetTxnManager(s).EnterNewTxn(ctx, &sessiontxn.EnterNewTxnRequest{
+		Type:    sessiontxn.EnterNewTxnWithBeginStmt,
+		TxnMode: ast.Pessimistic,
+	})
+	if err != nil {
+		return err
+	}
+	xidCopy := *xid
+	s.sessionVars.TxnCtx.XID = &xidCopy
+	return nil
+}
+
+// XAEnd implements the sessiontxn.XAController interface.
+func (s *session) XAEnd(xid *ast.XID) error {
+	txnCtx := s.sessionVars.TxnCtx
+	if txnCtx.XID == nil || txnCtx.XAIdle {
+		return exeerrors.ErrXaerRmfail.GenWithStackByArgs(xaStateName(txnCtx))
+	}
+	if *txnCtx.XID != *xid {
+		return exeerrors.ErrXaerNota.GenWithStackByArgs()
+	}
+	txnCtx.XAIdle = true
+	return nil
+}
+
+// XAPrepare implements the sessiontxn.XAController interface.
+func (s *session) XAPrepare(ctx context.Context, xid *ast.XID) error {
+	if err := s.checkIdleXABranch(xid); err != nil {
+		return err
+	}
+	sessVars := s.sessionVars
+	txnCtx := sessVars.TxnCtx
+	for _, tbl := range txnCtx.TemporaryTables {
+		if tbl.GetModified() && tbl.GetMeta().TempTableType == model.TempTableLocal {
+			return errors.Errorf("XA transactions can't write the local temporary table %s", tbl.GetMeta().Name.O)
+		}
+	}
+	txn, err := s.Txn(true)
+	if err != nil {
+		return err
+	}
+	mutations, err := encodeXAMutations(txn.GetMemBuffer(), txnCtx.TemporaryTables)
+	if err != nil {
+		return err
+	}
+	physicalTableIDs := make([]int64, 0, len(txnCtx.TableDeltaMap))
+	for id := range txnCtx.TableDeltaMap {
+		if _, ok := txnCtx.TemporaryTables[id]; !ok {
+			physicalTableIDs = append(physicalTableIDs, id)
+		}
+	}
+	is := sessiontxn.GetTxnManager(s).GetTxnInfoSchema()
+	tableVersions := make(map[int64]uint64, len(physicalTableIDs))
+	for _, id := range physicalTableIDs {
+		tableVersions[id] = xaTableVersion(is, id)
+	}
+	serverInfo, err := infosync.GetServerInfo()
+	if err != nil {
+		return err
+	}
+	// holder is the internal session which holds the transaction after the branch is prepared.
+	holder, err := createSession(s.store)
+	if err != nil {
+		return err
+	}
+
+	// All the keys written by the branch are locked, so none of them is changed by others before forUpdateTS.
+	forUpdateTS, err := s.store.GetOracle().GetTimestamp(ctx, &oracle.Option{TxnScope: oracle.GlobalTxnScope})
+	if err != nil {
+		holder.Close()
+		return err
+	}
+	recordKeys, err := s.insertXAPreparedRecord(ctx, xid, txn.StartTS(), forUpdateTS, serverInfo.ID, tableVersions, mutations)
+	if err != nil {
+		holder.Close()
+		return err
+	}
+	if err = s.lockXAPreparedRecord(ctx, txn, recordKeys); err != nil {
+		holder.Close()
+		if err1 := s.deleteXAPreparedRecord(ctx, xid); err1 != nil {
+			logutil.Logger(ctx).Warn("failed to delete the record of XA transaction branch",
+				zap.Stringer("xid", xid), zap.Error(err1))
+		}
+		return err
+	}
+
+	// The transaction is detached in the middle of the statement, finish the fair locking started by the statement,
+	// or the transaction can be neither committed nor rolled back.
+	if txn.IsInFairLockingMode() {
+		if err = txn.DoneFairLocking(ctx); err != nil {
+			holder.Close()
+			return err
+		}
+	}
+
+	// The MDL of the tables is released with the session, so the schema must be checked when committing the branch.
+	txn.SetOption(kv.SchemaChecker, domain.NewSchemaChecker(domain.GetDomain(s), is.SchemaMetaVersion(), physicalTableIDs, true))
+	txn.SetOption(kv.InfoSchema, txnCtx.InfoSchema)
+	txn.SetOption(kv.EnableAsyncCommit, sessVars.EnableAsyncCommit)
+	txn.SetOption(kv.Enable1PC, sessVars.Enable1PC)
+	if tables := txnCtx.TemporaryTables; len(tables) > 0 {
+		txn.SetOption(kv.KVFilter, temporaryTableKVFilter(tables))
+	}
+
+	// Move the transaction to the holder, the statement is finished as if the transaction is committed.
+	holder.txn.attach(s.txn.detach())
+	holder.SetMemoryFootprintChangeHook()
+	infosync.StoreInternalSession(holder)
+	registerXAPreparedBranch(*xid, &xaPreparedBranch{se: holder, recordKeys: recordKeys})
+	sessVars.SetInTxn(false)
+	return nil
+}
+
+// XACommit implements the sessiontxn.XAController interface.
+func (s *session) XACommit(ctx context.Context, xid *ast.XID, onePhase bool) error {
+	if err := validateXID(xid); err != nil {
+		return err
+	}
+	if onePhase {
+		if err := s.checkIdleXABranch(xid); err != nil {
+			return err
+		}
+		// The branch is committed as a local transaction after the statement, like COMMIT.
+		s.sessionVars.SetInTxn(false)
+		return nil
+	}
+	if err := s.checkNoXABranch(); err != nil {
+		return err
+	}
+	if branch := takeXAPreparedBranch(*xid); branch != nil {
+		return branch.commit(ctx)
+	}
+	return s.finishXAPreparedRecord(ctx, xid, true)
+}
+
+// XARollback implements the sessiontxn.XAController interface.
+func (s *session) XARollback(ctx context.Context, xid *ast.XID) error {
+	if err := validateXID(xid); err != nil {
+		return err
+	}
+	if s.sessionVars.TxnCtx.XID != nil {
+		if err := s.checkIdleXABranch(xid); err != nil {
+			return err
+		}
+		// The branch is rolled back as a local transaction, like ROLLBACK.
+		s.sessionVars.SetInTxn(false)
+		if !s.txn.Valid() {
+			return nil
+		}
+		s.sessionVars.TxnCtx.ClearDelta()
+		return s.txn.Rollback()
+	}
+	if err := s.checkNoXABranch(); err != nil {
+		return err
+	}
+	if branch := takeXAPreparedBranch(*xid); branch != nil {
+		branch.rollback(ctx)
+	}
+	return s.finishXAPreparedRecord(ctx, xid, false)
+}
+
+// XARecover implements the sessiontxn.XAController interface.
+func (s *session) XARecover(ctx context.Context, allUsers bool) ([]*ast.XID, error) {
+	se, clean, err := s.getInternalSession(sqlexec.ExecOption{})
+	if err != nil {
+		return nil, err
+	}
+	defer clean()
+
+	sql := "SELECT format_id, gtrid, bqual FROM mysql.tidb_xa_prepared"
+	var args []interface{}
+	if !allUsers {
+		user, host := s.xaUser()
+		sql += " WHERE user = %? AND host = %?"
+		args = append(args, user, host)
+	}
+	rows, err := execXAQuery(ctx, se, sql+" ORDER BY start_ts", args...)
+	if err != nil {
+		return nil, err
+	}
+	xids := make([]*ast.XID, 0, len(rows))
+	for _, row := range rows {
+		xids = append(xids, &ast.XID{
+			FormatID: row.GetUint64(0),
+			GTRID:    string(row.GetBytes(1)),
+			BQUAL:    string(row.GetBytes(2)),
+		})
+	}
+	return xids, nil
+}
+
+func (s *session) xaUser() (user, host string) {
+	if u := s.sessionVars.User; u != nil {
+		return u.AuthUsername, u.AuthHostname
+	}
+	return "", ""
+}
+
+func execXAQuery(ctx context.Context, se *session, sql string, args ...interface{}) ([]chunk.Row, error) {
+	rs, err := se.ExecuteInternal(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer terror.Call(rs.Close)
+	return drainRecordSet(ctx, se, rs, nil)
+}
+
+func (s *session) isXABranchPrepared(ctx context.Context, xid *ast.XID) (bool, error) {
+	if isXAPreparedBranchHeld(*xid) {
+		return true, nil
+	}
+	se, clean, err := s.getInternalSession(sqlexec.ExecOption{})
+	if err != nil {
+		return false, err
+	}
+	defer clean()
+	rows, err := execXAQuery(ctx, se, "SELECT 1 FROM mysql.tidb_xa_prepared WHERE format_id = %? AND gtrid = %? AND bqual = %?",
+		xid.FormatID, []byte(xid.GTRID), []byte(xid.BQUAL))
+	return len(rows) > 0, err
+}
+
+// insertXAPreparedRecord inserts the record of the prepared branch and the chunks of its mutations, and returns the
+// keys of them.
+func (s *session) insertXAPreparedRecord(
+	ctx context.Context,
+	xid *ast.XID,
+	startTS uint64,
+	forUpdateTS uint64,
+	owner string,
+	tableVersions map[int64]uint64,
+	mutations []byte,
+) ([]kv.Key, error) {
+	versions, err := json.Marshal(tableVersions)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	se, clean, err := s.getInternalSession(sqlexec.ExecOption{})
+	if err != nil {
+		return nil, err
+	}
+	defer clean()
+
+	if _, err = se.ExecuteInternal(ctx, "BEGIN PESSIMISTIC"); err != nil {
+		return nil, err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_, err := se.ExecuteInternal(ctx, "ROLLBACK")
+			terror.Log(err)
+		}
+	}()
+	user, host := s.xaUser()
+	_, err = se.ExecuteInternal(ctx, `INSERT INTO mysql.tidb_xa_prepared
+		(format_id, gtrid, bqual, start_ts, for_update_ts, owner, user, host, table_versions)
+		VALUES (%?, %?, %?, %?, %?, %?, %?, %?, %?)`,
+		xid.FormatID, []byte(xid.GTRID), []byte(xid.BQUAL), startTS, forUpdateTS, owner, user, host, string(versions))
+	if err != nil {
+		if kv.ErrKeyExists.Equal(err) {
+			return nil, exeerrors.ErrXaerDupid.GenWithStackByArgs()
+		}
+		return nil, err
+	}
+	for seq := 0; len(mutations) > 0; seq++ {
+		part := mutations[:mathutil.Min(len(mutations), xaMutationsChunkSize)]
+		mutations = mutations[len(part):]
+		_, err = se.ExecuteInternal(ctx, `INSERT INTO mysql.tidb_xa_prepared_mutations
+			(format_id, gtrid, bqual, seq, mutations) VALUES (%?, %?, %?, %?, %?)`,
+			xid.FormatID, []byte(xid.GTRID), []byte(xid.BQUAL), seq, part)
+		if err != nil {
+			return nil, err
+		}
+	}
+	txn, err := se.Txn(false)
+	if err != nil {
+		return nil, err
+	}
+	var keys []kv.Key
+	err = kv.WalkMemBuffer(txn.GetMemBuffer(), func(k kv.Key, _ []byte) error {
+		keys = append(keys, k.Clone())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = se.ExecuteInternal(ctx, "COMMIT"); err != nil {
+		return nil, err
+	}
+	committed = true
+	return keys, nil
+}
+
+// lockXAPreparedRecord locks the record of the prepared branch in the transaction of the branch, so the record can't
+// be deleted or re-applied by others until the branch is finished.
+func (s *session) lockXAPreparedRecord(ctx context.Context, txn kv.Transaction, recordKeys []kv.Key) error {
+	// The record is committed after the for_update_ts of the branch, so it's locked with a new one.
+	forUpdateTS, err := s.store.GetOracle().GetTimestamp(ctx, &oracle.Option{TxnScope: oracle.GlobalTxnScope})
+	if err != nil {
+		return err
+	}
+	lockCtx := tikvstore.NewLockCtx(forUpdateTS, tikvstore.LockNoWait, time.Now())
+	return txn.LockKeys(ctx, lockCtx, recordKeys...)
+}
+
+func (s *session) deleteXAPreparedRecord(ctx context.Context, xid *ast.XID) error {
+	se, clean, err := s.getInternalSession(sqlexec.ExecOption{})
+	if err != nil {
+		return err
+	}
+	defer clean()
+	if _, err = se.ExecuteInternal(ctx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	if err = deleteXAPreparedRows(ctx, se, xid); err != nil {
+		_, err1 := se.ExecuteInternal(ctx, "ROLLBACK")
+		terror.Log(err1)
+		return err
+	}
+	_, err = se.ExecuteInternal(ctx, "COMMIT")
+	return err
+}
+
+// deleteXAPreparedRows deletes the record of the branch and the chunks of its mutations in the transaction of se.
+func deleteXAPreparedRows(ctx context.Context, se *session, xid *ast.XID) error {
+	const where = " WHERE format_id = %? AND gtrid = %? AND bqual = %?"
+	args := []interface{}{xid.FormatID, []byte(xid.GTRID), []byte(xid.BQUAL)}
+	if _, err := se.ExecuteInternal(ctx, "DELETE FROM mysql.tidb_xa_prepared_mutations"+where, args...); err != nil {
+		return err
+	}
+	_, err := se.ExecuteInternal(ctx, "DELETE FROM mysql.tidb_xa_prepared"+where, args...)
+	return err
+}
+
+// readXAPreparedMutations reads the chunks of the mutations of the branch in order and concatenates them.
+func readXAPreparedMutations(ctx context.Context, se *session, xid *ast.XID) ([]byte, error) {
+	rows, err := execXAQuery(ctx, se, "SELECT mutations FROM mysql.tidb_xa_prepared_mutations WHERE format_id = %? AND gtrid = %? AND bqual = %? ORDER BY seq",
+		xid.FormatID, []byte(xid.GTRID), []byte(xid.BQUAL))
+	if err != nil {
+		return nil, err
+	}
+	var mutations []byte
+	for _, row := range rows {
+		mutations = append(mutations, row.GetBytes(0)...)
+	}
+	return mutations, nil
+}
+
+// finishXAPreparedRecord finishes a prepared branch which isn't held by this TiDB instance. If commit is true, the
+// persisted mutations of the branch are applied. The record of the branch is deleted in both cases.
+func (s *session) finishXAPreparedRecord(ctx context.Context, xid *ast.XID, commit bool) error {
+	se, clean, err := s.getInternalSession(sqlexec.ExecOption{})
+	if err != nil {
+		return err
+	}
+	defer clean()
+
+	// The record is locked if the branch is held by another alive TiDB instance, check the owner before locking it
+	// to avoid waiting for the lock.
+	const where = " FROM mysql.tidb_xa_prepared WHERE format_id = %? AND gtrid = %? AND bqual = %?"
+	args := []interface{}{xid.FormatID, []byte(xid.GTRID), []byte(xid.BQUAL)}
+	rows, err := execXAQuery(ctx, se, "SELECT owner"+where, args...)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return exeerrors.ErrXaerNota.GenWithStackByArgs()
+	}
+	if err = checkXABranchOwner(ctx, rows[0].GetString(0)); err != nil {
+		return err
+	}
+
+	if _, err = se.ExecuteInternal(ctx, "BEGIN PESSIMISTIC"); err != nil {
+		return err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_, err := se.ExecuteInternal(ctx, "ROLLBACK")
+			terror.Log(err)
+		}
+	}()
+	rows, err = execXAQuery(ctx, se, "SELECT table_versions, for_update_ts"+where+" FOR UPDATE", args...)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return exeerrors.ErrXaerNota.GenWithStackByArgs()
+	}
+	rolledBack := false
+	if commit {
+		if err = checkXATableVersions(domain.GetDomain(s).InfoSchema(), rows[0].GetString(0)); err != nil {
+			return err
+		}
+		txn, err := se.Txn(true)
+		if err != nil {
+			return err
+		}
+		mutations, err := readXAPreparedMutations(ctx, se, xid)
+		if err != nil {
+			return err
+		}
+		staging := txn.GetMemBuffer().Staging()
+		err = se.applyXAMutations(ctx, mutations, rows[0].GetUint64(1))
+		if kv.ErrWriteConflict.Equal(err) {
+			// The keys are written by others after the locks of the branch are lost, so the branch can never be
+			// committed. Discard the mutations and delete the record to roll it back.
+			logutil.Logger(ctx).Info("the XA transaction branch is rolled back because of write conflict",
+				zap.Stringer("xid", xid), zap.Error(err))
+			txn.GetMemBuffer().Cleanup(staging)
+			rolledBack = true
+		} else if err != nil {
+			return err
+		} else {
+			txn.GetMemBuffer().Release(staging)
+		}
+	}
+	if err = deleteXAPreparedRows(ctx, se, xid); err != nil {
+		return err
+	}
+	if _, err = se.ExecuteInternal(ctx, "COMMIT"); err != nil {
+		return err
+	}
+	committed = true
+	if rolledBack {
+		return exeerrors.ErrXaRbrollback.GenWithStackByArgs()
+	}
+	return nil
+}
+
+// checkXABranchOwner returns an error if the branch is held by another alive TiDB instance.
+func checkXABranchOwner(ctx context.Context, owner string) error {
+	self, err := infosync.GetServerInfo()
+	if err != nil {
+		return err
+	}
+	if owner == self.ID {
+		return nil
+	}
+	servers, err := infosync.GetAllServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+	if info, ok := servers[owner]; ok {
+		return errors.Errorf("the XA transaction branch is held by the TiDB instance %s:%d, please finish it on that instance",
+			info.IP, info.Port)
+	}
+	return nil
+}
+
+// xaTableVersion returns the UpdateTS of the table or partition, which is changed by every DDL on the table.
+func xaTableVersion(is infoschema.InfoSchema, physicalID int64) uint64 {
+	if tbl, ok := is.TableByID(physicalID); ok {
+		return tbl.Meta().UpdateTS
+	}
+	if tbl, _, _ := is.FindTableByPartitionID(physicalID); tbl != nil {
+		return tbl.Meta().UpdateTS
+	}
+	return 0
+}
+
+func checkXATableVersions(is infoschema.InfoSchema, versions string) error {
+	tableVersions := make(map[int64]uint64)
+	if err := json.Unmarshal([]byte(versions), &tableVersions); err != nil {
+		return errors.Trace(err)
+	}
+	for id, version := range tableVersions {
+		if xaTableVersion(is, id) != version {
+			return domain.ErrInfoSchemaChanged.GenWithStack(
+				"the table %d has been changed since the XA transaction branch is prepared", id)
+		}
+	}
+	return nil
+}
+
+// encodeXAMutations encodes the key-values written by the transaction, an empty value means the key is deleted.
+func encodeXAMutations(buf kv.MemBuffer, temporaryTables temporaryTableKVFilter) ([]byte, error) {
+	var mutations []byte
+	err := kv.WalkMemBuffer(buf, func(k kv.Key, v []byte) error {
+		if _, ok := temporaryTables[tablecodec.DecodeTableID(k)]; ok || tablecodec.IsUntouchedIndexKValue(k, v) {
+			return nil
+		}
+		mutations = codec.EncodeCompactBytes(mutations, k)
+		mutations = codec.EncodeCompactBytes(mutations, v)
+		return nil
+	})
+	return mutations, err
+}
+
+// applyXAMutations writes and locks the mutations encoded by encodeXAMutations in the transaction of the session. The
+// keys are locked with the for_update_ts of the branch, it returns a write conflict error if any key is changed after
+// that.
+func (s *session) applyXAMutations(ctx context.Context, mutations []byte, forUpdateTS uint64) error {
+	txn, err := s.Txn(true)
+	if err != nil {
+		return err
+	}
+	var keys []kv.Key
+	for len(mutations) > 0 {
+		var k, v []byte
+		if mutations, k, err = codec.DecodeCompactBytes(mutations); err != nil {
+			return err
+		}
+		if mutations, v, err = codec.DecodeCompactBytes(mutations); err != nil {
+			return err
+		}
+		if len(v) == 0 {
+			err = txn.Delete(k)
+		} else {
+			err = txn.Set(k, v)
+		}
+		if err != nil {
+			return err
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	lockCtx := tikvstore.NewLockCtx(forUpdateTS, s.sessionVars.LockWaitTimeout, time.Now())
+	return txn.LockKeys(ctx, lockCtx, keys...)
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXACommitAfterLosingLocks(t *testing.T) {
+	store, dom := CreateStoreAndBootstrap(t)
+	defer func() { require.NoError(t, store.Close()) }()
+	defer dom.Close()
+
+	ctx := context.Background()
+	se := CreateSessionAndSetID(t, store)
+	mustQuery := func(sql string) [][]string {
+		rs := mustExecToRecodeSet(t, se, sql)
+		rows, err := ResultSetToStringSlice(ctx, se, rs)
+		require.NoError(t, err)
+		return rows
+	}
+	// loseLocks simulates that the TiDB instance holding the branch restarts.
+	loseLocks := func(gtrid string) {
+		branch := takeXAPreparedBranch(ast.XID{GTRID: gtrid, FormatID: ast.DefaultXIDFormatID})
+		require.NotNil(t, branch)
+		branch.rollback(ctx)
+	}
+
+	mustExec(t, se, "use test")
+	mustExec(t, se, "create table t (id int primary key, v int, key idx_v(v))")
+	mustExec(t, se, "insert into t values (1, 1), (2, 2)")
+	mustExec(t, se, "xa start 'x1'")
+	mustExec(t, se, "update t set v = 10 where id = 1")
+	mustExec(t, se, "delete from t where id = 2")
+	mustExec(t, se, "insert into t values (3, 3)")
+	mustExec(t, se, "xa end 'x1'")
+	mustExec(t, se, "xa prepare 'x1'")
+	loseLocks("x1")
+	require.Equal(t, [][]string{{"1", "1"}, {"2", "2"}}, mustQuery("select * from t order by id"))
+
+	// The persisted mutations are applied.
+	mustExec(t, se, "xa commit 'x1'")
+	require.Equal(t, [][]string{{"1", "10"}, {"3", "3"}}, mustQuery("select * from t order by id"))
+	require.Equal(t, [][]string{{"3", "3"}, {"1", "10"}}, mustQuery("select * from t use index(idx_v) order by v"))
+	require.Empty(t, mustQuery("xa recover"))
+	_, err := exec(se, "xa commit 'x1'")
+	require.ErrorContains(t, err, "XAERNOTA")
+
+	// The branch can't be committed if the table is changed after the branch is prepared.
+	mustExec(t, se, "xa start 'x2'")
+	mustExec(t, se, "update t set v = 20 where id = 1")
+	mustExec(t, se, "xa end 'x2'")
+	mustExec(t, se, "xa prepare 'x2'")
+	loseLocks("x2")
+	mustExec(t, se, "alter table t add column c int")
+	_, err = exec(se, "xa commit 'x2'")
+	require.ErrorContains(t, err, "has been changed since the XA transaction branch is prepared")
+	require.Equal(t, [][]string{{"1", "2", "0", "x2"}}, mustQuery("xa recover"))
+	mustExec(t, se, "xa rollback 'x2'")
+	require.Empty(t, mustQuery("xa recover"))
+	require.Equal(t, [][]string{{"1", "10", "<nil>"}, {"3", "3", "<nil>"}}, mustQuery("select * from t order by id"))
+
+	// The branch is rolled back if the rows written by it are changed by others after the locks are lost.
+	se2 := CreateSessionAndSetID(t, store)
+	mustExec(t, se2, "use test")
+	mustExec(t, se, "xa start 'x3'")
+	mustExec(t, se, "update t set v = 30 where id = 1")
+	mustExec(t, se, "xa end 'x3'")
+	mustExec(t, se, "xa prepare 'x3'")
+	loseLocks("x3")
+	mustExec(t, se2, "update t set v = 11 where id = 1")
+	_, err = exec(se, "xa commit 'x3'")
+	require.ErrorContains(t, err, "XARBROLLBACK")
+	require.Empty(t, mustQuery("xa recover"))
+	require.Equal(t, [][]string{{"1", "11", "<nil>"}, {"3", "3", "<nil>"}}, mustQuery("select * from t order by id"))
+	require.Equal(t, [][]string{{"3", "3", "<nil>"}, {"1", "11", "<nil>"}}, mustQuery("select * from t use index(idx_v) order by v"))
+
+	// The writes on the other rows don't conflict with the branch.
+	mustExec(t, se, "xa start 'x4'")
+	mustExec(t, se, "update t set v = 40 where id = 1")
+	mustExec(t, se, "xa end 'x4'")
+	mustExec(t, se, "xa prepare 'x4'")
+	loseLocks("x4")
+	mustExec(t, se2, "update t set v = 4 where id = 3")
+	mustExec(t, se, "xa commit 'x4'")
+	require.Empty(t, mustQuery("xa recover"))
+	require.Equal(t, [][]string{{"1", "40", "<nil>"}, {"3", "4", "<nil>"}}, mustQuery("select * from t order by id"))
+
+	// The mutations of a large branch are persisted in chunks, each of which is below the entry size limit.
+	mustExec(t, se, "create table t2 (id int primary key, b longblob)")
+	mustExec(t, se, "xa start 'x5'")
+	for i := 0; i < 8; i++ {
+		mustExec(t, se, fmt.Sprintf("insert into t2 values (%d, repeat('a', 1048576))", i))
+	}
+	mustExec(t, se, "xa end 'x5'")
+	mustExec(t, se, "xa prepare 'x5'")
+	rows := mustQuery("select count(*) > 8, max(length(mutations)) <= 1048576 from mysql.tidb_xa_prepared_mutations where gtrid = 'x5'")
+	require.Equal(t, [][]string{{"1", "1"}}, rows)
+	loseLocks("x5")
+	mustExec(t, se, "xa commit 'x5'")
+	require.Equal(t, [][]string{{"8", "8388608"}}, mustQuery("select count(*), sum(length(b)) from t2"))
+	require.Equal(t, [][]string{{"0"}}, mustQuery("select count(*) from mysql.tidb_xa_prepared_mutations"))
+}
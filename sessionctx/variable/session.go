@@ -257,6 +257,11 @@ type TxnCtxNoNeedToRestore struct {
 	// Read results cannot be directly written into pessimisticLockCache because failed statement need to rollback
 	// its pessimistic locks.
 	CurrentStmtPessimisticLockCache map[string][]byte
+
+	// XID is the identifier of the XA transaction branch, it's nil if the transaction isn't started by XA START.
+	XID *ast.XID
+	// XAIdle indicates whether the XA transaction branch is in the IDLE state, which is entered by XA END.
+	XAIdle bool
 }
 
 // SavepointRecord indicates a transaction's savepoint record.
@@ -370,6 +375,8 @@ func (tc *TransactionContext) Cleanup() {
 	tc.IsStaleness = false
 	tc.Savepoints = nil
 	tc.EnableMDL = false
+	tc.XID = nil
+	tc.XAIdle = false
 }
 
 // ClearDelta clears the delta map.
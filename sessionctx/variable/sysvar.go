@@ -854,6 +854,13 @@ var defaultSysVars = []*SysVar{
 			AutoAnalyzePartitionBatchSize.Store(val)
 			return nil
 		}},
+	{Scope: ScopeGlobal, Name: TiDBAutoAnalyzeConcurrency,
+		Value: strconv.Itoa(DefTiDBAutoAnalyzeConcurrency),
+		Type:  TypeUnsigned, MinValue: 1, MaxValue: 256,
+		SetGlobal: func(_ context.Context, vars *SessionVars, s string) error {
+			AutoAnalyzeConcurrency.Store(int32(TidbOptInt64(s, DefTiDBAutoAnalyzeConcurrency)))
+			return nil
+		}},
 
 	// variable for top SQL feature.
 	// TopSQL enable only be controlled by TopSQL pub/sub sinker.
@@ -1162,6 +1169,21 @@ var defaultSysVars = []*SysVar{
 		}
 		return err
 	}},
+	{Scope: ScopeGlobal, Name: TiDBEnableInstancePlanCache, Value: BoolToOnOff(DefTiDBEnableInstancePlanCache), Type: TypeBool, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		EnableInstancePlanCache.Store(TiDBOptOn(val))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return BoolToOnOff(EnableInstancePlanCache.Load()), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBInstancePlanCacheMaxMemSize, Value: strconv.FormatUint(DefTiDBInstancePlanCacheMaxMemSize, 10), Type: TypeUnsigned, MinValue: 0, MaxValue: math.MaxUint64, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		uVal, err := strconv.ParseUint(val, 10, 64)
+		if err == nil {
+			InstancePlanCacheMaxMemSize.Store(uVal)
+		}
+		return err
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return strconv.FormatUint(InstancePlanCacheMaxMemSize.Load(), 10), nil
+	}},
 	{Scope: ScopeGlobal, Name: TiDBMemOOMAction, Value: DefTiDBMemOOMAction, PossibleValues: []string{"CANCEL", "LOG"}, Type: TypeEnum,
 		GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
 			return OOMAction.Load(), nil
@@ -1925,7 +1947,7 @@ var defaultSysVars = []*SysVar{
 		return nil
 	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEvolvePlanBaselines, Value: BoolToOnOff(DefTiDBEvolvePlanBaselines), Type: TypeBool, Validation: func(vars *SessionVars, normalizedValue string, originalValue string, scope ScopeFlag) (string, error) {
-		if normalizedValue == "ON" && !config.CheckTableBeforeDrop {
+		if normalizedValue == "ON" && !config.CheckTableBeforeDrop && !config.GetGlobalConfig().Experimental.EnablePlanBaselineEvolution {
 			return normalizedValue, errors.Errorf("Cannot enable baseline evolution feature, it is not generally available now")
 		}
 		return normalizedValue, nil
@@ -656,6 +656,9 @@ const (
 	// TiDBAutoAnalyzePartitionBatchSize indicates the batch size for partition tables for auto analyze in dynamic mode
 	TiDBAutoAnalyzePartitionBatchSize = "tidb_auto_analyze_partition_batch_size"
 
+	// TiDBAutoAnalyzeConcurrency indicates the max number of the auto analyze jobs running concurrently.
+	TiDBAutoAnalyzeConcurrency = "tidb_auto_analyze_concurrency"
+
 	// TiDBEnableIndexMergeJoin indicates whether to enable index merge join.
 	TiDBEnableIndexMergeJoin = "tidb_enable_index_merge_join"
 
@@ -808,6 +811,10 @@ const (
 	TiDBPlanCacheInvalidationOnFreshStats = "tidb_plan_cache_invalidation_on_fresh_stats"
 	// TiDBSessionPlanCacheSize controls the size of session plan cache.
 	TiDBSessionPlanCacheSize = "tidb_session_plan_cache_size"
+	// TiDBEnableInstancePlanCache indicates whether to share the cached plans between the sessions of a TiDB instance.
+	TiDBEnableInstancePlanCache = "tidb_enable_instance_plan_cache"
+	// TiDBInstancePlanCacheMaxMemSize controls the max memory used by the instance plan cache.
+	TiDBInstancePlanCacheMaxMemSize = "tidb_instance_plan_cache_max_mem_size"
 
 	// TiDBConstraintCheckInPlacePessimistic controls whether to skip certain kinds of pessimistic locks.
 	TiDBConstraintCheckInPlacePessimistic = "tidb_constraint_check_in_place_pessimistic"
@@ -1210,6 +1217,7 @@ const (
 	DefTiDBGuaranteeLinearizability                = true
 	DefTiDBAnalyzeVersion                          = 2
 	DefTiDBAutoAnalyzePartitionBatchSize           = 1
+	DefTiDBAutoAnalyzeConcurrency                  = 1
 	DefTiDBEnableIndexMergeJoin                    = false
 	DefTiDBTrackAggregateMemoryUsage               = true
 	DefCTEMaxRecursionDepth                        = 1000
@@ -1290,6 +1298,8 @@ const (
 	DefTiDBEnableNonPreparedPlanCacheForDML        = false
 	DefTiDBNonPreparedPlanCacheSize                = 100
 	DefTiDBPlanCacheMaxPlanSize                    = 2 * size.MB
+	DefTiDBEnableInstancePlanCache                 = false
+	DefTiDBInstancePlanCacheMaxMemSize             = 100 * size.MB
 	// MaxDDLReorgBatchSize is exported for testing.
 	MaxDDLReorgBatchSize                  int32  = 10240
 	MinDDLReorgBatchSize                  int32  = 32
@@ -1403,11 +1413,14 @@ var (
 	MaxAutoAnalyzeTime                   = atomic.NewInt64(DefTiDBMaxAutoAnalyzeTime)
 	// variables for plan cache
 	PreparedPlanCacheMemoryGuardRatio = atomic.NewFloat64(DefTiDBPrepPlanCacheMemoryGuardRatio)
+	EnableInstancePlanCache           = atomic.NewBool(DefTiDBEnableInstancePlanCache)
+	InstancePlanCacheMaxMemSize       = atomic.NewUint64(DefTiDBInstancePlanCacheMaxMemSize)
 	EnableDistTask                    = atomic.NewBool(DefTiDBEnableDistTask)
 	DDLForce2Queue                    = atomic.NewBool(false)
 	EnableNoopVariables               = atomic.NewBool(DefTiDBEnableNoopVariables)
 	EnableMDL                         = atomic.NewBool(false)
 	AutoAnalyzePartitionBatchSize     = atomic.NewInt64(DefTiDBAutoAnalyzePartitionBatchSize)
+	AutoAnalyzeConcurrency            = atomic.NewInt32(DefTiDBAutoAnalyzeConcurrency)
 	// EnableFastReorg indicates whether to use lightning to enhance DDL reorg performance.
 	EnableFastReorg = atomic.NewBool(DefTiDBEnableFastReorg)
 	// DDLDiskQuota is the temporary variable for set disk quota for lightning
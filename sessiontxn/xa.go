@@ -0,0 +1,40 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sessiontxn
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/parser/ast"
+)
+
+// XAController controls the XA transaction branches of a session.
+type XAController interface {
+	// XAStart starts an XA transaction branch in the session, the branch is in the ACTIVE state.
+	XAStart(ctx context.Context, xid *ast.XID) error
+	// XAEnd moves the ACTIVE branch of the session to the IDLE state.
+	XAEnd(xid *ast.XID) error
+	// XAPrepare prepares the IDLE branch of the session and detaches it from the session. After that, the branch can
+	// be committed or rolled back by any session.
+	XAPrepare(ctx context.Context, xid *ast.XID) error
+	// XACommit commits a branch. If onePhase is true, the branch must be the IDLE branch of the session, otherwise it
+	// must be a prepared branch.
+	XACommit(ctx context.Context, xid *ast.XID, onePhase bool) error
+	// XARollback rolls back the IDLE branch of the session or a prepared branch.
+	XARollback(ctx context.Context, xid *ast.XID) error
+	// XARecover returns the prepared branches. If allUsers is false, only the branches prepared by the current user
+	// are returned.
+	XARecover(ctx context.Context, allUsers bool) ([]*ast.XID, error)
+}
@@ -0,0 +1,591 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handle
+
+import (
+	"container/heap"
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/statistics"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// AutoAnalyzeJobPending means the job is waiting in the auto analyze queue.
+	AutoAnalyzeJobPending = "pending"
+	// AutoAnalyzeJobRunning means the job is being analyzed.
+	AutoAnalyzeJobRunning = "running"
+	// AutoAnalyzeJobFailed means the job failed recently and is retried after a backoff.
+	AutoAnalyzeJobFailed = "failed"
+)
+
+// The weights of the factors to calculate the priority of an auto analyze job.
+const (
+	changeRatioWeight = 0.4
+	tableSizeWeight   = 0.2
+	staleTimeWeight   = 0.2
+	queryUsageWeight  = 0.2
+)
+
+// autoAnalyzeJob is a job in the auto analyze priority queue.
+type autoAnalyzeJob struct {
+	// ID is the physical ID of the analyzed table, it's the partition ID for the partition in static prune mode.
+	ID            int64
+	DBName        string
+	TableName     string
+	PartitionName string
+	Reason        string
+	State         string
+	Priority      float64
+	ChangeRatio   float64
+	RowCount      int64
+	// LastAnalyzeTime, LastUsedTime and LastFailedTime are zero if the table has never been analyzed, used or failed.
+	LastAnalyzeTime time.Time
+	LastUsedTime    time.Time
+	LastFailedTime  time.Time
+
+	statsVer int
+	stmts    []autoAnalyzeStmt
+}
+
+type autoAnalyzeStmt struct {
+	sql    string
+	params []interface{}
+}
+
+// init fills the identity and the priority of the job.
+func (j *autoAnalyzeJob) init(id int64, db, tbl, partition string, statsTbl *statistics.Table, lastUsed, now time.Time) {
+	j.ID, j.DBName, j.TableName, j.PartitionName = id, db, tbl, partition
+	j.State = AutoAnalyzeJobPending
+	j.RowCount = statsTbl.RealtimeCount
+	j.LastUsedTime = lastUsed
+	// The table which has never been analyzed is regarded as totally changed.
+	j.ChangeRatio = 1
+	if TableAnalyzed(statsTbl) {
+		tblCnt := float64(statsTbl.RealtimeCount)
+		if histCnt := statsTbl.GetColRowCount(); histCnt > 0 {
+			tblCnt = histCnt
+		}
+		j.ChangeRatio = float64(statsTbl.ModifyCount) / math.Max(tblCnt, 1)
+		if version := lastAnalyzeVersion(statsTbl); version > 0 {
+			j.LastAnalyzeTime = oracle.GetTimeFromTS(version)
+		}
+	}
+	j.Priority = calcAutoAnalyzePriority(j.ChangeRatio, j.RowCount, j.LastAnalyzeTime, j.LastUsedTime, now)
+}
+
+// lastAnalyzeVersion returns the version of the latest analyzed column or index.
+func lastAnalyzeVersion(statsTbl *statistics.Table) (version uint64) {
+	for _, col := range statsTbl.Columns {
+		if col.IsAnalyzed() && col.LastUpdateVersion > version {
+			version = col.LastUpdateVersion
+		}
+	}
+	for _, idx := range statsTbl.Indices {
+		if idx.IsAnalyzed() && idx.LastUpdateVersion > version {
+			version = idx.LastUpdateVersion
+		}
+	}
+	return version
+}
+
+// calcAutoAnalyzePriority scores an auto analyze job in [0, 1], the job with a higher score is analyzed first.
+// The table which is modified more, is larger, has been analyzed longer ago or is used by the queries more
+// recently gets a higher score.
+func calcAutoAnalyzePriority(changeRatio float64, rowCount int64, lastAnalyze, lastUsed, now time.Time) float64 {
+	// 900% modifications, 10 billion rows and 1000 hours since the last analyze reach the highest scores.
+	changeScore := math.Min(math.Log10(1+math.Max(changeRatio, 0)), 1)
+	sizeScore := math.Min(math.Log10(1+math.Max(float64(rowCount), 0))/10, 1)
+	staleScore := 1.0
+	if !lastAnalyze.IsZero() {
+		staleScore = math.Min(math.Log10(1+math.Max(now.Sub(lastAnalyze).Hours(), 0))/3, 1)
+	}
+	usageScore := 0.0
+	if !lastUsed.IsZero() {
+		usageScore = 1 / (1 + math.Max(now.Sub(lastUsed).Hours(), 0))
+	}
+	return changeRatioWeight*changeScore + tableSizeWeight*sizeScore + staleTimeWeight*staleScore + queryUsageWeight*usageScore
+}
+
+// autoAnalyzeJobHeap is a max heap of the auto analyze jobs ordered by the priority.
+type autoAnalyzeJobHeap []*autoAnalyzeJob
+
+func (h autoAnalyzeJobHeap) Len() int { return len(h) }
+
+func (h autoAnalyzeJobHeap) Less(i, j int) bool {
+	if h[i].Priority != h[j].Priority {
+		return h[i].Priority > h[j].Priority
+	}
+	return h[i].ID < h[j].ID
+}
+
+func (h autoAnalyzeJobHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *autoAnalyzeJobHeap) Push(x interface{}) {
+	*h = append(*h, x.(*autoAnalyzeJob))
+}
+
+func (h *autoAnalyzeJobHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	job := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return job
+}
+
+// autoAnalyzePriorityTolerance is the change of the priority which is ignored when persisting the queue. The priority
+// changes slightly in every round with the time, and it's not worth writing the job again for it.
+const autoAnalyzePriorityTolerance = 0.01
+
+// autoAnalyzeQueue keeps the state of the auto analyze priority queue across the rounds, and runs the jobs by a pool
+// of at most tidb_auto_analyze_concurrency workers. A worker takes the next pending job as soon as it finishes one,
+// and exits when there are no pending jobs.
+type autoAnalyzeQueue struct {
+	sync.Mutex
+	// loaded indicates whether the failures and the persisted jobs have been loaded from mysql.auto_analyze_queue,
+	// so that the backoff of the failed jobs survives the change of the stats owner.
+	loaded bool
+	// failures records the time of the last failure of the jobs.
+	failures map[int64]time.Time
+	// persisted records the jobs which should be in mysql.auto_analyze_queue, so that only the changed jobs are written.
+	persisted map[int64]autoAnalyzeJob
+	// dirty records the IDs of the jobs which are changed in persisted but not written yet.
+	dirty map[int64]struct{}
+	// flushMu is held by the only goroutine which writes the dirty jobs, see flushAutoAnalyzeQueue.
+	flushMu sync.Mutex
+	// pending are the jobs waiting for the workers, they're replaced in every round.
+	pending autoAnalyzeJobHeap
+	// running records the IDs of the running jobs.
+	running map[int64]struct{}
+	// workers is the number of the workers, wg waits for them to exit.
+	workers int
+	wg      sync.WaitGroup
+	// analyzeSnapshot is the value of tidb_enable_analyze_snapshot in the latest round.
+	analyzeSnapshot bool
+}
+
+// buildAutoAnalyzeQueue scores all the tables which need to be analyzed and returns them in a priority queue.
+func (h *Handle) buildAutoAnalyzeQueue(is infoschema.InfoSchema, ratio float64, pruneMode variable.PartitionPruneMode) *autoAnalyzeJobHeap {
+	now := time.Now()
+	lastUsed := h.loadTableLastUsedTime()
+	jobs := &autoAnalyzeJobHeap{}
+	for _, db := range is.AllSchemaNames() {
+		if util.IsMemOrSysDB(strings.ToLower(db)) {
+			continue
+		}
+		for _, tbl := range is.SchemaTables(model.NewCIStr(db)) {
+			tblInfo := tbl.Meta()
+			// If the table is locked, skip analyze.
+			if tblInfo.IsView() || h.IsTableLocked(tblInfo.ID) {
+				continue
+			}
+			pi := tblInfo.GetPartitionInfo()
+			if pi == nil {
+				statsTbl := h.GetTableStats(tblInfo)
+				if job := h.newAutoAnalyzeTableJob(tblInfo, statsTbl, ratio, "analyze table %n.%n", db, tblInfo.Name.O); job != nil {
+					job.init(tblInfo.ID, db, tblInfo.Name.O, "", statsTbl, lastUsed[tblInfo.ID], now)
+					*jobs = append(*jobs, job)
+				}
+				continue
+			}
+			if pruneMode == variable.Dynamic {
+				if job := h.newAutoAnalyzePartitionTableJob(tblInfo, pi, db, ratio); job != nil {
+					job.init(tblInfo.ID, db, tblInfo.Name.O, "", h.GetTableStats(tblInfo), lastUsed[tblInfo.ID], now)
+					*jobs = append(*jobs, job)
+				}
+				continue
+			}
+			for _, def := range pi.Definitions {
+				statsTbl := h.GetPartitionStats(tblInfo, def.ID)
+				if job := h.newAutoAnalyzeTableJob(tblInfo, statsTbl, ratio, "analyze table %n.%n partition %n", db, tblInfo.Name.O, def.Name.O); job != nil {
+					job.init(def.ID, db, tblInfo.Name.O, def.Name.O, statsTbl, lastUsed[tblInfo.ID], now)
+					*jobs = append(*jobs, job)
+				}
+			}
+		}
+	}
+	heap.Init(jobs)
+	return jobs
+}
+
+// loadTableLastUsedTime loads the last time the columns of each table were used by the queries.
+func (h *Handle) loadTableLastUsedTime() map[int64]time.Time {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+	// Since we use another session from session pool to read mysql.column_stats_usage, which may have different @@time_zone, so we do time zone conversion here.
+	rows, _, err := h.execRestrictedSQL(ctx, "SELECT table_id, CONVERT_TZ(MAX(last_used_at), @@TIME_ZONE, '+00:00') FROM mysql.column_stats_usage WHERE last_used_at IS NOT NULL GROUP BY table_id")
+	if err != nil {
+		logutil.BgLogger().Warn("[stats] load the last used time of tables failed", zap.Error(err))
+		return nil
+	}
+	lastUsed := make(map[int64]time.Time, len(rows))
+	for _, row := range rows {
+		if row.IsNull(1) {
+			continue
+		}
+		t, err := row.GetTime(1).GoTime(time.UTC)
+		if err != nil {
+			continue
+		}
+		lastUsed[row.GetInt64(0)] = t
+	}
+	return lastUsed
+}
+
+// dispatchAutoAnalyzeJobs replaces the pending jobs with the jobs in the queue, and starts the workers to run them
+// without waiting. The running jobs are not dispatched again, and the jobs which failed recently are skipped until
+// the backoff passes, so that a failing table can't block the others. Only the changed jobs are persisted into
+// mysql.auto_analyze_queue. It returns whether there are any jobs to run.
+func (h *Handle) dispatchAutoAnalyzeJobs(jobs *autoAnalyzeJobHeap, analyzeSnapshot bool) bool {
+	q := &h.autoAnalyzeQueue
+	q.Lock()
+	loaded := q.loaded
+	q.Unlock()
+	var (
+		failures  map[int64]time.Time
+		persisted map[int64]autoAnalyzeJob
+	)
+	if !loaded {
+		// The jobs are only dispatched by the auto analyze worker of the domain, so the queue can be loaded without the lock.
+		failures, persisted = h.loadAutoAnalyzeQueue()
+	}
+	hasJobs := h.replacePendingAutoAnalyzeJobs(jobs, analyzeSnapshot, failures, persisted)
+	h.flushAutoAnalyzeQueue()
+	return hasJobs
+}
+
+// replacePendingAutoAnalyzeJobs is the in-memory part of dispatchAutoAnalyzeJobs. The failures and the persisted jobs
+// are used if they are loaded from mysql.auto_analyze_queue.
+func (h *Handle) replacePendingAutoAnalyzeJobs(jobs *autoAnalyzeJobHeap, analyzeSnapshot bool, failures map[int64]time.Time, persisted map[int64]autoAnalyzeJob) bool {
+	q := &h.autoAnalyzeQueue
+	q.Lock()
+	defer q.Unlock()
+	if !q.loaded {
+		q.failures, q.persisted = failures, persisted
+		q.dirty = make(map[int64]struct{})
+		q.loaded = true
+	}
+	if q.running == nil {
+		q.running = make(map[int64]struct{})
+	}
+	backoff := 20 * h.Lease()
+	now := time.Now()
+	all := make([]*autoAnalyzeJob, 0, jobs.Len())
+	pending := make(autoAnalyzeJobHeap, 0, jobs.Len())
+	for jobs.Len() > 0 {
+		job := heap.Pop(jobs).(*autoAnalyzeJob)
+		if _, ok := q.running[job.ID]; ok {
+			continue
+		}
+		all = append(all, job)
+		if failedTime, ok := q.failures[job.ID]; ok {
+			job.LastFailedTime = failedTime
+			if now.Sub(failedTime) < backoff {
+				job.State = AutoAnalyzeJobFailed
+				continue
+			}
+		}
+		pending = append(pending, job)
+	}
+	heap.Init(&pending)
+	q.pending = pending
+	q.analyzeSnapshot = analyzeSnapshot
+	changed, removed := diffAutoAnalyzeQueue(q.persisted, all, q.running)
+	for _, job := range changed {
+		q.markPersisted(job)
+	}
+	for _, id := range removed {
+		q.markRemoved(id)
+	}
+	concurrency := int(variable.AutoAnalyzeConcurrency.Load())
+	for n := len(q.running) + q.pending.Len(); q.workers < concurrency && q.workers < n; {
+		q.workers++
+		q.wg.Add(1)
+		go h.autoAnalyzeWorker()
+	}
+	return q.pending.Len() > 0
+}
+
+// markPersisted records the job to be written into mysql.auto_analyze_queue, the lock must be held.
+func (q *autoAnalyzeQueue) markPersisted(job *autoAnalyzeJob) {
+	q.persisted[job.ID] = *job
+	q.dirty[job.ID] = struct{}{}
+}
+
+// markRemoved records the job to be deleted from mysql.auto_analyze_queue, the lock must be held.
+func (q *autoAnalyzeQueue) markRemoved(id int64) {
+	delete(q.persisted, id)
+	q.dirty[id] = struct{}{}
+}
+
+// flushAutoAnalyzeQueue writes the dirty jobs into mysql.auto_analyze_queue. The jobs are copied out under the lock
+// and written without holding it, so that the workers don't wait for each other's writes. Only one goroutine writes
+// at a time, and it keeps writing until there are no dirty jobs, so the latest state of a job is always written last.
+// Others just return since their jobs will be written by it.
+func (h *Handle) flushAutoAnalyzeQueue() {
+	q := &h.autoAnalyzeQueue
+	for q.flushMu.TryLock() {
+		q.Lock()
+		if len(q.dirty) == 0 {
+			// Unlock flushMu under the lock, so that the jobs marked dirty later are flushed by the goroutine marking them.
+			q.flushMu.Unlock()
+			q.Unlock()
+			return
+		}
+		upserted := make([]*autoAnalyzeJob, 0, len(q.dirty))
+		removed := make([]int64, 0, len(q.dirty))
+		for id := range q.dirty {
+			if job, ok := q.persisted[id]; ok {
+				upserted = append(upserted, &job)
+			} else {
+				removed = append(removed, id)
+			}
+		}
+		q.dirty = make(map[int64]struct{})
+		q.Unlock()
+
+		slices.SortFunc(upserted, func(a, b *autoAnalyzeJob) bool { return a.ID < b.ID })
+		slices.Sort(removed)
+		var failed []int64
+		if err := h.upsertAutoAnalyzeJobs(upserted); err != nil {
+			logutil.BgLogger().Warn("[stats] save auto analyze queue failed", zap.Error(err))
+			for _, job := range upserted {
+				failed = append(failed, job.ID)
+			}
+		}
+		if err := h.deleteAutoAnalyzeJobs(removed); err != nil {
+			logutil.BgLogger().Warn("[stats] save auto analyze queue failed", zap.Error(err))
+			failed = append(failed, removed...)
+		}
+		if len(failed) > 0 {
+			// Write the failed jobs in the next flush instead of retrying right now.
+			q.Lock()
+			for _, id := range failed {
+				q.dirty[id] = struct{}{}
+			}
+			q.Unlock()
+			q.flushMu.Unlock()
+			return
+		}
+		q.flushMu.Unlock()
+	}
+}
+
+// ClearAutoAnalyzeJobs discards the pending auto analyze jobs, the running jobs are not interrupted. It's called when
+// the auto analyze is disabled or the instance is no longer the stats owner.
+func (h *Handle) ClearAutoAnalyzeJobs() {
+	q := &h.autoAnalyzeQueue
+	q.Lock()
+	defer q.Unlock()
+	q.pending = nil
+	// The persisted queue may be changed by the next stats owner, reload it when the jobs are dispatched again.
+	q.loaded = false
+}
+
+// WaitAutoAnalyzeJobs waits until all the dispatched auto analyze jobs are finished.
+func (h *Handle) WaitAutoAnalyzeJobs() {
+	h.autoAnalyzeQueue.wg.Wait()
+}
+
+// autoAnalyzeWorker runs the pending jobs one by one until there are no pending jobs.
+func (h *Handle) autoAnalyzeWorker() {
+	q := &h.autoAnalyzeQueue
+	defer q.wg.Done()
+	for {
+		job, analyzeSnapshot := h.nextAutoAnalyzeJob()
+		if job == nil {
+			return
+		}
+		h.flushAutoAnalyzeQueue()
+		util.WithRecovery(func() {
+			h.runAutoAnalyzeJob(job, analyzeSnapshot)
+		}, func(r interface{}) {
+			if r != nil {
+				logutil.BgLogger().Error("[stats] auto analyze job panicked", zap.Any("error", r), zap.Stack("stack"))
+			}
+		})
+		h.flushAutoAnalyzeQueue()
+	}
+}
+
+// nextAutoAnalyzeJob pops the pending job with the highest priority and marks it running. It returns nil and
+// the worker exits if there are no pending jobs, or there are more workers than tidb_auto_analyze_concurrency.
+func (h *Handle) nextAutoAnalyzeJob() (*autoAnalyzeJob, bool) {
+	q := &h.autoAnalyzeQueue
+	q.Lock()
+	defer q.Unlock()
+	if q.pending.Len() == 0 || q.workers > int(variable.AutoAnalyzeConcurrency.Load()) {
+		q.workers--
+		return nil, false
+	}
+	job := heap.Pop(&q.pending).(*autoAnalyzeJob)
+	job.State = AutoAnalyzeJobRunning
+	q.running[job.ID] = struct{}{}
+	q.markPersisted(job)
+	return job, q.analyzeSnapshot
+}
+
+// loadAutoAnalyzeQueue loads the persisted jobs and the failures of them from mysql.auto_analyze_queue.
+func (h *Handle) loadAutoAnalyzeQueue() (map[int64]time.Time, map[int64]autoAnalyzeJob) {
+	failures := make(map[int64]time.Time)
+	persisted := make(map[int64]autoAnalyzeJob)
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+	rows, _, err := h.execRestrictedSQL(ctx, "SELECT table_id, CONVERT_TZ(last_failed_time, @@TIME_ZONE, '+00:00') FROM mysql.auto_analyze_queue")
+	if err != nil {
+		logutil.BgLogger().Warn("[stats] load auto analyze queue failed", zap.Error(err))
+		return failures, persisted
+	}
+	for _, row := range rows {
+		id := row.GetInt64(0)
+		// Only the ID is loaded, so the job is written again in the next round unless it leaves the queue.
+		persisted[id] = autoAnalyzeJob{ID: id}
+		if row.IsNull(1) {
+			continue
+		}
+		if t, err := row.GetTime(1).GoTime(time.UTC); err == nil {
+			failures[id] = t
+		}
+	}
+	return failures, persisted
+}
+
+// diffAutoAnalyzeQueue compares the jobs with the persisted ones. It returns the jobs which are new or changed, and
+// the IDs of the persisted jobs which have left the queue. The running jobs are kept.
+func diffAutoAnalyzeQueue(persisted map[int64]autoAnalyzeJob, jobs []*autoAnalyzeJob, running map[int64]struct{}) (changed []*autoAnalyzeJob, removed []int64) {
+	ids := make(map[int64]struct{}, len(jobs))
+	for _, job := range jobs {
+		ids[job.ID] = struct{}{}
+		if old, ok := persisted[job.ID]; !ok || !old.sameRow(job) {
+			changed = append(changed, job)
+		}
+	}
+	for id := range persisted {
+		_, inQueue := ids[id]
+		_, isRunning := running[id]
+		if !inQueue && !isRunning {
+			removed = append(removed, id)
+		}
+	}
+	slices.Sort(removed)
+	return changed, removed
+}
+
+// sameRow checks whether the persisted row of the job is unchanged.
+func (j *autoAnalyzeJob) sameRow(other *autoAnalyzeJob) bool {
+	return j.DBName == other.DBName && j.TableName == other.TableName && j.PartitionName == other.PartitionName &&
+		j.Reason == other.Reason && j.State == other.State && j.RowCount == other.RowCount &&
+		math.Abs(j.Priority-other.Priority) < autoAnalyzePriorityTolerance &&
+		math.Abs(j.ChangeRatio-other.ChangeRatio) < autoAnalyzePriorityTolerance &&
+		j.LastAnalyzeTime.Equal(other.LastAnalyzeTime) && j.LastUsedTime.Equal(other.LastUsedTime) &&
+		j.LastFailedTime.Equal(other.LastFailedTime)
+}
+
+// upsertAutoAnalyzeJobs writes the jobs into mysql.auto_analyze_queue in batches, each of which is committed
+// separately, so a large queue doesn't make a large transaction.
+func (h *Handle) upsertAutoAnalyzeJobs(changed []*autoAnalyzeJob) error {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+	toUTCString := func(t time.Time) interface{} {
+		if t.IsZero() {
+			return nil
+		}
+		return t.UTC().Format(types.TimeFormat)
+	}
+	// Use batch insert to reduce cost.
+	for i := 0; i < len(changed); i += batchInsertSize {
+		end := i + batchInsertSize
+		if end > len(changed) {
+			end = len(changed)
+		}
+		sql := new(strings.Builder)
+		sqlexec.MustFormatSQL(sql, "INSERT INTO mysql.auto_analyze_queue (table_id, table_schema, table_name, partition_name, priority, change_ratio, row_count, last_analyze_time, last_used_time, last_failed_time, reason, state) VALUES ")
+		for j := i; j < end; j++ {
+			job := changed[j]
+			// The time is passed in UTC and converted to the time zone of the session, so that the stored time is right.
+			sqlexec.MustFormatSQL(sql, "(%?, %?, %?, %?, %?, %?, %?, CONVERT_TZ(%?, '+00:00', @@TIME_ZONE), CONVERT_TZ(%?, '+00:00', @@TIME_ZONE), CONVERT_TZ(%?, '+00:00', @@TIME_ZONE), %?, %?)",
+				job.ID, job.DBName, job.TableName, job.PartitionName, job.Priority, job.ChangeRatio, job.RowCount,
+				toUTCString(job.LastAnalyzeTime), toUTCString(job.LastUsedTime), toUTCString(job.LastFailedTime), job.Reason, job.State)
+			if j < end-1 {
+				sqlexec.MustFormatSQL(sql, ",")
+			}
+		}
+		sqlexec.MustFormatSQL(sql, " ON DUPLICATE KEY UPDATE table_schema = VALUES(table_schema), table_name = VALUES(table_name), partition_name = VALUES(partition_name), priority = VALUES(priority), change_ratio = VALUES(change_ratio), row_count = VALUES(row_count), last_analyze_time = VALUES(last_analyze_time), last_used_time = VALUES(last_used_time), last_failed_time = VALUES(last_failed_time), reason = VALUES(reason), state = VALUES(state)")
+		if _, _, err := h.execRestrictedSQL(ctx, sql.String()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// deleteAutoAnalyzeJobs deletes the jobs which have left the queue from mysql.auto_analyze_queue.
+func (h *Handle) deleteAutoAnalyzeJobs(removed []int64) error {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+	for i := 0; i < len(removed); i += batchInsertSize {
+		end := i + batchInsertSize
+		if end > len(removed) {
+			end = len(removed)
+		}
+		if _, _, err := h.execRestrictedSQL(ctx, "DELETE FROM mysql.auto_analyze_queue WHERE table_id IN (%?)", removed[i:end]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// runAutoAnalyzeJob runs the analyze statements of the job and marks the job to be removed from or updated in the
+// persisted queue, which is written by flushAutoAnalyzeQueue.
+func (h *Handle) runAutoAnalyzeJob(job *autoAnalyzeJob, analyzeSnapshot bool) {
+	var err error
+	for _, stmt := range job.stmts {
+		escaped, err1 := sqlexec.EscapeSQL(stmt.sql, stmt.params...)
+		if err1 != nil {
+			err = err1
+			break
+		}
+		logutil.BgLogger().Info("[stats] auto analyze triggered", zap.String("sql", escaped),
+			zap.String("reason", job.Reason), zap.Float64("priority", job.Priority))
+		if err1 := h.execAutoAnalyze(job.statsVer, analyzeSnapshot, stmt.sql, stmt.params...); err1 != nil {
+			err = err1
+		}
+	}
+
+	q := &h.autoAnalyzeQueue
+	q.Lock()
+	defer q.Unlock()
+	delete(q.running, job.ID)
+	if err == nil {
+		delete(q.failures, job.ID)
+		q.markRemoved(job.ID)
+		return
+	}
+	now := time.Now()
+	q.failures[job.ID] = now
+	job.State, job.LastFailedTime = AutoAnalyzeJobFailed, now
+	q.markPersisted(job)
+}
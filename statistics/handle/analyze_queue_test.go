@@ -0,0 +1,86 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handle
+
+import (
+	"container/heap"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcAutoAnalyzePriority(t *testing.T) {
+	now := time.Now()
+	base := calcAutoAnalyzePriority(0.5, 10000, now.Add(-time.Hour), time.Time{}, now)
+	require.Greater(t, base, 0.0)
+	require.LessOrEqual(t, base, 1.0)
+
+	// More modifications.
+	require.Greater(t, calcAutoAnalyzePriority(2, 10000, now.Add(-time.Hour), time.Time{}, now), base)
+	// Larger table.
+	require.Greater(t, calcAutoAnalyzePriority(0.5, 1000000, now.Add(-time.Hour), time.Time{}, now), base)
+	// Analyzed longer ago.
+	require.Greater(t, calcAutoAnalyzePriority(0.5, 10000, now.Add(-24*time.Hour), time.Time{}, now), base)
+	// Never analyzed.
+	require.Greater(t, calcAutoAnalyzePriority(0.5, 10000, time.Time{}, time.Time{}, now), base)
+	// Used by the queries recently.
+	require.Greater(t, calcAutoAnalyzePriority(0.5, 10000, now.Add(-time.Hour), now, now), base)
+	require.Greater(t, calcAutoAnalyzePriority(0.5, 10000, now.Add(-time.Hour), now, now),
+		calcAutoAnalyzePriority(0.5, 10000, now.Add(-time.Hour), now.Add(-time.Hour), now))
+
+	// All the scores are capped.
+	require.InDelta(t, 1.0, calcAutoAnalyzePriority(1000, 1<<62, time.Time{}, now, now), 1e-9)
+	require.InDelta(t, 0.0, calcAutoAnalyzePriority(0, 0, now, time.Time{}, now), 1e-9)
+}
+
+func TestAutoAnalyzeJobHeap(t *testing.T) {
+	jobs := &autoAnalyzeJobHeap{}
+	for i, priority := range []float64{0.3, 0.9, 0.1, 0.9, 0.5} {
+		heap.Push(jobs, &autoAnalyzeJob{ID: int64(i + 1), Priority: priority})
+	}
+	var ids []int64
+	for jobs.Len() > 0 {
+		ids = append(ids, heap.Pop(jobs).(*autoAnalyzeJob).ID)
+	}
+	// The jobs with the same priority are ordered by the ID.
+	require.Equal(t, []int64{2, 4, 5, 1, 3}, ids)
+}
+
+func TestDiffAutoAnalyzeQueue(t *testing.T) {
+	now := time.Now()
+	persisted := map[int64]autoAnalyzeJob{
+		1: {ID: 1, TableName: "t1", State: AutoAnalyzeJobPending, Priority: 0.5},
+		2: {ID: 2, TableName: "t2", State: AutoAnalyzeJobPending, Priority: 0.5},
+		3: {ID: 3, TableName: "t3", State: AutoAnalyzeJobRunning, Priority: 0.5},
+		4: {ID: 4, TableName: "t4", State: AutoAnalyzeJobPending, Priority: 0.5},
+		5: {ID: 5, TableName: "t5", State: AutoAnalyzeJobPending, Priority: 0.5},
+	}
+	jobs := []*autoAnalyzeJob{
+		// The slight change of the priority is ignored.
+		{ID: 1, TableName: "t1", State: AutoAnalyzeJobPending, Priority: 0.501},
+		{ID: 2, TableName: "t2", State: AutoAnalyzeJobPending, Priority: 0.6},
+		{ID: 4, TableName: "t4", State: AutoAnalyzeJobFailed, Priority: 0.5, LastFailedTime: now},
+		{ID: 6, TableName: "t6", State: AutoAnalyzeJobPending, Priority: 0.5},
+	}
+	changed, removed := diffAutoAnalyzeQueue(persisted, jobs, map[int64]struct{}{3: {}})
+	ids := make([]int64, 0, len(changed))
+	for _, job := range changed {
+		ids = append(ids, job.ID)
+	}
+	require.Equal(t, []int64{2, 4, 6}, ids)
+	// The running job is kept.
+	require.Equal(t, []int64{5}, removed)
+}
@@ -119,6 +119,9 @@ type Handle struct {
 	// StatsLoad is used to load stats concurrently
 	StatsLoad StatsLoad
 
+	// autoAnalyzeQueue keeps the state of the auto analyze priority queue.
+	autoAnalyzeQueue autoAnalyzeQueue
+
 	mu struct {
 		ctx sessionctx.Context
 		// rateMap contains the error rate delta from feedback.
@@ -388,6 +388,7 @@ func TestAutoUpdate(t *testing.T) {
 		require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
 		require.NoError(t, h.Update(is))
 		h.HandleAutoAnalyze(is)
+		h.WaitAutoAnalyzeJobs()
 		require.NoError(t, h.Update(is))
 		stats = h.GetTableStats(tableInfo)
 		require.Equal(t, int64(5), stats.RealtimeCount)
@@ -406,6 +407,7 @@ func TestAutoUpdate(t *testing.T) {
 		require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
 		require.NoError(t, h.Update(is))
 		h.HandleAutoAnalyze(is)
+		h.WaitAutoAnalyzeJobs()
 		require.NoError(t, h.Update(is))
 		stats = h.GetTableStats(tableInfo)
 		require.Equal(t, int64(6), stats.RealtimeCount)
@@ -416,6 +418,7 @@ func TestAutoUpdate(t *testing.T) {
 		require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
 		require.NoError(t, h.Update(is))
 		h.HandleAutoAnalyze(is)
+		h.WaitAutoAnalyzeJobs()
 		require.NoError(t, h.Update(is))
 		stats = h.GetTableStats(tableInfo)
 		require.Equal(t, int64(7), stats.RealtimeCount)
@@ -426,6 +429,7 @@ func TestAutoUpdate(t *testing.T) {
 		require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
 		require.NoError(t, h.Update(is))
 		h.HandleAutoAnalyze(is)
+		h.WaitAutoAnalyzeJobs()
 		require.NoError(t, h.Update(is))
 		stats = h.GetTableStats(tableInfo)
 		require.Equal(t, int64(8), stats.RealtimeCount)
@@ -445,6 +449,7 @@ func TestAutoUpdate(t *testing.T) {
 		require.NoError(t, err)
 		tableInfo = tbl.Meta()
 		h.HandleAutoAnalyze(is)
+		h.WaitAutoAnalyzeJobs()
 		require.NoError(t, h.Update(is))
 		testKit.MustExec("explain select * from t where a > 'a'")
 		require.NoError(t, h.LoadNeededHistograms())
@@ -492,6 +497,7 @@ func TestAutoUpdatePartition(t *testing.T) {
 		require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
 		require.NoError(t, h.Update(is))
 		h.HandleAutoAnalyze(is)
+		h.WaitAutoAnalyzeJobs()
 		stats = h.GetPartitionStats(tableInfo, pi.Definitions[0].ID)
 		require.Equal(t, int64(1), stats.RealtimeCount)
 		require.Equal(t, int64(0), stats.ModifyCount)
@@ -515,6 +521,7 @@ func TestAutoAnalyzeOnEmptyTable(t *testing.T) {
 	tk.MustExec(fmt.Sprintf("set global tidb_auto_analyze_start_time='%v'", start))
 	tk.MustExec(fmt.Sprintf("set global tidb_auto_analyze_end_time='%v'", end))
 	dom.StatsHandle().HandleAutoAnalyze(dom.InfoSchema())
+	dom.StatsHandle().WaitAutoAnalyzeJobs()
 
 	tk.MustExec("use test")
 	tk.MustExec("create table t (a int, index idx(a))")
@@ -527,10 +534,12 @@ func TestAutoAnalyzeOnEmptyTable(t *testing.T) {
 
 	// test if it will be limited by the time range
 	require.False(t, dom.StatsHandle().HandleAutoAnalyze(dom.InfoSchema()))
+	dom.StatsHandle().WaitAutoAnalyzeJobs()
 
 	tk.MustExec("set global tidb_auto_analyze_start_time='00:00 +0000'")
 	tk.MustExec("set global tidb_auto_analyze_end_time='23:59 +0000'")
 	require.True(t, dom.StatsHandle().HandleAutoAnalyze(dom.InfoSchema()))
+	dom.StatsHandle().WaitAutoAnalyzeJobs()
 }
 
 func TestAutoAnalyzeOutOfSpecifiedTime(t *testing.T) {
@@ -550,6 +559,7 @@ func TestAutoAnalyzeOutOfSpecifiedTime(t *testing.T) {
 	tk.MustExec(fmt.Sprintf("set global tidb_auto_analyze_start_time='%v'", start))
 	tk.MustExec(fmt.Sprintf("set global tidb_auto_analyze_end_time='%v'", end))
 	dom.StatsHandle().HandleAutoAnalyze(dom.InfoSchema())
+	dom.StatsHandle().WaitAutoAnalyzeJobs()
 
 	tk.MustExec("use test")
 	tk.MustExec("create table t (a int)")
@@ -561,14 +571,17 @@ func TestAutoAnalyzeOutOfSpecifiedTime(t *testing.T) {
 	require.NoError(t, dom.StatsHandle().Update(dom.InfoSchema()))
 
 	require.False(t, dom.StatsHandle().HandleAutoAnalyze(dom.InfoSchema()))
+	dom.StatsHandle().WaitAutoAnalyzeJobs()
 	tk.MustExec("analyze table t")
 
 	tk.MustExec("alter table t add index ia(a)")
 	require.False(t, dom.StatsHandle().HandleAutoAnalyze(dom.InfoSchema()))
+	dom.StatsHandle().WaitAutoAnalyzeJobs()
 
 	tk.MustExec("set global tidb_auto_analyze_start_time='00:00 +0000'")
 	tk.MustExec("set global tidb_auto_analyze_end_time='23:59 +0000'")
 	require.True(t, dom.StatsHandle().HandleAutoAnalyze(dom.InfoSchema()))
+	dom.StatsHandle().WaitAutoAnalyzeJobs()
 }
 
 func TestIssue25700(t *testing.T) {
@@ -592,6 +605,7 @@ func TestIssue25700(t *testing.T) {
 	require.NoError(t, dom.StatsHandle().Update(dom.InfoSchema()))
 
 	require.True(t, dom.StatsHandle().HandleAutoAnalyze(dom.InfoSchema()))
+	dom.StatsHandle().WaitAutoAnalyzeJobs()
 	require.Equal(t, "finished", tk.MustQuery("show analyze status").Rows()[1][7])
 }
 
@@ -617,6 +631,7 @@ func TestAutoAnalyzeOnChangeAnalyzeVer(t *testing.T) {
 	require.NoError(t, h.Update(is))
 	// Auto analyze when global ver is 1.
 	h.HandleAutoAnalyze(is)
+	h.WaitAutoAnalyzeJobs()
 	require.NoError(t, h.Update(is))
 	tbl, err := is.TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
 	require.NoError(t, err)
@@ -636,6 +651,7 @@ func TestAutoAnalyzeOnChangeAnalyzeVer(t *testing.T) {
 	require.NoError(t, h.Update(is))
 	// Auto analyze t whose version is 1 after setting global ver to 2.
 	h.HandleAutoAnalyze(is)
+	h.WaitAutoAnalyzeJobs()
 	require.NoError(t, h.Update(is))
 	statsTbl1 = h.GetTableStats(tbl.Meta())
 	require.Equal(t, int64(5), statsTbl1.RealtimeCount)
@@ -657,6 +673,7 @@ func TestAutoAnalyzeOnChangeAnalyzeVer(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, h.Update(is))
 	h.HandleAutoAnalyze(is)
+	h.WaitAutoAnalyzeJobs()
 	require.NoError(t, h.Update(is))
 	statsTbl2 := h.GetTableStats(tbl2.Meta())
 	// Since it's a newly created table. Auto analyze should analyze it's statistics to version2.
@@ -1964,6 +1981,7 @@ func BenchmarkHandleAutoAnalyze(b *testing.B) {
 	is := dom.InfoSchema()
 	for i := 0; i < b.N; i++ {
 		h.HandleAutoAnalyze(is)
+		h.WaitAutoAnalyzeJobs()
 	}
 }
 
@@ -2171,6 +2189,7 @@ func TestAutoUpdatePartitionInDynamicOnlyMode(t *testing.T) {
 		require.Equal(t, int64(1), partitionStats.ModifyCount)
 
 		h.HandleAutoAnalyze(is)
+		h.WaitAutoAnalyzeJobs()
 		require.NoError(t, h.Update(is))
 		globalStats = h.GetTableStats(tableInfo)
 		partitionStats = h.GetPartitionStats(tableInfo, pi.Definitions[0].ID)
@@ -2222,18 +2241,21 @@ func TestAutoAnalyzeRatio(t *testing.T) {
 	require.NoError(t, h.Update(is))
 	require.Equal(t, getStatsHealthy(), 44)
 	require.True(t, h.HandleAutoAnalyze(is))
+	h.WaitAutoAnalyzeJobs()
 
 	tk.MustExec("delete from t limit 12")
 	require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
 	require.NoError(t, h.Update(is))
 	require.Equal(t, getStatsHealthy(), 61)
 	require.False(t, h.HandleAutoAnalyze(is))
+	h.WaitAutoAnalyzeJobs()
 
 	tk.MustExec("delete from t limit 4")
 	require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
 	require.NoError(t, h.Update(is))
 	require.Equal(t, getStatsHealthy(), 48)
 	require.True(t, h.HandleAutoAnalyze(dom.InfoSchema()))
+	h.WaitAutoAnalyzeJobs()
 }
 
 func TestDumpColumnStatsUsage(t *testing.T) {
@@ -2441,6 +2463,7 @@ func TestStatsLockUnlockForAutoAnalyze(t *testing.T) {
 	require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
 	require.NoError(t, h.Update(is))
 	require.True(t, h.HandleAutoAnalyze(is))
+	h.WaitAutoAnalyzeJobs()
 
 	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
 	require.Nil(t, err)
@@ -2456,6 +2479,7 @@ func TestStatsLockUnlockForAutoAnalyze(t *testing.T) {
 	require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
 	require.NoError(t, h.Update(is))
 	require.False(t, h.HandleAutoAnalyze(is))
+	h.WaitAutoAnalyzeJobs()
 
 	tblStats1 := h.GetTableStats(tbl.Meta())
 	require.Equal(t, tblStats, tblStats1)
@@ -2729,6 +2753,7 @@ func TestAutoAnalyzePartitionTableAfterAddingIndex(t *testing.T) {
 	tk.MustExec("set session tidb_partition_prune_mode = 'dynamic'")
 	tk.MustExec("analyze table t")
 	require.False(t, h.HandleAutoAnalyze(dom.InfoSchema()))
+	h.WaitAutoAnalyzeJobs()
 	tk.MustExec("alter table t add index idx(a)")
 	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
 	require.NoError(t, err)
@@ -2736,5 +2761,68 @@ func TestAutoAnalyzePartitionTableAfterAddingIndex(t *testing.T) {
 	idxInfo := tblInfo.Indices[0]
 	require.Nil(t, h.GetTableStats(tblInfo).Indices[idxInfo.ID])
 	require.True(t, h.HandleAutoAnalyze(dom.InfoSchema()))
+	h.WaitAutoAnalyzeJobs()
 	require.NotNil(t, h.GetTableStats(tblInfo).Indices[idxInfo.ID])
 }
+
+func TestAutoAnalyzeQueue(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+
+	oriStart := tk.MustQuery("select @@tidb_auto_analyze_start_time").Rows()[0][0].(string)
+	oriEnd := tk.MustQuery("select @@tidb_auto_analyze_end_time").Rows()[0][0].(string)
+	handle.AutoAnalyzeMinCnt = 0
+	defer func() {
+		handle.AutoAnalyzeMinCnt = 1000
+		tk.MustExec(fmt.Sprintf("set global tidb_auto_analyze_start_time='%v'", oriStart))
+		tk.MustExec(fmt.Sprintf("set global tidb_auto_analyze_end_time='%v'", oriEnd))
+		tk.MustExec("set global tidb_auto_analyze_concurrency = default")
+	}()
+	tk.MustExec("set global tidb_auto_analyze_start_time='00:00 +0000'")
+	tk.MustExec("set global tidb_auto_analyze_end_time='23:59 +0000'")
+	tk.MustQuery("select @@global.tidb_auto_analyze_concurrency").Check(testkit.Rows("1"))
+	tk.MustExec("set global tidb_auto_analyze_concurrency = 0")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1292 Truncated incorrect tidb_auto_analyze_concurrency value: '0'"))
+	tk.MustQuery("select @@global.tidb_auto_analyze_concurrency").Check(testkit.Rows("1"))
+
+	h := dom.StatsHandle()
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (a int)")
+	require.NoError(t, h.HandleDDLEvent(<-h.DDLEventCh()))
+	tk.MustExec("create table t2 (a int)")
+	require.NoError(t, h.HandleDDLEvent(<-h.DDLEventCh()))
+	tk.MustExec("insert into t1 values (1)" + strings.Repeat(", (1)", 9))
+	tk.MustExec("insert into t2 values (1)" + strings.Repeat(", (1)", 9))
+	require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
+	tk.MustExec("analyze table t1, t2")
+	is := dom.InfoSchema()
+
+	// Both tables need to be analyzed, but t1 is modified more. The only worker analyzes t1 first, then takes t2
+	// without waiting for the next round.
+	tk.MustExec("insert into t1 values (1)" + strings.Repeat(", (1)", 29))
+	tk.MustExec("insert into t2 values (1)" + strings.Repeat(", (1)", 9))
+	require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
+	require.NoError(t, h.Update(is))
+	require.True(t, h.HandleAutoAnalyze(is))
+	h.WaitAutoAnalyzeJobs()
+	require.NoError(t, h.Update(is))
+	tk.MustQuery("select count(*) from information_schema.auto_analyze_queue").Check(testkit.Rows("0"))
+	tk.MustQuery("select table_name from mysql.analyze_jobs where job_info like 'auto analyze%' order by id").
+		Check(testkit.Rows("t1", "t2"))
+	tk.MustQuery("select count(*) from mysql.stats_meta where modify_count > 0 and table_id in (select tidb_table_id from information_schema.tables where table_schema = 'test')").
+		Check(testkit.Rows("0"))
+
+	// Both tables are analyzed in one round.
+	tk.MustExec("set global tidb_auto_analyze_concurrency = 2")
+	tk.MustExec("insert into t1 values (1)" + strings.Repeat(", (1)", 39))
+	require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
+	require.NoError(t, h.Update(is))
+	require.True(t, h.HandleAutoAnalyze(is))
+	h.WaitAutoAnalyzeJobs()
+	require.NoError(t, h.Update(is))
+	tk.MustQuery("select count(*) from information_schema.auto_analyze_queue").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(*) from mysql.stats_meta where modify_count > 0 and table_id in (select tidb_table_id from information_schema.tables where table_schema = 'test')").
+		Check(testkit.Rows("0"))
+	require.False(t, h.HandleAutoAnalyze(is))
+	h.WaitAutoAnalyzeJobs()
+}
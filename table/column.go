@@ -300,6 +300,11 @@ func CastValue(ctx sessionctx.Context, val types.Datum, col *model.ColumnInfo, r
 	if returnErr && err != nil {
 		return casted, err
 	}
+	if err == nil && col.GetType() == mysql.TypeGeometry && !casted.IsNull() {
+		if err = checkGeometrySRID(casted, col); err != nil {
+			return casted, err
+		}
+	}
 	if err != nil && types.ErrTruncated.Equal(err) && col.GetType() != mysql.TypeSet && col.GetType() != mysql.TypeEnum {
 		str, err1 := val.ToString()
 		if err1 != nil {
@@ -337,6 +342,19 @@ func CastValue(ctx sessionctx.Context, val types.Datum, col *model.ColumnInfo, r
 	return casted, err
 }
 
+// checkGeometrySRID checks whether the SRID of the geometry is the same as the
+// SRID restriction of the spatial column.
+func checkGeometrySRID(val types.Datum, col *model.ColumnInfo) error {
+	colSRID, ok := col.FieldType.GetSRID()
+	if !ok {
+		return nil
+	}
+	if srid := types.GeometrySRID(val.GetBytes()); srid != colSRID {
+		return types.ErrWrongSRIDForColumn.GenWithStackByArgs(col.Name.O, srid, colSRID)
+	}
+	return nil
+}
+
 // ColDesc describes column information like MySQL desc and show columns do.
 type ColDesc struct {
 	Field string
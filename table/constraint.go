@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/dbterror"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/mock"
@@ -86,6 +87,16 @@ func ToConstraint(constraintInfo *model.ConstraintInfo, tblInfo *model.TableInfo
 	}, nil
 }
 
+// ViolatedError returns the error of the row violating the check constraint. If
+// the constraint is JSON_SCHEMA_VALID, the reason of the validation failure is
+// appended as a warning.
+func (c *Constraint) ViolatedError(sctx sessionctx.Context, row chunk.Row) error {
+	if reason, ok := expression.JSONSchemaValidationFailure(c.ConstraintExpr, row); ok {
+		sctx.GetSessionVars().StmtCtx.AppendWarning(types.ErrJSONSchemaValidationErrorWithDetailedReport.FastGenByArgs(reason))
+	}
+	return ErrCheckConstraintViolated.FastGenByArgs(c.Name.O)
+}
+
 func buildConstraintExpression(ctx sessionctx.Context, exprString string,
 	columns []*expression.Column, names types.NameSlice) (expression.Expression, error) {
 	schema := expression.NewSchema(columns...)
@@ -48,6 +48,7 @@ import (
 	"github.com/pingcap/tidb/util/ranger"
 	"github.com/pingcap/tidb/util/stringutil"
 	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -96,6 +97,10 @@ type partitionedTable struct {
 	evalBufferTypes []*types.FieldType
 	evalBufferPool  sync.Pool
 
+	// subPartitionExpr is only set for subpartitioned tables, it locates the
+	// subpartition in the partition located by partitionExpr.
+	subPartitionExpr *PartitionExpr
+
 	// Only used during Reorganize partition
 	// reorganizePartitions is the currently used partitions that are reorganized
 	reorganizePartitions map[int64]interface{}
@@ -117,6 +122,12 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Part
 		return nil, errors.Trace(err)
 	}
 	ret.partitionExpr = partitionExpr
+	if pi.Sub != nil {
+		ret.subPartitionExpr, err = newSubPartitionExpr(tblInfo)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	initEvalBufferType(ret)
 	ret.evalBufferPool = sync.Pool{
 		New: func() interface{} {
@@ -236,6 +247,15 @@ func newPartitionExpr(tblInfo *model.TableInfo, defs []model.PartitionDefinition
 		return nil, err
 	}
 	pi := tblInfo.GetPartitionInfo()
+	if pi.Sub != nil {
+		// For subpartitioned tables, the expression locates the partition, which is
+		// a group of subpartitions, so it's built on the first level partitioning.
+		defs = pi.GetPartitionDefinitions(defs)
+		partTblInfo := *tblInfo
+		partTblInfo.Partition = pi.PartitionLevel()
+		tblInfo = &partTblInfo
+		pi = tblInfo.Partition
+	}
 	switch pi.Type {
 	case model.PartitionTypeRange:
 		return generateRangePartitionExpr(ctx, pi, defs, columns, names)
@@ -249,6 +269,22 @@ func newPartitionExpr(tblInfo *model.TableInfo, defs []model.PartitionDefinition
 	panic("cannot reach here")
 }
 
+// newSubPartitionExpr builds the expression which locates the subpartition of a
+// row in its partition, the caller should assure the table is subpartitioned.
+func newSubPartitionExpr(tblInfo *model.TableInfo) (*PartitionExpr, error) {
+	ctx := mock.NewContext()
+	dbName := model.NewCIStr(ctx.GetSessionVars().CurrentDB)
+	columns, names, err := expression.ColumnInfos2ColumnsAndNames(ctx, dbName, tblInfo.Name, tblInfo.Cols(), tblInfo)
+	if err != nil {
+		return nil, err
+	}
+	pi := tblInfo.GetPartitionInfo().SubPartitionLevel()
+	if pi.Type == model.PartitionTypeKey {
+		return generateKeyPartitionExpr(ctx, pi, columns, names)
+	}
+	return generateHashPartitionExpr(ctx, pi, columns, names)
+}
+
 // PartitionExpr is the partition definition expressions.
 type PartitionExpr struct {
 	// UpperBounds: (x < y1); (x < y2); (x < y3), used by locatePartition.
@@ -1155,16 +1191,35 @@ func generateHashPartitionExpr(ctx sessionctx.Context, pi *model.PartitionInfo,
 }
 
 // PartitionExpr returns the partition expression.
+// For subpartitioned tables, it's the expression of the first level partitioning.
 func (t *partitionedTable) PartitionExpr() *PartitionExpr {
 	return t.partitionExpr
 }
 
+// SubPartitionExpr returns the subpartition expression, it's nil if the table isn't subpartitioned.
+func (t *partitionedTable) SubPartitionExpr() *PartitionExpr {
+	return t.subPartitionExpr
+}
+
 func (t *partitionedTable) GetPartitionColumnIDs() []int64 {
-	// PARTITION BY {LIST|RANGE} COLUMNS uses columns directly without expressions
 	pi := t.Meta().Partition
-	if len(pi.Columns) > 0 {
-		colIDs := make([]int64, 0, len(pi.Columns))
-		for _, name := range pi.Columns {
+	colIDs := t.getPartitionColumnIDs(pi.Columns, t.partitionExpr)
+	if pi.Sub != nil {
+		// The subpartitioning columns are partitioning columns too.
+		for _, id := range t.getPartitionColumnIDs(pi.Sub.Columns, t.subPartitionExpr) {
+			if !slices.Contains(colIDs, id) {
+				colIDs = append(colIDs, id)
+			}
+		}
+	}
+	return colIDs
+}
+
+func (t *partitionedTable) getPartitionColumnIDs(columns []model.CIStr, partExpr *PartitionExpr) []int64 {
+	// PARTITION BY {LIST|RANGE} COLUMNS and KEY use columns directly without expressions
+	if len(columns) > 0 {
+		colIDs := make([]int64, 0, len(columns))
+		for _, name := range columns {
 			col := table.FindColLowerCase(t.Cols(), name.L)
 			if col == nil {
 				// For safety, should not happen
@@ -1175,7 +1230,7 @@ func (t *partitionedTable) GetPartitionColumnIDs() []int64 {
 		return colIDs
 	}
 
-	partitionCols := expression.ExtractColumns(t.partitionExpr.Expr)
+	partitionCols := expression.ExtractColumns(partExpr.Expr)
 	colIDs := make([]int64, 0, len(partitionCols))
 	for _, col := range partitionCols {
 		colIDs = append(colIDs, col.ID)
@@ -1185,7 +1240,7 @@ func (t *partitionedTable) GetPartitionColumnIDs() []int64 {
 
 func (t *partitionedTable) GetPartitionColumnNames() []model.CIStr {
 	pi := t.Meta().Partition
-	if len(pi.Columns) > 0 {
+	if len(pi.Columns) > 0 && pi.Sub == nil {
 		return pi.Columns
 	}
 	colIDs := t.GetPartitionColumnIDs()
@@ -1248,9 +1303,24 @@ func (t *partitionedTable) locatePartition(ctx sessionctx.Context, r []types.Dat
 	if err != nil {
 		return 0, errors.Trace(err)
 	}
+	if pi.Sub != nil {
+		subIdx, err := t.locateSubPartition(ctx, pi.Sub, r)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		idx = idx*int(pi.Sub.Num) + subIdx
+	}
 	return pi.Definitions[idx].ID, nil
 }
 
+// locateSubPartition returns the subpartition idx of the input record in its partition.
+func (t *partitionedTable) locateSubPartition(ctx sessionctx.Context, sub *model.SubPartitionInfo, r []types.Datum) (int, error) {
+	if sub.Type == model.PartitionTypeKey {
+		return t.subPartitionExpr.LocateKeyPartition(sub.Num, r)
+	}
+	return t.locateHashPartition(ctx, t.subPartitionExpr, sub.Num, r)
+}
+
 func (t *partitionedTable) locateReorgPartition(ctx sessionctx.Context, r []types.Datum) (int64, error) {
 	pi := t.Meta().GetPartitionInfo()
 	// Note that for KEY/HASH partitioning, since we do not support LINEAR,
@@ -1731,6 +1801,23 @@ func FindPartitionByName(meta *model.TableInfo, parName string) (int64, error) {
 	return -1, errors.Trace(table.ErrUnknownPartition.GenWithStackByArgs(parName, meta.Name.O))
 }
 
+// FindPartitionIDsByName finds partitions in table meta by name. For a subpartitioned table,
+// the name can also be the name of a partition, then all its subpartitions are returned.
+func FindPartitionIDsByName(meta *model.TableInfo, parName string) ([]int64, error) {
+	if subDefs := meta.Partition.GetSubPartitionDefinitions(parName); len(subDefs) > 0 {
+		ids := make([]int64, 0, len(subDefs))
+		for _, def := range subDefs {
+			ids = append(ids, def.ID)
+		}
+		return ids, nil
+	}
+	id, err := FindPartitionByName(meta, parName)
+	if err != nil {
+		return nil, err
+	}
+	return []int64{id}, nil
+}
+
 func parseExpr(p *parser.Parser, exprStr string) (ast.ExprNode, error) {
 	exprStr = "select " + exprStr
 	stmts, _, err := p.ParseSQL(exprStr)
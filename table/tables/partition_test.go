@@ -238,6 +238,35 @@ func TestHashPartitionAddRecord(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSubPartitionAddRecord(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`create table t (a int, b int, c varchar(10), key idx_b(b)) partition by list (a) subpartition by key (b) subpartitions 3 (partition p0 values in (1, 2, 3), partition p1 values in (4, 5, 6))`)
+	for i := 1; i <= 6; i++ {
+		for j := 0; j < 10; j++ {
+			tk.MustExec(fmt.Sprintf("insert into t values (%d, %d, '%d')", i, j, i*100+j))
+		}
+	}
+	tk.MustQuery(`select count(*) from t partition (p0sp0, p0sp1, p0sp2)`).Check(testkit.Rows("30"))
+	tk.MustQuery(`select count(*) from t partition (p1sp0, p1sp1, p1sp2)`).Check(testkit.Rows("30"))
+	for j := 0; j < 10; j++ {
+		tk.MustQuery(fmt.Sprintf("select count(*) from t where b = %d", j)).Check(testkit.Rows("6"))
+		tk.MustQuery(fmt.Sprintf("select count(*) from t use index(idx_b) where b = %d and a = 5", j)).Check(testkit.Rows("1"))
+	}
+	tk.MustGetErrCode(`insert into t partition (p0) values (4, 1, 'x')`, mysql.ErrRowDoesNotMatchGivenPartitionSet)
+	tk.MustGetErrCode(`insert into t values (7, 1, 'x')`, mysql.ErrNoPartitionForGivenValue)
+
+	// Updating the subpartitioning column moves the row to another subpartition.
+	tk.MustExec(`update t set b = b + 100 where a = 1`)
+	tk.MustQuery(`select count(*) from t where b >= 100`).Check(testkit.Rows("10"))
+	tk.MustExec(`update t set a = 4 where a = 2`)
+	tk.MustQuery(`select count(*) from t partition (p1)`).Check(testkit.Rows("40"))
+	tk.MustExec(`delete from t where b = 3`)
+	tk.MustQuery(`select count(*) from t`).Check(testkit.Rows("55"))
+	tk.MustExec(`admin check table t`)
+}
+
 // TestPartitionGetPhysicalID tests partition.GetPhysicalID().
 func TestPartitionGetPhysicalID(t *testing.T) {
 	createTable1 := `CREATE TABLE test.t1 (id int(11), index(id))
@@ -2169,7 +2198,7 @@ func TestKeyPartitionTableMixed(t *testing.T) {
 		"PARTITION p2 VALUES LESS THAN MAXVALUE\n" +
 		")")
 	result = tk.MustQuery("show warnings")
-	result.CheckContain("Unsupported subpartitioning, only using RANGE partitioning")
+	result.Check(testkit.Rows())
 
 	// It ignores /*!50100 */ format
 	tk.MustExec("CREATE TABLE tkey10 (`col1` int, `col2` char(5),`col3` date)" +
@@ -2392,12 +2421,12 @@ func TestKeyPartitionTableDDL(t *testing.T) {
 	tk.MustQuery("SELECT COUNT(*) FROM tkey14 partition(p3)").Check(testkit.Rows("0"))
 	tk.MustExec("ALTER TABLE tkey16 COALESCE PARTITION 2")
 	tk.MustExec("ALTER TABLE tkey14 ANALYZE PARTITION p3")
-	err = tk.ExecToErr("ALTER TABLE tkey14 CHECK PARTITION p2")
-	require.Regexp(t, "Unsupported check partition", err)
-	err = tk.ExecToErr("ALTER TABLE tkey14 OPTIMIZE PARTITION p2")
-	require.Regexp(t, "Unsupported optimize partition", err)
-	err = tk.ExecToErr("ALTER TABLE tkey14 REBUILD PARTITION p2")
-	require.Regexp(t, "Unsupported rebuild partition", err)
+	rows := tk.MustQuery("SELECT * FROM tkey14 partition(p2) order by col1, col3").Rows()
+	tk.MustExec("ALTER TABLE tkey14 CHECK PARTITION p2")
+	tk.MustExec("ALTER TABLE tkey14 OPTIMIZE PARTITION p2")
+	tk.MustExec("ALTER TABLE tkey14 REBUILD PARTITION p2")
+	tk.MustQuery("SELECT * FROM tkey14 partition(p2) order by col1, col3").Check(rows)
+	tk.MustExec("ADMIN CHECK TABLE tkey14")
 	err = tk.ExecToErr("ALTER TABLE tkey14 EXCHANGE PARTITION p3 WITH TABLE tkey15")
 	require.Regexp(t, "Unsupported partition type of table tkey14 when exchanging partition", err)
 
@@ -508,12 +508,13 @@ func (t *TableCommon) UpdateRecord(ctx context.Context, sctx sessionctx.Context,
 	}
 	// check data constraint
 	for _, constraint := range t.WritableConstraint() {
-		ok, isNull, err := constraint.ConstraintExpr.EvalInt(sctx, chunk.MutRowFromDatums(rowToCheck).ToRow())
+		checkRow := chunk.MutRowFromDatums(rowToCheck).ToRow()
+		ok, isNull, err := constraint.ConstraintExpr.EvalInt(sctx, checkRow)
 		if err != nil {
 			return err
 		}
 		if ok == 0 && !isNull {
-			return table.ErrCheckConstraintViolated.FastGenByArgs(constraint.Name.O)
+			return constraint.ViolatedError(sctx, checkRow)
 		}
 	}
 	sessVars := sctx.GetSessionVars()
@@ -968,12 +969,13 @@ func (t *TableCommon) AddRecord(sctx sessionctx.Context, r []types.Datum, opts .
 	}
 
 	for _, constraint := range t.WritableConstraint() {
-		ok, isNull, err := constraint.ConstraintExpr.EvalInt(sctx, chunk.MutRowFromDatums(r).ToRow())
+		checkRow := chunk.MutRowFromDatums(r).ToRow()
+		ok, isNull, err := constraint.ConstraintExpr.EvalInt(sctx, checkRow)
 		if err != nil {
 			return nil, err
 		}
 		if ok == 0 && !isNull {
-			return nil, table.ErrCheckConstraintViolated.FastGenByArgs(constraint.Name.O)
+			return nil, constraint.ViolatedError(sctx, checkRow)
 		}
 	}
 
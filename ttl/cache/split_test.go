@@ -20,6 +20,7 @@ import (
 	"math"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/pingcap/tidb/infoschema"
@@ -453,6 +454,60 @@ func TestSplitTTLScanRangesWithBytes(t *testing.T) {
 	}
 }
 
+func TestSplitTTLScanRangesBeforeExpire(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+
+	signedTbl := createTTLTableWithSQL(t, tk, "t1",
+		"create table test.t1(id bigint primary key, v int) TTL = `id` + interval 1 day TTL_TIMESTAMP_UNIT = 'SECOND'")
+	unsignedTbl := createTTLTableWithSQL(t, tk, "t2",
+		"create table test.t2(id bigint unsigned primary key, v int) TTL = `id` + interval 1 day TTL_TIMESTAMP_UNIT = 'SECOND'")
+	timeTbl := createTTLTable(t, tk, "t3", "bigint")
+	expire := time.Unix(450, 0)
+
+	tikvStore := newMockTiKVStore(t)
+	// test only one region
+	ranges, err := signedTbl.SplitScanRangesBeforeExpire(context.TODO(), tikvStore, 4, expire)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(ranges))
+	checkRange(t, ranges[0], types.Datum{}, types.NewIntDatum(450))
+
+	ranges, err = unsignedTbl.SplitScanRangesBeforeExpire(context.TODO(), tikvStore, 4, expire)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(ranges))
+	checkRange(t, ranges[0], types.NewUintDatum(uint64(math.MaxInt64)+1), types.Datum{})
+	checkRange(t, ranges[1], types.Datum{}, types.NewUintDatum(450))
+
+	// test one table has multiple regions, only the regions before the expired timestamp are split
+	for _, tbl := range []*cache.PhysicalTable{signedTbl, unsignedTbl, timeTbl} {
+		tikvStore.clearRegions()
+		tikvStore.addRegionBeginWithTablePrefix(tbl.ID, kv.IntHandle(0))
+		end := tikvStore.batchAddIntHandleRegions(tbl.ID, 8, 100, 0)
+		tikvStore.addRegionEndWithTablePrefix(end, tbl.ID)
+		ranges, err = tbl.SplitScanRangesBeforeExpire(context.TODO(), tikvStore, 3, expire)
+		require.NoError(t, err)
+		switch tbl {
+		case signedTbl:
+			require.Equal(t, 3, len(ranges))
+			checkRange(t, ranges[0], types.Datum{}, types.NewIntDatum(100))
+			checkRange(t, ranges[1], types.NewIntDatum(100), types.NewIntDatum(300))
+			checkRange(t, ranges[2], types.NewIntDatum(300), types.NewIntDatum(450))
+		case unsignedTbl:
+			require.Equal(t, 4, len(ranges))
+			checkRange(t, ranges[0], types.NewUintDatum(uint64(math.MaxInt64)+1), types.Datum{})
+			checkRange(t, ranges[1], types.Datum{}, types.NewUintDatum(100))
+			checkRange(t, ranges[2], types.NewUintDatum(100), types.NewUintDatum(300))
+			checkRange(t, ranges[3], types.NewUintDatum(300), types.NewUintDatum(450))
+		default:
+			// the time column is not the handle, all the regions are split
+			require.Equal(t, 3, len(ranges))
+			checkRange(t, ranges[0], types.Datum{}, types.NewIntDatum(300))
+			checkRange(t, ranges[1], types.NewIntDatum(300), types.NewIntDatum(600))
+			checkRange(t, ranges[2], types.NewIntDatum(600), types.Datum{})
+		}
+	}
+}
+
 func TestNoTTLSplitSupportTables(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -207,8 +207,55 @@ func (t *PhysicalTable) EvalExpireTime(ctx context.Context, se session.Session,
 	return tm.CoreTime().GoTime(tz)
 }
 
+// ArchiveTable returns the archive table of the TTL table. It's nil if the expired rows are deleted directly.
+func (t *PhysicalTable) ArchiveTable() *model.TTLArchiveTable {
+	if t.TTLInfo == nil {
+		return nil
+	}
+	return t.TTLInfo.ArchiveTable
+}
+
+// TimestampUnit returns the unit of the Unix timestamp stored in the time column. It's empty if the time column is not
+// an integer column.
+func (t *PhysicalTable) TimestampUnit() string {
+	if t.TTLInfo == nil || !mysql.IsIntegerType(t.TimeColumn.GetType()) {
+		return ""
+	}
+	return t.TTLInfo.TimestampUnit
+}
+
+// EvalExpireUnixTimestamp converts the expired time to the Unix timestamp in the unit of the integer time column
+func (t *PhysicalTable) EvalExpireUnixTimestamp(expire time.Time) int64 {
+	switch t.TimestampUnit() {
+	case model.TTLTimestampUnitMillisecond:
+		return expire.UnixMilli()
+	case model.TTLTimestampUnitMicrosecond:
+		return expire.UnixMicro()
+	default:
+		return expire.Unix()
+	}
+}
+
 // SplitScanRanges split ranges for TTL scan
 func (t *PhysicalTable) SplitScanRanges(ctx context.Context, store kv.Storage, splitCnt int) ([]ScanRange, error) {
+	return t.splitScanRanges(ctx, store, splitCnt, nil)
+}
+
+// SplitScanRangesBeforeExpire split ranges for TTL scan like `SplitScanRanges`. When the time column is an integer
+// handle, the rows whose handle is not less than the expired Unix timestamp can't be expired, so only the ranges before
+// it are split and scanned.
+func (t *PhysicalTable) SplitScanRangesBeforeExpire(ctx context.Context, store kv.Storage, splitCnt int, expire time.Time) ([]ScanRange, error) {
+	var endHandle kv.Handle
+	if t.PKIsHandle && len(t.KeyColumns) > 0 && t.KeyColumns[0].ID == t.TimeColumn.ID && t.TimestampUnit() != "" {
+		// a non-positive end can't be presented for an unsigned handle, just scan the whole table in this case
+		if ts := t.EvalExpireUnixTimestamp(expire); ts > 0 {
+			endHandle = kv.IntHandle(ts)
+		}
+	}
+	return t.splitScanRanges(ctx, store, splitCnt, endHandle)
+}
+
+func (t *PhysicalTable) splitScanRanges(ctx context.Context, store kv.Storage, splitCnt int, endHandle kv.Handle) ([]ScanRange, error) {
 	if len(t.KeyColumns) < 1 || splitCnt <= 1 {
 		return []ScanRange{newFullRange()}, nil
 	}
@@ -221,7 +268,7 @@ func (t *PhysicalTable) SplitScanRanges(ctx context.Context, store kv.Storage, s
 	ft := t.KeyColumns[0].FieldType
 	switch ft.GetType() {
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeInt24:
-		return t.splitIntRanges(ctx, tikvStore, splitCnt)
+		return t.splitIntRanges(ctx, tikvStore, splitCnt, endHandle)
 	case mysql.TypeBit:
 		return t.splitBinaryRanges(ctx, tikvStore, splitCnt)
 	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar:
@@ -242,16 +289,26 @@ func unsignedEdge(d types.Datum) types.Datum {
 	return types.NewUintDatum(uint64(d.GetInt64()))
 }
 
-func (t *PhysicalTable) splitIntRanges(ctx context.Context, store tikv.Storage, splitCnt int) ([]ScanRange, error) {
+// splitIntRanges splits the ranges of an int handle table. If endHandle is not nil, only the handles less than it
+// are split, and the last range ends with it.
+func (t *PhysicalTable) splitIntRanges(ctx context.Context, store tikv.Storage, splitCnt int, endHandle kv.Handle) ([]ScanRange, error) {
 	recordPrefix := tablecodec.GenTableRecordPrefix(t.ID)
 	startKey, endKey := tablecodec.GetTableHandleKeyRange(t.ID)
+	lastScanEnd := nullDatum()
+	if endHandle != nil {
+		endKey = tablecodec.EncodeRecordKey(recordPrefix, endHandle)
+		lastScanEnd = types.NewIntDatum(endHandle.IntValue())
+	}
 	keyRanges, err := t.splitRawKeyRanges(ctx, store, startKey, endKey, splitCnt)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(keyRanges) <= 1 {
-		return []ScanRange{newFullRange()}, nil
+		if endHandle == nil {
+			return []ScanRange{newFullRange()}, nil
+		}
+		keyRanges = []kv.KeyRange{{StartKey: startKey, EndKey: endKey}}
 	}
 
 	ft := t.KeyColumnTypes[0]
@@ -263,8 +320,9 @@ func (t *PhysicalTable) splitIntRanges(ctx context.Context, store tikv.Storage,
 			break
 		}
 
-		curScanEnd := nullDatum()
+		curScanEnd := lastScanEnd
 		if i < len(keyRanges)-1 {
+			curScanEnd = nullDatum()
 			if val := GetNextIntHandle(keyRange.EndKey, recordPrefix); val != nil {
 				curScanEnd = types.NewIntDatum(val.IntValue())
 			}
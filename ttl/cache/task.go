@@ -114,6 +114,10 @@ type TTLTaskState struct {
 	TotalRows   uint64 `json:"total_rows"`
 	SuccessRows uint64 `json:"success_rows"`
 	ErrorRows   uint64 `json:"error_rows"`
+	SkippedRows uint64 `json:"skipped_rows,omitempty"`
+
+	ArchivedRows     uint64 `json:"archived_rows,omitempty"`
+	ArchiveErrorRows uint64 `json:"archive_error_rows,omitempty"`
 
 	ScanTaskErr string `json:"scan_task_err"`
 }
@@ -41,7 +41,7 @@ const (
 	JobStatusFinished JobStatus = "finished"
 )
 
-const selectFromTTLTableStatus = "SELECT LOW_PRIORITY table_id,parent_table_id,table_statistics,last_job_id,last_job_start_time,last_job_finish_time,last_job_ttl_expire,last_job_summary,current_job_id,current_job_owner_id,current_job_owner_addr,current_job_owner_hb_time,current_job_start_time,current_job_ttl_expire,current_job_state,current_job_status,current_job_status_update_time FROM mysql.tidb_ttl_table_status"
+const selectFromTTLTableStatus = "SELECT LOW_PRIORITY table_id,parent_table_id,table_statistics,last_job_id,last_job_start_time,last_job_finish_time,last_job_ttl_expire,last_job_summary,current_job_id,current_job_owner_id,current_job_owner_addr,current_job_owner_hb_time,current_job_start_time,current_job_ttl_expire,current_job_state,current_job_status,current_job_status_update_time,last_job_archive_table,last_job_archived_rows,last_job_archive_error_rows FROM mysql.tidb_ttl_table_status"
 
 // SelectFromTTLTableStatusWithID returns an SQL statement to get the table status from table id
 func SelectFromTTLTableStatusWithID(tableID int64) (string, []interface{}) {
@@ -71,6 +71,10 @@ type TableStatus struct {
 	CurrentJobState            string
 	CurrentJobStatus           JobStatus
 	CurrentJobStatusUpdateTime time.Time
+
+	LastJobArchiveTable     string
+	LastJobArchivedRows     int64
+	LastJobArchiveErrorRows int64
 }
 
 // TableStatusCache is the cache for ttl table status, it builds a map from physical table id to the table status
@@ -190,6 +194,15 @@ func RowToTableStatus(sctx sessionctx.Context, row chunk.Row) (*TableStatus, err
 			return nil, err
 		}
 	}
+	if !row.IsNull(17) {
+		status.LastJobArchiveTable = row.GetString(17)
+	}
+	if !row.IsNull(18) {
+		status.LastJobArchivedRows = row.GetInt64(18)
+	}
+	if !row.IsNull(19) {
+		status.LastJobArchiveErrorRows = row.GetInt64(19)
+	}
 
 	return status, nil
 }
@@ -166,6 +166,21 @@ func TestTTLStatusCache(t *testing.T) {
 				assert.Equal(t, expectedTime, table.CurrentJobStatusUpdateTime)
 			},
 		},
+		{
+			"last_job_archive_table",
+			"'test.t_history'",
+			func(table *cache.TableStatus) { assert.Equal(t, "test.t_history", table.LastJobArchiveTable) },
+		},
+		{
+			"last_job_archived_rows",
+			"100",
+			func(table *cache.TableStatus) { assert.Equal(t, int64(100), table.LastJobArchivedRows) },
+		},
+		{
+			"last_job_archive_error_rows",
+			"3",
+			func(table *cache.TableStatus) { assert.Equal(t, int64(3), table.LastJobArchiveErrorRows) },
+		},
 	}
 	for index, testCase := range testCases {
 		t.Run(testCase.columnName, func(t *testing.T) {
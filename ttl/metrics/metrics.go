@@ -38,14 +38,19 @@ var (
 
 // TTL metrics
 var (
-	SelectSuccessDuration prometheus.Observer
-	SelectErrorDuration   prometheus.Observer
-	DeleteSuccessDuration prometheus.Observer
-	DeleteErrorDuration   prometheus.Observer
-
-	ScannedExpiredRows       prometheus.Counter
-	DeleteSuccessExpiredRows prometheus.Counter
-	DeleteErrorExpiredRows   prometheus.Counter
+	SelectSuccessDuration  prometheus.Observer
+	SelectErrorDuration    prometheus.Observer
+	DeleteSuccessDuration  prometheus.Observer
+	DeleteErrorDuration    prometheus.Observer
+	ArchiveSuccessDuration prometheus.Observer
+	ArchiveErrorDuration   prometheus.Observer
+
+	ScannedExpiredRows        prometheus.Counter
+	DeleteSuccessExpiredRows  prometheus.Counter
+	DeleteErrorExpiredRows    prometheus.Counter
+	DeleteSkippedExpiredRows  prometheus.Counter
+	ArchiveSuccessExpiredRows prometheus.Counter
+	ArchiveErrorExpiredRows   prometheus.Counter
 
 	RunningJobsCnt    prometheus.Gauge
 	CancellingJobsCnt prometheus.Gauge
@@ -106,6 +111,10 @@ func InitMetricsVars() {
 		prometheus.Labels{metrics.LblSQLType: "delete", metrics.LblResult: metrics.LblOK})
 	DeleteErrorDuration = metrics.TTLQueryDuration.With(
 		prometheus.Labels{metrics.LblSQLType: "delete", metrics.LblResult: metrics.LblError})
+	ArchiveSuccessDuration = metrics.TTLQueryDuration.With(
+		prometheus.Labels{metrics.LblSQLType: "archive", metrics.LblResult: metrics.LblOK})
+	ArchiveErrorDuration = metrics.TTLQueryDuration.With(
+		prometheus.Labels{metrics.LblSQLType: "archive", metrics.LblResult: metrics.LblError})
 
 	ScannedExpiredRows = metrics.TTLProcessedExpiredRowsCounter.With(
 		prometheus.Labels{metrics.LblSQLType: "select", metrics.LblResult: metrics.LblOK})
@@ -113,6 +122,12 @@ func InitMetricsVars() {
 		prometheus.Labels{metrics.LblSQLType: "delete", metrics.LblResult: metrics.LblOK})
 	DeleteErrorExpiredRows = metrics.TTLProcessedExpiredRowsCounter.With(
 		prometheus.Labels{metrics.LblSQLType: "delete", metrics.LblResult: metrics.LblError})
+	DeleteSkippedExpiredRows = metrics.TTLProcessedExpiredRowsCounter.With(
+		prometheus.Labels{metrics.LblSQLType: "delete", metrics.LblResult: "skipped"})
+	ArchiveSuccessExpiredRows = metrics.TTLProcessedExpiredRowsCounter.With(
+		prometheus.Labels{metrics.LblSQLType: "archive", metrics.LblResult: metrics.LblOK})
+	ArchiveErrorExpiredRows = metrics.TTLProcessedExpiredRowsCounter.With(
+		prometheus.Labels{metrics.LblSQLType: "archive", metrics.LblResult: metrics.LblError})
 
 	RunningJobsCnt = metrics.TTLJobStatus.With(prometheus.Labels{metrics.LblType: "running"})
 	CancellingJobsCnt = metrics.TTLJobStatus.With(prometheus.Labels{metrics.LblType: "cancelling"})
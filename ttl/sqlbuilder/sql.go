@@ -148,6 +148,45 @@ func (b *SQLBuilder) WriteDelete() error {
 	return nil
 }
 
+// WriteArchive writes an insert statement to copy the rows to the archive table without any condition
+func (b *SQLBuilder) WriteArchive() error {
+	if b.state != writeBegin {
+		return errors.Errorf("invalid state: %v", b.state)
+	}
+	archive := b.tbl.ArchiveTable()
+	if archive == nil {
+		return errors.Errorf("table '%s.%s' has no archive table", b.tbl.Schema.O, b.tbl.Name.O)
+	}
+
+	cols := make([]*model.ColumnInfo, 0, len(b.tbl.Columns))
+	for _, col := range b.tbl.Cols() {
+		if !col.Hidden {
+			cols = append(cols, col)
+		}
+	}
+
+	b.restoreCtx.WritePlain("INSERT LOW_PRIORITY INTO ")
+	tn := ast.TableName{Schema: archive.Schema, Name: archive.Table}
+	if err := tn.Restore(b.restoreCtx); err != nil {
+		return err
+	}
+	b.restoreCtx.WritePlain(" ")
+	b.writeColNames(cols, true)
+	b.restoreCtx.WritePlain(" SELECT ")
+	b.writeColNames(cols, false)
+	b.restoreCtx.WritePlain(" FROM ")
+	if err := b.writeTblName(); err != nil {
+		return err
+	}
+	if par := b.tbl.PartitionDef; par != nil {
+		b.restoreCtx.WritePlain(" PARTITION(")
+		b.restoreCtx.WriteName(par.Name.O)
+		b.restoreCtx.WritePlain(")")
+	}
+	b.state = writeSelOrDel
+	return nil
+}
+
 // WriteCommonCondition writes a new condition
 func (b *SQLBuilder) WriteCommonCondition(cols []*model.ColumnInfo, op string, dp []types.Datum) error {
 	switch b.state {
@@ -181,9 +220,14 @@ func (b *SQLBuilder) WriteExpireCondition(expire time.Time) error {
 
 	b.writeColNames([]*model.ColumnInfo{b.tbl.TimeColumn}, false)
 	b.restoreCtx.WritePlain(" < ")
-	b.restoreCtx.WritePlain("FROM_UNIXTIME(")
-	b.restoreCtx.WritePlain(strconv.FormatInt(expire.Unix(), 10))
-	b.restoreCtx.WritePlain(")")
+	if b.tbl.TimestampUnit() != "" {
+		// the time column is an integer column storing the Unix timestamp
+		b.restoreCtx.WritePlain(strconv.FormatInt(b.tbl.EvalExpireUnixTimestamp(expire), 10))
+	} else {
+		b.restoreCtx.WritePlain("FROM_UNIXTIME(")
+		b.restoreCtx.WritePlain(strconv.FormatInt(expire.Unix(), 10))
+		b.restoreCtx.WritePlain(")")
+	}
 	b.hasWriteExpireCond = true
 	return nil
 }
@@ -480,3 +524,30 @@ func BuildDeleteSQL(tbl *cache.PhysicalTable, rows [][]types.Datum, expire time.
 
 	return b.Build()
 }
+
+// BuildArchiveSQL builds an insert SQL to copy the expired rows to the archive table. It has the same conditions with
+// the delete SQL built by `BuildDeleteSQL`, so they copy and delete the same rows in one transaction.
+func BuildArchiveSQL(tbl *cache.PhysicalTable, rows [][]types.Datum, expire time.Time) (string, error) {
+	if len(rows) == 0 {
+		return "", errors.New("Cannot build archive SQL with empty rows")
+	}
+
+	b := NewSQLBuilder(tbl)
+	if err := b.WriteArchive(); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteInCondition(tbl.KeyColumns, rows...); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteExpireCondition(expire); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteLimit(len(rows)); err != nil {
+		return "", err
+	}
+
+	return b.Build()
+}
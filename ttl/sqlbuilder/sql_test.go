@@ -576,6 +576,31 @@ func TestSQLBuilder(t *testing.T) {
 	must(b.WriteInCondition(tp.KeyColumns, d("a"), d("b")))
 	must(b.WriteExpireCondition(time.UnixMilli(0).In(time.UTC)))
 	mustBuild(b, "DELETE LOW_PRIORITY FROM `testp`.`tp` PARTITION(`p1`) WHERE `id` IN ('a', 'b') AND `time` < FROM_UNIXTIME(0)")
+
+	// test the time column storing the Unix timestamp
+	for unit, expected := range map[string]string{
+		model.TTLTimestampUnitSecond:      "1690000000",
+		model.TTLTimestampUnitMillisecond: "1690000000123",
+		model.TTLTimestampUnitMicrosecond: "1690000000123456",
+	} {
+		ti := &cache.PhysicalTable{
+			Schema: model.NewCIStr("test"),
+			TableInfo: &model.TableInfo{
+				Name:    model.NewCIStr("ti"),
+				TTLInfo: &model.TTLInfo{ColumnName: model.NewCIStr("ts"), TimestampUnit: unit},
+			},
+			KeyColumns: t1.KeyColumns,
+			TimeColumn: &model.ColumnInfo{
+				Name:      model.NewCIStr("ts"),
+				FieldType: *types.NewFieldType(mysql.TypeLonglong),
+			},
+		}
+		b = sqlbuilder.NewSQLBuilder(ti)
+		must(b.WriteDelete())
+		must(b.WriteInCondition(ti.KeyColumns, d("a")))
+		must(b.WriteExpireCondition(time.UnixMicro(1690000000123456).In(time.UTC)))
+		mustBuild(b, "DELETE LOW_PRIORITY FROM `test`.`ti` WHERE `id` IN ('a') AND `ts` < "+expected)
+	}
 }
 
 func TestScanQueryGenerator(t *testing.T) {
@@ -931,6 +956,54 @@ func TestBuildDeleteSQL(t *testing.T) {
 	}
 }
 
+func TestBuildArchiveSQL(t *testing.T) {
+	id := &model.ColumnInfo{Name: model.NewCIStr("id"), State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeInt24)}
+	tm := &model.ColumnInfo{Name: model.NewCIStr("time"), State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeDatetime)}
+	v := &model.ColumnInfo{Name: model.NewCIStr("v"), State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeVarchar)}
+	hidden := &model.ColumnInfo{Name: model.NewCIStr("_V$_idx_0"), State: model.StatePublic, Hidden: true, FieldType: *types.NewFieldType(mysql.TypeInt24)}
+	writeOnly := &model.ColumnInfo{Name: model.NewCIStr("w"), State: model.StateWriteOnly, FieldType: *types.NewFieldType(mysql.TypeInt24)}
+
+	t1 := &cache.PhysicalTable{
+		Schema: model.NewCIStr("test"),
+		TableInfo: &model.TableInfo{
+			Name:    model.NewCIStr("t1"),
+			Columns: []*model.ColumnInfo{id, tm, v, hidden, writeOnly},
+			TTLInfo: &model.TTLInfo{
+				ArchiveTable: &model.TTLArchiveTable{Schema: model.NewCIStr("archive"), Table: model.NewCIStr("t1_history")},
+			},
+		},
+		KeyColumns: []*model.ColumnInfo{id},
+		TimeColumn: tm,
+	}
+
+	t2 := &cache.PhysicalTable{
+		Schema: model.NewCIStr("test"),
+		TableInfo: &model.TableInfo{
+			Name:    model.NewCIStr("t2"),
+			Columns: []*model.ColumnInfo{id, tm},
+			TTLInfo: &model.TTLInfo{
+				ArchiveTable: &model.TTLArchiveTable{Schema: model.NewCIStr("test"), Table: model.NewCIStr("t2_history")},
+			},
+		},
+		KeyColumns:   []*model.ColumnInfo{id},
+		TimeColumn:   tm,
+		PartitionDef: &model.PartitionDefinition{Name: model.NewCIStr("p0")},
+	}
+
+	sql, err := sqlbuilder.BuildArchiveSQL(t1, [][]types.Datum{d(1), d(2)}, time.UnixMilli(0).In(time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, "INSERT LOW_PRIORITY INTO `archive`.`t1_history` (`id`, `time`, `v`) SELECT `id`, `time`, `v` FROM `test`.`t1` WHERE `id` IN (1, 2) AND `time` < FROM_UNIXTIME(0) LIMIT 2", sql)
+
+	sql, err = sqlbuilder.BuildArchiveSQL(t2, [][]types.Datum{d(3)}, time.UnixMilli(0).In(time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, "INSERT LOW_PRIORITY INTO `test`.`t2_history` (`id`, `time`) SELECT `id`, `time` FROM `test`.`t2` PARTITION(`p0`) WHERE `id` IN (3) AND `time` < FROM_UNIXTIME(0) LIMIT 1", sql)
+
+	// a table without archive table
+	t2.TTLInfo.ArchiveTable = nil
+	_, err = sqlbuilder.BuildArchiveSQL(t2, [][]types.Datum{d(3)}, time.UnixMilli(0).In(time.UTC))
+	require.EqualError(t, err, "table 'test.t2' has no archive table")
+}
+
 func d(vs ...interface{}) []types.Datum {
 	datums := make([]types.Datum, len(vs))
 	for i, v := range vs {
@@ -22,6 +22,9 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/ttl/cache"
 	"github.com/pingcap/tidb/ttl/metrics"
@@ -103,52 +106,138 @@ func (t *ttlDeleteTask) doDelete(ctx context.Context, rawSe session.Session) (re
 			leftRows = leftRows[maxBatch:]
 		}
 
-		sql, err := sqlbuilder.BuildDeleteSQL(t.tbl, delBatch, t.expire)
+		batchRetryRows, stop := t.deleteBatch(ctx, se, delBatch)
+		if len(batchRetryRows) > 0 {
+			if retryRows == nil {
+				retryRows = make([][]types.Datum, 0, len(leftRows)+len(delBatch))
+			}
+			retryRows = append(retryRows, batchRetryRows...)
+		}
+
+		if stop {
+			return
+		}
+	}
+	return retryRows
+}
+
+// deleteBatch deletes (and archives if needed) the rows in one transaction. It returns the rows which should be
+// retried later, and whether the deleting of the task should be stopped.
+func (t *ttlDeleteTask) deleteBatch(ctx context.Context, se *ttlTableSession, delBatch [][]types.Datum) (retryRows [][]types.Datum, stop bool) {
+	tracer := metrics.PhaseTracerFromCtx(ctx)
+	archive := t.tbl.ArchiveTable() != nil
+
+	sql, err := sqlbuilder.BuildDeleteSQL(t.tbl, delBatch, t.expire)
+	if err != nil {
+		t.incErrorRows(len(delBatch))
+		logutil.BgLogger().Warn(
+			"build delete SQL in TTL failed",
+			zap.Error(err),
+			zap.String("table", t.tbl.Schema.O+"."+t.tbl.Name.O),
+		)
+		return nil, true
+	}
+
+	sqls := []string{sql}
+	if archive {
+		archiveSQL, err := sqlbuilder.BuildArchiveSQL(t.tbl, delBatch, t.expire)
 		if err != nil {
-			t.statistics.IncErrorRows(len(delBatch))
+			t.incErrorRows(len(delBatch))
 			logutil.BgLogger().Warn(
-				"build delete SQL in TTL failed",
+				"build archive SQL in TTL failed",
 				zap.Error(err),
 				zap.String("table", t.tbl.Schema.O+"."+t.tbl.Name.O),
 			)
-			return
+			return nil, true
 		}
+		// the expired rows are copied to the archive table and deleted in the same transaction, so they're never
+		// deleted without being archived.
+		sqls = []string{archiveSQL, sql}
+	}
 
-		tracer.EnterPhase(metrics.PhaseWaitToken)
-		if err = globalDelRateLimiter.Wait(ctx); err != nil {
-			t.statistics.IncErrorRows(len(delBatch))
-			return
-		}
-		tracer.EnterPhase(metrics.PhaseOther)
+	tracer.EnterPhase(metrics.PhaseWaitToken)
+	if err = globalDelRateLimiter.Wait(ctx); err != nil {
+		t.incErrorRows(len(delBatch))
+		return nil, true
+	}
+	tracer.EnterPhase(metrics.PhaseOther)
 
-		sqlStart := time.Now()
-		_, needRetry, err := se.ExecuteSQLWithCheck(ctx, sql)
-		sqlInterval := time.Since(sqlStart)
-		if err != nil {
+	sqlStart := time.Now()
+	_, needRetry, err := se.ExecuteSQLsWithCheck(ctx, sqls...)
+	sqlInterval := time.Since(sqlStart)
+	if err != nil {
+		if archive {
+			metrics.ArchiveErrorDuration.Observe(sqlInterval.Seconds())
+		} else {
 			metrics.DeleteErrorDuration.Observe(sqlInterval.Seconds())
-			needRetry = needRetry && ctx.Err() == nil
+		}
+
+		if isRowReferencedError(err) {
+			// Some rows are referenced by a child table with `ON DELETE RESTRICT` (or `NO ACTION`). Delete the rows one
+			// by one to find them out, and skip them without failing the other rows.
+			if len(delBatch) > 1 {
+				for i := range delBatch {
+					rowRetryRows, stop := t.deleteBatch(ctx, se, delBatch[i:i+1])
+					retryRows = append(retryRows, rowRetryRows...)
+					if stop {
+						return retryRows, true
+					}
+				}
+				return retryRows, false
+			}
+
+			t.statistics.IncSkippedRows(len(delBatch))
 			logutil.BgLogger().Warn(
-				"delete SQL in TTL failed",
+				"skip the expired row referenced by foreign key in TTL",
 				zap.Error(err),
-				zap.String("SQL", sql),
-				zap.Bool("needRetry", needRetry),
+				zap.String("table", t.tbl.Schema.O+"."+t.tbl.Name.O),
+				zap.Strings("SQL", sqls),
 			)
+			return nil, false
+		}
 
-			if needRetry {
-				if retryRows == nil {
-					retryRows = make([][]types.Datum, 0, len(leftRows)+len(delBatch))
-				}
-				retryRows = append(retryRows, delBatch...)
-			} else {
-				t.statistics.IncErrorRows(len(delBatch))
-			}
-			continue
+		needRetry = needRetry && ctx.Err() == nil
+		logutil.BgLogger().Warn(
+			"delete SQL in TTL failed",
+			zap.Error(err),
+			zap.Strings("SQL", sqls),
+			zap.Bool("needRetry", needRetry),
+		)
+
+		if needRetry {
+			return delBatch, false
 		}
+		t.incErrorRows(len(delBatch))
+		return nil, false
+	}
 
+	if archive {
+		metrics.ArchiveSuccessDuration.Observe(sqlInterval.Seconds())
+	} else {
 		metrics.DeleteSuccessDuration.Observe(sqlInterval.Seconds())
-		t.statistics.IncSuccessRows(len(delBatch))
 	}
-	return retryRows
+	t.incSuccessRows(len(delBatch))
+	return nil, false
+}
+
+// isRowReferencedError returns whether the error is caused by deleting a row referenced by a foreign key
+func isRowReferencedError(err error) bool {
+	terr, ok := errors.Cause(err).(*terror.Error)
+	return ok && terr.Code() == errno.ErrRowIsReferenced2
+}
+
+func (t *ttlDeleteTask) incSuccessRows(cnt int) {
+	t.statistics.IncSuccessRows(cnt)
+	if t.tbl.ArchiveTable() != nil {
+		t.statistics.IncArchivedRows(cnt)
+	}
+}
+
+func (t *ttlDeleteTask) incErrorRows(cnt int) {
+	t.statistics.IncErrorRows(cnt)
+	if t.tbl.ArchiveTable() != nil {
+		t.statistics.IncArchiveErrorRows(cnt)
+	}
 }
 
 type ttlDelRetryItem struct {
@@ -213,14 +302,14 @@ func (b *ttlDelRetryBuffer) recordRetryItem(task *ttlDeleteTask, retryRows [][]t
 	}
 
 	if retryCnt >= b.maxRetry {
-		task.statistics.IncErrorRows(len(retryRows))
+		task.incErrorRows(len(retryRows))
 		return false
 	}
 
 	for b.list.Len() > 0 && b.list.Len() >= b.maxSize {
 		ele := b.list.Front()
 		if item, ok := ele.Value.(*ttlDelRetryItem); ok {
-			item.task.statistics.IncErrorRows(len(item.task.rows))
+			item.task.incErrorRows(len(item.task.rows))
 		} else {
 			logutil.BgLogger().Error(fmt.Sprintf("invalid retry buffer item type: %T", ele))
 		}
@@ -21,10 +21,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/ttl/cache"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/dbterror"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/time/rate"
 )
@@ -278,6 +281,106 @@ func TestTTLDeleteTaskDoDelete(t *testing.T) {
 	}
 }
 
+func TestTTLDeleteTaskDoDeleteWithArchive(t *testing.T) {
+	origBatchSize := variable.TTLDeleteBatchSize.Load()
+	variable.TTLDeleteBatchSize.Store(3)
+	defer variable.TTLDeleteBatchSize.Store(origBatchSize)
+
+	tbl := newMockTTLTbl(t, "t1")
+	tbl.TTLInfo.ArchiveTable = &model.TTLArchiveTable{Schema: model.NewCIStr("test"), Table: model.NewCIStr("t1_history")}
+	s := newMockSession(t)
+	var sqls []string
+	s.executeSQL = func(ctx context.Context, sql string, args ...interface{}) ([]chunk.Row, error) {
+		sqls = append(sqls, sql)
+		s.sessionInfoSchema = newMockInfoSchema(tbl.TableInfo)
+		if strings.HasPrefix(sql, "INSERT") && strings.Contains(sql, "IN (3, 4, 5)") {
+			return nil, errors.New("mockErr")
+		}
+		return nil, nil
+	}
+
+	rows := make([][]types.Datum, 10)
+	for i := range rows {
+		rows[i] = []types.Datum{types.NewIntDatum(int64(i))}
+	}
+	task := &ttlDeleteTask{
+		tbl:        tbl,
+		expire:     time.UnixMilli(0),
+		rows:       rows,
+		statistics: &ttlStatistics{},
+	}
+	task.statistics.TotalRows.Add(10)
+
+	retryRows := task.doDelete(context.Background(), s)
+	// the delete SQL is not executed when the archive SQL fails
+	require.Equal(t, 7, len(sqls))
+	for _, i := range []int{0, 2, 3, 5} {
+		require.True(t, strings.HasPrefix(sqls[i], "INSERT LOW_PRIORITY INTO `test`.`t1_history` (`time`) SELECT `time` FROM `test`.`t1` WHERE"), sqls[i])
+	}
+	for _, i := range []int{1, 4, 6} {
+		require.True(t, strings.HasPrefix(sqls[i], "DELETE LOW_PRIORITY FROM `test`.`t1` WHERE"), sqls[i])
+	}
+	require.Equal(t, 3, len(retryRows))
+	for i, row := range retryRows {
+		require.Equal(t, int64(i+3), row[0].GetInt64())
+	}
+	require.Equal(t, uint64(7), task.statistics.SuccessRows.Load())
+	require.Equal(t, uint64(7), task.statistics.ArchivedRows.Load())
+	require.Equal(t, uint64(0), task.statistics.ErrorRows.Load())
+
+	// the rows failed to be retried are counted as archive error rows
+	buffer := newTTLDelRetryBuffer()
+	buffer.maxRetry = 0
+	buffer.RecordTaskResult(task, retryRows)
+	require.Equal(t, uint64(3), task.statistics.ErrorRows.Load())
+	require.Equal(t, uint64(3), task.statistics.ArchiveErrorRows.Load())
+}
+
+func TestTTLDeleteTaskDoDeleteReferencedByForeignKey(t *testing.T) {
+	origBatchSize := variable.TTLDeleteBatchSize.Load()
+	variable.TTLDeleteBatchSize.Store(3)
+	defer variable.TTLDeleteBatchSize.Store(origBatchSize)
+
+	tbl := newMockTTLTbl(t, "t1")
+	s := newMockSession(t)
+	var sqls []string
+	s.executeSQL = func(ctx context.Context, sql string, args ...interface{}) ([]chunk.Row, error) {
+		sqls = append(sqls, sql)
+		s.sessionInfoSchema = newMockInfoSchema(tbl.TableInfo)
+		if strings.Contains(sql, "IN (0, 1, 2)") || strings.Contains(sql, "IN (1)") {
+			return nil, dbterror.ClassExecutor.NewStd(errno.ErrRowIsReferenced2)
+		}
+		if strings.Contains(sql, "IN (3, 4, 5)") {
+			return nil, errors.New("mockErr")
+		}
+		return nil, nil
+	}
+
+	rows := make([][]types.Datum, 6)
+	for i := range rows {
+		rows[i] = []types.Datum{types.NewIntDatum(int64(i))}
+	}
+	task := &ttlDeleteTask{
+		tbl:        tbl,
+		expire:     time.UnixMilli(0),
+		rows:       rows,
+		statistics: &ttlStatistics{},
+	}
+	task.statistics.TotalRows.Add(6)
+
+	retryRows := task.doDelete(context.Background(), s)
+	// the batch referenced by foreign key is deleted row by row, and the referenced row is skipped
+	require.Equal(t, 5, len(sqls))
+	for i, in := range []string{"IN (0, 1, 2)", "IN (0)", "IN (1)", "IN (2)", "IN (3, 4, 5)"} {
+		require.True(t, strings.Contains(sqls[i], in), sqls[i])
+	}
+	// the other errors are still retried
+	require.Equal(t, 3, len(retryRows))
+	require.Equal(t, uint64(2), task.statistics.SuccessRows.Load())
+	require.Equal(t, uint64(1), task.statistics.SkippedRows.Load())
+	require.Equal(t, uint64(0), task.statistics.ErrorRows.Load())
+}
+
 func TestTTLDeleteRateLimiter(t *testing.T) {
 	origDeleteLimit := variable.TTLDeleteRateLimit.Load()
 	defer func() {
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/ttl/cache"
 	"github.com/pingcap/tidb/ttl/session"
 	"github.com/pingcap/tidb/util/logutil"
@@ -33,6 +34,9 @@ const finishJobTemplate = `UPDATE mysql.tidb_ttl_table_status
 		last_job_finish_time = %?,
 		last_job_ttl_expire = current_job_ttl_expire,
 		last_job_summary = %?,
+		last_job_archive_table = %?,
+		last_job_archived_rows = %?,
+		last_job_archive_error_rows = %?,
 		current_job_id = NULL,
 		current_job_owner_id = NULL,
 		current_job_owner_hb_time = NULL,
@@ -71,8 +75,23 @@ func updateJobCurrentStatusSQL(tableID int64, oldStatus cache.JobStatus, newStat
 	return updateJobCurrentStatusTemplate, []interface{}{string(newStatus), tableID, string(oldStatus), jobID}
 }
 
-func finishJobSQL(tableID int64, finishTime time.Time, summary string, jobID string) (string, []interface{}) {
-	return finishJobTemplate, []interface{}{finishTime.Format(timeFormat), summary, tableID, jobID}
+func finishJobSQL(tableID int64, finishTime time.Time, summary *TTLSummary, archiveTable *model.TTLArchiveTable, jobID string) (string, []interface{}) {
+	var archiveTableName, archivedRows, archiveErrorRows interface{}
+	if archiveTable != nil {
+		archiveTableName = archiveTable.Schema.O + "." + archiveTable.Table.O
+		archivedRows = summary.ArchivedRows
+		archiveErrorRows = summary.ArchiveErrorRows
+	}
+
+	return finishJobTemplate, []interface{}{
+		finishTime.Format(timeFormat),
+		summary.SummaryText,
+		archiveTableName,
+		archivedRows,
+		archiveErrorRows,
+		tableID,
+		jobID,
+	}
 }
 
 func removeTaskForJob(jobID string) (string, []interface{}) {
@@ -130,7 +149,7 @@ func (job *ttlJob) finish(se session.Session, now time.Time, summary *TTLSummary
 	// at this time, the job.ctx may have been canceled (to cancel this job)
 	// even when it's canceled, we'll need to update the states, so use another context
 	err := se.RunInTxn(context.TODO(), func() error {
-		sql, args := finishJobSQL(job.tbl.ID, now, summary.SummaryText, job.id)
+		sql, args := finishJobSQL(job.tbl.ID, now, summary, job.tbl.ArchiveTable(), job.id)
 		_, err := se.ExecuteSQL(context.TODO(), sql, args...)
 		if err != nil {
 			return errors.Wrapf(err, "execute sql: %s", sql)
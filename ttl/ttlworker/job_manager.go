@@ -620,7 +620,7 @@ func (m *JobManager) lockNewJob(ctx context.Context, se session.Session, table *
 			return errors.Wrapf(err, "execute sql: %s", sql)
 		}
 
-		ranges, err := table.SplitScanRanges(ctx, m.store, splitScanCount)
+		ranges, err := table.SplitScanRangesBeforeExpire(ctx, m.store, splitScanCount, expireTime)
 		if err != nil {
 			return errors.Wrap(err, "split scan ranges")
 		}
@@ -743,6 +743,10 @@ type TTLSummary struct {
 	TotalRows   uint64 `json:"total_rows"`
 	SuccessRows uint64 `json:"success_rows"`
 	ErrorRows   uint64 `json:"error_rows"`
+	SkippedRows uint64 `json:"skipped_rows,omitempty"`
+
+	ArchivedRows     uint64 `json:"archived_rows,omitempty"`
+	ArchiveErrorRows uint64 `json:"archive_error_rows,omitempty"`
 
 	TotalScanTask     int `json:"total_scan_task"`
 	ScheduledScanTask int `json:"scheduled_scan_task"`
@@ -773,6 +777,9 @@ func summarizeTaskResult(tasks []*cache.TTLTask) (*TTLSummary, error) {
 			summary.TotalRows += t.State.TotalRows
 			summary.SuccessRows += t.State.SuccessRows
 			summary.ErrorRows += t.State.ErrorRows
+			summary.SkippedRows += t.State.SkippedRows
+			summary.ArchivedRows += t.State.ArchivedRows
+			summary.ArchiveErrorRows += t.State.ArchiveErrorRows
 			if len(t.State.ScanTaskErr) > 0 {
 				allErr = multierr.Append(allErr, errors.New(t.State.ScanTaskErr))
 			}
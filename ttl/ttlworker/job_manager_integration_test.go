@@ -224,6 +224,7 @@ func TestTTLAutoAnalyze(t *testing.T) {
 	require.NoError(t, h.DumpStatsDeltaToKV(handle.DumpAll))
 	require.NoError(t, h.Update(is))
 	require.True(t, h.HandleAutoAnalyze(is))
+	h.WaitAutoAnalyzeJobs()
 }
 
 func TestTriggerTTLJob(t *testing.T) {
@@ -312,6 +313,111 @@ func TestTTLDeleteWithTimeZoneChange(t *testing.T) {
 	tk.MustQuery("select id from t2 order by id asc").Check(testkit.Rows("1"))
 }
 
+func TestTTLDeleteWithUnixTimestamp(t *testing.T) {
+	failpoint.Enable("github.com/pingcap/tidb/ttl/ttlworker/task-manager-loop-interval", fmt.Sprintf("return(%d)", time.Second))
+	defer failpoint.Disable("github.com/pingcap/tidb/ttl/ttlworker/task-manager-loop-interval")
+
+	store, do := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	now := time.Now()
+	tk.MustExec("create table t1(id int primary key, t bigint) TTL=`t` + INTERVAL 1 DAY TTL_TIMESTAMP_UNIT='SECOND' TTL_ENABLE='OFF'")
+	tbl1, err := do.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t1"))
+	require.NoError(t, err)
+	tblID1 := tbl1.Meta().ID
+	tk.MustExec("insert into t1 values(1, ?), (2, ?), (3, ?)",
+		now.Unix(), now.Add(-23*time.Hour).Unix(), now.Add(-25*time.Hour).Unix())
+
+	// the time column is also the handle
+	tk.MustExec("create table t2(t bigint unsigned primary key) TTL=`t` + INTERVAL 1 DAY TTL_TIMESTAMP_UNIT='MILLISECOND' TTL_ENABLE='OFF'")
+	tbl2, err := do.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t2"))
+	require.NoError(t, err)
+	tblID2 := tbl2.Meta().ID
+	tk.MustExec("insert into t2 values(?), (?), (?)",
+		now.UnixMilli(), now.Add(-23*time.Hour).UnixMilli(), now.Add(-25*time.Hour).UnixMilli())
+
+	tk.MustExec("alter table t1 TTL_ENABLE='ON'")
+	tk.MustExec("alter table t2 TTL_ENABLE='ON'")
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute)
+	defer cancel()
+	cli := do.TTLJobManager().GetCommandCli()
+	_, _ = client.TriggerNewTTLJob(ctx, cli, "test", "t1")
+	_, _ = client.TriggerNewTTLJob(ctx, cli, "test", "t2")
+
+	waitTTLJobFinished(t, tk, tblID1)
+	tk.MustQuery("select id from t1 order by id asc").Check(testkit.Rows("1", "2"))
+
+	waitTTLJobFinished(t, tk, tblID2)
+	tk.MustQuery("select count(*) from t2").Check(testkit.Rows("2"))
+}
+
+func TestTTLDeleteWithArchiveTable(t *testing.T) {
+	failpoint.Enable("github.com/pingcap/tidb/ttl/ttlworker/task-manager-loop-interval", fmt.Sprintf("return(%d)", time.Second))
+	defer failpoint.Disable("github.com/pingcap/tidb/ttl/ttlworker/task-manager-loop-interval")
+
+	store, do := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create table t_history(id int, t datetime, v varchar(16), archived_at timestamp default current_timestamp)")
+	tk.MustExec("create table t(id int primary key, t datetime, v varchar(16)) TTL=`t` + INTERVAL 1 DAY TTL_ARCHIVE_TABLE=t_history TTL_ENABLE='OFF'")
+	tbl, err := do.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tblID := tbl.Meta().ID
+	tk.MustExec("insert into t values(1, now(), 'a'), (2, now() - interval 23 hour, 'b'), (3, now() - interval 25 hour, 'c'), (4, now() - interval 2 day, 'd')")
+	tk.MustExec("alter table t TTL_ENABLE='ON'")
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute)
+	defer cancel()
+	_, _ = client.TriggerNewTTLJob(ctx, do.TTLJobManager().GetCommandCli(), "test", "t")
+
+	waitTTLJobFinished(t, tk, tblID)
+	tk.MustQuery("select id from t order by id asc").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id, v, archived_at is not null from t_history order by id asc").Check(testkit.Rows("3 c 1", "4 d 1"))
+	tk.MustQuery("select last_job_archive_table, last_job_archived_rows, last_job_archive_error_rows, "+
+		"last_job_summary->>'$.archived_rows' from mysql.tidb_ttl_table_status where table_id = ?", tblID).
+		Check(testkit.Rows("test.t_history 2 0 2"))
+}
+
+func TestTTLDeleteWithForeignKey(t *testing.T) {
+	failpoint.Enable("github.com/pingcap/tidb/ttl/ttlworker/task-manager-loop-interval", fmt.Sprintf("return(%d)", time.Second))
+	defer failpoint.Disable("github.com/pingcap/tidb/ttl/ttlworker/task-manager-loop-interval")
+
+	store, do := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@global.foreign_key_checks=1")
+
+	tk.MustExec("create table t(id int primary key, t datetime) TTL=`t` + INTERVAL 1 DAY TTL_ENABLE='OFF'")
+	tk.MustExec("create table t_cascade(id int primary key, pid int, foreign key fk(pid) references t(id) on delete cascade)")
+	tk.MustExec("create table t_set_null(id int primary key, pid int, foreign key fk(pid) references t(id) on delete set null)")
+	tk.MustExec("create table t_restrict(id int primary key, pid int, foreign key fk(pid) references t(id) on delete restrict)")
+	tbl, err := do.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tblID := tbl.Meta().ID
+	tk.MustExec("insert into t values(1, now()), (2, now() - interval 2 day), (3, now() - interval 2 day), (4, now() - interval 2 day), (5, now() - interval 2 day)")
+	tk.MustExec("insert into t_cascade values(1, 1), (2, 2)")
+	tk.MustExec("insert into t_set_null values(1, 1), (3, 3)")
+	tk.MustExec("insert into t_restrict values(1, 1), (4, 4)")
+	tk.MustExec("alter table t TTL_ENABLE='ON'")
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute)
+	defer cancel()
+	_, _ = client.TriggerNewTTLJob(ctx, do.TTLJobManager().GetCommandCli(), "test", "t")
+
+	waitTTLJobFinished(t, tk, tblID)
+	// the row referenced by `t_restrict` is skipped, and the others are deleted with the foreign key actions
+	tk.MustQuery("select id from t order by id asc").Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select id, pid from t_cascade order by id asc").Check(testkit.Rows("1 1"))
+	tk.MustQuery("select id, pid from t_set_null order by id asc").Check(testkit.Rows("1 1", "3 <nil>"))
+	tk.MustQuery("select id, pid from t_restrict order by id asc").Check(testkit.Rows("1 1", "4 4"))
+	tk.MustQuery("select last_job_summary->>'$.total_rows', last_job_summary->>'$.success_rows', "+
+		"last_job_summary->>'$.error_rows', last_job_summary->>'$.skipped_rows' from mysql.tidb_ttl_table_status where table_id = ?", tblID).
+		Check(testkit.Rows("4 3 0 1"))
+}
+
 func waitTTLJobFinished(t *testing.T, tk *testkit.TestKit, tableID int64) {
 	start := time.Now()
 	for time.Since(start) < time.Minute {
@@ -51,6 +51,9 @@ func newTTLTableStatusRows(status ...*cache.TableStatus) []chunk.Row {
 		types.NewFieldType(mysql.TypeString),   // current_job_state
 		types.NewFieldType(mysql.TypeString),   // current_job_status
 		types.NewFieldType(mysql.TypeDatetime), // current_job_status_update_time
+		types.NewFieldType(mysql.TypeString),   // last_job_archive_table
+		types.NewFieldType(mysql.TypeLonglong), // last_job_archived_rows
+		types.NewFieldType(mysql.TypeLonglong), // last_job_archive_error_rows
 	}, len(status))
 	var rows []chunk.Row
 
@@ -127,6 +130,17 @@ func newTTLTableStatusRows(status ...*cache.TableStatus) []chunk.Row {
 
 		currentJobStatusUpdateTime := types.NewDatum(types.NewTime(types.FromGoTime(s.CurrentJobStatusUpdateTime), mysql.TypeDatetime, types.MaxFsp))
 		c.AppendDatum(16, &currentJobStatusUpdateTime)
+
+		if s.LastJobArchiveTable == "" {
+			c.AppendNull(17)
+		} else {
+			lastJobArchiveTable := types.NewDatum(s.LastJobArchiveTable)
+			c.AppendDatum(17, &lastJobArchiveTable)
+		}
+		lastJobArchivedRows := types.NewDatum(s.LastJobArchivedRows)
+		c.AppendDatum(18, &lastJobArchivedRows)
+		lastJobArchiveErrorRows := types.NewDatum(s.LastJobArchiveErrorRows)
+		c.AppendDatum(19, &lastJobArchiveErrorRows)
 	}
 
 	iter := chunk.NewIterator4Chunk(c)
@@ -136,7 +150,7 @@ func newTTLTableStatusRows(status ...*cache.TableStatus) []chunk.Row {
 	return rows
 }
 
-var updateStatusSQL = "SELECT LOW_PRIORITY table_id,parent_table_id,table_statistics,last_job_id,last_job_start_time,last_job_finish_time,last_job_ttl_expire,last_job_summary,current_job_id,current_job_owner_id,current_job_owner_addr,current_job_owner_hb_time,current_job_start_time,current_job_ttl_expire,current_job_state,current_job_status,current_job_status_update_time FROM mysql.tidb_ttl_table_status"
+var updateStatusSQL = "SELECT LOW_PRIORITY table_id,parent_table_id,table_statistics,last_job_id,last_job_start_time,last_job_finish_time,last_job_ttl_expire,last_job_summary,current_job_id,current_job_owner_id,current_job_owner_addr,current_job_owner_hb_time,current_job_start_time,current_job_ttl_expire,current_job_state,current_job_status,current_job_status_update_time,last_job_archive_table,last_job_archived_rows,last_job_archive_error_rows FROM mysql.tidb_ttl_table_status"
 
 // TTLJob exports the ttlJob for test
 type TTLJob = ttlJob
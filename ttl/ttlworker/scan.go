@@ -44,6 +44,14 @@ type ttlStatistics struct {
 	TotalRows   atomic.Uint64
 	SuccessRows atomic.Uint64
 	ErrorRows   atomic.Uint64
+	// SkippedRows are the expired rows which are not deleted because they're still referenced by a foreign key
+	// with `ON DELETE RESTRICT` or `NO ACTION`
+	SkippedRows atomic.Uint64
+
+	// ArchivedRows and ArchiveErrorRows are only counted when the table has an archive table. They're also counted
+	// in SuccessRows and ErrorRows, because the expired rows are archived and deleted in the same transaction.
+	ArchivedRows     atomic.Uint64
+	ArchiveErrorRows atomic.Uint64
 }
 
 func (s *ttlStatistics) IncTotalRows(cnt int) {
@@ -61,14 +69,32 @@ func (s *ttlStatistics) IncErrorRows(cnt int) {
 	s.ErrorRows.Add(uint64(cnt))
 }
 
+func (s *ttlStatistics) IncSkippedRows(cnt int) {
+	metrics.DeleteSkippedExpiredRows.Add(float64(cnt))
+	s.SkippedRows.Add(uint64(cnt))
+}
+
+func (s *ttlStatistics) IncArchivedRows(cnt int) {
+	metrics.ArchiveSuccessExpiredRows.Add(float64(cnt))
+	s.ArchivedRows.Add(uint64(cnt))
+}
+
+func (s *ttlStatistics) IncArchiveErrorRows(cnt int) {
+	metrics.ArchiveErrorExpiredRows.Add(float64(cnt))
+	s.ArchiveErrorRows.Add(uint64(cnt))
+}
+
 func (s *ttlStatistics) Reset() {
 	s.SuccessRows.Store(0)
 	s.ErrorRows.Store(0)
+	s.SkippedRows.Store(0)
 	s.TotalRows.Store(0)
+	s.ArchivedRows.Store(0)
+	s.ArchiveErrorRows.Store(0)
 }
 
 func (s *ttlStatistics) String() string {
-	return fmt.Sprintf("Total Rows: %d, Success Rows: %d, Error Rows: %d", s.TotalRows.Load(), s.SuccessRows.Load(), s.ErrorRows.Load())
+	return fmt.Sprintf("Total Rows: %d, Success Rows: %d, Error Rows: %d, Skipped Rows: %d", s.TotalRows.Load(), s.SuccessRows.Load(), s.ErrorRows.Load(), s.SkippedRows.Load())
 }
 
 type ttlScanTask struct {
@@ -85,6 +85,7 @@ func getSession(pool sessionPool) (session.Session, error) {
 	originalRetryLimit := sctx.GetSessionVars().RetryLimit
 	originalEnable1PC := sctx.GetSessionVars().Enable1PC
 	originalEnableAsyncCommit := sctx.GetSessionVars().EnableAsyncCommit
+	originalForeignKeyChecks := sctx.GetSessionVars().ForeignKeyChecks
 	se := session.NewSession(sctx, exec, func(se session.Session) {
 		_, err = se.ExecuteSQL(context.Background(), fmt.Sprintf("set tidb_retry_limit=%d", originalRetryLimit))
 		if err != nil {
@@ -101,6 +102,9 @@ func getSession(pool sessionPool) (session.Session, error) {
 			terror.Log(err)
 		}
 
+		_, err = se.ExecuteSQL(context.Background(), fmt.Sprintf("set foreign_key_checks=%s", variable.BoolToOnOff(originalForeignKeyChecks)))
+		terror.Log(err)
+
 		DetachStatsCollector(exec)
 
 		pool.Put(resource)
@@ -129,6 +133,13 @@ func getSession(pool sessionPool) (session.Session, error) {
 		return nil, err
 	}
 
+	// follow the global foreign_key_checks, so the TTL deletes do the foreign key actions like other deletes
+	_, err = se.ExecuteSQL(context.Background(), "set foreign_key_checks=@@global.foreign_key_checks")
+	if err != nil {
+		se.Close()
+		return nil, err
+	}
+
 	// Force rollback the session to guarantee the session is not in any explicit transaction
 	if _, err = se.ExecuteSQL(context.Background(), "ROLLBACK"); err != nil {
 		se.Close()
@@ -153,6 +164,11 @@ type ttlTableSession struct {
 }
 
 func (s *ttlTableSession) ExecuteSQLWithCheck(ctx context.Context, sql string) ([]chunk.Row, bool, error) {
+	return s.ExecuteSQLsWithCheck(ctx, sql)
+}
+
+// ExecuteSQLsWithCheck executes the SQLs one by one in one transaction and returns the rows of the last one.
+func (s *ttlTableSession) ExecuteSQLsWithCheck(ctx context.Context, sqls ...string) ([]chunk.Row, bool, error) {
 	tracer := metrics.PhaseTracerFromCtx(ctx)
 	defer tracer.EnterPhase(tracer.Phase())
 
@@ -170,7 +186,13 @@ func (s *ttlTableSession) ExecuteSQLWithCheck(ctx context.Context, sql string) (
 	err := s.RunInTxn(ctx, func() error {
 		tracer.EnterPhase(metrics.PhaseQuery)
 		defer tracer.EnterPhase(tracer.Phase())
-		rows, err := s.ExecuteSQL(ctx, sql)
+		var rows []chunk.Row
+		var err error
+		for _, sql := range sqls {
+			if rows, err = s.ExecuteSQL(ctx, sql); err != nil {
+				break
+			}
+		}
 		tracer.EnterPhase(metrics.PhaseCheckTTL)
 		// We must check the configuration after ExecuteSQL because of MDL and the meta the current transaction used
 		// can only be determined after executed one query.
@@ -232,6 +254,15 @@ func validateTTLWork(ctx context.Context, s session.Session, tbl *cache.Physical
 		return errors.New("time column name changed")
 	}
 
+	if newTTLTbl.TimestampUnit() != tbl.TimestampUnit() {
+		return errors.New("timestamp unit changed")
+	}
+
+	if newArchive, archive := newTTLTbl.ArchiveTable(), tbl.ArchiveTable(); (newArchive == nil) != (archive == nil) ||
+		(archive != nil && (newArchive.Schema.L != archive.Schema.L || newArchive.Table.L != archive.Table.L)) {
+		return errors.New("archive table changed")
+	}
+
 	if newTblInfo.TTLInfo.IntervalExprStr != tbl.TTLInfo.IntervalExprStr ||
 		newTblInfo.TTLInfo.IntervalTimeUnit != tbl.TTLInfo.IntervalTimeUnit {
 		newExpireTime, err := newTTLTbl.EvalExpireTime(ctx, s, s.Now())
@@ -430,9 +430,12 @@ func (m *taskManager) syncTaskFromTable(se session.Session, jobID string, scanID
 func (m *taskManager) updateHeartBeat(ctx context.Context, se session.Session, now time.Time) error {
 	for _, task := range m.runningTasks {
 		state := &cache.TTLTaskState{
-			TotalRows:   task.statistics.TotalRows.Load(),
-			SuccessRows: task.statistics.SuccessRows.Load(),
-			ErrorRows:   task.statistics.ErrorRows.Load(),
+			TotalRows:        task.statistics.TotalRows.Load(),
+			SuccessRows:      task.statistics.SuccessRows.Load(),
+			ErrorRows:        task.statistics.ErrorRows.Load(),
+			SkippedRows:      task.statistics.SkippedRows.Load(),
+			ArchivedRows:     task.statistics.ArchivedRows.Load(),
+			ArchiveErrorRows: task.statistics.ArchiveErrorRows.Load(),
 		}
 		if task.result != nil && task.result.err != nil {
 			state.ScanTaskErr = task.result.err.Error()
@@ -470,9 +473,12 @@ func (m *taskManager) checkFinishedTask(se session.Session, now time.Time) {
 
 func (m *taskManager) reportTaskFinished(se session.Session, now time.Time, task *runningScanTask) error {
 	state := &cache.TTLTaskState{
-		TotalRows:   task.statistics.TotalRows.Load(),
-		SuccessRows: task.statistics.SuccessRows.Load(),
-		ErrorRows:   task.statistics.ErrorRows.Load(),
+		TotalRows:        task.statistics.TotalRows.Load(),
+		SuccessRows:      task.statistics.SuccessRows.Load(),
+		ErrorRows:        task.statistics.ErrorRows.Load(),
+		SkippedRows:      task.statistics.SkippedRows.Load(),
+		ArchivedRows:     task.statistics.ArchivedRows.Load(),
+		ArchiveErrorRows: task.statistics.ArchiveErrorRows.Load(),
 	}
 	if task.result.err != nil {
 		state.ScanTaskErr = task.result.err.Error()
@@ -575,5 +581,5 @@ type runningScanTask struct {
 }
 
 func (t *runningScanTask) finished() bool {
-	return t.result != nil && t.statistics.TotalRows.Load() == t.statistics.ErrorRows.Load()+t.statistics.SuccessRows.Load()
+	return t.result != nil && t.statistics.TotalRows.Load() == t.statistics.ErrorRows.Load()+t.statistics.SuccessRows.Load()+t.statistics.SkippedRows.Load()
 }
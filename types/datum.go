@@ -939,6 +939,8 @@ func (d *Datum) ConvertTo(sc *stmtctx.StatementContext, target *FieldType) (Datu
 		return d.convertToMysqlSet(sc, target)
 	case mysql.TypeJSON:
 		return d.convertToMysqlJSON(sc, target)
+	case mysql.TypeGeometry:
+		return d.convertToGeometry(sc, target)
 	case mysql.TypeNull:
 		return Datum{}, nil
 	default:
@@ -1686,6 +1688,22 @@ func (d *Datum) convertToMysqlSet(sc *stmtctx.StatementContext, target *FieldTyp
 	return ret, err
 }
 
+// convertToGeometry checks whether the datum is a valid geometry in the
+// internal format, and the geometry type can be stored in the target type.
+func (d *Datum) convertToGeometry(_ *stmtctx.StatementContext, target *FieldType) (ret Datum, err error) {
+	switch d.k {
+	case KindString, KindBytes, KindBinaryLiteral:
+		g, ok := DecodeGeometry(d.GetBytes())
+		if !ok || !IsGeometrySubtypeOf(g.Tp, target.GetGeometryType()) {
+			return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+		}
+		ret.SetString(d.GetString(), target.GetCollate())
+	default:
+		return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+	}
+	return ret, nil
+}
+
 func (d *Datum) convertToMysqlJSON(_ *stmtctx.StatementContext, _ *FieldType) (ret Datum, err error) {
 	switch d.k {
 	case KindString, KindBytes:
@@ -2349,7 +2367,8 @@ func GetMaxValue(ft *FieldType) (max Datum) {
 		max.SetFloat32(float32(GetMaxFloat(ft.GetFlen(), ft.GetDecimal())))
 	case mysql.TypeDouble:
 		max.SetFloat64(GetMaxFloat(ft.GetFlen(), ft.GetDecimal()))
-	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob,
+		mysql.TypeGeometry:
 		// codec.Encode KindMaxValue, to avoid import circle
 		bytes := []byte{250}
 		max.SetString(string(bytes), ft.GetCollate())
@@ -2380,7 +2399,8 @@ func GetMinValue(ft *FieldType) (min Datum) {
 		min.SetFloat32(float32(-GetMaxFloat(ft.GetFlen(), ft.GetDecimal())))
 	case mysql.TypeDouble:
 		min.SetFloat64(-GetMaxFloat(ft.GetFlen(), ft.GetDecimal()))
-	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob,
+		mysql.TypeGeometry:
 		// codec.Encode KindMinNotNull, to avoid import circle
 		bytes := []byte{1}
 		min.SetString(string(bytes), ft.GetCollate())
@@ -94,4 +94,22 @@ var (
 	ErrPartitionColumnStatsMissing = dbterror.ClassTypes.NewStd(mysql.ErrPartitionColumnStatsMissing)
 	// ErrIncorrectDatetimeValue is returned when the input value is in wrong format for datetime.
 	ErrIncorrectDatetimeValue = dbterror.ClassTypes.NewStd(mysql.ErrIncorrectDatetimeValue)
+	// ErrCantCreateGeometryObject is returned when the value can't be stored in a spatial column.
+	ErrCantCreateGeometryObject = dbterror.ClassTypes.NewStd(mysql.ErrCantCreateGeometryObject)
+	// ErrGISInvalidData is returned when the spatial function gets an invalid geometry.
+	ErrGISInvalidData = dbterror.ClassTypes.NewStd(mysql.ErrGISInvalidData)
+	// ErrGISDifferentSRIDs is returned when the geometries of a binary spatial function have different SRIDs.
+	ErrGISDifferentSRIDs = dbterror.ClassTypes.NewStd(mysql.ErrGISDifferentSRIDs)
+	// ErrGISUnsupportedArgument is returned when the spatial function doesn't support the geometry types.
+	ErrGISUnsupportedArgument = dbterror.ClassTypes.NewStd(mysql.ErrGISUnsupportedArgument)
+	// ErrSRSNotFound is returned when the SRID is not a known spatial reference system.
+	ErrSRSNotFound = dbterror.ClassTypes.NewStd(mysql.ErrSRSNotFound)
+	// ErrLongitudeOutOfRange is returned when the longitude of a geographic point is out of range.
+	ErrLongitudeOutOfRange = dbterror.ClassTypes.NewStd(mysql.ErrLongitudeOutOfRange)
+	// ErrLatitudeOutOfRange is returned when the latitude of a geographic point is out of range.
+	ErrLatitudeOutOfRange = dbterror.ClassTypes.NewStd(mysql.ErrLatitudeOutOfRange)
+	// ErrWrongSRIDForColumn is returned when the SRID of the geometry doesn't match the SRID of the column.
+	ErrWrongSRIDForColumn = dbterror.ClassTypes.NewStd(mysql.ErrWrongSRIDForColumn)
+	// ErrNonPositiveRadius is returned when the sphere radius of a spatial function isn't positive.
+	ErrNonPositiveRadius = dbterror.ClassTypes.NewStd(mysql.ErrNonPositiveRadius)
 )
@@ -51,7 +51,7 @@ func IsTypeUnspecified(tp byte) bool {
 // IsTypePrefixable returns a boolean indicating
 // whether an index on a column with the tp can be defined with a prefix.
 func IsTypePrefixable(tp byte) bool {
-	return IsTypeBlob(tp) || IsTypeChar(tp)
+	return IsTypeBlob(tp) || IsTypeChar(tp) || tp == mysql.TypeGeometry
 }
 
 // IsTypeFractionable returns a boolean indicating
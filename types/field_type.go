@@ -1402,6 +1402,12 @@ func CheckModifyTypeCompatible(origin *FieldType, to *FieldType) (canReorg bool,
 }
 
 func needReorgToChange(origin *FieldType, to *FieldType) (needReorg bool, reasonMsg string) {
+	if origin.GetType() == mysql.TypeGeometry && to.GetType() == mysql.TypeGeometry {
+		if GeometryChangeNeedsReorg(origin, to) {
+			return true, "spatial type or SRID change needs reorganization"
+		}
+		return false, ""
+	}
 	toFlen := to.GetFlen()
 	originFlen := origin.GetFlen()
 	if mysql.IsIntegerType(to.GetType()) && mysql.IsIntegerType(origin.GetType()) {
@@ -1435,7 +1441,24 @@ func needReorgToChange(origin *FieldType, to *FieldType) (needReorg bool, reason
 	return false, ""
 }
 
+// GeometryChangeNeedsReorg checks whether the values of a spatial column need to be
+// checked when changing the column type, which means the geometry subtype or the
+// SRID of the new type is more restrictive.
+func GeometryChangeNeedsReorg(origin *FieldType, to *FieldType) bool {
+	if !IsGeometrySubtypeOf(origin.GetGeometryType(), to.GetGeometryType()) {
+		return true
+	}
+	toSRID, toHasSRID := to.GetSRID()
+	originSRID, originHasSRID := origin.GetSRID()
+	return toHasSRID && (!originHasSRID || originSRID != toSRID)
+}
+
 func checkTypeChangeSupported(origin *FieldType, to *FieldType) bool {
+	if (origin.GetType() == mysql.TypeGeometry) != (to.GetType() == mysql.TypeGeometry) {
+		// TODO: Currently spatial types can only be changed to spatial types.
+		return false
+	}
+
 	if (IsTypeTime(origin.GetType()) || origin.GetType() == mysql.TypeDuration || origin.GetType() == mysql.TypeYear ||
 		IsString(origin.GetType()) || origin.GetType() == mysql.TypeJSON) &&
 		to.GetType() == mysql.TypeBit {
@@ -0,0 +1,730 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+
+	ast "github.com/pingcap/tidb/parser/types"
+)
+
+const (
+	// SRIDCartesian is the SRID of the cartesian plane, which is the default SRID of geometries.
+	SRIDCartesian uint32 = 0
+	// SRIDWGS84 is the SRID of the WGS 84 geographic spatial reference system.
+	SRIDWGS84 uint32 = 4326
+	// DefaultSphereRadius is the default sphere radius of ST_Distance_Sphere in meters.
+	DefaultSphereRadius = 6370986.0
+)
+
+// IsKnownSRID checks whether the SRID is a supported spatial reference system.
+func IsKnownSRID(srid uint32) bool {
+	return srid == SRIDCartesian || srid == SRIDWGS84
+}
+
+// GeomPoint is a point of a geometry. For geographic spatial reference systems,
+// X is the longitude and Y is the latitude.
+type GeomPoint struct {
+	X, Y float64
+}
+
+// Geometry is a decoded spatial value. Geometries are stored in the MySQL
+// internal format, which is a 4-byte little-endian SRID followed by the WKB
+// representation of the geometry.
+type Geometry struct {
+	SRID uint32
+	// Tp is one of the geometry subtypes except GeometryTypeGeometry.
+	Tp byte
+	// coords are the coordinates of a Point or a LineString.
+	coords []GeomPoint
+	// rings are the rings of a Polygon, the first one is the exterior ring.
+	rings [][]GeomPoint
+	// geoms are the children of a MultiPoint, MultiLineString, MultiPolygon or GeometryCollection.
+	geoms []Geometry
+}
+
+// geometry header sizes in the internal format.
+const (
+	geomSRIDLen      = 4
+	wkbHeaderLen     = 5
+	wkbLittleEndian  = 1
+	wkbBigEndian     = 0
+	geomMaxNestDepth = 64
+)
+
+// IsGeometrySubtypeOf checks whether a geometry of type tp can be stored in a
+// column of the geometry subtype colTp.
+func IsGeometrySubtypeOf(tp, colTp byte) bool {
+	switch colTp {
+	case ast.GeometryTypeGeometry:
+		return true
+	case ast.GeometryTypeGeometryCollection:
+		return tp == ast.GeometryTypeGeometryCollection || tp == ast.GeometryTypeMultiPoint ||
+			tp == ast.GeometryTypeMultiLineString || tp == ast.GeometryTypeMultiPolygon
+	}
+	return tp == colTp
+}
+
+// GeometrySRID returns the SRID of a geometry in the internal format.
+func GeometrySRID(b []byte) uint32 {
+	if len(b) < geomSRIDLen {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+// DecodeGeometry decodes a geometry in the internal format. The second return
+// value is false if the data is not a valid geometry.
+func DecodeGeometry(b []byte) (Geometry, bool) {
+	if len(b) < geomSRIDLen+wkbHeaderLen {
+		return Geometry{}, false
+	}
+	g, rest, ok := decodeWKB(b[geomSRIDLen:], 0)
+	if !ok || len(rest) != 0 {
+		return Geometry{}, false
+	}
+	g.setSRID(binary.LittleEndian.Uint32(b))
+	return g, true
+}
+
+// Encode encodes the geometry in the internal format.
+func (g *Geometry) Encode() []byte {
+	b := make([]byte, geomSRIDLen, 64)
+	binary.LittleEndian.PutUint32(b, g.SRID)
+	return g.appendWKB(b)
+}
+
+func (g *Geometry) setSRID(srid uint32) {
+	g.SRID = srid
+	for i := range g.geoms {
+		g.geoms[i].setSRID(srid)
+	}
+}
+
+func (g *Geometry) appendWKB(b []byte) []byte {
+	b = append(b, wkbLittleEndian)
+	b = binary.LittleEndian.AppendUint32(b, uint32(g.Tp))
+	switch g.Tp {
+	case ast.GeometryTypePoint:
+		b = appendWKBPoint(b, g.coords[0])
+	case ast.GeometryTypeLineString:
+		b = appendWKBPoints(b, g.coords)
+	case ast.GeometryTypePolygon:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(g.rings)))
+		for _, ring := range g.rings {
+			b = appendWKBPoints(b, ring)
+		}
+	default:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(g.geoms)))
+		for i := range g.geoms {
+			b = g.geoms[i].appendWKB(b)
+		}
+	}
+	return b
+}
+
+func appendWKBPoint(b []byte, p GeomPoint) []byte {
+	b = binary.LittleEndian.AppendUint64(b, math.Float64bits(p.X))
+	return binary.LittleEndian.AppendUint64(b, math.Float64bits(p.Y))
+}
+
+func appendWKBPoints(b []byte, points []GeomPoint) []byte {
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(points)))
+	for _, p := range points {
+		b = appendWKBPoint(b, p)
+	}
+	return b
+}
+
+// wkbReader reads the numbers of a WKB geometry in its byte order.
+type wkbReader struct {
+	order binary.ByteOrder
+	data  []byte
+	ok    bool
+}
+
+func (r *wkbReader) uint32() uint32 {
+	if len(r.data) < 4 {
+		r.ok = false
+		return 0
+	}
+	v := r.order.Uint32(r.data)
+	r.data = r.data[4:]
+	return v
+}
+
+func (r *wkbReader) point() GeomPoint {
+	if len(r.data) < 16 {
+		r.ok = false
+		return GeomPoint{}
+	}
+	p := GeomPoint{
+		X: math.Float64frombits(r.order.Uint64(r.data)),
+		Y: math.Float64frombits(r.order.Uint64(r.data[8:])),
+	}
+	r.data = r.data[16:]
+	if math.IsNaN(p.X) || math.IsInf(p.X, 0) || math.IsNaN(p.Y) || math.IsInf(p.Y, 0) {
+		r.ok = false
+	}
+	return p
+}
+
+func (r *wkbReader) points(minCnt int) []GeomPoint {
+	n := r.uint32()
+	// Every point takes 16 bytes, so the count can't be larger than the left data.
+	if !r.ok || int(n) < minCnt || uint64(n)*16 > uint64(len(r.data)) {
+		r.ok = false
+		return nil
+	}
+	points := make([]GeomPoint, 0, n)
+	for i := uint32(0); i < n && r.ok; i++ {
+		points = append(points, r.point())
+	}
+	return points
+}
+
+// decodeWKB decodes a WKB geometry and returns the remaining data.
+func decodeWKB(b []byte, depth int) (g Geometry, rest []byte, ok bool) {
+	if len(b) < wkbHeaderLen || depth > geomMaxNestDepth {
+		return g, nil, false
+	}
+	r := wkbReader{ok: true}
+	switch b[0] {
+	case wkbLittleEndian:
+		r.order = binary.LittleEndian
+	case wkbBigEndian:
+		r.order = binary.BigEndian
+	default:
+		return g, nil, false
+	}
+	r.data = b[1:]
+	tp := r.uint32()
+	if tp < uint32(ast.GeometryTypePoint) || tp > uint32(ast.GeometryTypeGeometryCollection) {
+		return g, nil, false
+	}
+	g.Tp = byte(tp)
+	switch g.Tp {
+	case ast.GeometryTypePoint:
+		g.coords = []GeomPoint{r.point()}
+	case ast.GeometryTypeLineString:
+		g.coords = r.points(2)
+	case ast.GeometryTypePolygon:
+		n := r.uint32()
+		if !r.ok || n == 0 {
+			return g, nil, false
+		}
+		for i := uint32(0); i < n && r.ok; i++ {
+			ring := r.points(4)
+			if r.ok && ring[0] != ring[len(ring)-1] {
+				return g, nil, false
+			}
+			g.rings = append(g.rings, ring)
+		}
+	default:
+		n := r.uint32()
+		if !r.ok || (n == 0 && g.Tp != ast.GeometryTypeGeometryCollection) {
+			return g, nil, false
+		}
+		for i := uint32(0); i < n; i++ {
+			var child Geometry
+			child, r.data, r.ok = decodeWKB(r.data, depth+1)
+			if !r.ok || !isValidChildGeometry(g.Tp, child.Tp) {
+				return g, nil, false
+			}
+			g.geoms = append(g.geoms, child)
+		}
+	}
+	if !r.ok {
+		return g, nil, false
+	}
+	return g, r.data, true
+}
+
+func isValidChildGeometry(tp, childTp byte) bool {
+	switch tp {
+	case ast.GeometryTypeMultiPoint:
+		return childTp == ast.GeometryTypePoint
+	case ast.GeometryTypeMultiLineString:
+		return childTp == ast.GeometryTypeLineString
+	case ast.GeometryTypeMultiPolygon:
+		return childTp == ast.GeometryTypePolygon
+	}
+	return true
+}
+
+// isGeographic returns whether the coordinates are in a geographic spatial
+// reference system. The axis order of geographic WKT is latitude-longitude,
+// while the coordinates are stored as longitude-latitude.
+func isGeographic(srid uint32) bool {
+	return srid == SRIDWGS84
+}
+
+// ParseGeometryFromText parses a geometry from its WKT representation. The
+// second return value is false if the text is not a valid WKT.
+func ParseGeometryFromText(wkt string, srid uint32) (Geometry, bool) {
+	p := wktParser{s: wkt, swapAxes: isGeographic(srid)}
+	g, ok := p.parseGeometry(0)
+	if !ok {
+		return Geometry{}, false
+	}
+	p.skipSpaces()
+	if p.pos != len(p.s) {
+		return Geometry{}, false
+	}
+	g.setSRID(srid)
+	return g, true
+}
+
+type wktParser struct {
+	s        string
+	pos      int
+	swapAxes bool
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n' || p.s[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+func (p *wktParser) consume(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) peek(c byte) bool {
+	p.skipSpaces()
+	return p.pos < len(p.s) && p.s[p.pos] == c
+}
+
+func (p *wktParser) word() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && (p.s[p.pos] >= 'a' && p.s[p.pos] <= 'z' || p.s[p.pos] >= 'A' && p.s[p.pos] <= 'Z') {
+		p.pos++
+	}
+	return strings.ToUpper(p.s[start:p.pos])
+}
+
+func (p *wktParser) number() (float64, bool) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("+-.0123456789eE", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	if err != nil || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
+
+func (p *wktParser) point() (GeomPoint, bool) {
+	x, ok1 := p.number()
+	y, ok2 := p.number()
+	if p.swapAxes {
+		x, y = y, x
+	}
+	return GeomPoint{X: x, Y: y}, ok1 && ok2
+}
+
+// points parses `(x y, x y, ...)`.
+func (p *wktParser) points(minCnt int) ([]GeomPoint, bool) {
+	if !p.consume('(') {
+		return nil, false
+	}
+	var points []GeomPoint
+	for {
+		pt, ok := p.point()
+		if !ok {
+			return nil, false
+		}
+		points = append(points, pt)
+		if !p.consume(',') {
+			break
+		}
+	}
+	return points, p.consume(')') && len(points) >= minCnt
+}
+
+// polygon parses `((x y, ...), (x y, ...))`.
+func (p *wktParser) polygon() (Geometry, bool) {
+	g := Geometry{Tp: ast.GeometryTypePolygon}
+	if !p.consume('(') {
+		return g, false
+	}
+	for {
+		ring, ok := p.points(4)
+		if !ok || ring[0] != ring[len(ring)-1] {
+			return g, false
+		}
+		g.rings = append(g.rings, ring)
+		if !p.consume(',') {
+			break
+		}
+	}
+	return g, p.consume(')')
+}
+
+func (p *wktParser) parseGeometry(depth int) (g Geometry, ok bool) {
+	if depth > geomMaxNestDepth {
+		return g, false
+	}
+	switch p.word() {
+	case "POINT":
+		if !p.consume('(') {
+			return g, false
+		}
+		pt, ok := p.point()
+		return Geometry{Tp: ast.GeometryTypePoint, coords: []GeomPoint{pt}}, ok && p.consume(')')
+	case "LINESTRING":
+		g.Tp = ast.GeometryTypeLineString
+		g.coords, ok = p.points(2)
+		return g, ok
+	case "POLYGON":
+		return p.polygon()
+	case "MULTIPOINT":
+		g.Tp = ast.GeometryTypeMultiPoint
+		if !p.consume('(') {
+			return g, false
+		}
+		for {
+			// Both `MULTIPOINT(0 0, 1 1)` and `MULTIPOINT((0 0), (1 1))` are valid.
+			parens := p.consume('(')
+			pt, ok := p.point()
+			if !ok || (parens && !p.consume(')')) {
+				return g, false
+			}
+			g.geoms = append(g.geoms, Geometry{Tp: ast.GeometryTypePoint, coords: []GeomPoint{pt}})
+			if !p.consume(',') {
+				break
+			}
+		}
+		return g, p.consume(')')
+	case "MULTILINESTRING":
+		g.Tp = ast.GeometryTypeMultiLineString
+		if !p.consume('(') {
+			return g, false
+		}
+		for {
+			coords, ok := p.points(2)
+			if !ok {
+				return g, false
+			}
+			g.geoms = append(g.geoms, Geometry{Tp: ast.GeometryTypeLineString, coords: coords})
+			if !p.consume(',') {
+				break
+			}
+		}
+		return g, p.consume(')')
+	case "MULTIPOLYGON":
+		g.Tp = ast.GeometryTypeMultiPolygon
+		if !p.consume('(') {
+			return g, false
+		}
+		for {
+			poly, ok := p.polygon()
+			if !ok {
+				return g, false
+			}
+			g.geoms = append(g.geoms, poly)
+			if !p.consume(',') {
+				break
+			}
+		}
+		return g, p.consume(')')
+	case "GEOMETRYCOLLECTION", "GEOMCOLLECTION":
+		g.Tp = ast.GeometryTypeGeometryCollection
+		if p.word() == "EMPTY" {
+			return g, true
+		}
+		if !p.consume('(') {
+			return g, false
+		}
+		if p.consume(')') {
+			return g, true
+		}
+		for {
+			child, ok := p.parseGeometry(depth + 1)
+			if !ok {
+				return g, false
+			}
+			g.geoms = append(g.geoms, child)
+			if !p.consume(',') {
+				break
+			}
+		}
+		return g, p.consume(')')
+	}
+	return g, false
+}
+
+// AsText returns the WKT representation of the geometry.
+func (g *Geometry) AsText() string {
+	var sb strings.Builder
+	g.writeText(&sb, true)
+	return sb.String()
+}
+
+func (g *Geometry) writeText(sb *strings.Builder, withName bool) {
+	if withName {
+		if g.Tp == ast.GeometryTypeGeometryCollection {
+			sb.WriteString("GEOMETRYCOLLECTION")
+			if len(g.geoms) == 0 {
+				sb.WriteString(" EMPTY")
+				return
+			}
+		} else {
+			sb.WriteString(strings.ToUpper(ast.GeometryTypeStr(g.Tp)))
+		}
+	}
+	sb.WriteByte('(')
+	switch g.Tp {
+	case ast.GeometryTypePoint, ast.GeometryTypeLineString:
+		g.writePoints(sb, g.coords)
+	case ast.GeometryTypePolygon:
+		for i, ring := range g.rings {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteByte('(')
+			g.writePoints(sb, ring)
+			sb.WriteByte(')')
+		}
+	default:
+		for i := range g.geoms {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			// The children of a GeometryCollection have names, while the others don't.
+			g.geoms[i].writeText(sb, g.Tp == ast.GeometryTypeGeometryCollection)
+		}
+	}
+	sb.WriteByte(')')
+}
+
+func (g *Geometry) writePoints(sb *strings.Builder, points []GeomPoint) {
+	for i, pt := range points {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		x, y := pt.X, pt.Y
+		if isGeographic(g.SRID) {
+			x, y = y, x
+		}
+		sb.WriteString(formatGeomNumber(x))
+		sb.WriteByte(' ')
+		sb.WriteString(formatGeomNumber(y))
+	}
+}
+
+func formatGeomNumber(f float64) string {
+	if f == 0 {
+		// Avoid printing -0.
+		return "0"
+	}
+	return strings.Replace(strconv.FormatFloat(f, 'g', -1, 64), "e+", "e", 1)
+}
+
+// CheckGeographicRange checks whether the coordinates of a geographic geometry
+// are in the range of longitude (-180, 180] and latitude [-90, 90].
+func (g *Geometry) CheckGeographicRange(funcName string) error {
+	if !isGeographic(g.SRID) {
+		return nil
+	}
+	return g.checkCoordinateRange(funcName)
+}
+
+func (g *Geometry) checkCoordinateRange(funcName string) error {
+	check := func(points []GeomPoint) error {
+		for _, pt := range points {
+			if pt.X <= -180 || pt.X > 180 {
+				return ErrLongitudeOutOfRange.GenWithStackByArgs(pt.X, funcName, -180.0, 180.0)
+			}
+			if pt.Y < -90 || pt.Y > 90 {
+				return ErrLatitudeOutOfRange.GenWithStackByArgs(pt.Y, funcName, -90.0, 90.0)
+			}
+		}
+		return nil
+	}
+	if err := check(g.coords); err != nil {
+		return err
+	}
+	for _, ring := range g.rings {
+		if err := check(ring); err != nil {
+			return err
+		}
+	}
+	for i := range g.geoms {
+		if err := g.geoms[i].checkCoordinateRange(funcName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// points returns the points of a Point or a MultiPoint.
+func (g *Geometry) points() ([]GeomPoint, bool) {
+	switch g.Tp {
+	case ast.GeometryTypePoint:
+		return g.coords, true
+	case ast.GeometryTypeMultiPoint:
+		points := make([]GeomPoint, 0, len(g.geoms))
+		for i := range g.geoms {
+			points = append(points, g.geoms[i].coords[0])
+		}
+		return points, true
+	}
+	return nil, false
+}
+
+// polygons returns the polygons of a Polygon or a MultiPolygon.
+func (g *Geometry) polygons() ([][][]GeomPoint, bool) {
+	switch g.Tp {
+	case ast.GeometryTypePolygon:
+		return [][][]GeomPoint{g.rings}, true
+	case ast.GeometryTypeMultiPolygon:
+		polys := make([][][]GeomPoint, 0, len(g.geoms))
+		for i := range g.geoms {
+			polys = append(polys, g.geoms[i].rings)
+		}
+		return polys, true
+	}
+	return nil, false
+}
+
+// Contains checks whether the geometry contains the other geometry, which
+// means no points of other lie in the exterior of g, and at least one point
+// of the interior of other lies in the interior of g. Only (Multi)Point and
+// (Multi)Polygon containing (Multi)Point are supported, the second return
+// value is false for other geometry types. Geographic coordinates are
+// computed on the longitude-latitude plane.
+func (g *Geometry) Contains(other *Geometry) (contains bool, supported bool) {
+	points, ok := other.points()
+	if !ok {
+		return false, false
+	}
+	if container, ok := g.points(); ok {
+		for _, pt := range points {
+			if !containsGeomPoint(container, pt) {
+				return false, true
+			}
+		}
+		return true, true
+	}
+	polys, ok := g.polygons()
+	if !ok {
+		return false, false
+	}
+	hasInterior := false
+	for _, pt := range points {
+		inside, onBoundary := false, false
+		for _, poly := range polys {
+			in, on := locatePointInPolygon(poly, pt)
+			inside, onBoundary = inside || in, onBoundary || on
+		}
+		if !inside && !onBoundary {
+			return false, true
+		}
+		hasInterior = hasInterior || inside
+	}
+	return hasInterior, true
+}
+
+func containsGeomPoint(points []GeomPoint, pt GeomPoint) bool {
+	for _, p := range points {
+		if p == pt {
+			return true
+		}
+	}
+	return false
+}
+
+// locatePointInPolygon returns whether the point is in the interior or on the
+// boundary of the polygon.
+func locatePointInPolygon(rings [][]GeomPoint, pt GeomPoint) (inside bool, onBoundary bool) {
+	for i, ring := range rings {
+		in, on := locatePointInRing(ring, pt)
+		if on {
+			return false, true
+		}
+		if i == 0 && !in {
+			return false, false
+		}
+		// The point is in a hole.
+		if i > 0 && in {
+			return false, false
+		}
+	}
+	return true, false
+}
+
+// locatePointInRing uses the ray casting algorithm to check whether the point is inside the ring.
+func locatePointInRing(ring []GeomPoint, pt GeomPoint) (inside bool, onBoundary bool) {
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		cross := (b.X-a.X)*(pt.Y-a.Y) - (b.Y-a.Y)*(pt.X-a.X)
+		if cross == 0 && math.Min(a.X, b.X) <= pt.X && pt.X <= math.Max(a.X, b.X) &&
+			math.Min(a.Y, b.Y) <= pt.Y && pt.Y <= math.Max(a.Y, b.Y) {
+			return false, true
+		}
+		if (a.Y > pt.Y) != (b.Y > pt.Y) && pt.X < (b.X-a.X)*(pt.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside, false
+}
+
+// DistanceSphere returns the minimum spherical distance between the points of
+// two (Multi)Points in meters, and the coordinates are interpreted as degrees
+// of longitude and latitude. The second return value is false if any of them
+// is not a (Multi)Point.
+func (g *Geometry) DistanceSphere(other *Geometry, radius float64) (float64, bool) {
+	points1, ok1 := g.points()
+	points2, ok2 := other.points()
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	dist := math.Inf(1)
+	for _, p1 := range points1 {
+		for _, p2 := range points2 {
+			dist = math.Min(dist, haversine(p1, p2, radius))
+		}
+	}
+	return dist, true
+}
+
+// CheckSphereRange checks whether the coordinates can be used as longitude and
+// latitude, it's used by the functions treating the cartesian coordinates as
+// geographic ones like ST_Distance_Sphere.
+func (g *Geometry) CheckSphereRange(funcName string) error {
+	return g.checkCoordinateRange(funcName)
+}
+
+func haversine(p1, p2 GeomPoint, radius float64) float64 {
+	const rad = math.Pi / 180
+	lat1, lat2 := p1.Y*rad, p2.Y*rad
+	sinLat := math.Sin((lat2 - lat1) / 2)
+	sinLon := math.Sin((p2.X - p1.X) * rad / 2)
+	h := sinLat*sinLat + math.Cos(lat1)*math.Cos(lat2)*sinLon*sinLon
+	return 2 * radius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"math"
+	"testing"
+
+	ast "github.com/pingcap/tidb/parser/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeometryText(t *testing.T) {
+	tests := []struct {
+		wkt    string
+		tp     byte
+		expect string
+	}{
+		{"POINT(1 2)", ast.GeometryTypePoint, "POINT(1 2)"},
+		{" point ( -1.5  2e3 ) ", ast.GeometryTypePoint, "POINT(-1.5 2000)"},
+		{"POINT(1e20 0.1)", ast.GeometryTypePoint, "POINT(1e20 0.1)"},
+		{"LINESTRING(0 0, 1 1,2 2)", ast.GeometryTypeLineString, "LINESTRING(0 0,1 1,2 2)"},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0),(1 1,2 1,2 2,1 1))", ast.GeometryTypePolygon, "POLYGON((0 0,10 0,10 10,0 10,0 0),(1 1,2 1,2 2,1 1))"},
+		{"MULTIPOINT(0 0, 1 1)", ast.GeometryTypeMultiPoint, "MULTIPOINT((0 0),(1 1))"},
+		{"MULTIPOINT((0 0),(1 1))", ast.GeometryTypeMultiPoint, "MULTIPOINT((0 0),(1 1))"},
+		{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", ast.GeometryTypeMultiLineString, "MULTILINESTRING((0 0,1 1),(2 2,3 3))"},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((5 5,6 5,6 6,5 5)))", ast.GeometryTypeMultiPolygon, "MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((5 5,6 5,6 6,5 5)))"},
+		{"GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))", ast.GeometryTypeGeometryCollection, "GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))"},
+		{"GEOMETRYCOLLECTION EMPTY", ast.GeometryTypeGeometryCollection, "GEOMETRYCOLLECTION EMPTY"},
+		{"GEOMCOLLECTION()", ast.GeometryTypeGeometryCollection, "GEOMETRYCOLLECTION EMPTY"},
+	}
+	for _, tt := range tests {
+		g, ok := ParseGeometryFromText(tt.wkt, SRIDCartesian)
+		require.True(t, ok, tt.wkt)
+		require.Equal(t, tt.tp, g.Tp, tt.wkt)
+		require.Equal(t, tt.expect, g.AsText())
+
+		b := g.Encode()
+		require.Equal(t, uint32(SRIDCartesian), GeometrySRID(b))
+		decoded, ok := DecodeGeometry(b)
+		require.True(t, ok, tt.wkt)
+		require.Equal(t, tt.expect, decoded.AsText())
+	}
+
+	for _, wkt := range []string{
+		"POINT(1 2",
+		"POINT(1,2)",
+		"LINESTRING(0 0)",
+		"POLYGON((0 0,1 1,0 1,0 2))",
+		"FOO(1 2)",
+		"POINT(1 2) x",
+	} {
+		_, ok := ParseGeometryFromText(wkt, SRIDCartesian)
+		require.False(t, ok, wkt)
+	}
+	_, ok := DecodeGeometry([]byte("abc"))
+	require.False(t, ok)
+}
+
+func TestGeographicGeometry(t *testing.T) {
+	// The axis order of SRID 4326 is latitude-longitude.
+	g, ok := ParseGeometryFromText("POINT(45 -120)", SRIDWGS84)
+	require.True(t, ok)
+	require.Equal(t, []GeomPoint{{X: -120, Y: 45}}, g.coords)
+	require.Equal(t, "POINT(45 -120)", g.AsText())
+	require.Equal(t, uint32(SRIDWGS84), GeometrySRID(g.Encode()))
+	require.NoError(t, g.CheckGeographicRange("st_geomfromtext"))
+
+	g, ok = ParseGeometryFromText("POINT(-120 45)", SRIDWGS84)
+	require.True(t, ok)
+	err := g.CheckGeographicRange("st_geomfromtext")
+	require.True(t, ErrLatitudeOutOfRange.Equal(err), "%v", err)
+
+	// The range is only checked for the geographic SRS.
+	g, ok = ParseGeometryFromText("POINT(-120 200)", SRIDCartesian)
+	require.True(t, ok)
+	require.NoError(t, g.CheckGeographicRange("st_geomfromtext"))
+	err = g.CheckSphereRange("st_distance_sphere")
+	require.True(t, ErrLatitudeOutOfRange.Equal(err), "%v", err)
+}
+
+func TestGeometryContains(t *testing.T) {
+	poly, ok := ParseGeometryFromText("POLYGON((0 0,10 0,10 10,0 10,0 0),(4 4,6 4,6 6,4 6,4 4))", SRIDCartesian)
+	require.True(t, ok)
+	tests := []struct {
+		wkt       string
+		contains  bool
+		supported bool
+	}{
+		{"POINT(1 1)", true, true},
+		{"POINT(5 5)", false, true},
+		{"POINT(0 5)", false, true},
+		{"POINT(11 1)", false, true},
+		{"POINT(4 5)", false, true},
+		{"MULTIPOINT(0 0,1 1)", true, true},
+		{"MULTIPOINT(0 0,10 10)", false, true},
+		{"LINESTRING(1 1,2 2)", false, false},
+	}
+	for _, tt := range tests {
+		g, ok := ParseGeometryFromText(tt.wkt, SRIDCartesian)
+		require.True(t, ok, tt.wkt)
+		contains, supported := poly.Contains(&g)
+		require.Equal(t, tt.contains, contains, tt.wkt)
+		require.Equal(t, tt.supported, supported, tt.wkt)
+	}
+
+	points, _ := ParseGeometryFromText("MULTIPOINT(1 1,2 2)", SRIDCartesian)
+	point, _ := ParseGeometryFromText("POINT(2 2)", SRIDCartesian)
+	contains, supported := points.Contains(&point)
+	require.True(t, contains)
+	require.True(t, supported)
+}
+
+func TestGeometryDistanceSphere(t *testing.T) {
+	g1, _ := ParseGeometryFromText("POINT(-87.6770458 41.9631174)", SRIDCartesian)
+	g2, _ := ParseGeometryFromText("POINT(-73.9898293 40.7628267)", SRIDCartesian)
+	dist, ok := g1.DistanceSphere(&g2, DefaultSphereRadius)
+	require.True(t, ok)
+	require.InDelta(t, 1148798.72, dist, 0.01)
+
+	g1, _ = ParseGeometryFromText("POINT(0 0)", SRIDCartesian)
+	g2, _ = ParseGeometryFromText("MULTIPOINT(180 0, 90 0)", SRIDCartesian)
+	dist, ok = g1.DistanceSphere(&g2, 1)
+	require.True(t, ok)
+	require.InDelta(t, math.Pi/2, dist, 1e-9)
+
+	line, _ := ParseGeometryFromText("LINESTRING(0 0,1 1)", SRIDCartesian)
+	_, ok = g1.DistanceSphere(&line, DefaultSphereRadius)
+	require.False(t, ok)
+}
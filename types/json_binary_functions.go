@@ -254,6 +254,13 @@ func (bj BinaryJSON) Extract(pathExprList []JSONPathExpression) (ret BinaryJSON,
 	return
 }
 
+// ExtractAll returns all the values matched by the path expression in document
+// order. Unlike Extract, the values are never wrapped as an array.
+func (bj BinaryJSON) ExtractAll(pathExpr JSONPathExpression) []BinaryJSON {
+	buf := make([]BinaryJSON, 0, 1)
+	return bj.extractTo(buf, pathExpr, make(map[*byte]struct{}), false)
+}
+
 func (bj BinaryJSON) extractOne(pathExpr JSONPathExpression) []BinaryJSON {
 	result := make([]BinaryJSON, 0, 1)
 	return bj.extractTo(result, pathExpr, nil, true)
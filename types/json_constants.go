@@ -240,6 +240,12 @@ var (
 	ErrInvalidJSONPathArrayCell = dbterror.ClassJSON.NewStd(mysql.ErrInvalidJSONPathArrayCell)
 	// ErrUnsupportedSecondArgumentType means unsupported second argument type in json_objectagg
 	ErrUnsupportedSecondArgumentType = dbterror.ClassJSON.NewStd(mysql.ErrUnsupportedSecondArgumentType)
+	// ErrInvalidJSONType means the JSON argument is not the required JSON type.
+	ErrInvalidJSONType = dbterror.ClassJSON.NewStd(mysql.ErrInvalidJSONType)
+	// ErrUnsupportedJSONSchemaRef means the JSON Schema contains a reference which can't be resolved in the schema itself.
+	ErrUnsupportedJSONSchemaRef = dbterror.ClassJSON.NewStd(mysql.ErrNotSupportedYet)
+	// ErrJSONSchemaValidationErrorWithDetailedReport is the detailed reason why the JSON document fails the JSON Schema.
+	ErrJSONSchemaValidationErrorWithDetailedReport = dbterror.ClassJSON.NewStd(mysql.ErrJSONSchemaValidationErrorWithDetailedReport)
 )
 
 // json_contains_path function type choices
@@ -0,0 +1,695 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// JSONSchema is a compiled JSON Schema. Only the keywords of draft-04 are
+// supported, and `$ref` can only refer to the locations in the schema itself.
+// See https://datatracker.ietf.org/doc/html/draft-fge-json-schema-validation-00
+type JSONSchema struct {
+	root *jsonSchemaNode
+}
+
+// JSONSchemaValidationReport is the result of validating a JSON document against a JSON Schema.
+type JSONSchemaValidationReport struct {
+	Valid bool
+	// SchemaLocation is the JSON pointer to the sub-schema which the document fails.
+	SchemaLocation string
+	// DocumentLocation is the JSON pointer to the value which fails the sub-schema.
+	DocumentLocation string
+	// FailedKeyword is the keyword of the sub-schema which the value fails.
+	FailedKeyword string
+}
+
+// Reason returns the human readable reason why the validation fails.
+func (r *JSONSchemaValidationReport) Reason() string {
+	return fmt.Sprintf("The JSON document location '%s' failed requirement '%s' at JSON Schema location '%s'",
+		r.DocumentLocation, r.FailedKeyword, r.SchemaLocation)
+}
+
+// ToBinaryJSON returns the report in the format of JSON_SCHEMA_VALIDATION_REPORT.
+func (r *JSONSchemaValidationReport) ToBinaryJSON() BinaryJSON {
+	if r.Valid {
+		return CreateBinaryJSON(map[string]interface{}{"valid": true})
+	}
+	return CreateBinaryJSON(map[string]interface{}{
+		"valid":                 false,
+		"reason":                r.Reason(),
+		"schema-location":       r.SchemaLocation,
+		"document-location":     r.DocumentLocation,
+		"schema-failed-keyword": r.FailedKeyword,
+	})
+}
+
+type jsonSchemaProperty struct {
+	name   string
+	schema *jsonSchemaNode
+}
+
+type jsonSchemaPatternProperty struct {
+	pattern *regexp.Regexp
+	schema  *jsonSchemaNode
+}
+
+type jsonSchemaPropertyDependency struct {
+	name     string
+	required []string
+}
+
+// jsonSchemaNode is a compiled (sub-)schema. The unsupported keywords and the
+// keywords with the values of wrong types are ignored.
+type jsonSchemaNode struct {
+	location string
+	// ref is the location of the referenced schema, the other keywords are
+	// ignored if it's set.
+	ref     string
+	refNode *jsonSchemaNode
+
+	types []string
+	enum  []BinaryJSON
+
+	multipleOf       float64
+	maximum          float64
+	minimum          float64
+	hasMaximum       bool
+	hasMinimum       bool
+	exclusiveMaximum bool
+	exclusiveMinimum bool
+
+	// The length and size limits are -1 if they are not specified.
+	maxLength int
+	minLength int
+	pattern   *regexp.Regexp
+
+	items             *jsonSchemaNode
+	tupleItems        []*jsonSchemaNode
+	additionalItems   *jsonSchemaNode
+	noAdditionalItems bool
+	maxItems          int
+	minItems          int
+	uniqueItems       bool
+
+	maxProperties          int
+	minProperties          int
+	required               []string
+	properties             []jsonSchemaProperty
+	patternProperties      []jsonSchemaPatternProperty
+	additionalProperties   *jsonSchemaNode
+	noAdditionalProperties bool
+	propertyDependencies   []jsonSchemaPropertyDependency
+	schemaDependencies     []jsonSchemaProperty
+
+	allOf []*jsonSchemaNode
+	anyOf []*jsonSchemaNode
+	oneOf []*jsonSchemaNode
+	not   *jsonSchemaNode
+}
+
+type jsonSchemaCompiler struct {
+	funcName string
+	doc      BinaryJSON
+	nodes    map[string]*jsonSchemaNode
+	refs     []*jsonSchemaNode
+}
+
+// CompileJSONSchema compiles the JSON Schema, funcName is used in the error
+// messages if the schema is invalid.
+func CompileJSONSchema(schema BinaryJSON, funcName string) (*JSONSchema, error) {
+	if schema.TypeCode != JSONTypeCodeObject {
+		return nil, ErrInvalidJSONType.GenWithStackByArgs(1, funcName, "object")
+	}
+	c := &jsonSchemaCompiler{funcName: funcName, doc: schema, nodes: make(map[string]*jsonSchemaNode)}
+	root, err := c.compile(schema, "#")
+	if err != nil {
+		return nil, err
+	}
+	// Compiling the referenced schemas may add more references.
+	for i := 0; i < len(c.refs); i++ {
+		node := c.refs[i]
+		if target, ok := c.nodes[node.ref]; ok {
+			node.refNode = target
+			continue
+		}
+		sub, ok := c.resolvePointer(node.ref)
+		if !ok || sub.TypeCode != JSONTypeCodeObject {
+			return nil, ErrUnsupportedJSONSchemaRef.GenWithStackByArgs("references in JSON Schema")
+		}
+		if node.refNode, err = c.compile(sub, node.ref); err != nil {
+			return nil, err
+		}
+	}
+	// A schema referring to itself without other keywords never terminates.
+	for _, node := range c.refs {
+		visited := make(map[*jsonSchemaNode]struct{})
+		for n := node; n.refNode != nil; n = n.refNode {
+			if _, ok := visited[n]; ok {
+				return nil, ErrUnsupportedJSONSchemaRef.GenWithStackByArgs("recursive references in JSON Schema")
+			}
+			visited[n] = struct{}{}
+		}
+	}
+	return &JSONSchema{root: root}, nil
+}
+
+// resolvePointer resolves the URI fragment which is a JSON pointer to the schema document.
+func (c *jsonSchemaCompiler) resolvePointer(ref string) (BinaryJSON, bool) {
+	if !strings.HasPrefix(ref, "#") {
+		return BinaryJSON{}, false
+	}
+	cur := c.doc
+	pointer := ref[1:]
+	if pointer == "" {
+		return cur, true
+	}
+	if pointer[0] != '/' {
+		return BinaryJSON{}, false
+	}
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch cur.TypeCode {
+		case JSONTypeCodeObject:
+			val, ok := cur.objectSearchKey([]byte(token))
+			if !ok {
+				return BinaryJSON{}, false
+			}
+			cur = val
+		case JSONTypeCodeArray:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= cur.GetElemCount() {
+				return BinaryJSON{}, false
+			}
+			cur = cur.ArrayGetElem(idx)
+		default:
+			return BinaryJSON{}, false
+		}
+	}
+	return cur, true
+}
+
+func escapeJSONPointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func jsonSchemaNumber(bj BinaryJSON) (float64, bool) {
+	switch bj.TypeCode {
+	case JSONTypeCodeInt64:
+		return float64(bj.GetInt64()), true
+	case JSONTypeCodeUint64:
+		return float64(bj.GetUint64()), true
+	case JSONTypeCodeFloat64:
+		return bj.GetFloat64(), true
+	}
+	return 0, false
+}
+
+// jsonSchemaLimit returns the non-negative integer of the keyword, or -1 if it's invalid.
+func jsonSchemaLimit(bj BinaryJSON) int {
+	f, ok := jsonSchemaNumber(bj)
+	if !ok || f < 0 || f != math.Trunc(f) {
+		return -1
+	}
+	if f > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(f)
+}
+
+func jsonSchemaBool(bj BinaryJSON) (val bool, ok bool) {
+	if bj.TypeCode != JSONTypeCodeLiteral || bj.Value[0] == JSONLiteralNil {
+		return false, false
+	}
+	return bj.Value[0] == JSONLiteralTrue, true
+}
+
+func (c *jsonSchemaCompiler) compile(schema BinaryJSON, location string) (*jsonSchemaNode, error) {
+	node := &jsonSchemaNode{
+		location:      location,
+		maxLength:     -1,
+		minLength:     -1,
+		maxItems:      -1,
+		minItems:      -1,
+		maxProperties: -1,
+		minProperties: -1,
+	}
+	c.nodes[location] = node
+	if schema.TypeCode != JSONTypeCodeObject {
+		return node, nil
+	}
+	var err error
+	compileChild := func(val BinaryJSON, loc string) *jsonSchemaNode {
+		if err != nil || val.TypeCode != JSONTypeCodeObject {
+			return nil
+		}
+		var child *jsonSchemaNode
+		child, err = c.compile(val, loc)
+		return child
+	}
+	compileArray := func(val BinaryJSON, loc string) []*jsonSchemaNode {
+		if val.TypeCode != JSONTypeCodeArray {
+			return nil
+		}
+		children := make([]*jsonSchemaNode, 0, val.GetElemCount())
+		for i := 0; i < val.GetElemCount(); i++ {
+			if child := compileChild(val.ArrayGetElem(i), loc+"/"+strconv.Itoa(i)); child != nil {
+				children = append(children, child)
+			}
+		}
+		return children
+	}
+	compileObject := func(val BinaryJSON, loc string) []jsonSchemaProperty {
+		if val.TypeCode != JSONTypeCodeObject {
+			return nil
+		}
+		props := make([]jsonSchemaProperty, 0, val.GetElemCount())
+		for i := 0; i < val.GetElemCount(); i++ {
+			name := string(val.objectGetKey(i))
+			if child := compileChild(val.objectGetVal(i), loc+"/"+escapeJSONPointerToken(name)); child != nil {
+				props = append(props, jsonSchemaProperty{name: name, schema: child})
+			}
+		}
+		return props
+	}
+	stringArray := func(val BinaryJSON) []string {
+		if val.TypeCode != JSONTypeCodeArray {
+			return nil
+		}
+		strs := make([]string, 0, val.GetElemCount())
+		for i := 0; i < val.GetElemCount(); i++ {
+			if elem := val.ArrayGetElem(i); elem.TypeCode == JSONTypeCodeString {
+				strs = append(strs, string(elem.GetString()))
+			}
+		}
+		return strs
+	}
+
+	for i := 0; i < schema.GetElemCount() && err == nil; i++ {
+		key, val := string(schema.objectGetKey(i)), schema.objectGetVal(i)
+		loc := location + "/" + escapeJSONPointerToken(key)
+		switch key {
+		case "$ref":
+			if val.TypeCode != JSONTypeCodeString {
+				continue
+			}
+			node.ref = string(val.GetString())
+			if !strings.HasPrefix(node.ref, "#") {
+				return nil, ErrUnsupportedJSONSchemaRef.GenWithStackByArgs("references in JSON Schema")
+			}
+			c.refs = append(c.refs, node)
+		case "type":
+			if val.TypeCode == JSONTypeCodeString {
+				node.types = []string{string(val.GetString())}
+			} else {
+				node.types = stringArray(val)
+			}
+		case "enum":
+			if val.TypeCode == JSONTypeCodeArray {
+				node.enum = make([]BinaryJSON, 0, val.GetElemCount())
+				for j := 0; j < val.GetElemCount(); j++ {
+					node.enum = append(node.enum, val.ArrayGetElem(j))
+				}
+			}
+		case "multipleOf":
+			if f, ok := jsonSchemaNumber(val); ok && f > 0 {
+				node.multipleOf = f
+			}
+		case "maximum":
+			node.maximum, node.hasMaximum = jsonSchemaNumber(val)
+		case "minimum":
+			node.minimum, node.hasMinimum = jsonSchemaNumber(val)
+		case "exclusiveMaximum":
+			node.exclusiveMaximum, _ = jsonSchemaBool(val)
+		case "exclusiveMinimum":
+			node.exclusiveMinimum, _ = jsonSchemaBool(val)
+		case "maxLength":
+			node.maxLength = jsonSchemaLimit(val)
+		case "minLength":
+			node.minLength = jsonSchemaLimit(val)
+		case "pattern":
+			if val.TypeCode == JSONTypeCodeString {
+				if node.pattern, err = regexp.Compile(string(val.GetString())); err != nil {
+					return nil, ErrInvalidJSONData.GenWithStackByArgs(c.funcName, err.Error())
+				}
+			}
+		case "items":
+			if val.TypeCode == JSONTypeCodeArray {
+				node.tupleItems = compileArray(val, loc)
+			} else {
+				node.items = compileChild(val, loc)
+			}
+		case "additionalItems":
+			if b, ok := jsonSchemaBool(val); ok {
+				node.noAdditionalItems = !b
+			} else {
+				node.additionalItems = compileChild(val, loc)
+			}
+		case "maxItems":
+			node.maxItems = jsonSchemaLimit(val)
+		case "minItems":
+			node.minItems = jsonSchemaLimit(val)
+		case "uniqueItems":
+			node.uniqueItems, _ = jsonSchemaBool(val)
+		case "maxProperties":
+			node.maxProperties = jsonSchemaLimit(val)
+		case "minProperties":
+			node.minProperties = jsonSchemaLimit(val)
+		case "required":
+			node.required = stringArray(val)
+		case "properties":
+			node.properties = compileObject(val, loc)
+		case "patternProperties":
+			if val.TypeCode != JSONTypeCodeObject {
+				continue
+			}
+			for j := 0; j < val.GetElemCount() && err == nil; j++ {
+				pattern := string(val.objectGetKey(j))
+				re, reErr := regexp.Compile(pattern)
+				if reErr != nil {
+					return nil, ErrInvalidJSONData.GenWithStackByArgs(c.funcName, reErr.Error())
+				}
+				if child := compileChild(val.objectGetVal(j), loc+"/"+escapeJSONPointerToken(pattern)); child != nil {
+					node.patternProperties = append(node.patternProperties, jsonSchemaPatternProperty{pattern: re, schema: child})
+				}
+			}
+		case "additionalProperties":
+			if b, ok := jsonSchemaBool(val); ok {
+				node.noAdditionalProperties = !b
+			} else {
+				node.additionalProperties = compileChild(val, loc)
+			}
+		case "dependencies":
+			if val.TypeCode != JSONTypeCodeObject {
+				continue
+			}
+			for j := 0; j < val.GetElemCount() && err == nil; j++ {
+				name, dep := string(val.objectGetKey(j)), val.objectGetVal(j)
+				if dep.TypeCode == JSONTypeCodeArray {
+					node.propertyDependencies = append(node.propertyDependencies, jsonSchemaPropertyDependency{name: name, required: stringArray(dep)})
+				} else if child := compileChild(dep, loc+"/"+escapeJSONPointerToken(name)); child != nil {
+					node.schemaDependencies = append(node.schemaDependencies, jsonSchemaProperty{name: name, schema: child})
+				}
+			}
+		case "allOf":
+			node.allOf = compileArray(val, loc)
+		case "anyOf":
+			node.anyOf = compileArray(val, loc)
+		case "oneOf":
+			node.oneOf = compileArray(val, loc)
+		case "not":
+			node.not = compileChild(val, loc)
+		case "definitions":
+			compileObject(val, loc)
+		}
+	}
+	return node, err
+}
+
+// Validate validates the JSON document against the schema.
+func (s *JSONSchema) Validate(doc BinaryJSON) JSONSchemaValidationReport {
+	if report := s.root.validate(doc, "#"); report != nil {
+		return *report
+	}
+	return JSONSchemaValidationReport{Valid: true}
+}
+
+func (n *jsonSchemaNode) fail(docLocation, keyword string) *JSONSchemaValidationReport {
+	return &JSONSchemaValidationReport{
+		SchemaLocation:   n.location,
+		DocumentLocation: docLocation,
+		FailedKeyword:    keyword,
+	}
+}
+
+// validate returns nil if the value is valid, otherwise returns the report of the first failure.
+func (n *jsonSchemaNode) validate(val BinaryJSON, docLocation string) *JSONSchemaValidationReport {
+	if n.refNode != nil {
+		return n.refNode.validate(val, docLocation)
+	}
+	if len(n.types) > 0 && !n.matchType(val) {
+		return n.fail(docLocation, "type")
+	}
+	if n.enum != nil && !n.matchEnum(val) {
+		return n.fail(docLocation, "enum")
+	}
+	var report *JSONSchemaValidationReport
+	switch val.TypeCode {
+	case JSONTypeCodeInt64, JSONTypeCodeUint64, JSONTypeCodeFloat64:
+		report = n.validateNumber(val, docLocation)
+	case JSONTypeCodeString:
+		report = n.validateString(string(val.GetString()), docLocation)
+	case JSONTypeCodeArray:
+		report = n.validateArray(val, docLocation)
+	case JSONTypeCodeObject:
+		report = n.validateObject(val, docLocation)
+	}
+	if report != nil {
+		return report
+	}
+	for _, sub := range n.allOf {
+		if sub.validate(val, docLocation) != nil {
+			return n.fail(docLocation, "allOf")
+		}
+	}
+	if len(n.anyOf) > 0 {
+		matched := false
+		for _, sub := range n.anyOf {
+			if sub.validate(val, docLocation) == nil {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return n.fail(docLocation, "anyOf")
+		}
+	}
+	if len(n.oneOf) > 0 {
+		matched := 0
+		for _, sub := range n.oneOf {
+			if sub.validate(val, docLocation) == nil {
+				matched++
+			}
+		}
+		if matched != 1 {
+			return n.fail(docLocation, "oneOf")
+		}
+	}
+	if n.not != nil && n.not.validate(val, docLocation) == nil {
+		return n.fail(docLocation, "not")
+	}
+	return nil
+}
+
+func (n *jsonSchemaNode) matchType(val BinaryJSON) bool {
+	for _, tp := range n.types {
+		switch tp {
+		case "object":
+			if val.TypeCode == JSONTypeCodeObject {
+				return true
+			}
+		case "array":
+			if val.TypeCode == JSONTypeCodeArray {
+				return true
+			}
+		case "string":
+			if val.TypeCode == JSONTypeCodeString {
+				return true
+			}
+		case "number":
+			if _, ok := jsonSchemaNumber(val); ok {
+				return true
+			}
+		case "integer":
+			if val.TypeCode == JSONTypeCodeInt64 || val.TypeCode == JSONTypeCodeUint64 {
+				return true
+			}
+		case "boolean":
+			if _, ok := jsonSchemaBool(val); ok {
+				return true
+			}
+		case "null":
+			if val.TypeCode == JSONTypeCodeLiteral && val.Value[0] == JSONLiteralNil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (n *jsonSchemaNode) matchEnum(val BinaryJSON) bool {
+	for _, e := range n.enum {
+		if e.TypeCode == val.TypeCode || isJSONSchemaNumber(e) && isJSONSchemaNumber(val) {
+			if CompareBinaryJSON(e, val) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isJSONSchemaNumber(bj BinaryJSON) bool {
+	_, ok := jsonSchemaNumber(bj)
+	return ok
+}
+
+func (n *jsonSchemaNode) validateNumber(val BinaryJSON, docLocation string) *JSONSchemaValidationReport {
+	f, _ := jsonSchemaNumber(val)
+	if n.multipleOf > 0 {
+		q := f / n.multipleOf
+		if math.IsInf(q, 0) || q != math.Trunc(q) {
+			return n.fail(docLocation, "multipleOf")
+		}
+	}
+	if n.hasMaximum && (f > n.maximum || n.exclusiveMaximum && f == n.maximum) {
+		return n.fail(docLocation, "maximum")
+	}
+	if n.hasMinimum && (f < n.minimum || n.exclusiveMinimum && f == n.minimum) {
+		return n.fail(docLocation, "minimum")
+	}
+	return nil
+}
+
+func (n *jsonSchemaNode) validateString(s string, docLocation string) *JSONSchemaValidationReport {
+	if n.maxLength >= 0 || n.minLength >= 0 {
+		length := utf8.RuneCountInString(s)
+		if n.maxLength >= 0 && length > n.maxLength {
+			return n.fail(docLocation, "maxLength")
+		}
+		if n.minLength >= 0 && length < n.minLength {
+			return n.fail(docLocation, "minLength")
+		}
+	}
+	if n.pattern != nil && !n.pattern.MatchString(s) {
+		return n.fail(docLocation, "pattern")
+	}
+	return nil
+}
+
+func (n *jsonSchemaNode) validateArray(val BinaryJSON, docLocation string) *JSONSchemaValidationReport {
+	cnt := val.GetElemCount()
+	if n.maxItems >= 0 && cnt > n.maxItems {
+		return n.fail(docLocation, "maxItems")
+	}
+	if n.minItems >= 0 && cnt < n.minItems {
+		return n.fail(docLocation, "minItems")
+	}
+	if n.uniqueItems {
+		for i := 0; i < cnt; i++ {
+			for j := i + 1; j < cnt; j++ {
+				a, b := val.ArrayGetElem(i), val.ArrayGetElem(j)
+				if (a.TypeCode == b.TypeCode || isJSONSchemaNumber(a) && isJSONSchemaNumber(b)) && CompareBinaryJSON(a, b) == 0 {
+					return n.fail(docLocation, "uniqueItems")
+				}
+			}
+		}
+	}
+	if n.tupleItems == nil {
+		if n.items != nil {
+			for i := 0; i < cnt; i++ {
+				if report := n.items.validate(val.ArrayGetElem(i), docLocation+"/"+strconv.Itoa(i)); report != nil {
+					return report
+				}
+			}
+		}
+		return nil
+	}
+	for i := 0; i < cnt; i++ {
+		elemLocation := docLocation + "/" + strconv.Itoa(i)
+		if i < len(n.tupleItems) {
+			if report := n.tupleItems[i].validate(val.ArrayGetElem(i), elemLocation); report != nil {
+				return report
+			}
+			continue
+		}
+		if n.noAdditionalItems {
+			return n.fail(docLocation, "additionalItems")
+		}
+		if n.additionalItems != nil {
+			if report := n.additionalItems.validate(val.ArrayGetElem(i), elemLocation); report != nil {
+				return report
+			}
+		}
+	}
+	return nil
+}
+
+func (n *jsonSchemaNode) validateObject(val BinaryJSON, docLocation string) *JSONSchemaValidationReport {
+	cnt := val.GetElemCount()
+	if n.maxProperties >= 0 && cnt > n.maxProperties {
+		return n.fail(docLocation, "maxProperties")
+	}
+	if n.minProperties >= 0 && cnt < n.minProperties {
+		return n.fail(docLocation, "minProperties")
+	}
+	for _, name := range n.required {
+		if _, ok := val.objectSearchKey([]byte(name)); !ok {
+			return n.fail(docLocation, "required")
+		}
+	}
+	for _, dep := range n.propertyDependencies {
+		if _, ok := val.objectSearchKey([]byte(dep.name)); !ok {
+			continue
+		}
+		for _, name := range dep.required {
+			if _, ok := val.objectSearchKey([]byte(name)); !ok {
+				return n.fail(docLocation, "dependencies")
+			}
+		}
+	}
+	for _, dep := range n.schemaDependencies {
+		if _, ok := val.objectSearchKey([]byte(dep.name)); ok && dep.schema.validate(val, docLocation) != nil {
+			return n.fail(docLocation, "dependencies")
+		}
+	}
+	for i := 0; i < cnt; i++ {
+		name, propVal := string(val.objectGetKey(i)), val.objectGetVal(i)
+		propLocation := docLocation + "/" + escapeJSONPointerToken(name)
+		matched := false
+		for _, prop := range n.properties {
+			if prop.name == name {
+				matched = true
+				if report := prop.schema.validate(propVal, propLocation); report != nil {
+					return report
+				}
+			}
+		}
+		for _, prop := range n.patternProperties {
+			if prop.pattern.MatchString(name) {
+				matched = true
+				if report := prop.schema.validate(propVal, propLocation); report != nil {
+					return report
+				}
+			}
+		}
+		if matched {
+			continue
+		}
+		if n.noAdditionalProperties {
+			return n.fail(docLocation, "additionalProperties")
+		}
+		if n.additionalProperties != nil {
+			if report := n.additionalProperties.validate(propVal, propLocation); report != nil {
+				return report
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchemaValidate(t *testing.T) {
+	tests := []struct {
+		schema  string
+		doc     string
+		valid   bool
+		keyword string
+		schLoc  string
+		docLoc  string
+	}{
+		{`{}`, `[1, "a", null]`, true, "", "", ""},
+		// type
+		{`{"type": "object"}`, `{}`, true, "", "", ""},
+		{`{"type": "object"}`, `[]`, false, "type", "#", "#"},
+		{`{"type": "integer"}`, `1`, true, "", "", ""},
+		{`{"type": "integer"}`, `1.5`, false, "type", "#", "#"},
+		{`{"type": "number"}`, `1`, true, "", "", ""},
+		{`{"type": ["string", "null"]}`, `null`, true, "", "", ""},
+		{`{"type": ["string", "null"]}`, `false`, false, "type", "#", "#"},
+		{`{"type": "boolean"}`, `false`, true, "", "", ""},
+		// enum
+		{`{"enum": [1, "a", [1, 2]]}`, `[1, 2]`, true, "", "", ""},
+		{`{"enum": [1, "a", [1, 2]]}`, `1.0`, true, "", "", ""},
+		{`{"enum": [1, "a", [1, 2]]}`, `"b"`, false, "enum", "#", "#"},
+		// numbers
+		{`{"minimum": 1, "maximum": 3}`, `3`, true, "", "", ""},
+		{`{"minimum": 1, "maximum": 3, "exclusiveMaximum": true}`, `3`, false, "maximum", "#", "#"},
+		{`{"minimum": 1, "exclusiveMinimum": true}`, `1`, false, "minimum", "#", "#"},
+		{`{"multipleOf": 0.5}`, `2.5`, true, "", "", ""},
+		{`{"multipleOf": 2}`, `3`, false, "multipleOf", "#", "#"},
+		// strings
+		{`{"minLength": 2, "maxLength": 3}`, `"中文"`, true, "", "", ""},
+		{`{"maxLength": 3}`, `"abcd"`, false, "maxLength", "#", "#"},
+		{`{"minLength": 3}`, `"ab"`, false, "minLength", "#", "#"},
+		{`{"pattern": "^a+$"}`, `"aaa"`, true, "", "", ""},
+		{`{"pattern": "^a+$"}`, `"ab"`, false, "pattern", "#", "#"},
+		{`{"pattern": "^a+$"}`, `1`, true, "", "", ""},
+		// arrays
+		{`{"items": {"type": "integer"}}`, `[1, 2]`, true, "", "", ""},
+		{`{"items": {"type": "integer"}}`, `[1, "a"]`, false, "type", "#/items", "#/1"},
+		{`{"items": [{"type": "integer"}], "additionalItems": false}`, `[1, 2]`, false, "additionalItems", "#", "#"},
+		{`{"items": [{"type": "integer"}], "additionalItems": {"type": "string"}}`, `[1, "a"]`, true, "", "", ""},
+		{`{"items": [{"type": "integer"}], "additionalItems": {"type": "string"}}`, `[1, 2]`, false, "type", "#/additionalItems", "#/1"},
+		{`{"minItems": 1, "maxItems": 2}`, `[]`, false, "minItems", "#", "#"},
+		{`{"minItems": 1, "maxItems": 2}`, `[1, 2, 3]`, false, "maxItems", "#", "#"},
+		{`{"uniqueItems": true}`, `[1, 1.0]`, false, "uniqueItems", "#", "#"},
+		{`{"uniqueItems": true}`, `[1, "1"]`, true, "", "", ""},
+		// objects
+		{`{"required": ["a", "b"]}`, `{"a": 1}`, false, "required", "#", "#"},
+		{`{"minProperties": 2}`, `{"a": 1}`, false, "minProperties", "#", "#"},
+		{`{"maxProperties": 1}`, `{"a": 1, "b": 2}`, false, "maxProperties", "#", "#"},
+		{`{"properties": {"a": {"type": "string"}}}`, `{"a": 1}`, false, "type", "#/properties/a", "#/a"},
+		{`{"properties": {"a/b": {"type": "string"}}}`, `{"a/b": 1}`, false, "type", "#/properties/a~1b", "#/a~1b"},
+		{`{"properties": {"a": {}}, "additionalProperties": false}`, `{"a": 1, "b": 2}`, false, "additionalProperties", "#", "#"},
+		{`{"properties": {"a": {}}, "patternProperties": {"^x": {"type": "integer"}}, "additionalProperties": false}`, `{"a": 1, "x1": 2}`, true, "", "", ""},
+		{`{"patternProperties": {"^x": {"type": "integer"}}}`, `{"x1": "a"}`, false, "type", "#/patternProperties/^x", "#/x1"},
+		{`{"additionalProperties": {"type": "integer"}}`, `{"a": "a"}`, false, "type", "#/additionalProperties", "#/a"},
+		{`{"dependencies": {"a": ["b"]}}`, `{"a": 1}`, false, "dependencies", "#", "#"},
+		{`{"dependencies": {"a": ["b"]}}`, `{"b": 1}`, true, "", "", ""},
+		{`{"dependencies": {"a": {"required": ["c"]}}}`, `{"a": 1}`, false, "dependencies", "#", "#"},
+		// combinations
+		{`{"allOf": [{"type": "integer"}, {"minimum": 2}]}`, `1`, false, "allOf", "#", "#"},
+		{`{"anyOf": [{"type": "integer"}, {"type": "string"}]}`, `"a"`, true, "", "", ""},
+		{`{"anyOf": [{"type": "integer"}, {"type": "string"}]}`, `null`, false, "anyOf", "#", "#"},
+		{`{"oneOf": [{"type": "integer"}, {"minimum": 1}]}`, `2`, false, "oneOf", "#", "#"},
+		{`{"oneOf": [{"type": "integer"}, {"minimum": 1}]}`, `1.5`, true, "", "", ""},
+		{`{"not": {"type": "null"}}`, `null`, false, "not", "#", "#"},
+		// references
+		{`{"definitions": {"pos": {"type": "integer", "minimum": 0}}, "items": {"$ref": "#/definitions/pos"}}`, `[0, -1]`, false, "minimum", "#/definitions/pos", "#/1"},
+		{`{"type": "object", "properties": {"child": {"$ref": "#"}}}`, `{"child": {"child": 1}}`, false, "type", "#", "#/child/child"},
+		{`{"properties": {"a": {"type": "integer"}, "b": {"$ref": "#/properties/a"}}}`, `{"b": "x"}`, false, "type", "#/properties/a", "#/b"},
+	}
+	for _, tt := range tests {
+		schema, err := ParseBinaryJSONFromString(tt.schema)
+		require.NoError(t, err)
+		doc, err := ParseBinaryJSONFromString(tt.doc)
+		require.NoError(t, err)
+		s, err := CompileJSONSchema(schema, "json_schema_valid")
+		require.NoError(t, err, tt.schema)
+		report := s.Validate(doc)
+		require.Equal(t, tt.valid, report.Valid, "%s %s", tt.schema, tt.doc)
+		if !tt.valid {
+			require.Equal(t, tt.keyword, report.FailedKeyword, "%s %s", tt.schema, tt.doc)
+			require.Equal(t, tt.schLoc, report.SchemaLocation, "%s %s", tt.schema, tt.doc)
+			require.Equal(t, tt.docLoc, report.DocumentLocation, "%s %s", tt.schema, tt.doc)
+		}
+	}
+}
+
+func TestCompileJSONSchema(t *testing.T) {
+	tests := []struct {
+		schema string
+		err    error
+	}{
+		{`[]`, ErrInvalidJSONType},
+		{`"a"`, ErrInvalidJSONType},
+		{`{"$ref": "http://json-schema.org/draft-04/schema#"}`, ErrUnsupportedJSONSchemaRef},
+		{`{"$ref": "#/definitions/a"}`, ErrUnsupportedJSONSchemaRef},
+		{`{"definitions": {"a": {"$ref": "#/definitions/b"}, "b": {"$ref": "#/definitions/a"}}, "$ref": "#/definitions/a"}`, ErrUnsupportedJSONSchemaRef},
+		{`{"pattern": "("}`, ErrInvalidJSONData},
+		// The keywords of wrong types are ignored.
+		{`{"type": 1, "minimum": "a", "required": {}}`, nil},
+	}
+	for _, tt := range tests {
+		schema, err := ParseBinaryJSONFromString(tt.schema)
+		require.NoError(t, err)
+		_, err = CompileJSONSchema(schema, "json_schema_valid")
+		if tt.err == nil {
+			require.NoError(t, err, tt.schema)
+		} else {
+			require.True(t, tt.err.(interface{ Equal(error) bool }).Equal(err), "%s %v", tt.schema, err)
+		}
+	}
+}
+
+func TestJSONSchemaValidationReport(t *testing.T) {
+	schema, err := ParseBinaryJSONFromString(`{"properties": {"latitude": {"type": "number", "minimum": -90, "maximum": 90}}}`)
+	require.NoError(t, err)
+	s, err := CompileJSONSchema(schema, "json_schema_validation_report")
+	require.NoError(t, err)
+
+	doc, err := ParseBinaryJSONFromString(`{"latitude": 63.444697}`)
+	require.NoError(t, err)
+	report := s.Validate(doc)
+	require.Equal(t, `{"valid": true}`, report.ToBinaryJSON().String())
+
+	doc, err = ParseBinaryJSONFromString(`{"latitude": 91}`)
+	require.NoError(t, err)
+	report = s.Validate(doc)
+	require.Equal(t, "The JSON document location '#/latitude' failed requirement 'maximum' at JSON Schema location '#/properties/latitude'", report.Reason())
+	reportJSON := report.ToBinaryJSON()
+	val, ok := reportJSON.objectSearchKey([]byte("schema-failed-keyword"))
+	require.True(t, ok)
+	require.Equal(t, "maximum", string(val.GetString()))
+	val, ok = reportJSON.objectSearchKey([]byte("valid"))
+	require.True(t, ok)
+	require.Equal(t, "false", val.String())
+}
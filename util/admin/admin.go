@@ -68,7 +68,8 @@ const (
 // It returns the count greater type, the index offset and an error.
 // It returns nil if the count from the index is equal to the count from the table columns,
 // otherwise it returns an error and the corresponding index's offset.
-func CheckIndicesCount(ctx sessionctx.Context, dbName, tableName string, indices []string) (byte, int, error) {
+// If partitionNames is not empty, only the rows of these partitions are counted.
+func CheckIndicesCount(ctx sessionctx.Context, dbName, tableName string, partitionNames []string, indices []string) (byte, int, error) {
 	// Here we need check all indexes, includes invisible index
 	ctx.GetSessionVars().OptimizerUseInvisibleIndexes = true
 	defer func() {
@@ -89,12 +90,20 @@ func CheckIndicesCount(ctx sessionctx.Context, dbName, tableName string, indices
 
 	// Add `` for some names like `table name`.
 	exec := ctx.(sqlexec.RestrictedSQLExecutor)
-	tblCnt, err := getCount(exec, snapshot, "SELECT COUNT(*) FROM %n.%n USE INDEX()", dbName, tableName)
+	from := "%n.%n"
+	args := []interface{}{dbName, tableName}
+	if len(partitionNames) > 0 {
+		from += " PARTITION(" + strings.TrimSuffix(strings.Repeat("%n,", len(partitionNames)), ",") + ")"
+		for _, name := range partitionNames {
+			args = append(args, name)
+		}
+	}
+	tblCnt, err := getCount(exec, snapshot, "SELECT COUNT(*) FROM "+from+" USE INDEX()", args...)
 	if err != nil {
 		return 0, 0, errors.Trace(err)
 	}
 	for i, idx := range indices {
-		idxCnt, err := getCount(exec, snapshot, "SELECT COUNT(*) FROM %n.%n USE INDEX(%n)", dbName, tableName, idx)
+		idxCnt, err := getCount(exec, snapshot, "SELECT COUNT(*) FROM "+from+" USE INDEX(%n)", append(args, idx)...)
 		if err != nil {
 			return 0, i, errors.Trace(err)
 		}
@@ -130,6 +130,11 @@ func (c *Column) isFixed() bool {
 	return c.elemBuf != nil
 }
 
+// Rows returns the number of rows in the Column.
+func (c *Column) Rows() int {
+	return c.length
+}
+
 // Reset resets this Column according to the EvalType.
 // Different from reset, Reset will reset the elemBuf.
 func (c *Column) Reset(eType types.EvalType) {
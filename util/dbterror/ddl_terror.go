@@ -143,12 +143,6 @@ var (
 	ErrUnsupportedCoalescePartition = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "coalesce partitions"), nil))
 	// ErrUnsupportedReorganizePartition returns for does not support reorganize partitions.
 	ErrUnsupportedReorganizePartition = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "reorganize partition"), nil))
-	// ErrUnsupportedCheckPartition returns for does not support check partitions.
-	ErrUnsupportedCheckPartition = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "check partition"), nil))
-	// ErrUnsupportedOptimizePartition returns for does not support optimize partitions.
-	ErrUnsupportedOptimizePartition = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "optimize partition"), nil))
-	// ErrUnsupportedRebuildPartition returns for does not support rebuild partitions.
-	ErrUnsupportedRebuildPartition = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "rebuild partition"), nil))
 	// ErrUnsupportedRemovePartition returns for does not support remove partitions.
 	ErrUnsupportedRemovePartition = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "remove partitioning"), nil))
 	// ErrUnsupportedRepairPartition returns for does not support repair partitions.
@@ -306,6 +300,8 @@ var (
 	ErrPartitionExchangePartTable = ClassDDL.NewStd(mysql.ErrPartitionExchangePartTable)
 	// ErrPartitionExchangeTempTable is returned when exchange table partition with a temporary table
 	ErrPartitionExchangeTempTable = ClassDDL.NewStd(mysql.ErrPartitionExchangeTempTable)
+	// ErrPartitionInsteadOfSubpartition is returned when exchange a partition of a subpartitioned table.
+	ErrPartitionInsteadOfSubpartition = ClassDDL.NewStd(mysql.ErrPartitionInsteadOfSubpartition)
 	// ErrTablesDifferentMetadata is returned when exchanges tables is not compatible.
 	ErrTablesDifferentMetadata = ClassDDL.NewStd(mysql.ErrTablesDifferentMetadata)
 	// ErrRowDoesNotMatchPartition is returned when the row record of exchange table does not match the partition rule.
@@ -433,7 +429,7 @@ var (
 	ErrUnsupportedColumnInTTLConfig = ClassDDL.NewStd(mysql.ErrUnsupportedColumnInTTLConfig)
 	// ErrTTLColumnCannotDrop returns when a column is dropped while referenced by TTL config
 	ErrTTLColumnCannotDrop = ClassDDL.NewStd(mysql.ErrTTLColumnCannotDrop)
-	// ErrSetTTLOptionForNonTTLTable returns when the `TTL_ENABLE` or `TTL_JOB_INTERVAL` option is set on a non-TTL table
+	// ErrSetTTLOptionForNonTTLTable returns when the `TTL_ENABLE`, `TTL_JOB_INTERVAL` or `TTL_TIMESTAMP_UNIT` option is set on a non-TTL table
 	ErrSetTTLOptionForNonTTLTable = ClassDDL.NewStd(mysql.ErrSetTTLOptionForNonTTLTable)
 	// ErrTempTableNotAllowedWithTTL returns when setting TTL config for a temp table
 	ErrTempTableNotAllowedWithTTL = ClassDDL.NewStd(mysql.ErrTempTableNotAllowedWithTTL)
@@ -441,6 +437,10 @@ var (
 	ErrUnsupportedTTLReferencedByFK = ClassDDL.NewStd(mysql.ErrUnsupportedTTLReferencedByFK)
 	// ErrUnsupportedPrimaryKeyTypeWithTTL returns when create or alter a table with TTL options but the primary key is not supported
 	ErrUnsupportedPrimaryKeyTypeWithTTL = ClassDDL.NewStd(mysql.ErrUnsupportedPrimaryKeyTypeWithTTL)
+	// ErrUnsupportedTTLTimestampUnit returns when the `TTL_TIMESTAMP_UNIT` option is set but the TTL column is not an integer
+	ErrUnsupportedTTLTimestampUnit = ClassDDL.NewStd(mysql.ErrUnsupportedTTLTimestampUnit)
+	// ErrUnsupportedTTLArchiveTable returns when the `TTL_ARCHIVE_TABLE` option refers to a table which cannot store the expired rows
+	ErrUnsupportedTTLArchiveTable = ClassDDL.NewStd(mysql.ErrUnsupportedTTLArchiveTable)
 
 	// ErrNotSupportedYet returns when tidb does not support this feature.
 	ErrNotSupportedYet = ClassDDL.NewStd(mysql.ErrNotSupportedYet)
@@ -80,6 +80,8 @@ var (
 	ErrUnsupportedFlashbackTmpTable = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message("Recover/flashback table is not supported on temporary tables", nil))
 	ErrTruncateWrongInsertValue     = dbterror.ClassTable.NewStdErr(mysql.ErrTruncatedWrongValue, parser_mysql.Message("Incorrect %-.32s value: '%-.128s' for column '%.192s' at row %d", nil))
 	ErrExistsInHistoryPassword      = dbterror.ClassExecutor.NewStd(mysql.ErrExistsInHistoryPassword)
+	ErrMissingJSONTableValue        = dbterror.ClassExecutor.NewStd(mysql.ErrMissingJSONTableValue)
+	ErrWrongJSONTableValue          = dbterror.ClassExecutor.NewStd(mysql.ErrWrongJSONTableValue)
 
 	ErrWarnTooFewRecords              = dbterror.ClassExecutor.NewStd(mysql.ErrWarnTooFewRecords)
 	ErrWarnTooManyRecords             = dbterror.ClassExecutor.NewStd(mysql.ErrWarnTooManyRecords)
@@ -99,4 +101,12 @@ var (
 	ErrLoadDataInvalidOperation       = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataInvalidOperation)
 	ErrLoadDataLocalUnsupportedOption = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataLocalUnsupportedOption)
 	ErrLoadDataPreCheckFailed         = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataPreCheckFailed)
+
+	ErrXaerNota     = dbterror.ClassExecutor.NewStd(mysql.ErrXaerNota)
+	ErrXaerInval    = dbterror.ClassExecutor.NewStd(mysql.ErrXaerInval)
+	ErrXaerRmfail   = dbterror.ClassExecutor.NewStd(mysql.ErrXaerRmfail)
+	ErrXaerOutside  = dbterror.ClassExecutor.NewStd(mysql.ErrXaerOutside)
+	ErrXaerRmerr    = dbterror.ClassExecutor.NewStd(mysql.ErrXaerRmerr)
+	ErrXaerDupid    = dbterror.ClassExecutor.NewStd(mysql.ErrXaerDupid)
+	ErrXaRbrollback = dbterror.ClassExecutor.NewStd(mysql.ErrXaRbrollback)
 )